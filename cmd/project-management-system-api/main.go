@@ -5,6 +5,7 @@ import (
 	"errors"
 	"example/project-management-system/internal/config"
 	"example/project-management-system/internal/database"
+	"example/project-management-system/internal/migrations"
 	"example/project-management-system/internal/server"
 	"example/project-management-system/pkg/logger"
 	"log"
@@ -44,10 +45,32 @@ func main() {
 	appLogger := logger.NewLogger()
 
 	// Initialize database
-	db := database.NewPostgresConnection(cfg, appLogger)
+	db := database.NewConnection(cfg, appLogger)
+
+	all, err := migrations.All()
+	if err != nil {
+		log.Fatal(err)
+	}
+	migrator := database.NewMigrator(db.DB, all)
+
+	// The schema is only changed by the migrate command
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), migrator, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if pending > 0 {
+		log.Fatalf("%d migrations are pending, run the migrate up command first", pending)
+	}
 
 	// Initialize server
-	server, err := server.NewHTTPServer(db.DB, cfg)
+	server, err := server.NewHTTPServer(db.DB, cfg, appLogger)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -24,50 +24,60 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/tasks": {
-            "put": {
+        "/attachments/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a task's details",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete an attached file, which only its uploader or the project owner can do",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Attachments"
                 ],
-                "summary": "Update a task",
+                "summary": "Delete an attachment",
                 "parameters": [
                     {
-                        "description": "Task details to be updated",
-                        "name": "task",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
-                        }
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task updated successfully",
+                        "description": "Attachment deleted",
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "403": {
+                        "description": "Not the uploader or project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -75,50 +85,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/comments": {
-            "post": {
+        "/attachments/{id}/content": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new comment associated with a specific task",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Stream the content of an attached file as a download",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Comments"
+                    "Attachments"
                 ],
-                "summary": "Create a new comment",
+                "summary": "Download an attachment",
                 "parameters": [
                     {
-                        "description": "Comment Creation Request",
-                        "name": "comment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Comment"
-                        }
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Comment created successfully",
+                    "200": {
+                        "description": "Content of the file",
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Comment"
+                            "type": "file"
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "403": {
+                        "description": "Not a project member",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -126,69 +137,121 @@ const docTemplate = `{
                 }
             }
         },
-        "/comments/{id}": {
+        "/audit": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a comment using its unique ID",
+                "description": "Retrieve the paginated audit log of every change, newest first. Only available to admins.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Audit"
                 ],
-                "summary": "Get a comment by ID",
+                "summary": "Get the audit log",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Comment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only changes made by this user",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "create, update, delete, member_added or member_removed",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "user, project, task, team, comment or webhook",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only changes of this entity",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes made at or after this RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes made before this RFC 3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of entries per page",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Successful response",
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Comment"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid filter",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "Comment not found",
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/checklist-items/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a comment from the system by its ID",
+                "description": "Remove an item from the checklist of its task",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Checklists"
                 ],
-                "summary": "Delete a comment",
+                "summary": "Delete a checklist item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Comment ID to delete",
+                        "description": "Checklist item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -196,7 +259,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Comment deleted successfully",
+                        "description": "Item deleted",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -204,14 +267,20 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -219,60 +288,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects": {
-            "get": {
+        "/checklist-items/{id}/toggle": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated list of projects",
+                "description": "Check an unchecked item as done by the current user, or uncheck a checked one",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Checklists"
                 ],
-                "summary": "Get all projects",
+                "summary": "Toggle a checklist item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of projects per page",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "Checklist item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Item toggled",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/example_project-management-system_internal_models.ChecklistItem"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/comments": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new project with provided details",
+                "description": "Create a new comment associated with a specific task",
                 "consumes": [
                     "application/json"
                 ],
@@ -280,28 +355,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Comments"
                 ],
-                "summary": "Create a new project",
+                "summary": "Create a new comment",
                 "parameters": [
                     {
-                        "description": "Project Creation Request",
-                        "name": "project",
+                        "description": "Comment Creation Request",
+                        "name": "comment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Comment"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Project created successfully",
+                        "description": "Comment created successfully",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Comment"
                         }
                     },
                     "400": {
@@ -310,6 +388,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
+                    "409": {
+                        "description": "Request with the Idempotency-Key in progress, or the project is archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Server error",
                         "schema": {
@@ -319,35 +409,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}": {
+        "/comments/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific project by their unique identifier",
+                "description": "Retrieve a comment using its unique ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Comments"
                 ],
-                "summary": "Get project by ID",
+                "summary": "Get a comment by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
+                        "description": "Comment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "Set to html to include sanitized content_html",
+                        "name": "render",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Successful response",
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Comment"
                         }
                     },
                     "400": {
@@ -357,53 +456,44 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Comment not found",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a project's details by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove a comment from the system by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Comments"
                 ],
-                "summary": "Update an existing project",
+                "summary": "Delete a comment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
+                        "description": "Comment ID to delete",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated Project Details",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Comment deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -412,55 +502,89 @@ const docTemplate = `{
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "409": {
+                        "description": "The project is archived",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
-                    }
-                }
-            },
-            "delete": {
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a project from the system by their ID",
+                "description": "Apply a JSON merge patch (RFC 7396) to the content of a comment. Omitted fields are kept and null resets a field.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Comments"
                 ],
-                "summary": "Delete a project",
+                "summary": "Partially update a comment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID to delete",
+                        "description": "Comment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Comment"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Project deleted successfully",
+                        "description": "The updated comment",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Comment"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid patch",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "403": {
+                        "description": "Only the author can change a comment",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "The body is not a merge patch",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -468,110 +592,138 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{projectID}/tasks": {
+        "/comments/{id}/attachments": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list task by their ID",
+                "description": "Retrieve the files attached to a comment, oldest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Attachments"
                 ],
-                "summary": "Get task by project ID",
+                "summary": "Get the attachments of a comment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
-                        "name": "projectID",
+                        "description": "Comment ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Attachments",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.Attachment"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "403": {
+                        "description": "Not a project member",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
-            }
-        },
-        "/projects/{project_id}/tasks": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated tasks associated with a specific project",
+                "description": "Upload a file to a comment as multipart/form-data in the file field. Only project members can attach files, the size and type of which are limited.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Attachments"
                 ],
-                "summary": "Retrieve tasks by project ID",
+                "summary": "Attach a file to a comment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
-                        "name": "project_id",
+                        "description": "Comment ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size (default: 10)",
-                        "name": "page_size",
-                        "in": "query"
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Paginated list of tasks",
+                    "201": {
+                        "description": "File attached",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Attachment"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid upload",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a project member",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "File type not allowed",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -579,14 +731,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks": {
+        "/comments/{id}/reactions": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new task with provided details",
+                "description": "Add an emoji reaction of the current user to a comment",
                 "consumes": [
                     "application/json"
                 ],
@@ -594,27 +746,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Reactions"
                 ],
-                "summary": "Create a new task",
+                "summary": "React to a comment",
                 "parameters": [
                     {
-                        "description": "Task Creation Request",
-                        "name": "task",
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Emoji shortcode",
+                        "name": "reaction",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                            "$ref": "#/definitions/internal_handlers.ReactionRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Task created successfully",
+                        "description": "Updated reaction counts",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.ReactionCount"
                             }
                         }
                     },
@@ -624,8 +789,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Reaction already exists",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key used for a different request",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -633,69 +810,87 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}": {
-            "get": {
+        "/comments/{id}/reactions/{emoji}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific task by their unique identifier",
+                "description": "Remove an emoji reaction of the current user from a comment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Reactions"
                 ],
-                "summary": "Get task by ID",
+                "summary": "Remove a comment reaction",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Comment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Emoji shortcode",
+                        "name": "emoji",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Updated reaction counts",
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.ReactionCount"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Reaction not found",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/comments/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a task by its ID",
+                "description": "Take a deleted comment out of the trash",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Trash"
                 ],
-                "summary": "Delete a task",
+                "summary": "Restore a comment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
+                        "description": "Comment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -703,7 +898,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task deleted successfully",
+                        "description": "Comment restored",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -711,14 +906,20 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "404": {
+                        "description": "Not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The task is in the trash",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -726,148 +927,165 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{task_id}/comments": {
-            "get": {
+        "/custom-fields/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated comments associated with a specific task",
+                "description": "Change the name, options, default, position and whether a custom field is required. Its key and type cannot change. Removed options are cleared from the tasks that have them.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Custom fields"
                 ],
-                "summary": "Get comments by task",
+                "summary": "Update a custom field",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "task_id",
+                        "description": "Custom field ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of comments per page",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Custom field",
+                        "name": "field",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.CustomFieldRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Custom field updated",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/example_project-management-system_internal_models.CustomField"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid custom field",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Custom field not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
-            }
-        },
-        "/teams": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated list of teams",
+                "description": "Delete a custom field with the values tasks have for it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Custom fields"
                 ],
-                "summary": "Get all teams",
+                "summary": "Delete a custom field",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of teams per page",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "Custom field ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Custom field deleted",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Custom field not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/me/email-preferences": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new team with provided details",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve how often the current user receives notifications by email",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
-                ],
-                "summary": "Create a new team",
-                "parameters": [
-                    {
-                        "description": "Team Creation Request",
-                        "name": "team",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
-                        }
-                    }
+                    "Notifications"
                 ],
+                "summary": "Get my email preference",
                 "responses": {
-                    "201": {
-                        "description": "Team created successfully",
+                    "200": {
+                        "description": "Email preference",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/example_project-management-system_internal_models.EmailPreference"
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -879,150 +1097,145 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/teams/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific team by their unique identifier",
+                "description": "Receive notifications by email immediately, as an hourly or daily digest, or not at all",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Notifications"
                 ],
-                "summary": "Get team by ID",
+                "summary": "Update my email preference",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Email frequency: immediate, hourly, daily or off",
+                        "name": "preference",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.EmailPreference"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Updated email preference",
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
+                            "$ref": "#/definitions/example_project-management-system_internal_models.EmailPreference"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid input",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/me/notification-preferences": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a team's details by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve whether the current user is subscribed to each notification event type",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
-                ],
-                "summary": "Update an existing team",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated Team Details",
-                        "name": "team",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
-                        }
-                    }
+                    "Notifications"
                 ],
+                "summary": "Get my notification preferences",
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Subscription per event type",
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "500": {
+                        "description": "Server error",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a team from the system by their ID",
+                "description": "Subscribe to or unsubscribe from notification event types, e.g. {\"task.status_changed\": false}",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Notifications"
                 ],
-                "summary": "Delete a team",
+                "summary": "Update my notification preferences",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Team ID to delete",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Subscription per event type",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Team deleted successfully",
+                        "description": "Updated subscription per event type",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "string"
+                                "type": "boolean"
                             }
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid input",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -1030,22 +1243,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/me/notifications": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated list of users",
+                "description": "Retrieve the paginated inbox of the current user, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Notifications"
                 ],
-                "summary": "Get all users",
+                "summary": "Get my notifications",
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only return unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -1056,8 +1275,8 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 10,
-                        "description": "Number of users per page",
-                        "name": "pageSize",
+                        "description": "Number of notifications per page",
+                        "name": "page_size",
                         "in": "query"
                     }
                 ],
@@ -1069,45 +1288,39 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/me/notifications/read-all": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new user with provided details",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mark every unread notification of the current user as read",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Create a new user",
-                "parameters": [
-                    {
-                        "description": "Username",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.User"
-                        }
-                    }
+                    "Notifications"
                 ],
+                "summary": "Mark all notifications as read",
                 "responses": {
-                    "201": {
-                        "description": "User created successfully",
+                    "200": {
+                        "description": "Number of notifications marked as read",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1115,8 +1328,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -1130,121 +1343,318 @@ const docTemplate = `{
                 }
             }
         },
-        "/users-projects/{projectId}/users/{userId}": {
+        "/me/notifications/{id}/read": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a user to a specified project by their IDs",
+                "description": "Mark one notification of the current user as read",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "user_project"
+                    "Notifications"
                 ],
-                "summary": "Add a user to a project",
+                "summary": "Mark a notification as read",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "projectId",
+                        "description": "Notification ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "Notification marked as read",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
                     }
                 }
             }
         },
-        "/users/{id}": {
+        "/me/timer": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific user by their unique identifier",
+                "description": "Retrieve the running timer of the current user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Get user by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Time tracking"
                 ],
+                "summary": "Get my timer",
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Running timer",
                         "schema": {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Timer"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "No timer running",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove an user from the system by their ID",
+                "description": "Start the timer of the current user on a task. A user runs one timer at a time.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Time tracking"
                 ],
-                "summary": "Delete an user",
+                "summary": "Start my timer",
+                "parameters": [
+                    {
+                        "description": "Timer",
+                        "name": "timer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.TimerRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Timer started",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Timer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Timer already running or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop the timer of the current user without logging its time",
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Discard my timer",
+                "responses": {
+                    "204": {
+                        "description": "Timer discarded"
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No timer running",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/timer/stop": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop the timer of the current user and log the time since it was started, at least a minute. The timer keeps running when the time cannot be logged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Stop my timer",
+                "responses": {
+                    "201": {
+                        "description": "Time logged",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Worklog"
+                        }
+                    },
+                    "400": {
+                        "description": "Timer ran for more than a day",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No timer running",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlapping worklog, locked week or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/timesheets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the timesheets the current user submitted with their review, oldest week first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get my submitted timesheets",
+                "responses": {
+                    "200": {
+                        "description": "Timesheets",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.TimesheetSubmission"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/watching": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the paginated tasks the current user watches, most recently updated first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchers"
+                ],
+                "summary": "Get the tasks I watch",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID to delete",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of tasks per page",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Successful response",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthenticated",
                         "schema": {
                             "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
                         }
@@ -1257,26 +1667,6917 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "example_project-management-system_internal_models.Comment": {
-            "type": "object",
-            "properties": {
-                "content": {
+        },
+        "/milestones/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a milestone with its tasks and progress. It is at risk when the hours left on its open tasks exceed the capacity of the working days until its target date.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Milestones"
+                ],
+                "summary": "Get a milestone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Milestone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Milestone",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Milestone"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Milestone not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the name, description, target date and daily capacity of a milestone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Milestones"
+                ],
+                "summary": "Update a milestone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Milestone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Milestone",
+                        "name": "milestone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.MilestoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Milestone updated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Milestone"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid milestone",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Milestone not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a milestone, its tasks are kept without it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Milestones"
+                ],
+                "summary": "Delete a milestone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Milestone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Milestone deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Milestone not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/milestones/{id}/tasks": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Link tasks of the project to a milestone, moving them from the milestone they were linked to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Milestones"
+                ],
+                "summary": "Link tasks to a milestone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Milestone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tasks",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.MilestoneTasksRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Milestone with its tasks and progress",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Milestone"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or task of another project",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Milestone or task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/milestones/{id}/tasks/{taskId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unlink a task from its milestone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Milestones"
+                ],
+                "summary": "Unlink a task from a milestone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Milestone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Task unlinked"
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Milestone or task not found, or the task is not linked to the milestone",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/project-templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the paginated project templates, ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project templates"
+                ],
+                "summary": "Get project templates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of templates per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a template of the teams, task skeletons, labels and workflow new projects start with. Due dates are days from the start of the project. The workflow defaults to all task statuses, tasks without a status start in its first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project templates"
+                ],
+                "summary": "Create a project template",
+                "parameters": [
+                    {
+                        "description": "Template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.ProjectTemplate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Template created",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.ProjectTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid template",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the Idempotency-Key in progress",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/project-templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a project template with its teams, tasks and workflow",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project templates"
+                ],
+                "summary": "Get a project template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.ProjectTemplate"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a project template, projects created from it are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project templates"
+                ],
+                "summary": "Delete a project template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the template owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated list of projects",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get all projects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of projects per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include archived projects",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "Set to html to include sanitized description_html",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new project with provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Create a new project",
+                "parameters": [
+                    {
+                        "description": "Project Creation Request",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Project created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the Idempotency-Key in progress",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key used for a different request, or a status other than planned and active",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/from-template": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a project starting on start_date with the teams, tasks, labels and workflow of a template, in one transaction. The tasks are assigned to the caller. Due dates are the offsets of the template from the start date. The name and description default to those of the template.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Create a project from a template",
+                "parameters": [
+                    {
+                        "description": "Template and the new project",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.FromTemplateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Project created",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific project by their unique identifier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get project by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "Set to html to include sanitized description_html",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a project's details by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Update an existing project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Project Details",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the project"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived, or still has open tasks",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The project was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Illegal status change",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a project from the system by their ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Delete a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project version being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The project was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a JSON merge patch (RFC 7396) to the name, description, dates and status of a project. Omitted fields are kept and null resets a field.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Partially update a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated project",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the project"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid patch",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived, or still has open tasks",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The project was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "The body is not a merge patch",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Illegal status change",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a project with the teams, tasks, subtasks and labels of another, without members, in one transaction. The tasks are assigned to the caller. Due dates keep their distance to the start date, which defaults to the start date of the source. The name defaults to the source name followed by \"(copy)\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Clone a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name, description and start date of the clone",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_services.NewProjectOptions"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The clone",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/custom-fields": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the custom fields of the tasks of a project by position",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Custom fields"
+                ],
+                "summary": "Get the custom fields of a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Custom fields",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.CustomField"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a typed field to the tasks of a project owned by the current user. Tasks hold its values in custom_fields by key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Custom fields"
+                ],
+                "summary": "Add a custom field",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Custom field",
+                        "name": "field",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.CustomFieldRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Custom field created",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.CustomField"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid custom field",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the paginated changes made to a project and its members, newest first. Only available to its members and admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get the history of a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of entries per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a project member",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/milestones": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the milestones of a project by target date with their progress: done and total tasks, the hours left on the open tasks, the capacity until the target date and whether it is at risk",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Milestones"
+                ],
+                "summary": "Get the milestones of a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Milestones",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.Milestone"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a release with a target date to a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Milestones"
+                ],
+                "summary": "Add a milestone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Milestone",
+                        "name": "milestone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.MilestoneRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Milestone created",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Milestone"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid milestone",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take a deleted project out of the trash, with the teams, tasks and comments deleted along with it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project restored",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/sprints": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the sprints of a project in the order they start, without their tasks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Get the sprints of a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sprints",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.Sprint"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a planned sprint to a project. The end date is the last day of the sprint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Plan a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sprint",
+                        "name": "sprint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.SprintRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Sprint created",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Sprint"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sprint",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/status": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a project through its lifecycle: planned, active, on_hold, completed and archived. Completing a project needs all its tasks done unless forced. Archived projects, with their tasks, teams and comments, are read-only until brought back as active or completed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Change the status of a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.ProjectStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The project",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the project"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project still has open tasks",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The project was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Illegal status change",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/templates": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a template of the teams and tasks of a project, without members and assignees. Due dates become days from the start date of the project and are left out when it has none.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project templates"
+                ],
+                "summary": "Capture a project as a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template name",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.CaptureTemplateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Template created",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.ProjectTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/timesheet": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total the time logged on the tasks of a project per day or week, user and task. Defaults to the current week.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get the timesheet of a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "day or week",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "json or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timesheet",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Timesheet"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/timesheets": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the time the current user logged on the tasks of a project in a week to the project owner for approval. The worklogs of the week are locked until the timesheet is rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Submit a weekly timesheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Week",
+                        "name": "timesheet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.SubmitTimesheetRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Timesheet submitted",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.TimesheetSubmission"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid week or no time logged",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Already submitted or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/timesheets/pending": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the submitted timesheets of a project waiting for review with their total hours, oldest week first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get the pending approvals of a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pending approvals",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.PendingApprovals"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the webhooks registered for a project",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get project webhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhooks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.Webhook"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register an endpoint receiving HMAC-SHA256 signed project events. The secret is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.WebhookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook created",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the Idempotency-Key in progress",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{projectId}/users/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a user from a specified project by their IDs",
+                "tags": [
+                    "user_project"
+                ],
+                "summary": "Remove a user from a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated tasks associated with a specific project. Tasks are filtered by custom fields with cf.\u003ckey\u003e parameters: comma separated values match any of them, and min..max matches the numbers or dates in a range where either bound can be left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Retrieve tasks by project ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-cf.severity,title",
+                        "description": "Comma separated id, title, status, due_date, created_at, updated_at or cf.\u003ckey\u003e, descending when prefixed by -",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "Set to html to include sanitized description_html",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Paginated list of tasks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sprints/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a sprint with its tasks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Get a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sprint",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Sprint"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Sprint not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the name, goal and dates of a sprint that is not closed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Update a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sprint",
+                        "name": "sprint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.SprintRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sprint updated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Sprint"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sprint",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Sprint not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Sprint closed or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a planned or closed sprint, its tasks go back to the backlog",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Delete a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sprint deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Sprint not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Sprint active or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sprints/{id}/burndown": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the tasks in the sprint and those not done at the end of each of its days up to today, computed from the task history, with the ideal line from the work of the first day to none on the last. The last day of a closed sprint is counted when it was closed, before its unfinished tasks were carried over.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Get the burndown of a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Burndown",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Burndown"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Sprint not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sprints/{id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close the active sprint. Its tasks that are not done are carried over to carry_over_to, or back to the backlog when it is left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Close a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sprint the unfinished tasks are carried over to",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.CloseSprintRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sprint closed, with its done tasks",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Sprint"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid carry-over sprint",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Sprint not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Sprint not active or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sprints/{id}/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make a planned sprint the active sprint of its project, a project has one active sprint at a time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Start a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sprint started",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Sprint"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Sprint not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Sprint not planned, another sprint active or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sprints/{id}/tasks": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Plan tasks of the project in a sprint that is not closed, moving them from the backlog or another sprint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Add tasks to a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tasks",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.SprintTasksRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sprint with its tasks",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Sprint"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or task of another project",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Sprint or task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Sprint closed or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sprints/{id}/tasks/{taskId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a task of a sprint that is not closed back to the backlog",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sprints"
+                ],
+                "summary": "Remove a task from a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Task removed"
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Sprint or task not found, or the task is not in the sprint",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Sprint closed or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new task with provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Create a new task",
+                "parameters": [
+                    {
+                        "description": "Task Creation Request",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Task created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the Idempotency-Key in progress, or the project is archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create tasks, update their assignee, status, labels and due date, move them to another project or delete them, up to 100 operations per call. With atomic all operations are applied or none: when one fails the response is 422 and the others report 424. Otherwise each operation is applied on its own and its result tells whether it failed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Change many tasks at once",
+                "parameters": [
+                    {
+                        "description": "Operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.BulkTaskRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Results of the operations, in order",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "An atomic request failed and was rolled back",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific task by their unique identifier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Get task by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "Set to html to include sanitized description_html",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the task, for If-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a task's details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Update a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the task version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Task details to be updated",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived, or the checklist is incomplete",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The task was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a task by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Delete a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the task version being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The task was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a JSON merge patch (RFC 7396) to the title, description, status, assignee, labels, due date, estimates and custom fields of a task. Omitted fields are kept and null resets a field.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Partially update a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the task version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated task",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid patch",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived, or the checklist is incomplete",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The task was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "The body is not a merge patch",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the files attached to a task and to its comments, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Get the attachments of a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachments",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.Attachment"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a project member",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a file to a task as multipart/form-data in the file field. Only project members can attach files, the size and type of which are limited.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Attach a file to a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "File attached",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid upload",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a project member",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "File type not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the items of the checklist of a task in order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Get the checklist of a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checklist",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.ChecklistItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an unchecked item at the end of the checklist of a task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Add a checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.ChecklistItemRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Item added",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.ChecklistItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Order the checklist of a task as the given items, which list each of its items once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Reorder a checklist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Items in order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.ReorderChecklistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reordered checklist",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.ChecklistItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Items missing or unknown",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/copy": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copy a task, with its subtasks, comments and labels unless they are left out, into a project, which may be its own. The assignees must be members of that project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Copy a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target project and what to carry along, all carried by default",
+                        "name": "options",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_services.TaskTransferOptions"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The copy",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task or project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the Idempotency-Key in progress, or the project is archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "An assignee is not a member of the project",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the paginated changes made to a task, newest first. Only available to members of its project and admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Get the history of a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of entries per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a project member",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a task, with its subtasks, comments and labels unless they are left out, to another project. The assignees must be members of that project. Subtasks left out become tasks of their own in the old project, comments left out go to the trash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Move a task to another project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the task version being moved",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Target project and what to carry along, all carried by default",
+                        "name": "options",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_services.TaskTransferOptions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The moved task",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task or project not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived, or time logged on the task is in a submitted or approved timesheet",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The task was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "An assignee is not a member of the project",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/reactions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an emoji reaction of the current user to a task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reactions"
+                ],
+                "summary": "React to a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Emoji shortcode",
+                        "name": "reaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.ReactionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Updated reaction counts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.ReactionCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Reaction already exists",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/reactions/{emoji}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an emoji reaction of the current user from a task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reactions"
+                ],
+                "summary": "Remove a task reaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Emoji shortcode",
+                        "name": "emoji",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated reaction counts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.ReactionCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Reaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take a deleted task out of the trash, with the comments deleted along with it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task restored",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/watch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Notify the current user about the changes and comments of a task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchers"
+                ],
+                "summary": "Watch a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watchers of the task",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop notifying the current user about the changes and comments of a task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchers"
+                ],
+                "summary": "Stop watching a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watchers of the task",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/watchers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the users notified about the changes and comments of a task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchers"
+                ],
+                "summary": "Get the watchers of a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watchers of the task",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/worklogs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the time logged on a task in the order it was started",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get the worklogs of a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Worklogs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/example_project-management-system_internal_models.Worklog"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Log time of the current user on a task. Worklogs of a user cannot overlap.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Log time on a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Worklog",
+                        "name": "worklog",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.WorklogRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Time logged",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Worklog"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid worklog",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlapping worklog, locked week or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{task_id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated comments associated with a specific task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Get comments by task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "task_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of comments per page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "Set to html to include sanitized content_html",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated list of teams",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get all teams",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of teams per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new team with provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Create a new team",
+                "parameters": [
+                    {
+                        "description": "Team Creation Request",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Team created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the Idempotency-Key in progress, or the project is archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific team by their unique identifier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get team by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the team, for If-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a team's details by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Update an existing team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the team version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Team Details",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the team"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The team was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a team from the system by their ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Delete a team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the team version being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Team deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The team was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a JSON merge patch (RFC 7396) to the name and description of a team. Omitted fields are kept and null resets a field.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Partially update a team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the team version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated team",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Team"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the team"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid patch",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The team was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "The body is not a merge patch",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the paginated changes made to a team, newest first. Only available to members of its project and admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get the history of a team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of entries per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a project member",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take a deleted team out of the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Team restored",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The project is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/timesheets/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a submitted timesheet with the worklogs of its week",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get a submitted timesheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Timesheet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timesheet",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.TimesheetSubmission"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Timesheet not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/timesheets/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a submitted timesheet of a project owned by the current user. Its worklogs stay locked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Approve a timesheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Timesheet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.ReviewTimesheetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timesheet approved",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.TimesheetSubmission"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Timesheet not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Timesheet already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/timesheets/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a submitted timesheet of a project owned by the current user back with a comment, unlocking its worklogs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Reject a timesheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Timesheet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.ReviewTimesheetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timesheet rejected",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.TimesheetSubmission"
+                        }
+                    },
+                    "400": {
+                        "description": "Comment missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Timesheet not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Timesheet already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the paginated deleted users, projects, tasks, teams and comments, most recently deleted first. They can be restored until they are purged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Get the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user, project, task, team or comment",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid type",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated list of users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of users per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new user with provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "Username",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of the request return its first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the Idempotency-Key in progress",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users-projects/{projectId}/users/{userId}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a user to a specified project by their IDs",
+                "tags": [
+                    "user_project"
+                ],
+                "summary": "Add a user to a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific user by their unique identifier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the user, for If-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an user from the system by their ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete an user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user version being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The user was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a JSON merge patch (RFC 7396) to the email and names of an user. Omitted fields are kept and null resets a field.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Partially update an user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated user",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid patch",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the user and administrators can change a user",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The user was changed since that version",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "The body is not a merge patch",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take a deleted user out of the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User restored",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/timesheet": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total the time logged by a user per day or week and task. Defaults to the current week.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get the timesheet of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "day or week",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "json or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timesheet",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Timesheet"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a webhook, including whether it was disabled after repeated failures",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Webhook"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the URL, event filter, secret or active flag of a webhook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated webhook",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook and its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the paginated delivery log of a webhook, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of deliveries per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the payload of a previous delivery again, as a new delivery attempted right away",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "New delivery",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.WebhookDelivery"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the project owner",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook or delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/worklogs/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the start, duration and note of a worklog of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Update a worklog",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Worklog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Worklog",
+                        "name": "worklog",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.WorklogRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Worklog updated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Worklog"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid worklog",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Worklog of another user",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Worklog not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlapping worklog, locked week or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a worklog of the current user",
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Delete a worklog",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Worklog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Worklog deleted"
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Worklog of another user",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Worklog not found",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Locked week or project archived",
+                        "schema": {
+                            "$ref": "#/definitions/example_project-management-system_internal_utils_response.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "example_project-management-system_internal_models.Attachment": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "Hex encoded SHA-256 of the content",
+                    "type": "string"
+                },
+                "comment_id": {
+                    "description": "Set when the file is attached to a comment of the task",
+                    "type": "integer"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "bytes",
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "uploaded_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Burndown": {
+            "type": "object",
+            "properties": {
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.BurndownPoint"
+                    }
+                },
+                "sprint_id": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "description": "what the work is counted in, tasks",
+                    "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.BurndownPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD, in UTC",
+                    "type": "string"
+                },
+                "ideal": {
+                    "description": "Remaining work on a straight line from the first day to none on the last",
+                    "type": "number"
+                },
+                "remaining": {
+                    "description": "tasks in the sprint that are not done",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "tasks in the sprint",
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.ChecklistItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "done_at": {
+                    "type": "string"
+                },
+                "done_by": {
+                    "description": "User who checked the item and when, unset while it is unchecked",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "Order of the item in the checklist, from 1",
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.ChecklistProgress": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Comment": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "CommonMark source",
+                    "type": "string"
+                },
+                "content_html": {
+                    "description": "Sanitized HTML rendering of Content, only set when requested with ?render=html",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reactions": {
+                    "description": "Aggregated emoji reactions, filled by the repository",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.ReactionCount"
+                    }
+                },
+                "task": {
+                    "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.CustomField": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "default": {
+                    "description": "Value of new tasks that set none, of the type of the field",
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "Lowercase letters, digits and underscores, used in task values, filters and sorting",
+                    "type": "string",
+                    "example": "severity"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "description": "Choices of select and multi_select fields",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "date",
+                        "select",
+                        "multi_select",
+                        "user"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.EmailPreference": {
+            "type": "object",
+            "properties": {
+                "frequency": {
+                    "type": "string",
+                    "example": "hourly"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Milestone": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_capacity": {
+                    "description": "Hours per working day the team spends on the milestone, 8 for each project\nmember and assignee when 0",
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "progress": {
+                    "$ref": "#/definitions/example_project-management-system_internal_models.MilestoneProgress"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "target_date": {
+                    "type": "string"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.MilestoneProgress": {
+            "type": "object",
+            "properties": {
+                "at_risk": {
+                    "description": "The remaining estimate exceeds the capacity",
+                    "type": "boolean"
+                },
+                "capacity": {
+                    "description": "Hours the team can spend on the milestone in the working days up to its target date",
+                    "type": "number"
+                },
+                "done_tasks": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "description": "done tasks of all tasks",
+                    "type": "number"
+                },
+                "remaining_estimate": {
+                    "description": "Hours of work left on the tasks that are not done",
+                    "type": "number"
+                },
+                "total_tasks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.PendingApprovals": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "timesheets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.TimesheetSubmission"
+                    }
+                },
+                "total_hours": {
+                    "type": "number"
+                },
+                "total_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Project": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "description": "CommonMark source",
+                    "type": "string"
+                },
+                "description_html": {
+                    "description": "Sanitized HTML rendering of Description, only set when requested with ?render=html",
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "User who created the project, allowed to manage its webhooks",
+                    "type": "integer"
+                },
+                "require_checklist": {
+                    "description": "Tasks cannot be done while their checklist has unchecked items",
+                    "type": "boolean"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "planned",
+                        "active",
+                        "on_hold",
+                        "completed",
+                        "archived"
+                    ]
+                },
+                "tasks": {
+                    "description": "One-to-Many with Tasks",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                    }
+                },
+                "teams": {
+                    "description": "One-to-Many with Teams",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.Team"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.ProjectTemplate": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_offset_days": {
+                    "description": "Days from the start to the end of a project, no end date when null",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "user who created the template",
+                    "type": "integer"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.TemplateTask"
+                    }
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.TemplateTeam"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workflow": {
+                    "description": "Task statuses the project works through in order, new tasks start in the first",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "example_project-management-system_internal_models.ReactionCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "emoji": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Sprint": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "description": "When the sprint was closed, its unfinished tasks were carried over then",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "description": "last day of the sprint",
+                    "type": "string"
+                },
+                "goal": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "planned",
+                        "active",
+                        "closed"
+                    ]
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Task": {
+            "type": "object",
+            "properties": {
+                "assigned_to": {
+                    "description": "Many-to-One với User",
+                    "type": "integer"
+                },
+                "assignee": {
+                    "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                },
+                "checklist": {
+                    "description": "Checked and total items of the checklist of the task, filled by the repository",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.ChecklistProgress"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "custom_fields": {
+                    "description": "Values of the custom fields of the project by their key, filled by the repository",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "description": "CommonMark source",
+                    "type": "string"
+                },
+                "description_html": {
+                    "description": "Sanitized HTML rendering of Description, only set when requested with ?render=html",
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "description": "stored as a JSON array",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "milestone_id": {
+                    "description": "Milestone of the project the task is needed for",
+                    "type": "integer"
+                },
+                "original_estimate": {
+                    "description": "Hours of work the task was estimated at, and hours of work left on it",
+                    "type": "number"
+                },
+                "parent_id": {
+                    "description": "Task this is a subtask of, in the same project. Subtasks have no subtasks.",
+                    "type": "integer"
+                },
+                "project": {
+                    "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                },
+                "project_id": {
+                    "description": "Many-to-One với Project",
+                    "type": "integer"
+                },
+                "reactions": {
+                    "description": "Aggregated emoji reactions, filled by the repository",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.ReactionCount"
+                    }
+                },
+                "remaining_estimate": {
+                    "type": "number"
+                },
+                "sprint_id": {
+                    "description": "Sprint of the project the task is planned in, in the backlog when null",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "todo, in_progress or done",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Team": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project": {
+                    "description": "onUpdate:CASCADE: Khi ProjectID trong bảng Project thay đổi, nó sẽ cập nhật tự động trong bảng Team. onDelete:SET NULL: Nếu một project bị xóa, ProjectID trong bảng Team sẽ được đặt thành NULL thay vì xóa toàn bộ team.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                        }
+                    ]
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "users": {
+                    "description": "onUpdate:CASCADE: Khi UserID trong bảng User thay đổi, liên kết trong bảng trung gian (user_teams) sẽ được cập nhật. onDelete:CASCADE: Khi một user bị xóa, liên kết trong bảng trung gian (user_teams) cũng bị xóa.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.TemplateTask": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "due_offset_days": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.TemplateTask"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.TemplateTeam": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Timer": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Timesheet": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "YYYY-MM-DD, in UTC",
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string",
+                    "enum": [
+                        "day",
+                        "week"
+                    ]
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.TimesheetRow"
+                    }
+                },
+                "to": {
+                    "description": "last day, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "total_hours": {
+                    "type": "number"
+                },
+                "total_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.TimesheetRow": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "number"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "task_title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.TimesheetSubmission": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Comment of the reviewer, required to reject the timesheet",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "submitted",
+                        "approved",
+                        "rejected"
+                    ]
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "total_minutes": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "week_start": {
+                    "description": "Monday the week starts on, in UTC",
+                    "type": "string"
+                },
+                "worklogs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.Worklog"
+                    }
+                }
+            }
+        },
+        "example_project-management-system_internal_models.User": {
+            "description": "User model with basic information and relationships",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "email": {
+                    "description": "@Description Unique email address of the user",
+                    "type": "string"
+                },
+                "first_name": {
+                    "description": "@Description User's first name",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "description": "@Description User's last name",
+                    "type": "string"
+                },
+                "project_ids": {
+                    "description": "Relationships are typically not serialized in Swagger docs",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                    }
+                },
+                "role": {
+                    "description": "@Description User's role in the system",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "description": "@Description Unique username for the user",
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "consecutive_failures": {
+                    "description": "Failed deliveries since the last successful one, the webhook is disabled after too many",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Event types sent to the endpoint, every webhook event type when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Shared secret for the X-Webhook-Signature header, only returned when the webhook is created",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "response_body": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending, succeeded or failed",
                     "type": "string"
                 },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_models.Worklog": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "deleted_at": {
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "ended_at": {
+                    "description": "StartedAt plus the duration, set when the worklog is saved",
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "task": {
-                    "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                "note": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
                 },
                 "task_id": {
                     "type": "integer"
@@ -1284,208 +8585,356 @@ const docTemplate = `{
                 "updated_at": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/example_project-management-system_internal_models.User"
-                },
                 "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "example_project-management-system_internal_models.Project": {
+        "example_project-management-system_internal_services.BulkTaskOperation": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "fields": {
+                    "type": "object"
                 },
-                "deleted_at": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "description": {
-                    "type": "string"
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "move",
+                        "delete"
+                    ]
                 },
-                "end_date": {
-                    "type": "string"
+                "project_id": {
+                    "type": "integer"
                 },
-                "id": {
+                "task": {
+                    "$ref": "#/definitions/example_project-management-system_internal_models.Task"
+                },
+                "version": {
                     "type": "integer"
+                }
+            }
+        },
+        "example_project-management-system_internal_services.NewProjectOptions": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
                 "start_date": {
                     "type": "string"
+                }
+            }
+        },
+        "example_project-management-system_internal_services.TaskTransferOptions": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "boolean"
                 },
-                "status": {
-                    "type": "string"
-                },
-                "tasks": {
-                    "description": "One-to-Many with Tasks",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/example_project-management-system_internal_models.Task"
-                    }
+                "labels": {
+                    "type": "boolean"
                 },
-                "teams": {
-                    "description": "One-to-Many with Teams",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/example_project-management-system_internal_models.Team"
-                    }
+                "project_id": {
+                    "type": "integer"
                 },
-                "updated_at": {
+                "subtasks": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "example_project-management-system_internal_utils_response.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string"
                 },
-                "user_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handlers.BulkTaskRequest": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
                 },
-                "users": {
+                "operations": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                        "$ref": "#/definitions/example_project-management-system_internal_services.BulkTaskOperation"
                     }
                 }
             }
         },
-        "example_project-management-system_internal_models.Task": {
+        "internal_handlers.CaptureTemplateRequest": {
             "type": "object",
             "properties": {
-                "assigned_to": {
-                    "description": "Many-to-One với User",
-                    "type": "integer"
-                },
-                "assignee": {
-                    "$ref": "#/definitions/example_project-management-system_internal_models.User"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "deleted_at": {
+                "name": {
+                    "description": "Defaults to the name of the project",
                     "type": "string"
+                }
+            }
+        },
+        "internal_handlers.ChecklistItemRequest": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "Screenshots attached"
+                }
+            }
+        },
+        "internal_handlers.CloseSprintRequest": {
+            "type": "object",
+            "properties": {
+                "carry_over_to": {
+                    "description": "Planned or active sprint of the project, the backlog when null",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handlers.CustomFieldRequest": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "description": "Value of new tasks that set none, of the type of the field",
+                    "type": "object"
                 },
-                "description": {
-                    "type": "string"
+                "key": {
+                    "description": "Lowercase letters, digits and underscores, cannot change",
+                    "type": "string",
+                    "example": "severity"
                 },
-                "id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "Severity"
                 },
-                "project": {
-                    "$ref": "#/definitions/example_project-management-system_internal_models.Project"
+                "options": {
+                    "description": "Choices of select and multi_select fields",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "low",
+                        "high"
+                    ]
                 },
-                "project_id": {
-                    "description": "Many-to-One với Project",
+                "position": {
                     "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "required": {
+                    "type": "boolean"
                 },
-                "updated_at": {
-                    "type": "string"
+                "type": {
+                    "description": "Cannot change",
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "date",
+                        "select",
+                        "multi_select",
+                        "user"
+                    ],
+                    "example": "select"
                 }
             }
         },
-        "example_project-management-system_internal_models.Team": {
+        "internal_handlers.FromTemplateRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "description": {
                     "type": "string"
                 },
-                "deleted_at": {
+                "name": {
                     "type": "string"
                 },
-                "description": {
+                "start_date": {
                     "type": "string"
                 },
-                "id": {
+                "template_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "internal_handlers.MilestoneRequest": {
+            "type": "object",
+            "properties": {
+                "daily_capacity": {
+                    "description": "Hours per working day the team spends on the milestone, 8 for each project\nmember and assignee when 0",
+                    "type": "number"
                 },
-                "name": {
+                "description": {
                     "type": "string"
                 },
-                "project": {
-                    "description": "onUpdate:CASCADE: Khi ProjectID trong bảng Project thay đổi, nó sẽ cập nhật tự động trong bảng Team. onDelete:SET NULL: Nếu một project bị xóa, ProjectID trong bảng Team sẽ được đặt thành NULL thay vì xóa toàn bộ team.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/example_project-management-system_internal_models.Project"
-                        }
-                    ]
-                },
-                "project_id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "v2.0"
                 },
-                "updated_at": {
+                "target_date": {
                     "type": "string"
-                },
-                "users": {
-                    "description": "onUpdate:CASCADE: Khi UserID trong bảng User thay đổi, liên kết trong bảng trung gian (user_teams) sẽ được cập nhật. onDelete:CASCADE: Khi một user bị xóa, liên kết trong bảng trung gian (user_teams) cũng bị xóa.",
+                }
+            }
+        },
+        "internal_handlers.MilestoneTasksRequest": {
+            "type": "object",
+            "properties": {
+                "task_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/example_project-management-system_internal_models.User"
+                        "type": "integer"
                     }
                 }
             }
         },
-        "example_project-management-system_internal_models.User": {
-            "description": "User model with basic information and relationships",
+        "internal_handlers.ProjectStatusRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "force": {
+                    "description": "Completes the project even though some of its tasks are not done",
+                    "type": "boolean"
                 },
-                "deleted_at": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "planned",
+                        "active",
+                        "on_hold",
+                        "completed",
+                        "archived"
+                    ]
+                }
+            }
+        },
+        "internal_handlers.ReactionRequest": {
+            "type": "object",
+            "properties": {
+                "emoji": {
+                    "type": "string",
+                    "example": "thumbsup"
+                }
+            }
+        },
+        "internal_handlers.ReorderChecklistRequest": {
+            "type": "object",
+            "properties": {
+                "item_ids": {
+                    "description": "Every item of the checklist once, in the new order",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "internal_handlers.ReviewTimesheetRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Required to reject the timesheet",
                     "type": "string"
-                },
-                "email": {
-                    "description": "@Description Unique email address of the user",
+                }
+            }
+        },
+        "internal_handlers.SprintRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "last day of the sprint",
                     "type": "string"
                 },
-                "first_name": {
-                    "description": "@Description User's first name",
+                "goal": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "Sprint 14"
                 },
-                "last_name": {
-                    "description": "@Description User's last name",
+                "start_date": {
                     "type": "string"
-                },
-                "project_ids": {
-                    "description": "Relationships are typically not serialized in Swagger docs",
+                }
+            }
+        },
+        "internal_handlers.SprintTasksRequest": {
+            "type": "object",
+            "properties": {
+                "task_ids": {
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
+                }
+            }
+        },
+        "internal_handlers.SubmitTimesheetRequest": {
+            "type": "object",
+            "properties": {
+                "week_start": {
+                    "description": "Monday of the week, YYYY-MM-DD. Another day submits the week it falls in.",
+                    "type": "string",
+                    "example": "2026-03-02"
+                }
+            }
+        },
+        "internal_handlers.TimerRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
                 },
-                "projects": {
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handlers.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Only used on update, re-enables a disabled webhook",
+                    "type": "boolean"
+                },
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/example_project-management-system_internal_models.Project"
-                    }
-                },
-                "role": {
-                    "description": "@Description User's role in the system",
-                    "type": "string"
+                        "type": "string"
+                    },
+                    "example": [
+                        "task.created",
+                        "task.updated"
+                    ]
                 },
-                "updated_at": {
+                "secret": {
+                    "description": "Generated when empty on creation, kept when empty on update",
                     "type": "string"
                 },
-                "username": {
-                    "description": "@Description Unique username for the user",
-                    "type": "string"
+                "url": {
+                    "type": "string",
+                    "example": "https://ci.example.com/hooks/pms"
                 }
             }
         },
-        "example_project-management-system_internal_utils_response.Response": {
+        "internal_handlers.WorklogRequest": {
             "type": "object",
             "properties": {
-                "error": {
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "note": {
                     "type": "string"
                 },
-                "status": {
+                "started_at": {
                     "type": "string"
                 }
             }
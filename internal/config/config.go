@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 )
@@ -12,15 +13,87 @@ type Config struct {
 	AUTH0_DOMAIN string `env:"AUTH0_DOMAIN" envDefault:"https://dev-n5mocwlrk8i63cjm.us.auth0.com/"`
 	AUTH0_AUDIENCE string `env:"AUTH0_AUDIENCE" envDefault:"https://project-management-api"`
 	ENVIRONMENT string	`env:"ENVIRONMENT" envDefault:"local"`
+	BaseURL string `env:"BASE_URL" envDefault:"http://localhost:8080"`
+	TrustProxy bool `env:"TRUST_PROXY" envDefault:"false"` // take the client IP from X-Forwarded-For
+	RequireIfMatch bool `env:"REQUIRE_IF_MATCH" envDefault:"true"` // reject updates and deletes without the entity's ETag
+	Email EmailConfig // Embedded struct for email notification delivery
+	Webhooks WebhookConfig // Embedded struct for outgoing webhook delivery
+	Outbox OutboxConfig // Embedded struct for domain event dispatching
+	Trash TrashConfig // Embedded struct for soft deleted entities
+	Idempotency IdempotencyConfig // Embedded struct for retried create requests
+	Attachments AttachmentConfig // Embedded struct for files attached to tasks and comments
 }
 
+// DbConfig selects the database. Driver is "postgres", "mysql" or "sqlite", which
+// uses the file at Path, or an in-process database when it is ":memory:".
 type DbConfig struct {
+	Driver   string `env:"DB_DRIVER" envDefault:"postgres"`
 	Host     string `env:"DB_HOST" envDefault:"localhost"`
 	Port     string `env:"DB_PORT" envDefault:"5432"`
 	User     string `env:"DB_USER" envDefault:"postgres"`
 	Password string `env:"DB_PASSWORD" envDefault:"password"`
 	DBName   string `env:"DB_NAME" envDefault:"project_management_system"`
 	SSLMode  string `env:"SSL_MODE" envDefault:"disable"`
+	Path     string `env:"DB_PATH" envDefault:"project_management_system.db"`
+}
+
+// EmailConfig selects how notification emails are sent. Transport is "smtp",
+// "file" (write .eml files to FileDir) or empty to disable email delivery.
+type EmailConfig struct {
+	Transport      string `env:"EMAIL_TRANSPORT" envDefault:""`
+	From           string `env:"EMAIL_FROM" envDefault:"Project Management <no-reply@localhost>"`
+	SMTPHost       string `env:"SMTP_HOST" envDefault:"localhost"`
+	SMTPPort       string `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername   string `env:"SMTP_USERNAME" envDefault:""`
+	SMTPPassword   string `env:"SMTP_PASSWORD" envDefault:""`
+	FileDir        string `env:"EMAIL_FILE_DIR" envDefault:"mail"`
+	DailyDigestHour int   `env:"EMAIL_DAILY_DIGEST_HOUR" envDefault:"8"` // UTC
+}
+
+// WebhookConfig controls retries of failed webhook deliveries. The wait before
+// a retry starts at BackoffBase and doubles with every attempt.
+type WebhookConfig struct {
+	MaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"5"`
+	BackoffBase  time.Duration `env:"WEBHOOK_BACKOFF_BASE" envDefault:"30s"`
+	DisableAfter int           `env:"WEBHOOK_DISABLE_AFTER" envDefault:"10"` // consecutive failed deliveries
+	Timeout      time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+}
+
+// OutboxConfig controls how published events are read from the outbox. An event
+// missing for longer than GapTimeout is assumed rolled back and skipped.
+type OutboxConfig struct {
+	PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"1s"`
+	GapTimeout   time.Duration `env:"OUTBOX_GAP_TIMEOUT" envDefault:"30s"`
+	BatchSize    int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+}
+
+// TrashConfig controls how long deleted entities can be restored before they are
+// permanently deleted, and how often that is checked.
+type TrashConfig struct {
+	Retention     time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+	PurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
+}
+
+// IdempotencyConfig controls how long the responses to requests sent with an
+// Idempotency-Key are replayed, and how often expired ones are deleted.
+type IdempotencyConfig struct {
+	TTL             time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+	CleanupInterval time.Duration `env:"IDEMPOTENCY_CLEANUP_INTERVAL" envDefault:"1h"`
+}
+
+// AttachmentConfig selects where attached files are stored and limits them. Storage
+// is "local" (files in Dir) or "s3", a bucket of an S3-compatible service such as
+// MinIO. AllowedTypes are media types, image/* allows every image type.
+type AttachmentConfig struct {
+	Storage      string   `env:"ATTACHMENT_STORAGE" envDefault:"local"`
+	Dir          string   `env:"ATTACHMENT_DIR" envDefault:"attachments"`
+	MaxSize      int64    `env:"ATTACHMENT_MAX_SIZE" envDefault:"26214400"` // bytes
+	AllowedTypes []string `env:"ATTACHMENT_ALLOWED_TYPES" envDefault:"image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain,text/csv,application/zip" envSeparator:","`
+	S3Endpoint   string   `env:"S3_ENDPOINT" envDefault:"http://localhost:9000"`
+	S3Region     string   `env:"S3_REGION" envDefault:"us-east-1"`
+	S3Bucket     string   `env:"S3_BUCKET" envDefault:"attachments"`
+	S3AccessKey  string   `env:"S3_ACCESS_KEY" envDefault:""`
+	S3SecretKey  string   `env:"S3_SECRET_KEY" envDefault:""`
 }
 
 func LoadEnvConfigs() *Config {
@@ -2,39 +2,36 @@ package database
 
 import (
 	"example/project-management-system/internal/config"
-	"example/project-management-system/internal/migrations"
 	"example/project-management-system/pkg/logger"
 	"fmt"
+	"net/url"
 
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+)
+
+// sqliteMemory is the SQLite path of a database that only lives in the process
+const sqliteMemory = ":memory:"
+
 type Database struct {
-	DB *gorm.DB
+	DB  *gorm.DB
 	Log logger.Logger
 }
 
-type MigrationVersion struct {
-    Version int `gorm:"primaryKey"`
-}
-
-func NewPostgresConnection(cfg *config.Config, logger logger.Logger) *Database {
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Db.Host, cfg.Db.Port, cfg.Db.User, cfg.Db.Password, cfg.Db.DBName, cfg.Db.SSLMode,
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// NewConnection connects to the database of the configured driver. The schema is
+// not migrated, that is done with the migrate command, see Migrator.
+func NewConnection(cfg *config.Config, logger logger.Logger) *Database {
+	db, err := Open(cfg.Db)
 	if err != nil {
-		logger.Fatal("Failed to connect database", "error", err)
-	}
-
-	// Auto migrate models
-	err = Migrate(db)
-
-	if err != nil {
-		logger.Fatal("Failed to migrate database", "error", err)
+		logger.Fatal("Failed to connect database", "driver", cfg.Db.Driver, "error", err)
 	}
 
 	return &Database{
@@ -43,50 +40,51 @@ func NewPostgresConnection(cfg *config.Config, logger logger.Logger) *Database {
 	}
 }
 
-func Migrate(db *gorm.DB) error {
-	tx := db.Begin()
-	if tx.Error != nil {
-        return fmt.Errorf("failed to begin transaction: %v", tx.Error)
-    }
-
-    // Rollback if err
-    defer func() {
-        if r := recover(); r != nil {
-            tx.Rollback()
-        }
-    }()
-
-
-    err := tx.AutoMigrate(&MigrationVersion{})
-    if err != nil {
-		tx.Rollback()
-        return err
-    }
-
-    migrationFuncs := []func(*gorm.DB) error{
-        migrations.MigrateV1,
-        migrations.MigrateV2,
-    }
-
-    for i, migrate := range migrationFuncs {
-        version := i + 1
-        
-        var existingVersion MigrationVersion
-        err := tx.Where("version = ?", version).First(&existingVersion).Error
-        if err == gorm.ErrRecordNotFound {
-            err := migrate(tx)
-            if err != nil {
-				tx.Rollback()
-                return fmt.Errorf("migration v%d failed: %v", version, err)
-            }
+// Open opens a connection pool to a PostgreSQL, MySQL or SQLite database.
+func Open(cfg config.DbConfig) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch cfg.Driver {
+	case DriverPostgres:
+		dialector = postgres.Open(fmt.Sprintf(
+			"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+			cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
+		))
+	case DriverMySQL:
+		dialector = mysql.Open(fmt.Sprintf(
+			"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+			cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DBName,
+		))
+	case DriverSQLite:
+		dialector = sqlite.Open(sqliteDSN(cfg.Path))
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", cfg.Driver)
+	}
 
-            tx.Create(&MigrationVersion{Version: version})
-        }
-    }
+	// Translated errors let callers tell e.g. duplicate keys apart on every driver
+	db, err := gorm.Open(dialector, &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, err
+	}
 
-    if err := tx.Commit().Error; err != nil {
-        return fmt.Errorf("databae failed to commit transaction: %v", err)
-    }
+	// Every connection to an in-memory SQLite database opens a new, empty one
+	if cfg.Driver == DriverSQLite && cfg.Path == sqliteMemory {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+	return db, nil
+}
 
-    return nil
+// sqliteDSN enables foreign keys, which SQLite does not enforce by default, and
+// waits for locks held by other connections to the same file.
+func sqliteDSN(path string) string {
+	pragmas := url.Values{}
+	pragmas.Add("_pragma", "foreign_keys(1)")
+	pragmas.Add("_pragma", "busy_timeout(5000)")
+	if path != sqliteMemory {
+		pragmas.Add("_pragma", "journal_mode(WAL)")
+	}
+	return "file:" + path + "?" + pragmas.Encode()
 }
@@ -0,0 +1,292 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/migrations"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// migrationLockID keys the PostgreSQL advisory lock held while migrating
+	migrationLockID = 4_715_302_118
+	// migrationLockName names the MySQL lock held while migrating
+	migrationLockName    = "project_management_system_migrations"
+	migrationLockTimeout = 60 // seconds
+)
+
+const (
+	MigrationApplied  = "applied"
+	MigrationPending  = "pending"
+	MigrationModified = "modified" // applied, but its source changed since
+	MigrationUnknown  = "unknown"  // applied, but no longer part of the binary
+)
+
+var ErrMigrationModified = errors.New("applied migrations were modified")
+
+// MigrationVersion is a row of the migration_versions table, one per applied
+// migration. Rows written before checksums were tracked have an empty one.
+type MigrationVersion struct {
+	Version   int    `gorm:"primaryKey"`
+	Name      string `gorm:"size:128"`
+	Checksum  string `gorm:"size:64"`
+	AppliedAt time.Time
+}
+
+// MigrationStatus describes whether a migration was applied
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	State     string // applied, pending, modified or unknown
+	AppliedAt *time.Time
+}
+
+// Migrator applies and rolls back the versioned migrations. Every command holds a
+// database lock so instances started at the same time do not migrate concurrently,
+// and each migration runs in its own transaction.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []migrations.Migration
+}
+
+func NewMigrator(db *gorm.DB, migrations []migrations.Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Up applies every pending migration in version order and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]migrations.Migration, error) {
+	var applied []migrations.Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		versions, err := m.verify(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(db, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the last steps applied migrations, newest first, and returns them.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]migrations.Migration, error) {
+	var rolledBack []migrations.Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		versions, err := m.verify(db)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+			if err := m.rollback(db, migration); err != nil {
+				return err
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Redo rolls back the last applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) (*migrations.Migration, error) {
+	var redone *migrations.Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		versions, err := m.verify(db)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+			if err := m.rollback(db, migration); err != nil {
+				return err
+			}
+			if err := m.apply(db, migration); err != nil {
+				return err
+			}
+			redone = &migration
+			return nil
+		}
+		return nil
+	})
+	return redone, err
+}
+
+// Status lists every known and applied migration in version order.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		versions, err := m.appliedVersions(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name, State: MigrationPending}
+			if version, ok := versions[migration.Version]; ok {
+				appliedAt := version.AppliedAt
+				status.AppliedAt = &appliedAt
+				status.State = MigrationApplied
+				if version.Checksum != "" && version.Checksum != migration.Checksum {
+					status.State = MigrationModified
+				}
+				delete(versions, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+		for _, version := range versions {
+			appliedAt := version.AppliedAt
+			statuses = append(statuses, MigrationStatus{
+				Version:   version.Version,
+				Name:      version.Name,
+				State:     MigrationUnknown,
+				AppliedAt: &appliedAt,
+			})
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Pending returns how many migrations have not been applied yet.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.State == MigrationPending {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) apply(db *gorm.DB, migration migrations.Migration) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := migration.Up(tx); err != nil {
+			return fmt.Errorf("migration v%d %s failed: %v", migration.Version, migration.Name, err)
+		}
+		return tx.Create(&MigrationVersion{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+}
+
+func (m *Migrator) rollback(db *gorm.DB, migration migrations.Migration) error {
+	// DropTable reorders the tables it is given and relies on foreign keys being
+	// off to drop them in any order, which SQLite ignores within a transaction.
+	// They are turned off around it on the migration connection instead.
+	if db.Dialector.Name() == "sqlite" {
+		if err := db.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+			return err
+		}
+		defer db.Exec("PRAGMA foreign_keys = ON")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := migration.Down(tx); err != nil {
+			return fmt.Errorf("rollback of v%d %s failed: %v", migration.Version, migration.Name, err)
+		}
+		return tx.Delete(&MigrationVersion{}, migration.Version).Error
+	})
+}
+
+// verify fails when an applied migration was edited since. Migrations applied
+// before checksums were tracked adopt the current checksum.
+func (m *Migrator) verify(db *gorm.DB) (map[int]MigrationVersion, error) {
+	versions, err := m.appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var modified []int
+	for _, migration := range m.migrations {
+		version, ok := versions[migration.Version]
+		if !ok {
+			continue
+		}
+		switch version.Checksum {
+		case migration.Checksum:
+		case "":
+			err := db.Model(&MigrationVersion{}).
+				Where("version = ?", migration.Version).
+				Updates(map[string]interface{}{"name": migration.Name, "checksum": migration.Checksum}).Error
+			if err != nil {
+				return nil, err
+			}
+		default:
+			modified = append(modified, migration.Version)
+		}
+	}
+	if len(modified) > 0 {
+		return nil, fmt.Errorf("%w: versions %v", ErrMigrationModified, modified)
+	}
+	return versions, nil
+}
+
+func (m *Migrator) appliedVersions(db *gorm.DB) (map[int]MigrationVersion, error) {
+	if err := db.AutoMigrate(&MigrationVersion{}); err != nil {
+		return nil, fmt.Errorf("failed to create migration_versions table: %v", err)
+	}
+
+	var rows []MigrationVersion
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	versions := make(map[int]MigrationVersion, len(rows))
+	for _, row := range rows {
+		versions[row.Version] = row
+	}
+	return versions, nil
+}
+
+// withLock runs fn on a single connection holding the migration lock. Databases
+// without named locks, e.g. SQLite, are only ever migrated by one process.
+func (m *Migrator) withLock(ctx context.Context, fn func(db *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		conn = conn.Session(&gorm.Session{NewDB: true})
+		switch conn.Dialector.Name() {
+		case "postgres":
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+				return fmt.Errorf("failed to acquire migration lock: %v", err)
+			}
+			defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID)
+		case "mysql":
+			var acquired int
+			if err := conn.Raw("SELECT GET_LOCK(?, ?)", migrationLockName, migrationLockTimeout).Scan(&acquired).Error; err != nil {
+				return fmt.Errorf("failed to acquire migration lock: %v", err)
+			}
+			if acquired != 1 {
+				return fmt.Errorf("timed out waiting for the migration lock")
+			}
+			defer conn.Exec("SELECT RELEASE_LOCK(?)", migrationLockName)
+		}
+		return fn(conn)
+	})
+}
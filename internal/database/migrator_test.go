@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"example/project-management-system/internal/config"
+	"example/project-management-system/internal/migrations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, *gorm.DB, []migrations.Migration) {
+	db, err := Open(config.DbConfig{Driver: DriverSQLite, Path: sqliteMemory})
+	require.NoError(t, err)
+	all, err := migrations.All()
+	require.NoError(t, err)
+	return NewMigrator(db, all), db, all
+}
+
+func TestMigratorUpAndDown(t *testing.T) {
+	ctx := context.Background()
+	migrator, db, all := newTestMigrator(t)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(all))
+	assert.True(t, db.Migrator().HasTable("users"))
+
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, pending)
+
+	rolledBack, err := migrator.Down(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, all[len(all)-1].Version, rolledBack[0].Version)
+	pending, err = migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pending)
+
+	redone, err := migrator.Redo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, all[len(all)-3].Version, redone.Version)
+
+	rolledBack, err = migrator.Down(ctx, len(all))
+	require.NoError(t, err)
+	assert.Len(t, rolledBack, len(all)-2)
+	assert.False(t, db.Migrator().HasTable("users"))
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(all))
+}
+
+func TestMigratorRejectsModifiedMigrations(t *testing.T) {
+	ctx := context.Background()
+	migrator, db, all := newTestMigrator(t)
+	_, err := migrator.Up(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Model(&MigrationVersion{}).Where("version = ?", all[0].Version).Update("checksum", "edited").Error)
+
+	_, err = migrator.Up(ctx)
+	assert.ErrorIs(t, err, ErrMigrationModified)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationModified, statuses[0].State)
+	assert.Equal(t, MigrationApplied, statuses[1].State)
+}
+
+func TestMigratorAdoptsLegacyVersions(t *testing.T) {
+	ctx := context.Background()
+	migrator, db, all := newTestMigrator(t)
+	require.NoError(t, db.AutoMigrate(&MigrationVersion{}))
+	require.NoError(t, all[0].Up(db))
+	require.NoError(t, db.Create(&MigrationVersion{Version: all[0].Version}).Error)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(all)-1)
+
+	var version MigrationVersion
+	require.NoError(t, db.First(&version, all[0].Version).Error)
+	assert.Equal(t, all[0].Checksum, version.Checksum)
+	assert.Equal(t, all[0].Name, version.Name)
+}
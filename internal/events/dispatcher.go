@@ -0,0 +1,143 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"example/project-management-system/pkg/logger"
+	"sync"
+	"time"
+)
+
+const maxRetryDelay = time.Minute
+
+// DispatcherSettings controls how often the outbox is read and how long a gap in
+// event IDs, left by a transaction that has not committed yet, holds delivery back.
+type DispatcherSettings struct {
+	PollInterval time.Duration
+	GapTimeout   time.Duration
+	BatchSize    int
+}
+
+type subscription struct {
+	name     string
+	handler  Handler
+	failures int
+	retryAt  time.Time
+	gapSince time.Time
+}
+
+// Dispatcher delivers outbox events to subscribers in publish order, at least once.
+// Every subscriber has its own checkpoint, so a failing subscriber retries its
+// event without holding back the others.
+type Dispatcher struct {
+	store    Store
+	settings DispatcherSettings
+	log      logger.Logger
+
+	mu            sync.Mutex
+	subscriptions []*subscription
+}
+
+func NewDispatcher(store Store, settings DispatcherSettings, log logger.Logger) *Dispatcher {
+	return &Dispatcher{store: store, settings: settings, log: log}
+}
+
+// Subscribe registers a handler under a unique name, which keys its checkpoint.
+func (d *Dispatcher) Subscribe(name string, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscriptions = append(d.subscriptions, &subscription{name: name, handler: handler})
+}
+
+// Run dispatches new events every poll interval until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.settings.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := d.DispatchPending(ctx, now); err != nil {
+				d.log.Error("Failed to dispatch outbox events", "error", err)
+			}
+		}
+	}
+}
+
+// DispatchPending hands every subscriber the events after its checkpoint.
+func (d *Dispatcher) DispatchPending(ctx context.Context, now time.Time) error {
+	d.mu.Lock()
+	subscriptions := d.subscriptions
+	d.mu.Unlock()
+
+	for _, sub := range subscriptions {
+		if sub.retryAt.After(now) {
+			continue
+		}
+		if err := d.dispatch(ctx, sub, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, sub *subscription, now time.Time) error {
+	checkpoint, err := d.store.Checkpoint(ctx, sub.name)
+	if err != nil {
+		return err
+	}
+
+	for {
+		records, err := d.store.After(ctx, checkpoint, d.settings.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			// A missing ID may belong to a transaction still in progress. It is only
+			// skipped once it stayed missing for the gap timeout, e.g. after a rollback.
+			if record.ID != checkpoint+1 {
+				if sub.gapSince.IsZero() {
+					sub.gapSince = now
+				}
+				if now.Sub(sub.gapSince) < d.settings.GapTimeout {
+					return nil
+				}
+			}
+			sub.gapSince = time.Time{}
+
+			var event Event
+			if err := json.Unmarshal(record.Payload, &event); err != nil {
+				d.log.Error("Skipping undecodable outbox event", "subscriber", sub.name, "eventId", record.ID, "error", err)
+			} else if err := sub.handler(ctx, event); err != nil {
+				sub.failures++
+				sub.retryAt = now.Add(d.retryDelay(sub.failures))
+				d.log.Error("Failed to handle event", "subscriber", sub.name, "eventId", record.ID, "type", event.Type, "attempt", sub.failures, "error", err)
+				return nil
+			}
+
+			sub.failures = 0
+			checkpoint = record.ID
+			if err := d.store.SaveCheckpoint(ctx, sub.name, checkpoint); err != nil {
+				return err
+			}
+		}
+
+		if len(records) < d.settings.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (d *Dispatcher) retryDelay(failures int) time.Duration {
+	delay := d.settings.PollInterval
+	for i := 1; i < failures && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,173 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps the outbox in memory, IDs can be left out to simulate gaps
+type memoryStore struct {
+	records     []Record
+	checkpoints map[string]uint
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{checkpoints: map[string]uint{}}
+}
+
+func (s *memoryStore) Append(ctx context.Context, eventType Type, payload []byte) error {
+	var id uint = 1
+	if len(s.records) > 0 {
+		id = s.records[len(s.records)-1].ID + 1
+	}
+	s.records = append(s.records, Record{ID: id, Payload: payload})
+	return nil
+}
+
+func (s *memoryStore) After(ctx context.Context, afterID uint, limit int) ([]Record, error) {
+	var records []Record
+	for _, record := range s.records {
+		if record.ID > afterID && len(records) < limit {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (s *memoryStore) Checkpoint(ctx context.Context, subscriber string) (uint, error) {
+	return s.checkpoints[subscriber], nil
+}
+
+func (s *memoryStore) SaveCheckpoint(ctx context.Context, subscriber string, eventID uint) error {
+	s.checkpoints[subscriber] = eventID
+	return nil
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Info(msg string, keysAndValues ...interface{})  {}
+func (nopLogger) Error(msg string, keysAndValues ...interface{}) {}
+func (nopLogger) Debug(msg string, keysAndValues ...interface{}) {}
+func (nopLogger) Warn(msg string, keysAndValues ...interface{})  {}
+func (nopLogger) Fatal(msg string, keysAndValues ...interface{}) {}
+
+var testSettings = DispatcherSettings{PollInterval: time.Second, GapTimeout: 10 * time.Second, BatchSize: 2}
+
+func publishTasks(t *testing.T, outbox *Outbox, taskIDs ...uint) {
+	for _, taskID := range taskIDs {
+		require.NoError(t, outbox.Publish(context.Background(), Event{Type: TaskCreated, TaskID: taskID}))
+	}
+}
+
+func TestDispatcherDeliversInOrder(t *testing.T) {
+	store := newMemoryStore()
+	publishTasks(t, NewOutbox(store), 1, 2, 3)
+
+	dispatcher := NewDispatcher(store, testSettings, nopLogger{})
+	var first, second []uint
+	dispatcher.Subscribe("first", func(ctx context.Context, event Event) error {
+		first = append(first, event.TaskID)
+		return nil
+	})
+	dispatcher.Subscribe("second", func(ctx context.Context, event Event) error {
+		second = append(second, event.TaskID)
+		return nil
+	})
+
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), time.Now()))
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), time.Now()))
+
+	assert.Equal(t, []uint{1, 2, 3}, first)
+	assert.Equal(t, []uint{1, 2, 3}, second)
+	assert.Equal(t, uint(3), store.checkpoints["first"])
+}
+
+func TestDispatcherRetriesFailedEvent(t *testing.T) {
+	store := newMemoryStore()
+	publishTasks(t, NewOutbox(store), 1, 2)
+
+	dispatcher := NewDispatcher(store, testSettings, nopLogger{})
+	var handled []uint
+	failing, healthy := true, 0
+	dispatcher.Subscribe("failing", func(ctx context.Context, event Event) error {
+		if failing {
+			return errors.New("unavailable")
+		}
+		handled = append(handled, event.TaskID)
+		return nil
+	})
+	dispatcher.Subscribe("healthy", func(ctx context.Context, event Event) error {
+		healthy++
+		return nil
+	})
+
+	now := time.Now()
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), now))
+	assert.Empty(t, handled)
+	assert.Equal(t, 2, healthy)
+	assert.Equal(t, uint(0), store.checkpoints["failing"])
+
+	// Not retried before the backoff has passed
+	failing = false
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), now.Add(500*time.Millisecond)))
+	assert.Empty(t, handled)
+
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), now.Add(testSettings.PollInterval)))
+	assert.Equal(t, []uint{1, 2}, handled)
+	assert.Equal(t, 2, healthy)
+}
+
+func TestDispatcherResumesFromCheckpoint(t *testing.T) {
+	store := newMemoryStore()
+	publishTasks(t, NewOutbox(store), 1, 2, 3)
+	store.checkpoints["notifications"] = 2
+
+	dispatcher := NewDispatcher(store, testSettings, nopLogger{})
+	var handled []uint
+	dispatcher.Subscribe("notifications", func(ctx context.Context, event Event) error {
+		handled = append(handled, event.TaskID)
+		return nil
+	})
+
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), time.Now()))
+
+	assert.Equal(t, []uint{3}, handled)
+}
+
+func TestDispatcherHoldsGapUntilTimeout(t *testing.T) {
+	store := newMemoryStore()
+	publishTasks(t, NewOutbox(store), 1, 2, 3)
+	// Event 2 belongs to a transaction that has not committed yet
+	uncommitted := store.records[1]
+	store.records = append(store.records[:1], store.records[2])
+
+	dispatcher := NewDispatcher(store, testSettings, nopLogger{})
+	var handled []uint
+	dispatcher.Subscribe("webhooks", func(ctx context.Context, event Event) error {
+		handled = append(handled, event.TaskID)
+		return nil
+	})
+
+	now := time.Now()
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), now))
+	assert.Equal(t, []uint{1}, handled)
+
+	// The transaction commits within the gap timeout
+	store.records = []Record{store.records[0], uncommitted, store.records[1]}
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), now.Add(time.Second)))
+	assert.Equal(t, []uint{1, 2, 3}, handled)
+
+	// A rolled back event is skipped once the gap timeout has passed
+	store.records = append(store.records, Record{ID: 5, Payload: store.records[2].Payload})
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), now.Add(2*time.Second)))
+	assert.Equal(t, []uint{1, 2, 3}, handled)
+
+	require.NoError(t, dispatcher.DispatchPending(context.Background(), now.Add(2*time.Second+testSettings.GapTimeout)))
+	assert.Equal(t, []uint{1, 2, 3, 3}, handled)
+	assert.Equal(t, uint(5), store.checkpoints["webhooks"])
+}
@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Type identifies what happened
+type Type string
+
+const (
+	TaskAssigned         Type = "task.assigned"
+	TaskStatusChanged    Type = "task.status_changed"
+	CommentCreated       Type = "comment.created"
+	UserMentioned        Type = "user.mentioned"
+	ProjectMemberAdded   Type = "project.member_added"
+	ProjectMemberRemoved Type = "project.member_removed"
+
+	TaskCreated    Type = "task.created"
+	TaskUpdated    Type = "task.updated"
+	TaskDeleted    Type = "task.deleted"
+	CommentUpdated Type = "comment.updated"
+	CommentDeleted Type = "comment.deleted"
+	TeamCreated    Type = "team.created"
+	TeamUpdated    Type = "team.updated"
+	TeamDeleted    Type = "team.deleted"
+	ProjectCreated Type = "project.created"
+	ProjectUpdated Type = "project.updated"
+	ProjectDeleted Type = "project.deleted"
+)
+
+// Event describes a change made by ActorID. UserID is the user the event is about,
+// e.g. the new assignee or the mentioned user. Recipients are the users to notify,
+// the watchers of the task for task changes, and UserID when there are none.
+// Payload is a snapshot of the changed entity for create, update and delete events.
+type Event struct {
+	Type       Type              `json:"type"`
+	ActorID    uint              `json:"actor_id"`
+	UserID     uint              `json:"user_id,omitempty"`
+	Recipients []uint            `json:"recipients,omitempty"`
+	ProjectID  uint              `json:"project_id,omitempty"`
+	TaskID     uint              `json:"task_id,omitempty"`
+	CommentID  uint              `json:"comment_id,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+	Payload    interface{}       `json:"payload,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+// Handler reacts to a published event
+type Handler func(ctx context.Context, event Event) error
+
+// Publisher is used by services to announce changes. Events are published in the
+// transaction of the change, so they are only seen once it commits.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Record is a published event as stored in the outbox, IDs increase in publish order
+type Record struct {
+	ID      uint
+	Payload []byte
+}
+
+// Store persists the outbox and the checkpoint of every subscriber
+type Store interface {
+	Append(ctx context.Context, eventType Type, payload []byte) error
+	After(ctx context.Context, afterID uint, limit int) ([]Record, error)
+	Checkpoint(ctx context.Context, subscriber string) (uint, error)
+	SaveCheckpoint(ctx context.Context, subscriber string, eventID uint) error
+}
+
+// Outbox publishes events by appending them to the store. Called with the context
+// of a transaction, the event is committed or rolled back together with the change.
+type Outbox struct {
+	store Store
+}
+
+func NewOutbox(store Store) *Outbox {
+	return &Outbox{store: store}
+}
+
+func (o *Outbox) Publish(ctx context.Context, event Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+	return o.store.Append(ctx, event.Type, payload)
+}
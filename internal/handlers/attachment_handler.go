@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// maxAttachmentMemory is how much of an upload is held in memory, the rest of the
+// file is buffered on disk
+const maxAttachmentMemory = 8 << 20
+
+type AttachmentHandler interface {
+	AttachToTask(w http.ResponseWriter, r *http.Request)
+	GetTaskAttachments(w http.ResponseWriter, r *http.Request)
+	AttachToComment(w http.ResponseWriter, r *http.Request)
+	GetCommentAttachments(w http.ResponseWriter, r *http.Request)
+	DownloadAttachment(w http.ResponseWriter, r *http.Request)
+	DeleteAttachment(w http.ResponseWriter, r *http.Request)
+}
+
+type AttachmentHandlerImplementation struct {
+	service services.AttachmentService
+}
+
+func NewAttachmentHandler(service services.AttachmentService) *AttachmentHandlerImplementation {
+	return &AttachmentHandlerImplementation{service: service}
+}
+
+// AttachToTask godoc
+//	@Summary		Attach a file to a task
+//	@Description	Upload a file to a task as multipart/form-data in the file field. Only project members can attach files, the size and type of which are limited.
+//	@Tags			Attachments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Task ID"
+//	@Param			file	formData	file				true	"File"
+//	@Success		201		{object}	models.Attachment	"File attached"
+//	@Failure		400		{object}	response.Response	"Invalid upload"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		403		{object}	response.Response	"Not a project member"
+//	@Failure		404		{object}	response.Response	"Task not found"
+//	@Failure		409		{object}	response.Response	"Project archived"
+//	@Failure		413		{object}	response.Response	"File too large"
+//	@Failure		415		{object}	response.Response	"File type not allowed"
+//	@Router			/tasks/{id}/attachments [post]
+func (h *AttachmentHandlerImplementation) AttachToTask(w http.ResponseWriter, r *http.Request) {
+	h.attach(w, r, h.service.AttachToTask)
+}
+
+// GetTaskAttachments godoc
+//	@Summary		Get the attachments of a task
+//	@Description	Retrieve the files attached to a task and to its comments, oldest first
+//	@Tags			Attachments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Task ID"
+//	@Success		200	{array}		models.Attachment	"Attachments"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Not a project member"
+//	@Failure		404	{object}	response.Response	"Task not found"
+//	@Router			/tasks/{id}/attachments [get]
+func (h *AttachmentHandlerImplementation) GetTaskAttachments(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, h.service.GetTaskAttachments)
+}
+
+// AttachToComment godoc
+//	@Summary		Attach a file to a comment
+//	@Description	Upload a file to a comment as multipart/form-data in the file field. Only project members can attach files, the size and type of which are limited.
+//	@Tags			Attachments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Comment ID"
+//	@Param			file	formData	file				true	"File"
+//	@Success		201		{object}	models.Attachment	"File attached"
+//	@Failure		400		{object}	response.Response	"Invalid upload"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		403		{object}	response.Response	"Not a project member"
+//	@Failure		404		{object}	response.Response	"Comment not found"
+//	@Failure		409		{object}	response.Response	"Project archived"
+//	@Failure		413		{object}	response.Response	"File too large"
+//	@Failure		415		{object}	response.Response	"File type not allowed"
+//	@Router			/comments/{id}/attachments [post]
+func (h *AttachmentHandlerImplementation) AttachToComment(w http.ResponseWriter, r *http.Request) {
+	h.attach(w, r, h.service.AttachToComment)
+}
+
+// GetCommentAttachments godoc
+//	@Summary		Get the attachments of a comment
+//	@Description	Retrieve the files attached to a comment, oldest first
+//	@Tags			Attachments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Comment ID"
+//	@Success		200	{array}		models.Attachment	"Attachments"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Not a project member"
+//	@Failure		404	{object}	response.Response	"Comment not found"
+//	@Router			/comments/{id}/attachments [get]
+func (h *AttachmentHandlerImplementation) GetCommentAttachments(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, h.service.GetCommentAttachments)
+}
+
+// DownloadAttachment godoc
+//	@Summary		Download an attachment
+//	@Description	Stream the content of an attached file as a download
+//	@Tags			Attachments
+//	@Produce		octet-stream
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Attachment ID"
+//	@Success		200	{file}		file				"Content of the file"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Not a project member"
+//	@Failure		404	{object}	response.Response	"Attachment not found"
+//	@Router			/attachments/{id}/content [get]
+func (h *AttachmentHandlerImplementation) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	attachment, content, err := h.service.OpenAttachment(r.Context(), id)
+	if err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+	defer content.Close()
+
+	// Files are always downloaded, never rendered in the context of the API
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.Header().Set("ETag", strconv.Quote(attachment.Checksum))
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, content)
+}
+
+// DeleteAttachment godoc
+//	@Summary		Delete an attachment
+//	@Description	Delete an attached file, which only its uploader or the project owner can do
+//	@Tags			Attachments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Attachment ID"
+//	@Success		200	{object}	map[string]string	"Attachment deleted"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Not the uploader or project owner"
+//	@Failure		404	{object}	response.Response	"Attachment not found"
+//	@Failure		409	{object}	response.Response	"Project archived"
+//	@Router			/attachments/{id} [delete]
+func (h *AttachmentHandlerImplementation) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.DeleteAttachment(r.Context(), id); err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": "attachment deleted successfully"})
+}
+
+// attach reads the file field of a multipart upload and attaches it with attachFn
+// to the task or comment in the path
+func (h *AttachmentHandlerImplementation) attach(w http.ResponseWriter, r *http.Request, attachFn func(ctx context.Context, id uint, upload services.AttachmentUpload) (*models.Attachment, error)) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	// Leave room for the multipart framing around a file of the largest size
+	r.Body = http.MaxBytesReader(w, r.Body, h.service.MaxSize()+1<<20)
+	if err := r.ParseMultipartForm(maxAttachmentMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeAttachmentError(w, fmt.Errorf("%w: files can be at most %d bytes", services.ErrAttachmentTooLarge, h.service.MaxSize()))
+			return
+		}
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("invalid multipart upload: %w", err)))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s", "the file field is required")))
+		return
+	}
+	defer file.Close()
+
+	attachment, err := attachFn(r.Context(), id, services.AttachmentUpload{
+		Filename:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Size:        header.Size,
+		Content:     file,
+	})
+	if err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, attachment)
+}
+
+func (h *AttachmentHandlerImplementation) list(w http.ResponseWriter, r *http.Request, listFn func(ctx context.Context, id uint) ([]models.Attachment, error)) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	attachments, err := listFn(r.Context(), id)
+	if err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+	if attachments == nil {
+		attachments = []models.Attachment{}
+	}
+
+	response.WriteJson(w, http.StatusOK, attachments)
+}
+
+func writeAttachmentError(w http.ResponseWriter, err error) {
+	if writeProjectStateError(w, err) {
+		return
+	}
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrAttachmentNotFound), errors.Is(err, services.ErrTaskNotFound),
+		errors.Is(err, services.ErrCommentNotFound), errors.Is(err, services.ErrProjectNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrNotProjectMember), errors.Is(err, services.ErrNotAttachmentUploader):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidAttachment):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrAttachmentTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, services.ErrAttachmentTypeNotAllowed):
+		status = http.StatusUnsupportedMediaType
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+}
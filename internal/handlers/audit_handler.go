@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AuditHandler interface {
+	GetAuditLog(w http.ResponseWriter, r *http.Request)
+	GetTaskHistory(w http.ResponseWriter, r *http.Request)
+	GetProjectHistory(w http.ResponseWriter, r *http.Request)
+	GetTeamHistory(w http.ResponseWriter, r *http.Request)
+}
+
+type AuditHandlerImplementation struct {
+	service services.AuditService
+}
+
+func NewAuditHandler(service services.AuditService) *AuditHandlerImplementation {
+	return &AuditHandlerImplementation{service: service}
+}
+
+// GetAuditLog godoc
+//	@Summary		Get the audit log
+//	@Description	Retrieve the paginated audit log of every change, newest first. Only available to admins.
+//	@Tags			Audit
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			actor_id	query		int						false	"Only changes made by this user"
+//	@Param			action		query		string					false	"create, update, delete, member_added or member_removed"
+//	@Param			entity_type	query		string					false	"user, project, task, team, comment or webhook"
+//	@Param			entity_id	query		int						false	"Only changes of this entity"
+//	@Param			from		query		string					false	"Changes made at or after this RFC 3339 time"
+//	@Param			to			query		string					false	"Changes made before this RFC 3339 time"
+//	@Param			page		query		int						false	"Page number"				default(1)
+//	@Param			page_size	query		int						false	"Number of entries per page"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Successful response"
+//	@Failure		400			{object}	response.Response		"Invalid filter"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		403			{object}	response.Response		"Not an admin"
+//	@Router			/audit [get]
+func (h *AuditHandlerImplementation) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+	page, pageSize := parsePagination(r)
+
+	entries, total, err := h.service.GetEntries(r.Context(), userID, filter, page, pageSize)
+	if err != nil {
+		if errors.Is(err, services.ErrAdminRequired) {
+			response.WriteJson(w, http.StatusForbidden, response.GeneralError(err))
+			return
+		}
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]interface{}{
+		"entries": entries,
+		"total":   total,
+		"page":    page,
+	})
+}
+
+// GetTaskHistory godoc
+//	@Summary		Get the history of a task
+//	@Description	Retrieve the paginated changes made to a task, newest first. Only available to members of its project and admins.
+//	@Tags			Tasks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"Task ID"
+//	@Param			page		query		int						false	"Page number"				default(1)
+//	@Param			page_size	query		int						false	"Number of entries per page"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Successful response"
+//	@Failure		400			{object}	response.Response		"Invalid ID"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		403			{object}	response.Response		"Not a project member"
+//	@Failure		404			{object}	response.Response		"Task not found"
+//	@Router			/tasks/{id}/history [get]
+func (h *AuditHandlerImplementation) GetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	h.getHistory(w, r, models.AuditEntityTask)
+}
+
+// GetProjectHistory godoc
+//	@Summary		Get the history of a project
+//	@Description	Retrieve the paginated changes made to a project and its members, newest first. Only available to its members and admins.
+//	@Tags			Projects
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"Project ID"
+//	@Param			page		query		int						false	"Page number"				default(1)
+//	@Param			page_size	query		int						false	"Number of entries per page"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Successful response"
+//	@Failure		400			{object}	response.Response		"Invalid ID"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		403			{object}	response.Response		"Not a project member"
+//	@Failure		404			{object}	response.Response		"Project not found"
+//	@Router			/projects/{id}/history [get]
+func (h *AuditHandlerImplementation) GetProjectHistory(w http.ResponseWriter, r *http.Request) {
+	h.getHistory(w, r, models.AuditEntityProject)
+}
+
+// GetTeamHistory godoc
+//	@Summary		Get the history of a team
+//	@Description	Retrieve the paginated changes made to a team, newest first. Only available to members of its project and admins.
+//	@Tags			Teams
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"Team ID"
+//	@Param			page		query		int						false	"Page number"				default(1)
+//	@Param			page_size	query		int						false	"Number of entries per page"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Successful response"
+//	@Failure		400			{object}	response.Response		"Invalid ID"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		403			{object}	response.Response		"Not a project member"
+//	@Failure		404			{object}	response.Response		"Team not found"
+//	@Router			/teams/{id}/history [get]
+func (h *AuditHandlerImplementation) GetTeamHistory(w http.ResponseWriter, r *http.Request) {
+	h.getHistory(w, r, models.AuditEntityTeam)
+}
+
+func (h *AuditHandlerImplementation) getHistory(w http.ResponseWriter, r *http.Request, entityType string) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	page, pageSize := parsePagination(r)
+
+	entries, total, err := h.service.GetHistory(r.Context(), userID, entityType, id, page, pageSize)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrHistoryNotAllowed), errors.Is(err, services.ErrAdminRequired):
+			status = http.StatusForbidden
+		case errors.Is(err, services.ErrTaskNotFound), errors.Is(err, services.ErrProjectNotFound), errors.Is(err, services.ErrTeamNotFound):
+			status = http.StatusNotFound
+		}
+		response.WriteJson(w, status, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]interface{}{
+		"entries": entries,
+		"total":   total,
+		"page":    page,
+	})
+}
+
+func parseAuditFilter(r *http.Request) (repositories.AuditFilter, error) {
+	query := r.URL.Query()
+	filter := repositories.AuditFilter{
+		Action:     query.Get("action"),
+		EntityType: query.Get("entity_type"),
+	}
+
+	for name, target := range map[string]*uint{"actor_id": &filter.ActorID, "entity_id": &filter.EntityID} {
+		if value := query.Get(name); value != "" {
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s", name)
+			}
+			*target = uint(id)
+		}
+	}
+	for name, target := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := query.Get(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s, expected an RFC 3339 time", name)
+			}
+			*target = t
+		}
+	}
+	return filter, nil
+}
+
+// parsePagination reads the page and page_size query parameters, defaulting to the first page of 10.
+func parsePagination(r *http.Request) (int, int) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	return page, pageSize
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/pkg/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditService mocks the AuditService for testing
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) Record(ctx context.Context, action, entityType string, entityID uint, before, after interface{}) error {
+	args := m.Called(ctx, action, entityType, entityID, before, after)
+	return args.Error(0)
+}
+
+func (m *MockAuditService) GetEntries(ctx context.Context, userID uint, filter repositories.AuditFilter, page, pageSize int) ([]models.AuditEntry, int64, error) {
+	args := m.Called(ctx, userID, filter, page, pageSize)
+	entries, _ := args.Get(0).([]models.AuditEntry)
+	return entries, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAuditService) GetHistory(ctx context.Context, userID uint, entityType string, entityID uint, page, pageSize int) ([]models.AuditEntry, int64, error) {
+	args := m.Called(ctx, userID, entityType, entityID, page, pageSize)
+	entries, _ := args.Get(0).([]models.AuditEntry)
+	return entries, args.Get(1).(int64), args.Error(2)
+}
+
+func TestGetAuditLog(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockAuditService)
+		expectedStatus int
+	}{
+		{
+			name:  "Filtered Audit Log",
+			query: "?actor_id=4&entity_type=project&action=delete&from=2024-05-01T00:00:00Z&page=2",
+			mockSetup: func(mas *MockAuditService) {
+				filter := repositories.AuditFilter{
+					ActorID:    4,
+					Action:     models.AuditActionDelete,
+					EntityType: models.AuditEntityProject,
+					From:       time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+				}
+				mas.On("GetEntries", mock.Anything, uint(1), filter, 2, 10).
+					Return([]models.AuditEntry{{ID: 9, ActorID: 4, Action: models.AuditActionDelete}}, int64(11), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Not An Admin",
+			query: "",
+			mockSetup: func(mas *MockAuditService) {
+				mas.On("GetEntries", mock.Anything, uint(1), repositories.AuditFilter{}, 1, 10).
+					Return(nil, int64(0), services.ErrAdminRequired)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Invalid Time",
+			query:          "?from=yesterday",
+			mockSetup:      func(mas *MockAuditService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockAuditService)
+			tc.mockSetup(mockService)
+
+			handler := NewAuditHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/audit"+tc.query, nil)
+			req = req.WithContext(middleware.WithUserID(req.Context(), 1))
+			w := httptest.NewRecorder()
+
+			handler.GetAuditLog(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetTaskHistory(t *testing.T) {
+	mockService := new(MockAuditService)
+	mockService.On("GetHistory", mock.Anything, uint(1), models.AuditEntityTask, uint(5), 1, 10).
+		Return([]models.AuditEntry{{ID: 2, EntityType: models.AuditEntityTask, EntityID: 5}}, int64(1), nil)
+
+	handler := NewAuditHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/5/history", nil)
+	req.SetPathValue("id", "5")
+	req = req.WithContext(middleware.WithUserID(req.Context(), 1))
+	w := httptest.NewRecorder()
+
+	handler.GetTaskHistory(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"entity_type":"task"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetTaskHistoryOfNonMember(t *testing.T) {
+	mockService := new(MockAuditService)
+	mockService.On("GetHistory", mock.Anything, uint(1), models.AuditEntityTask, uint(5), 1, 10).
+		Return(nil, int64(0), services.ErrHistoryNotAllowed)
+
+	handler := NewAuditHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/5/history", nil)
+	req.SetPathValue("id", "5")
+	req = req.WithContext(middleware.WithUserID(req.Context(), 1))
+	w := httptest.NewRecorder()
+
+	handler.GetTaskHistory(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+)
+
+// ChecklistItemRequest is the body used to add an item to a checklist
+type ChecklistItemRequest struct {
+	Text string `json:"text" example:"Screenshots attached"`
+}
+
+// ReorderChecklistRequest is the body used to reorder a checklist
+type ReorderChecklistRequest struct {
+	// Every item of the checklist once, in the new order
+	ItemIDs []uint `json:"item_ids"`
+}
+
+type ChecklistHandler interface {
+	AddChecklistItem(w http.ResponseWriter, r *http.Request)
+	GetChecklist(w http.ResponseWriter, r *http.Request)
+	ReorderChecklist(w http.ResponseWriter, r *http.Request)
+	ToggleChecklistItem(w http.ResponseWriter, r *http.Request)
+	DeleteChecklistItem(w http.ResponseWriter, r *http.Request)
+}
+
+type ChecklistHandlerImplementation struct {
+	service services.ChecklistService
+}
+
+func NewChecklistHandler(service services.ChecklistService) *ChecklistHandlerImplementation {
+	return &ChecklistHandlerImplementation{service: service}
+}
+
+// AddChecklistItem godoc
+//	@Summary		Add a checklist item
+//	@Description	Add an unchecked item at the end of the checklist of a task
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int						true	"Task ID"
+//	@Param			item			body		ChecklistItemRequest	true	"Item"
+//	@Param			Idempotency-Key	header		string					false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.ChecklistItem	"Item added"
+//	@Failure		400				{object}	response.Response		"Invalid item"
+//	@Failure		401				{object}	response.Response		"Unauthenticated"
+//	@Failure		404				{object}	response.Response		"Task not found"
+//	@Failure		409				{object}	response.Response		"Project archived"
+//	@Router			/tasks/{id}/checklist [post]
+func (h *ChecklistHandlerImplementation) AddChecklistItem(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req ChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	item, err := h.service.AddItem(r.Context(), taskID, req.Text)
+	if err != nil {
+		writeChecklistError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, item)
+}
+
+// GetChecklist godoc
+//	@Summary		Get the checklist of a task
+//	@Description	Retrieve the items of the checklist of a task in order
+//	@Tags			Checklists
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int						true	"Task ID"
+//	@Success		200	{array}		models.ChecklistItem	"Checklist"
+//	@Failure		401	{object}	response.Response		"Unauthenticated"
+//	@Failure		404	{object}	response.Response		"Task not found"
+//	@Router			/tasks/{id}/checklist [get]
+func (h *ChecklistHandlerImplementation) GetChecklist(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	items, err := h.service.GetChecklist(r.Context(), taskID)
+	if err != nil {
+		writeChecklistError(w, err)
+		return
+	}
+	if items == nil {
+		items = []models.ChecklistItem{}
+	}
+
+	response.WriteJson(w, http.StatusOK, items)
+}
+
+// ReorderChecklist godoc
+//	@Summary		Reorder a checklist
+//	@Description	Order the checklist of a task as the given items, which list each of its items once
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Task ID"
+//	@Param			order	body		ReorderChecklistRequest	true	"Items in order"
+//	@Success		200		{array}		models.ChecklistItem	"Reordered checklist"
+//	@Failure		400		{object}	response.Response		"Items missing or unknown"
+//	@Failure		401		{object}	response.Response		"Unauthenticated"
+//	@Failure		404		{object}	response.Response		"Task not found"
+//	@Failure		409		{object}	response.Response		"Project archived"
+//	@Router			/tasks/{id}/checklist/order [put]
+func (h *ChecklistHandlerImplementation) ReorderChecklist(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req ReorderChecklistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	items, err := h.service.ReorderItems(r.Context(), taskID, req.ItemIDs)
+	if err != nil {
+		writeChecklistError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, items)
+}
+
+// ToggleChecklistItem godoc
+//	@Summary		Toggle a checklist item
+//	@Description	Check an unchecked item as done by the current user, or uncheck a checked one
+//	@Tags			Checklists
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int						true	"Checklist item ID"
+//	@Success		200	{object}	models.ChecklistItem	"Item toggled"
+//	@Failure		401	{object}	response.Response		"Unauthenticated"
+//	@Failure		404	{object}	response.Response		"Item not found"
+//	@Failure		409	{object}	response.Response		"Project archived"
+//	@Router			/checklist-items/{id}/toggle [post]
+func (h *ChecklistHandlerImplementation) ToggleChecklistItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	item, err := h.service.ToggleItem(r.Context(), id)
+	if err != nil {
+		writeChecklistError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, item)
+}
+
+// DeleteChecklistItem godoc
+//	@Summary		Delete a checklist item
+//	@Description	Remove an item from the checklist of its task
+//	@Tags			Checklists
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Checklist item ID"
+//	@Success		200	{object}	map[string]string	"Item deleted"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Item not found"
+//	@Failure		409	{object}	response.Response	"Project archived"
+//	@Router			/checklist-items/{id} [delete]
+func (h *ChecklistHandlerImplementation) DeleteChecklistItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.DeleteItem(r.Context(), id); err != nil {
+		writeChecklistError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": "checklist item deleted successfully"})
+}
+
+func writeChecklistError(w http.ResponseWriter, err error) {
+	if writeProjectStateError(w, err) {
+		return
+	}
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrChecklistItemNotFound), errors.Is(err, services.ErrTaskNotFound), errors.Is(err, services.ErrProjectNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidChecklistItem):
+		status = http.StatusBadRequest
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+}
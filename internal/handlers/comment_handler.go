@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"example/project-management-system/internal/models"
 	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/markdown"
 	"example/project-management-system/internal/utils/response"
 	"fmt"
 	"net/http"
@@ -15,15 +17,17 @@ type CommentHandler interface {
 	CreateComment(w http.ResponseWriter, r *http.Request)
 	GetCommentByID(w http.ResponseWriter, r *http.Request)
 	GetCommentsByTask(w http.ResponseWriter, r *http.Request)
+	PatchComment(w http.ResponseWriter, r *http.Request)
 	DeleteComment(w http.ResponseWriter, r *http.Request)
 }
 
 type CommentHandlerImplementation struct {
-	service services.CommentService
+	service  services.CommentService
+	renderer *markdown.Renderer
 }
 
-func NewCommentHandler(service services.CommentService) *CommentHandlerImplementation {
-	return &CommentHandlerImplementation{service: service}
+func NewCommentHandler(service services.CommentService, renderer *markdown.Renderer) *CommentHandlerImplementation {
+	return &CommentHandlerImplementation{service: service, renderer: renderer}
 }
 
 // CreateComment godoc
@@ -34,8 +38,11 @@ func NewCommentHandler(service services.CommentService) *CommentHandlerImplement
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			comment	body		models.Comment		true	"Comment Creation Request"
+//	@Param			Idempotency-Key	header		string			false	"Key that makes retries of the request return its first response"
 //	@Success		201		{object}	models.Comment		"Comment created successfully"
 //	@Failure		400		{object}	response.Response	"Invalid input"
+//	@Failure		409		{object}	response.Response	"Request with the Idempotency-Key in progress, or the project is archived"
+//	@Failure		422		{object}	response.Response	"Idempotency-Key used for a different request"
 //	@Failure		500		{object}	response.Response	"Server error"
 //	@Router			/comments [post]
 func (h *CommentHandlerImplementation) CreateComment(w http.ResponseWriter, r *http.Request) {
@@ -46,6 +53,9 @@ func (h *CommentHandlerImplementation) CreateComment(w http.ResponseWriter, r *h
 	}
 
 	if err := h.service.CreateComment(r.Context(), &comment); err != nil {
+		if writeProjectStateError(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
@@ -59,8 +69,9 @@ func (h *CommentHandlerImplementation) CreateComment(w http.ResponseWriter, r *h
 //	@Tags			Comments
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id	path		int					true	"Comment ID"
-//	@Success		200	{object}	models.Comment		"Successful response"
+//	@Param			id		path		int					true	"Comment ID"
+//	@Param			render	query		string				false	"Set to html to include sanitized content_html"	Enums(html)
+//	@Success		200		{object}	models.Comment		"Successful response"
 //	@Failure		400	{object}	response.Response	"Bad request"
 //	@Failure		404	{object}	response.Response	"Comment not found"
 //	@Router			/comments/{id} [get]
@@ -77,6 +88,15 @@ func (h *CommentHandlerImplementation) GetCommentByID(w http.ResponseWriter, r *
 		return
 	}
 
+	if wantsHTML(r) {
+		comments := []models.Comment{*comment}
+		if err := renderCommentContents(r.Context(), h.renderer, comments); err != nil {
+			response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+			return
+		}
+		comment = &comments[0]
+	}
+
 	response.WriteJson(w, http.StatusOK, comment)
 }
 
@@ -89,6 +109,7 @@ func (h *CommentHandlerImplementation) GetCommentByID(w http.ResponseWriter, r *
 //	@Param			task_id		path		int						true	"Task ID"
 //	@Param			page		query		int						false	"Page number"					default(1)
 //	@Param			page_size	query		int						false	"Number of comments per page"	default(10)
+//	@Param			render		query		string					false	"Set to html to include sanitized content_html"	Enums(html)
 //	@Success		200			{object}	map[string]interface{}	"Successful response"
 //	@Failure		400			{object}	response.Response		"Bad request"
 //	@Router			/tasks/{task_id}/comments [get]
@@ -113,6 +134,13 @@ func (h *CommentHandlerImplementation) GetCommentsByTask(w http.ResponseWriter,
 		return
 	}
 
+	if wantsHTML(r) {
+		if err := renderCommentContents(r.Context(), h.renderer, comments); err != nil {
+			response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+			return
+		}
+	}
+
 	response.WriteJson(w, http.StatusOK, map[string]interface{}{
 		"comments": comments,
 		"total":    total,
@@ -120,6 +148,64 @@ func (h *CommentHandlerImplementation) GetCommentsByTask(w http.ResponseWriter,
 	})
 }
 
+// PatchComment godoc
+//	@Summary		Partially update a comment
+//	@Description	Apply a JSON merge patch (RFC 7396) to the content of a comment. Omitted fields are kept and null resets a field.
+//	@Tags			Comments
+//	@Accept			json,application/merge-patch+json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int					true	"Comment ID"
+//	@Param			patch		body		models.Comment		true	"Fields to change"
+//	@Success		200			{object}	models.Comment		"The updated comment"
+//	@Failure		400			{object}	response.Response	"Invalid patch"
+//	@Failure		403			{object}	response.Response	"Only the author can change a comment"
+//	@Failure		404			{object}	response.Response	"Comment not found"
+//	@Failure		409			{object}	response.Response	"The project is archived"
+//	@Failure		415			{object}	response.Response	"The body is not a merge patch"
+//	@Router			/comments/{id} [patch]
+func (h *CommentHandlerImplementation) PatchComment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+
+	comment, err := h.service.GetCommentByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+		return
+	}
+	if !applyMergePatch(w, comment, patch, commentPatchFields) {
+		return
+	}
+
+	if err := h.service.UpdateComment(r.Context(), comment); err != nil {
+		if writeProjectStateError(w, err) {
+			return
+		}
+		if errors.Is(err, services.ErrNotCommentAuthor) {
+			response.WriteJson(w, http.StatusForbidden, response.GeneralError(err))
+			return
+		}
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	// Reload the comment for its up to date relations
+	comment, err = h.service.GetCommentByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, comment)
+}
+
 // DeleteComment godoc
 //	@Summary		Delete a comment
 //	@Description	Remove a comment from the system by its ID
@@ -129,6 +215,7 @@ func (h *CommentHandlerImplementation) GetCommentsByTask(w http.ResponseWriter,
 //	@Param			id	path		int					true	"Comment ID to delete"
 //	@Success		200	{object}	map[string]string	"Comment deleted successfully"
 //	@Failure		400	{object}	response.Response	"Bad request"
+//	@Failure		409	{object}	response.Response	"The project is archived"
 //	@Failure		500	{object}	response.Response	"Server error"
 //	@Router			/comments/{id} [delete]
 func (h *CommentHandlerImplementation) DeleteComment(w http.ResponseWriter, r *http.Request) {
@@ -139,6 +226,9 @@ func (h *CommentHandlerImplementation) DeleteComment(w http.ResponseWriter, r *h
 	}
 
 	if err := h.service.DeleteComment(r.Context(), uint(id)); err != nil {
+		if writeProjectStateError(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/utils/markdown"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -40,6 +41,11 @@ func (m *MockCommentService) GetCommentsByTask(ctx context.Context, taskID uint,
 	return args.Get(0).([]models.Comment), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockCommentService) UpdateComment(ctx context.Context, comment *models.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
 func (m *MockCommentService) DeleteComment(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -84,7 +90,7 @@ func TestCreateComment(t *testing.T) {
 			tc.mockSetup(mockService)
 
 			// Create handler
-			handler := NewCommentHandler(mockService)
+			handler := NewCommentHandler(mockService, markdown.NewRenderer(nil))
 
 			// Prepare request body
 			jsonBody, _ := json.Marshal(tc.inputComment)
@@ -151,7 +157,7 @@ func TestGetCommentByID(t *testing.T) {
 			tc.mockSetup(mockService)
 
 			// Create handler
-			handler := NewCommentHandler(mockService)
+			handler := NewCommentHandler(mockService, markdown.NewRenderer(nil))
 
 			// Prepare request
 			req := httptest.NewRequest(http.MethodGet, "/comments/"+tc.commentID, nil)
@@ -225,7 +231,7 @@ func TestGetCommentByID(t *testing.T) {
 // 			tc.mockSetup(mockService)
 
 // 			// Create handler
-// 			handler := NewCommentHandler(mockService)
+// 			handler := NewCommentHandler(mockService, markdown.NewRenderer(nil))
 
 // 			// Prepare request
 // 			req := httptest.NewRequest(http.MethodGet, "/tasks/"+tc.taskID+"/comments", nil)
@@ -292,7 +298,7 @@ func TestDeleteComment(t *testing.T) {
 			tc.mockSetup(mockService)
 
 			// Create handler
-			handler := NewCommentHandler(mockService)
+			handler := NewCommentHandler(mockService, markdown.NewRenderer(nil))
 
 			// Prepare request
 			req := httptest.NewRequest(http.MethodDelete, "/comments/"+tc.commentID, nil)
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"example/project-management-system/internal/utils/response"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"net/http"
+)
+
+// requireUserID returns the authenticated user ID, or writes a 401 response when there is none.
+func requireUserID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.WriteJson(w, http.StatusUnauthorized, response.GeneralError(fmt.Errorf("%s", "Requires authentication")))
+		return 0, false
+	}
+	return userID, true
+}
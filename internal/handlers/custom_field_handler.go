@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+)
+
+// CustomFieldRequest is the body used to create or update a custom field
+type CustomFieldRequest struct {
+	// Lowercase letters, digits and underscores, cannot change
+	Key  string `json:"key" example:"severity"`
+	Name string `json:"name" example:"Severity"`
+	// Cannot change
+	Type string `json:"type" enums:"text,number,date,select,multi_select,user" example:"select"`
+	// Choices of select and multi_select fields
+	Options  []string `json:"options,omitempty" example:"low,high"`
+	Required bool     `json:"required"`
+	// Value of new tasks that set none, of the type of the field
+	Default  interface{} `json:"default,omitempty" swaggertype:"object"`
+	Position int         `json:"position"`
+}
+
+type CustomFieldHandler interface {
+	CreateCustomField(w http.ResponseWriter, r *http.Request)
+	GetCustomFields(w http.ResponseWriter, r *http.Request)
+	UpdateCustomField(w http.ResponseWriter, r *http.Request)
+	DeleteCustomField(w http.ResponseWriter, r *http.Request)
+}
+
+type CustomFieldHandlerImplementation struct {
+	service services.CustomFieldService
+}
+
+func NewCustomFieldHandler(service services.CustomFieldService) *CustomFieldHandlerImplementation {
+	return &CustomFieldHandlerImplementation{service: service}
+}
+
+// CreateCustomField godoc
+//	@Summary		Add a custom field
+//	@Description	Add a typed field to the tasks of a project owned by the current user. Tasks hold its values in custom_fields by key.
+//	@Tags			Custom fields
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int					true	"Project ID"
+//	@Param			field			body		CustomFieldRequest	true	"Custom field"
+//	@Param			Idempotency-Key	header		string				false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.CustomField	"Custom field created"
+//	@Failure		400				{object}	response.Response	"Invalid custom field"
+//	@Failure		401				{object}	response.Response	"Unauthenticated"
+//	@Failure		403				{object}	response.Response	"Not the project owner"
+//	@Failure		404				{object}	response.Response	"Project not found"
+//	@Failure		409				{object}	response.Response	"Project archived"
+//	@Router			/projects/{id}/custom-fields [post]
+func (h *CustomFieldHandlerImplementation) CreateCustomField(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req CustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	field := req.toModel()
+	if err := h.service.CreateField(r.Context(), projectID, &field); err != nil {
+		writeCustomFieldError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, field)
+}
+
+// GetCustomFields godoc
+//	@Summary		Get the custom fields of a project
+//	@Description	Retrieve the custom fields of the tasks of a project by position
+//	@Tags			Custom fields
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Project ID"
+//	@Success		200	{array}		models.CustomField	"Custom fields"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Project not found"
+//	@Router			/projects/{id}/custom-fields [get]
+func (h *CustomFieldHandlerImplementation) GetCustomFields(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	fields, err := h.service.GetFieldsByProject(r.Context(), projectID)
+	if err != nil {
+		writeCustomFieldError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, fields)
+}
+
+// UpdateCustomField godoc
+//	@Summary		Update a custom field
+//	@Description	Change the name, options, default, position and whether a custom field is required. Its key and type cannot change. Removed options are cleared from the tasks that have them.
+//	@Tags			Custom fields
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Custom field ID"
+//	@Param			field	body		CustomFieldRequest	true	"Custom field"
+//	@Success		200		{object}	models.CustomField	"Custom field updated"
+//	@Failure		400		{object}	response.Response	"Invalid custom field"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		403		{object}	response.Response	"Not the project owner"
+//	@Failure		404		{object}	response.Response	"Custom field not found"
+//	@Failure		409		{object}	response.Response	"Project archived"
+//	@Router			/custom-fields/{id} [put]
+func (h *CustomFieldHandlerImplementation) UpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req CustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	field := req.toModel()
+	if err := h.service.UpdateField(r.Context(), id, &field); err != nil {
+		writeCustomFieldError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, field)
+}
+
+// DeleteCustomField godoc
+//	@Summary		Delete a custom field
+//	@Description	Delete a custom field with the values tasks have for it
+//	@Tags			Custom fields
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Custom field ID"
+//	@Success		200	{object}	map[string]string	"Custom field deleted"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Not the project owner"
+//	@Failure		404	{object}	response.Response	"Custom field not found"
+//	@Failure		409	{object}	response.Response	"Project archived"
+//	@Router			/custom-fields/{id} [delete]
+func (h *CustomFieldHandlerImplementation) DeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.DeleteField(r.Context(), id); err != nil {
+		writeCustomFieldError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": "custom field deleted successfully"})
+}
+
+func (req CustomFieldRequest) toModel() models.CustomField {
+	return models.CustomField{
+		Key:      req.Key,
+		Name:     req.Name,
+		Type:     req.Type,
+		Options:  req.Options,
+		Required: req.Required,
+		Default:  req.Default,
+		Position: req.Position,
+	}
+}
+
+func writeCustomFieldError(w http.ResponseWriter, err error) {
+	if writeProjectStateError(w, err) {
+		return
+	}
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrCustomFieldNotFound), errors.Is(err, services.ErrProjectNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrNotFieldManager):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidCustomField):
+		status = http.StatusBadRequest
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+}
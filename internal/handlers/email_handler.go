@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+)
+
+type EmailHandler interface {
+	GetMyEmailPreference(w http.ResponseWriter, r *http.Request)
+	UpdateMyEmailPreference(w http.ResponseWriter, r *http.Request)
+}
+
+type EmailHandlerImplementation struct {
+	service services.EmailService
+}
+
+func NewEmailHandler(service services.EmailService) *EmailHandlerImplementation {
+	return &EmailHandlerImplementation{service: service}
+}
+
+// GetMyEmailPreference godoc
+//	@Summary		Get my email preference
+//	@Description	Retrieve how often the current user receives notifications by email
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	models.EmailPreference	"Email preference"
+//	@Failure		401	{object}	response.Response		"Unauthenticated"
+//	@Failure		500	{object}	response.Response		"Server error"
+//	@Router			/me/email-preferences [get]
+func (h *EmailHandlerImplementation) GetMyEmailPreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	preference, err := h.service.GetPreference(r.Context(), userID)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, preference)
+}
+
+// UpdateMyEmailPreference godoc
+//	@Summary		Update my email preference
+//	@Description	Receive notifications by email immediately, as an hourly or daily digest, or not at all
+//	@Tags			Notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			preference	body		models.EmailPreference	true	"Email frequency: immediate, hourly, daily or off"
+//	@Success		200			{object}	models.EmailPreference	"Updated email preference"
+//	@Failure		400			{object}	response.Response		"Invalid input"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Router			/me/email-preferences [put]
+func (h *EmailHandlerImplementation) UpdateMyEmailPreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.EmailPreference
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	preference, err := h.service.UpdatePreference(r.Context(), userID, req.Frequency)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, preference)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// setETag identifies the version of the entity in the response, to be sent back
+// in If-Match when changing it
+func setETag(w http.ResponseWriter, version uint) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatUint(uint64(version), 10)))
+}
+
+// ifMatchVersion returns the version the If-Match header makes the request
+// conditional on, 0 when it is missing or "*". It writes a 400 response and
+// returns false when the header is not a single version ETag or "*".
+func ifMatchVersion(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	tag, err := strconv.Unquote(header)
+	version, parseErr := strconv.ParseUint(tag, 10, 64)
+	if err != nil || parseErr != nil || version == 0 {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf(`If-Match must be the ETag of the entity, e.g. "3", or *`)))
+		return 0, false
+	}
+	return uint(version), true
+}
+
+// writePreconditionFailed writes a 412 response when err is a version conflict
+func writePreconditionFailed(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, services.ErrVersionConflict) {
+		return false
+	}
+	response.WriteJson(w, http.StatusPreconditionFailed, response.GeneralError(err))
+	return true
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+const (
+	// IdempotencyKeyHeader identifies a create request across its retries
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed for a retried request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+type IdempotencyHandler interface {
+	Idempotent(next http.HandlerFunc) http.HandlerFunc
+}
+
+type IdempotencyHandlerImplementation struct {
+	service services.IdempotencyService
+}
+
+func NewIdempotencyHandler(service services.IdempotencyService) *IdempotencyHandlerImplementation {
+	return &IdempotencyHandlerImplementation{service: service}
+}
+
+// Idempotent makes a create endpoint safe to retry. The first request with an
+// Idempotency-Key runs next and its response is stored, retries with the same key
+// and body get the stored response. Failed requests, with a 5xx status, are not
+// stored so they can be retried. Requests without the header run as usual.
+func (h *IdempotencyHandlerImplementation) Idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength)))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID, _ := middleware.UserIDFromContext(r.Context())
+		stored, replay, err := h.service.Begin(r.Context(), userID, key, requestHash(r, body))
+		switch {
+		case errors.Is(err, services.ErrIdempotencyKeyReused):
+			response.WriteJson(w, http.StatusUnprocessableEntity, response.GeneralError(err))
+			return
+		case errors.Is(err, services.ErrIdempotencyKeyInProgress):
+			response.WriteJson(w, http.StatusConflict, response.GeneralError(err))
+			return
+		case err != nil:
+			response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+			return
+		case replay:
+			writeStoredResponse(w, stored)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(recorder, r)
+
+		if recorder.status >= http.StatusInternalServerError {
+			err = h.service.Release(r.Context(), stored)
+		} else {
+			stored.StatusCode = recorder.status
+			stored.ContentType = recorder.Header().Get("Content-Type")
+			stored.Body = recorder.body.Bytes()
+			err = h.service.Complete(r.Context(), stored)
+		}
+		if err != nil {
+			slog.Error("failed to store the idempotent response", slog.String("key", key), slog.Any("error", err))
+		}
+	}
+}
+
+func writeStoredResponse(w http.ResponseWriter, stored *models.IdempotencyKey) {
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+	w.Write(stored.Body)
+}
+
+// requestHash identifies a request by its method, path and body
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.RequestURI())
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder passes a response through while keeping its status and body
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
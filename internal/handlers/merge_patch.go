@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"example/project-management-system/internal/utils/mergepatch"
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// Fields of each resource that PATCH requests may change
+var (
+	userPatchFields    = []string{"email", "first_name", "last_name"}
+	projectPatchFields = []string{"name", "description", "start_date", "end_date", "status", "require_checklist"}
+	taskPatchFields    = []string{"title", "description", "status", "assigned_to", "labels", "due_date", "original_estimate", "remaining_estimate", "custom_fields"}
+	teamPatchFields    = []string{"name", "description"}
+	commentPatchFields = []string{"content"}
+)
+
+// readMergePatch reads the JSON merge patch in the request body. It writes a 415
+// response and returns false when the body is neither a merge patch nor JSON.
+func readMergePatch(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != mergepatch.ContentType && mediaType != "application/json" {
+		response.WriteJson(w, http.StatusUnsupportedMediaType, response.GeneralError(fmt.Errorf("the patch must be sent as %s", mergepatch.ContentType)))
+		return nil, false
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return nil, false
+	}
+	return patch, true
+}
+
+// applyMergePatch applies the patch to the allowed fields of target, or writes a
+// 400 response and returns false when it is invalid
+func applyMergePatch(w http.ResponseWriter, target interface{}, patch []byte, fields []string) bool {
+	if err := mergepatch.Apply(target, patch, fields...); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, mergepatch.ErrInvalidPatch) {
+			status = http.StatusBadRequest
+		}
+		response.WriteJson(w, status, response.GeneralError(err))
+		return false
+	}
+	return true
+}
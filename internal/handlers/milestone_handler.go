@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+	"time"
+)
+
+// MilestoneRequest is the body used to add or change a milestone
+type MilestoneRequest struct {
+	Name        string    `json:"name" example:"v2.0"`
+	Description string    `json:"description"`
+	TargetDate  time.Time `json:"target_date"`
+	// Hours per working day the team spends on the milestone, 8 for each project
+	// member and assignee when 0
+	DailyCapacity float64 `json:"daily_capacity"`
+}
+
+// MilestoneTasksRequest lists the tasks linked to a milestone
+type MilestoneTasksRequest struct {
+	TaskIDs []uint `json:"task_ids"`
+}
+
+type MilestoneHandler interface {
+	CreateMilestone(w http.ResponseWriter, r *http.Request)
+	GetProjectMilestones(w http.ResponseWriter, r *http.Request)
+	GetMilestoneByID(w http.ResponseWriter, r *http.Request)
+	UpdateMilestone(w http.ResponseWriter, r *http.Request)
+	DeleteMilestone(w http.ResponseWriter, r *http.Request)
+	AddMilestoneTasks(w http.ResponseWriter, r *http.Request)
+	RemoveMilestoneTask(w http.ResponseWriter, r *http.Request)
+}
+
+type MilestoneHandlerImplementation struct {
+	service services.MilestoneService
+}
+
+func NewMilestoneHandler(service services.MilestoneService) *MilestoneHandlerImplementation {
+	return &MilestoneHandlerImplementation{service: service}
+}
+
+// CreateMilestone godoc
+//	@Summary		Add a milestone
+//	@Description	Add a release with a target date to a project
+//	@Tags			Milestones
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int					true	"Project ID"
+//	@Param			milestone		body		MilestoneRequest	true	"Milestone"
+//	@Param			Idempotency-Key	header		string				false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.Milestone	"Milestone created"
+//	@Failure		400				{object}	response.Response	"Invalid milestone"
+//	@Failure		401				{object}	response.Response	"Unauthenticated"
+//	@Failure		404				{object}	response.Response	"Project not found"
+//	@Failure		409				{object}	response.Response	"Project archived"
+//	@Router			/projects/{id}/milestones [post]
+func (h *MilestoneHandlerImplementation) CreateMilestone(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req MilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	milestone := models.Milestone{
+		ProjectID:     projectID,
+		Name:          req.Name,
+		Description:   req.Description,
+		TargetDate:    req.TargetDate,
+		DailyCapacity: req.DailyCapacity,
+	}
+	if err := h.service.CreateMilestone(r.Context(), &milestone); err != nil {
+		writeMilestoneError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, milestone)
+}
+
+// GetProjectMilestones godoc
+//	@Summary		Get the milestones of a project
+//	@Description	Retrieve the milestones of a project by target date with their progress: done and total tasks, the hours left on the open tasks, the capacity until the target date and whether it is at risk
+//	@Tags			Milestones
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Project ID"
+//	@Success		200	{array}		models.Milestone	"Milestones"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Project not found"
+//	@Router			/projects/{id}/milestones [get]
+func (h *MilestoneHandlerImplementation) GetProjectMilestones(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	milestones, err := h.service.GetMilestonesByProject(r.Context(), projectID)
+	if err != nil {
+		writeMilestoneError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, milestones)
+}
+
+// GetMilestoneByID godoc
+//	@Summary		Get a milestone
+//	@Description	Retrieve a milestone with its tasks and progress. It is at risk when the hours left on its open tasks exceed the capacity of the working days until its target date.
+//	@Tags			Milestones
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Milestone ID"
+//	@Success		200	{object}	models.Milestone	"Milestone"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Milestone not found"
+//	@Router			/milestones/{id} [get]
+func (h *MilestoneHandlerImplementation) GetMilestoneByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	milestone, err := h.service.GetMilestoneByID(r.Context(), id)
+	if err != nil {
+		writeMilestoneError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, milestone)
+}
+
+// UpdateMilestone godoc
+//	@Summary		Update a milestone
+//	@Description	Change the name, description, target date and daily capacity of a milestone
+//	@Tags			Milestones
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int					true	"Milestone ID"
+//	@Param			milestone	body		MilestoneRequest	true	"Milestone"
+//	@Success		200			{object}	models.Milestone	"Milestone updated"
+//	@Failure		400			{object}	response.Response	"Invalid milestone"
+//	@Failure		401			{object}	response.Response	"Unauthenticated"
+//	@Failure		404			{object}	response.Response	"Milestone not found"
+//	@Failure		409			{object}	response.Response	"Project archived"
+//	@Router			/milestones/{id} [put]
+func (h *MilestoneHandlerImplementation) UpdateMilestone(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req MilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	milestone := models.Milestone{
+		ID:            id,
+		Name:          req.Name,
+		Description:   req.Description,
+		TargetDate:    req.TargetDate,
+		DailyCapacity: req.DailyCapacity,
+	}
+	if err := h.service.UpdateMilestone(r.Context(), &milestone); err != nil {
+		writeMilestoneError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, milestone)
+}
+
+// DeleteMilestone godoc
+//	@Summary		Delete a milestone
+//	@Description	Delete a milestone, its tasks are kept without it
+//	@Tags			Milestones
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Milestone ID"
+//	@Success		200	{object}	map[string]string	"Milestone deleted"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Milestone not found"
+//	@Failure		409	{object}	response.Response	"Project archived"
+//	@Router			/milestones/{id} [delete]
+func (h *MilestoneHandlerImplementation) DeleteMilestone(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.DeleteMilestone(r.Context(), id); err != nil {
+		writeMilestoneError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": "milestone deleted successfully"})
+}
+
+// AddMilestoneTasks godoc
+//	@Summary		Link tasks to a milestone
+//	@Description	Link tasks of the project to a milestone, moving them from the milestone they were linked to
+//	@Tags			Milestones
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Milestone ID"
+//	@Param			request	body		MilestoneTasksRequest	true	"Tasks"
+//	@Success		200		{object}	models.Milestone		"Milestone with its tasks and progress"
+//	@Failure		400		{object}	response.Response		"Invalid input or task of another project"
+//	@Failure		401		{object}	response.Response		"Unauthenticated"
+//	@Failure		404		{object}	response.Response		"Milestone or task not found"
+//	@Failure		409		{object}	response.Response		"Project archived"
+//	@Router			/milestones/{id}/tasks [post]
+func (h *MilestoneHandlerImplementation) AddMilestoneTasks(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req MilestoneTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	milestone, err := h.service.AddTasks(r.Context(), id, req.TaskIDs)
+	if err != nil {
+		writeMilestoneError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, milestone)
+}
+
+// RemoveMilestoneTask godoc
+//	@Summary		Unlink a task from a milestone
+//	@Description	Unlink a task from its milestone
+//	@Tags			Milestones
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Milestone ID"
+//	@Param			taskId	path		int					true	"Task ID"
+//	@Success		204		"Task unlinked"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		404		{object}	response.Response	"Milestone or task not found, or the task is not linked to the milestone"
+//	@Failure		409		{object}	response.Response	"Project archived"
+//	@Router			/milestones/{id}/tasks/{taskId} [delete]
+func (h *MilestoneHandlerImplementation) RemoveMilestoneTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	taskID, ok := parseIDParam(w, r, "taskId")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.RemoveTask(r.Context(), id, taskID); err != nil {
+		writeMilestoneError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeMilestoneError(w http.ResponseWriter, err error) {
+	if writeProjectStateError(w, err) {
+		return
+	}
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrMilestoneNotFound), errors.Is(err, services.ErrProjectNotFound),
+		errors.Is(err, services.ErrTaskNotFound), errors.Is(err, services.ErrTaskNotInMilestone):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidMilestone), errors.Is(err, services.ErrInvalidMilestoneTask):
+		status = http.StatusBadRequest
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+type NotificationHandler interface {
+	GetMyNotifications(w http.ResponseWriter, r *http.Request)
+	MarkNotificationAsRead(w http.ResponseWriter, r *http.Request)
+	MarkAllNotificationsAsRead(w http.ResponseWriter, r *http.Request)
+	GetMyNotificationPreferences(w http.ResponseWriter, r *http.Request)
+	UpdateMyNotificationPreferences(w http.ResponseWriter, r *http.Request)
+}
+
+type NotificationHandlerImplementation struct {
+	service services.NotificationService
+}
+
+func NewNotificationHandler(service services.NotificationService) *NotificationHandlerImplementation {
+	return &NotificationHandlerImplementation{service: service}
+}
+
+// GetMyNotifications godoc
+//	@Summary		Get my notifications
+//	@Description	Retrieve the paginated inbox of the current user, newest first
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			unread		query		bool					false	"Only return unread notifications"
+//	@Param			page		query		int						false	"Page number"						default(1)
+//	@Param			page_size	query		int						false	"Number of notifications per page"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Successful response"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		500			{object}	response.Response		"Server error"
+//	@Router			/me/notifications [get]
+func (h *NotificationHandlerImplementation) GetMyNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	notifications, total, unread, err := h.service.GetNotifications(r.Context(), userID, unreadOnly, page, pageSize)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]interface{}{
+		"notifications": notifications,
+		"total":         total,
+		"unread":        unread,
+		"page":          page,
+	})
+}
+
+// MarkNotificationAsRead godoc
+//	@Summary		Mark a notification as read
+//	@Description	Mark one notification of the current user as read
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Notification ID"
+//	@Success		200	{object}	map[string]string	"Notification marked as read"
+//	@Failure		400	{object}	response.Response	"Bad request"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Notification not found"
+//	@Router			/me/notifications/{id}/read [post]
+func (h *NotificationHandlerImplementation) MarkNotificationAsRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s", "invalid ID")))
+		return
+	}
+
+	if err := h.service.MarkAsRead(r.Context(), userID, uint(id)); err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+			return
+		}
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": "notification marked as read"})
+}
+
+// MarkAllNotificationsAsRead godoc
+//	@Summary		Mark all notifications as read
+//	@Description	Mark every unread notification of the current user as read
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]int64	"Number of notifications marked as read"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		500	{object}	response.Response	"Server error"
+//	@Router			/me/notifications/read-all [post]
+func (h *NotificationHandlerImplementation) MarkAllNotificationsAsRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := h.service.MarkAllAsRead(r.Context(), userID)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]int64{"updated": updated})
+}
+
+// GetMyNotificationPreferences godoc
+//	@Summary		Get my notification preferences
+//	@Description	Retrieve whether the current user is subscribed to each notification event type
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]bool		"Subscription per event type"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		500	{object}	response.Response	"Server error"
+//	@Router			/me/notification-preferences [get]
+func (h *NotificationHandlerImplementation) GetMyNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.service.GetPreferences(r.Context(), userID)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, preferences)
+}
+
+// UpdateMyNotificationPreferences godoc
+//	@Summary		Update my notification preferences
+//	@Description	Subscribe to or unsubscribe from notification event types, e.g. {"task.status_changed": false}
+//	@Tags			Notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			preferences	body		map[string]bool		true	"Subscription per event type"
+//	@Success		200			{object}	map[string]bool		"Updated subscription per event type"
+//	@Failure		400			{object}	response.Response	"Invalid input"
+//	@Failure		401			{object}	response.Response	"Unauthenticated"
+//	@Router			/me/notification-preferences [put]
+func (h *NotificationHandlerImplementation) UpdateMyNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var preferences map[events.Type]bool
+	if err := json.NewDecoder(r.Body).Decode(&preferences); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	updated, err := h.service.UpdatePreferences(r.Context(), userID, preferences)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, updated)
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/pkg/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationService mocks the NotificationService for testing
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) HandleEvent(ctx context.Context, event events.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) GetNotifications(ctx context.Context, userID uint, unreadOnly bool, page, pageSize int) ([]models.Notification, int64, int64, error) {
+	args := m.Called(ctx, userID, unreadOnly, page, pageSize)
+	if notifications, ok := args.Get(0).([]models.Notification); ok {
+		return notifications, args.Get(1).(int64), args.Get(2).(int64), args.Error(3)
+	}
+	return nil, 0, 0, args.Error(3)
+}
+
+func (m *MockNotificationService) MarkAsRead(ctx context.Context, userID, id uint) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationService) GetPreferences(ctx context.Context, userID uint) (map[events.Type]bool, error) {
+	args := m.Called(ctx, userID)
+	if preferences, ok := args.Get(0).(map[events.Type]bool); ok {
+		return preferences, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationService) UpdatePreferences(ctx context.Context, userID uint, preferences map[events.Type]bool) (map[events.Type]bool, error) {
+	args := m.Called(ctx, userID, preferences)
+	if updated, ok := args.Get(0).(map[events.Type]bool); ok {
+		return updated, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestGetMyNotifications(t *testing.T) {
+	testCases := []struct {
+		name           string
+		userID         uint
+		query          string
+		mockSetup      func(*MockNotificationService)
+		expectedStatus int
+	}{
+		{
+			name:   "Unread Notifications",
+			userID: 2,
+			query:  "?unread=true&page=2&page_size=5",
+			mockSetup: func(mns *MockNotificationService) {
+				mns.On("GetNotifications", mock.Anything, uint(2), true, 2, 5).
+					Return([]models.Notification{{ID: 1, UserID: 2, Type: string(events.TaskAssigned)}}, int64(6), int64(6), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Default Pagination",
+			userID: 2,
+			mockSetup: func(mns *MockNotificationService) {
+				mns.On("GetNotifications", mock.Anything, uint(2), false, 1, 10).
+					Return([]models.Notification{}, int64(0), int64(0), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unauthenticated",
+			mockSetup:      func(mns *MockNotificationService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "Service Error",
+			userID: 2,
+			mockSetup: func(mns *MockNotificationService) {
+				mns.On("GetNotifications", mock.Anything, uint(2), false, 1, 10).
+					Return(nil, int64(0), int64(0), errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			tc.mockSetup(mockService)
+
+			handler := NewNotificationHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/me/notifications"+tc.query, nil)
+			if tc.userID != 0 {
+				req = req.WithContext(middleware.WithUserID(req.Context(), tc.userID))
+			}
+			w := httptest.NewRecorder()
+
+			handler.GetMyNotifications(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarkNotificationAsRead(t *testing.T) {
+	testCases := []struct {
+		name           string
+		notificationID string
+		mockSetup      func(*MockNotificationService)
+		expectedStatus int
+	}{
+		{
+			name:           "Successful Mark",
+			notificationID: "4",
+			mockSetup: func(mns *MockNotificationService) {
+				mns.On("MarkAsRead", mock.Anything, uint(2), uint(4)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Notification Not Found",
+			notificationID: "4",
+			mockSetup: func(mns *MockNotificationService) {
+				mns.On("MarkAsRead", mock.Anything, uint(2), uint(4)).Return(services.ErrNotificationNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid ID",
+			notificationID: "abc",
+			mockSetup:      func(mns *MockNotificationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			tc.mockSetup(mockService)
+
+			handler := NewNotificationHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/me/notifications/"+tc.notificationID+"/read", nil)
+			req.SetPathValue("id", tc.notificationID)
+			req = req.WithContext(middleware.WithUserID(req.Context(), 2))
+			w := httptest.NewRecorder()
+
+			handler.MarkNotificationAsRead(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateMyNotificationPreferences(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockNotificationService)
+		expectedStatus int
+	}{
+		{
+			name: "Successful Update",
+			body: `{"task.status_changed": false}`,
+			mockSetup: func(mns *MockNotificationService) {
+				mns.On("UpdatePreferences", mock.Anything, uint(2), map[events.Type]bool{events.TaskStatusChanged: false}).
+					Return(map[events.Type]bool{events.TaskStatusChanged: false, events.TaskAssigned: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Unknown Event Type",
+			body: `{"task.exploded": false}`,
+			mockSetup: func(mns *MockNotificationService) {
+				mns.On("UpdatePreferences", mock.Anything, uint(2), mock.Anything).
+					Return(nil, errors.New(`unknown notification event type "task.exploded"`))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid JSON",
+			body:           `{"task.status_changed":`,
+			mockSetup:      func(mns *MockNotificationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			tc.mockSetup(mockService)
+
+			handler := NewNotificationHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/me/notification-preferences", bytes.NewBufferString(tc.body))
+			req = req.WithContext(middleware.WithUserID(req.Context(), 2))
+			w := httptest.NewRecorder()
+
+			handler.UpdateMyNotificationPreferences(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"example/project-management-system/internal/models"
 	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/markdown"
 	"example/project-management-system/internal/utils/response"
 	"fmt"
 	"net/http"
@@ -15,16 +17,25 @@ type ProjectHandler interface {
 	GetProjectByID(w http.ResponseWriter, r *http.Request)
 	GetAllProjects(w http.ResponseWriter, r *http.Request)
 	UpdateProject(w http.ResponseWriter, r *http.Request)
+	PatchProject(w http.ResponseWriter, r *http.Request)
+	ChangeProjectStatus(w http.ResponseWriter, r *http.Request)
 	DeleteProject(w http.ResponseWriter, r *http.Request)
-	GetTaskByProjectID(w http.ResponseWriter, r *http.Request)
 }
 
 type ProjectHandlerImplementation struct {
-	service services.ProjectService
+	service  services.ProjectService
+	renderer *markdown.Renderer
 }
 
-func NewProjectHandler(service services.ProjectService) *ProjectHandlerImplementation {
-	return &ProjectHandlerImplementation{service: service}
+func NewProjectHandler(service services.ProjectService, renderer *markdown.Renderer) *ProjectHandlerImplementation {
+	return &ProjectHandlerImplementation{service: service, renderer: renderer}
+}
+
+// ProjectStatusRequest moves a project to another status of its lifecycle
+type ProjectStatusRequest struct {
+	Status string `json:"status" enums:"planned,active,on_hold,completed,archived"`
+	// Completes the project even though some of its tasks are not done
+	Force bool `json:"force"`
 }
 
 
@@ -36,8 +47,11 @@ func NewProjectHandler(service services.ProjectService) *ProjectHandlerImplement
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			project	body		models.Project		true	"Project Creation Request"
+//	@Param			Idempotency-Key	header		string			false	"Key that makes retries of the request return its first response"
 //	@Success		201		{object}	map[string]int		"Project created successfully"
 //	@Failure		400		{object}	response.Response	"Invalid input"
+//	@Failure		409		{object}	response.Response	"Request with the Idempotency-Key in progress"
+//	@Failure		422		{object}	response.Response	"Idempotency-Key used for a different request, or a status other than planned and active"
 //	@Failure		500		{object}	response.Response	"Server error"
 //	@Router			/projects [post]
 func (h *ProjectHandlerImplementation) CreateProject(w http.ResponseWriter, r *http.Request) {
@@ -53,6 +67,9 @@ func (h *ProjectHandlerImplementation) CreateProject(w http.ResponseWriter, r *h
 	}
 
 	if err := h.service.CreateProject(r.Context(), &project); err != nil {
+		if writeProjectStateError(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
@@ -69,6 +86,8 @@ func (h *ProjectHandlerImplementation) CreateProject(w http.ResponseWriter, r *h
 //	@Security		BearerAuth
 //	@Param			page		query		int						false	"Page number"					default(1)
 //	@Param			pageSize	query		int						false	"Number of projects per page"	default(10)
+//	@Param			include_archived	query	bool					false	"Include archived projects"	default(false)
+//	@Param			render		query		string					false	"Set to html to include sanitized description_html"	Enums(html)
 //	@Success		200			{object}	map[string]interface{}	"Successful response"
 //	@Failure		400			{object}	response.Response		"Bad request"
 //	@Router			/projects [get]
@@ -87,12 +106,21 @@ func (h *ProjectHandlerImplementation) GetAllProjects(w http.ResponseWriter, r *
 		pageSize = 10
 	}
 
-	projects, total, err := h.service.GetPaginatedProjects(r.Context(), page, pageSize)
+	includeArchived, _ := strconv.ParseBool(r.URL.Query().Get("include_archived"))
+
+	projects, total, err := h.service.GetPaginatedProjects(r.Context(), page, pageSize, includeArchived)
 	if err != nil {
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
 
+	if wantsHTML(r) {
+		if err := renderProjectDescriptions(r.Context(), h.renderer, projects); err != nil {
+			response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+			return
+		}
+	}
+
 	response.WriteJson(w, http.StatusOK, map[string]interface{}{
 		"projects": projects,
 		"total":    total,
@@ -107,8 +135,9 @@ func (h *ProjectHandlerImplementation) GetAllProjects(w http.ResponseWriter, r *
 //	@Tags			Projects
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id	path		int					true	"Project ID"
-//	@Success		200	{object}	models.Project		"Successful response"
+//	@Param			id		path		int					true	"Project ID"
+//	@Param			render	query		string				false	"Set to html to include sanitized description_html"	Enums(html)
+//	@Success		200		{object}	models.Project		"Successful response"
 //	@Failure		400	{object}	response.Response	"Bad request"
 //	@Failure		404	{object}	response.Response	"User not found"
 //	@Router			/projects/{id} [get]
@@ -130,6 +159,16 @@ func (h *ProjectHandlerImplementation) GetProjectByID(w http.ResponseWriter, r *
 		return
 	}
 
+	if wantsHTML(r) {
+		projects := []models.Project{*project}
+		if err := renderProjectDescriptions(r.Context(), h.renderer, projects); err != nil {
+			response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+			return
+		}
+		project = &projects[0]
+	}
+
+	setETag(w, project.Version)
 	response.WriteJson(w, http.StatusOK, project)
 }
 
@@ -141,11 +180,17 @@ func (h *ProjectHandlerImplementation) GetProjectByID(w http.ResponseWriter, r *
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id		path		int					true	"Project ID"
-//	@Param			project	body		models.Project		true	"Updated Project Details"
-//	@Success		200		{object}	models.Project		"Successful response"
-//	@Failure		400		{object}	response.Response	"Bad request"
-//	@Failure		404		{object}	response.Response	"User not found"
+//	@Param			id			path		int					true	"Project ID"
+//	@Param			If-Match	header		string				true	"ETag of the project version being updated"
+//	@Param			project		body		models.Project		true	"Updated Project Details"
+//	@Success		200			{object}	models.Project		"Successful response"
+//	@Header			200			{string}	ETag				"New version of the project"
+//	@Failure		400			{object}	response.Response	"Bad request"
+//	@Failure		404			{object}	response.Response	"User not found"
+//	@Failure		409			{object}	response.Response	"The project is archived, or still has open tasks"
+//	@Failure		412			{object}	response.Response	"The project was changed since that version"
+//	@Failure		422			{object}	response.Response	"Illegal status change"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
 //	@Router			/projects/{id} [put]
 func (h *ProjectHandlerImplementation) UpdateProject(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
@@ -154,6 +199,11 @@ func (h *ProjectHandlerImplementation) UpdateProject(w http.ResponseWriter, r *h
 		return
 	}
 
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
 	var project models.Project
 	if err := json.NewDecoder(r.Body).Decode(&project); err != nil {
 		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
@@ -161,65 +211,190 @@ func (h *ProjectHandlerImplementation) UpdateProject(w http.ResponseWriter, r *h
 	}
 
 	project.ID = uint(id)
+	project.Version = version
 	if err := h.service.UpdateProject(r.Context(), &project); err != nil {
+		if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
 
+	setETag(w, project.Version)
 	response.WriteJson(w, http.StatusOK, project)
 }
 
 
-// DeleteUser godoc
-//	@Summary		Delete a project
-//	@Description	Remove a project from the system by their ID
+// PatchProject godoc
+//	@Summary		Partially update a project
+//	@Description	Apply a JSON merge patch (RFC 7396) to the name, description, dates and status of a project. Omitted fields are kept and null resets a field.
 //	@Tags			Projects
+//	@Accept			json,application/merge-patch+json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id	path		int					true	"Project ID to delete"
-//	@Success		200	{object}	map[string]string	"Project deleted successfully"
-//	@Failure		400	{object}	response.Response	"Bad request"
-//	@Failure		500	{object}	response.Response	"Server error"
-//	@Router			/projects/{id} [delete]
-func (h *ProjectHandlerImplementation) DeleteProject(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
-	if err != nil || id <= 0 {
+//	@Param			id			path		int					true	"Project ID"
+//	@Param			If-Match	header		string				true	"ETag of the project version being updated"
+//	@Param			patch		body		models.Project		true	"Fields to change"
+//	@Success		200			{object}	models.Project		"The updated project"
+//	@Header			200			{string}	ETag				"New version of the project"
+//	@Failure		400			{object}	response.Response	"Invalid patch"
+//	@Failure		404			{object}	response.Response	"Project not found"
+//	@Failure		409			{object}	response.Response	"The project is archived, or still has open tasks"
+//	@Failure		412			{object}	response.Response	"The project was changed since that version"
+//	@Failure		415			{object}	response.Response	"The body is not a merge patch"
+//	@Failure		422			{object}	response.Response	"Illegal status change"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
+//	@Router			/projects/{id} [patch]
+func (h *ProjectHandlerImplementation) PatchProject(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+
+	project, err := h.service.GetProjectByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+		return
+	}
+	if !applyMergePatch(w, project, patch, projectPatchFields) {
+		return
+	}
+	if version != 0 {
+		project.Version = version
+	}
+
+	if err := h.service.UpdateProject(r.Context(), project); err != nil {
+		if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
 		return
 	}
 
-	if err := h.service.DeleteProject(r.Context(), uint(id)); err != nil {
+	// Reload the project for its up to date relations
+	project, err = h.service.GetProjectByID(r.Context(), id)
+	if err != nil {
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
 
-	response.WriteJson(w, http.StatusOK, map[string]string{"message": "project deleted successfully"})
+	setETag(w, project.Version)
+	response.WriteJson(w, http.StatusOK, project)
 }
 
+// ChangeProjectStatus godoc
+//	@Summary		Change the status of a project
+//	@Description	Move a project through its lifecycle: planned, active, on_hold, completed and archived. Completing a project needs all its tasks done unless forced. Archived projects, with their tasks, teams and comments, are read-only until brought back as active or completed.
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"Project ID"
+//	@Param			If-Match	header		string					true	"ETag of the project version being changed"
+//	@Param			request		body		ProjectStatusRequest	true	"New status"
+//	@Success		200			{object}	models.Project			"The project"
+//	@Header			200			{string}	ETag					"New version of the project"
+//	@Failure		400			{object}	response.Response		"Invalid input"
+//	@Failure		404			{object}	response.Response		"Project not found"
+//	@Failure		409			{object}	response.Response		"The project still has open tasks"
+//	@Failure		412			{object}	response.Response		"The project was changed since that version"
+//	@Failure		422			{object}	response.Response		"Illegal status change"
+//	@Failure		428			{object}	response.Response		"If-Match is missing"
+//	@Router			/projects/{id}/status [post]
+func (h *ProjectHandlerImplementation) ChangeProjectStatus(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	var request ProjectStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	project, err := h.service.ChangeProjectStatus(r.Context(), id, version, request.Status, request.Force)
+	if err != nil {
+		if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+			return
+		}
+		if errors.Is(err, services.ErrProjectNotFound) {
+			response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+			return
+		}
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	setETag(w, project.Version)
+	response.WriteJson(w, http.StatusOK, project)
+}
 
 // DeleteUser godoc
-//	@Summary		Get task by project ID
-//	@Description	Retrieve a list task by their ID
+//	@Summary		Delete a project
+//	@Description	Remove a project from the system by their ID
 //	@Tags			Projects
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			projectID	path		int					true	"Project ID"
-//	@Success		200			{object}	map[string]string	"Successful response"
+//	@Param			id			path		int					true	"Project ID to delete"
+//	@Param			If-Match	header		string				true	"ETag of the project version being deleted"
+//	@Success		200			{object}	map[string]string	"Project deleted successfully"
 //	@Failure		400			{object}	response.Response	"Bad request"
+//	@Failure		412			{object}	response.Response	"The project was changed since that version"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
 //	@Failure		500			{object}	response.Response	"Server error"
-//	@Router			/projects/{projectID}/tasks [get]
-func (h *ProjectHandlerImplementation) GetTaskByProjectID(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseUint(r.PathValue("projectID"), 10, 64)
+//	@Router			/projects/{id} [delete]
+func (h *ProjectHandlerImplementation) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
 	if err != nil || id <= 0 {
 		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
 		return
 	}
 
-	tasks, err := h.service.GetTaskByProjectID(r.Context(), uint(id))
-	if err != nil {
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteProject(r.Context(), uint(id), version); err != nil {
+		if writePreconditionFailed(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
 
-	response.WriteJson(w, http.StatusOK, tasks)
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": "project deleted successfully"})
+}
+
+
+// writeProjectStateError writes the response for changes the lifecycle of a
+// project does not allow and reports whether err was one of them
+func writeProjectStateError(w http.ResponseWriter, err error) bool {
+	var status int
+	switch {
+	case errors.Is(err, services.ErrProjectArchived), errors.Is(err, services.ErrProjectHasOpenTasks):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrInvalidProjectStatus):
+		status = http.StatusUnprocessableEntity
+	default:
+		return false
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+	return true
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/utils/markdown"
 	"example/project-management-system/internal/services"
 	"net/http"
 	"net/http/httptest"
@@ -42,10 +43,10 @@ func TestProjectHandlers(t *testing.T) {
 			}
 			return nil, nil
 		},
-		GetPaginatedProjectsFunc: func(ctx context.Context, page, pageSize int) ([]models.Project, int64, error) {
+		GetPaginatedProjectsFunc: func(ctx context.Context, page, pageSize int, includeArchived bool) ([]models.Project, int64, error) {
 			return mockProjects, int64(len(mockProjects)), nil
 		},
-		DeleteProjectFunc: func(ctx context.Context, id uint) error {
+		DeleteProjectFunc: func(ctx context.Context, id uint, version uint) error {
 			return nil
 		},
 		GetTasksByProjectIDFunc: func(ctx context.Context, projectID uint) ([]models.Task, error) {
@@ -57,7 +58,7 @@ func TestProjectHandlers(t *testing.T) {
 
 	}
 
-	handler := NewProjectHandler(mockService)
+	handler := NewProjectHandler(mockService, markdown.NewRenderer(nil))
 
 	t.Run("CreateProject", func(t *testing.T) {
 		project := models.Project{
@@ -134,7 +135,7 @@ func TestProjectHandlers(t *testing.T) {
 	})
 
 	t.Run("DeleteProject", func(t *testing.T) {
-		mockService.DeleteProjectFunc = func(ctx context.Context, id uint) error {
+		mockService.DeleteProjectFunc = func(ctx context.Context, id uint, version uint) error {
 			return nil
 		}
 
@@ -156,3 +157,49 @@ func TestProjectHandlers(t *testing.T) {
 		assert.Equal(t, "project deleted successfully", response["message"])
 	})
 }
+
+func TestChangeProjectStatus(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedForce  bool
+		expectedStatus int
+	}{
+		{name: "Successful Change", body: `{"status": "active"}`, expectedStatus: http.StatusOK},
+		{name: "Forced Completion", body: `{"status": "completed", "force": true}`, expectedForce: true, expectedStatus: http.StatusOK},
+		{name: "Open Tasks", body: `{"status": "completed"}`, err: services.ErrProjectHasOpenTasks, expectedStatus: http.StatusConflict},
+		{name: "Illegal Transition", body: `{"status": "planned"}`, err: services.ErrInvalidProjectStatus, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "Stale Version", body: `{"status": "active"}`, err: services.ErrVersionConflict, expectedStatus: http.StatusPreconditionFailed},
+		{name: "Project Not Found", body: `{"status": "active"}`, err: services.ErrProjectNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &services.MockProjectService{
+				ChangeProjectStatusFunc: func(ctx context.Context, id, version uint, status string, force bool) (*models.Project, error) {
+					assert.Equal(t, uint(1), id)
+					assert.Equal(t, uint(3), version)
+					assert.Equal(t, tc.expectedForce, force)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &models.Project{BaseModel: models.BaseModel{ID: id, Version: version + 1}, Status: status}, nil
+				},
+			}
+			handler := NewProjectHandler(mockService, markdown.NewRenderer(nil))
+
+			req := httptest.NewRequest(http.MethodPost, "/projects/1/status", bytes.NewBufferString(tc.body))
+			req.SetPathValue("id", "1")
+			req.Header.Set("If-Match", `"3"`)
+			w := httptest.NewRecorder()
+
+			handler.ChangeProjectStatus(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+)
+
+// CaptureTemplateRequest names the template captured from a project
+type CaptureTemplateRequest struct {
+	// Defaults to the name of the project
+	Name string `json:"name"`
+}
+
+// FromTemplateRequest creates a project from a template
+type FromTemplateRequest struct {
+	TemplateID uint `json:"template_id"`
+	services.NewProjectOptions
+}
+
+type ProjectTemplateHandler interface {
+	CreateTemplate(w http.ResponseWriter, r *http.Request)
+	CreateTemplateFromProject(w http.ResponseWriter, r *http.Request)
+	GetTemplates(w http.ResponseWriter, r *http.Request)
+	GetTemplateByID(w http.ResponseWriter, r *http.Request)
+	DeleteTemplate(w http.ResponseWriter, r *http.Request)
+	CloneProject(w http.ResponseWriter, r *http.Request)
+	CreateProjectFromTemplate(w http.ResponseWriter, r *http.Request)
+}
+
+type ProjectTemplateHandlerImplementation struct {
+	service  services.ProjectTemplateService
+	projects services.ProjectService
+}
+
+func NewProjectTemplateHandler(service services.ProjectTemplateService, projects services.ProjectService) *ProjectTemplateHandlerImplementation {
+	return &ProjectTemplateHandlerImplementation{service: service, projects: projects}
+}
+
+// CreateTemplate godoc
+//	@Summary		Create a project template
+//	@Description	Create a template of the teams, task skeletons, labels and workflow new projects start with. Due dates are days from the start of the project. The workflow defaults to all task statuses, tasks without a status start in its first.
+//	@Tags			Project templates
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			template		body		models.ProjectTemplate	true	"Template"
+//	@Param			Idempotency-Key	header		string					false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.ProjectTemplate	"Template created"
+//	@Failure		400				{object}	response.Response		"Invalid template"
+//	@Failure		401				{object}	response.Response		"Unauthenticated"
+//	@Failure		409				{object}	response.Response		"Request with the Idempotency-Key in progress"
+//	@Router			/project-templates [post]
+func (h *ProjectTemplateHandlerImplementation) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var template models.ProjectTemplate
+	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	if err := h.service.CreateTemplate(r.Context(), &template); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, template)
+}
+
+// CreateTemplateFromProject godoc
+//	@Summary		Capture a project as a template
+//	@Description	Create a template of the teams and tasks of a project, without members and assignees. Due dates become days from the start date of the project and are left out when it has none.
+//	@Tags			Project templates
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int						true	"Project ID"
+//	@Param			request			body		CaptureTemplateRequest	false	"Template name"
+//	@Param			Idempotency-Key	header		string					false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.ProjectTemplate	"Template created"
+//	@Failure		400				{object}	response.Response		"Invalid input"
+//	@Failure		401				{object}	response.Response		"Unauthenticated"
+//	@Failure		404				{object}	response.Response		"Project not found"
+//	@Router			/projects/{id}/templates [post]
+func (h *ProjectTemplateHandlerImplementation) CreateTemplateFromProject(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var request CaptureTemplateRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+			return
+		}
+	}
+
+	template, err := h.service.CreateTemplateFromProject(r.Context(), projectID, request.Name)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, template)
+}
+
+// GetTemplates godoc
+//	@Summary		Get project templates
+//	@Description	Retrieve the paginated project templates, ordered by name
+//	@Tags			Project templates
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int						false	"Page number"					default(1)
+//	@Param			page_size	query		int						false	"Number of templates per page"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Successful response"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Router			/project-templates [get]
+func (h *ProjectTemplateHandlerImplementation) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+	page, pageSize := parsePagination(r)
+
+	templates, total, err := h.service.GetTemplates(r.Context(), page, pageSize)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]interface{}{
+		"templates": templates,
+		"total":     total,
+		"page":      page,
+	})
+}
+
+// GetTemplateByID godoc
+//	@Summary		Get a project template
+//	@Description	Retrieve a project template with its teams, tasks and workflow
+//	@Tags			Project templates
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int						true	"Template ID"
+//	@Success		200	{object}	models.ProjectTemplate	"Template"
+//	@Failure		401	{object}	response.Response		"Unauthenticated"
+//	@Failure		404	{object}	response.Response		"Template not found"
+//	@Router			/project-templates/{id} [get]
+func (h *ProjectTemplateHandlerImplementation) GetTemplateByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	template, err := h.service.GetTemplateByID(r.Context(), id)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, template)
+}
+
+// DeleteTemplate godoc
+//	@Summary		Delete a project template
+//	@Description	Delete a project template, projects created from it are kept
+//	@Tags			Project templates
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Template ID"
+//	@Success		200	{object}	map[string]string	"Template deleted"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Not the template owner"
+//	@Failure		404	{object}	response.Response	"Template not found"
+//	@Router			/project-templates/{id} [delete]
+func (h *ProjectTemplateHandlerImplementation) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.DeleteTemplate(r.Context(), id); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": "template deleted successfully"})
+}
+
+// CloneProject godoc
+//	@Summary		Clone a project
+//	@Description	Create a project with the teams, tasks, subtasks and labels of another, without members, in one transaction. The tasks are assigned to the caller. Due dates keep their distance to the start date, which defaults to the start date of the source. The name defaults to the source name followed by "(copy)".
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int							true	"Project ID"
+//	@Param			options			body		services.NewProjectOptions	false	"Name, description and start date of the clone"
+//	@Param			Idempotency-Key	header		string						false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.Project				"The clone"
+//	@Failure		400				{object}	response.Response			"Invalid input"
+//	@Failure		401				{object}	response.Response			"Unauthenticated"
+//	@Failure		404				{object}	response.Response			"Project not found"
+//	@Router			/projects/{id}/clone [post]
+func (h *ProjectTemplateHandlerImplementation) CloneProject(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var options services.NewProjectOptions
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+			response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+			return
+		}
+	}
+
+	project, err := h.service.CloneProject(r.Context(), projectID, options)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+	h.writeCreatedProject(w, r, project.ID)
+}
+
+// CreateProjectFromTemplate godoc
+//	@Summary		Create a project from a template
+//	@Description	Create a project starting on start_date with the teams, tasks, labels and workflow of a template, in one transaction. The tasks are assigned to the caller. Due dates are the offsets of the template from the start date. The name and description default to those of the template.
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request			body		FromTemplateRequest	true	"Template and the new project"
+//	@Param			Idempotency-Key	header		string				false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.Project		"Project created"
+//	@Failure		400				{object}	response.Response	"Invalid input"
+//	@Failure		401				{object}	response.Response	"Unauthenticated"
+//	@Failure		404				{object}	response.Response	"Template not found"
+//	@Router			/projects/from-template [post]
+func (h *ProjectTemplateHandlerImplementation) CreateProjectFromTemplate(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var request FromTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	project, err := h.service.CreateProjectFromTemplate(r.Context(), request.TemplateID, request.NewProjectOptions)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+	h.writeCreatedProject(w, r, project.ID)
+}
+
+// writeCreatedProject responds with the created project and its teams and tasks
+func (h *ProjectTemplateHandlerImplementation) writeCreatedProject(w http.ResponseWriter, r *http.Request, id uint) {
+	project, err := h.projects.GetProjectByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+	setETag(w, project.Version)
+	response.WriteJson(w, http.StatusCreated, project)
+}
+
+func writeTemplateError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, services.ErrProjectTemplateNotFound), errors.Is(err, services.ErrProjectNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrNotTemplateOwner):
+		status = http.StatusForbidden
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/pkg/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectTemplateService mocks the ProjectTemplateService for testing
+type MockProjectTemplateService struct {
+	mock.Mock
+}
+
+func (m *MockProjectTemplateService) CreateTemplate(ctx context.Context, template *models.ProjectTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockProjectTemplateService) CreateTemplateFromProject(ctx context.Context, projectID uint, name string) (*models.ProjectTemplate, error) {
+	args := m.Called(ctx, projectID, name)
+	template, _ := args.Get(0).(*models.ProjectTemplate)
+	return template, args.Error(1)
+}
+
+func (m *MockProjectTemplateService) GetTemplateByID(ctx context.Context, id uint) (*models.ProjectTemplate, error) {
+	args := m.Called(ctx, id)
+	template, _ := args.Get(0).(*models.ProjectTemplate)
+	return template, args.Error(1)
+}
+
+func (m *MockProjectTemplateService) GetTemplates(ctx context.Context, page, pageSize int) ([]models.ProjectTemplate, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]models.ProjectTemplate), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProjectTemplateService) DeleteTemplate(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProjectTemplateService) CloneProject(ctx context.Context, projectID uint, options services.NewProjectOptions) (*models.Project, error) {
+	args := m.Called(ctx, projectID, options)
+	project, _ := args.Get(0).(*models.Project)
+	return project, args.Error(1)
+}
+
+func (m *MockProjectTemplateService) CreateProjectFromTemplate(ctx context.Context, templateID uint, options services.NewProjectOptions) (*models.Project, error) {
+	args := m.Called(ctx, templateID, options)
+	project, _ := args.Get(0).(*models.Project)
+	return project, args.Error(1)
+}
+
+func TestCreateProjectFromTemplate(t *testing.T) {
+	start := time.Date(2026, time.April, 6, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockProjectTemplateService)
+		expectedStatus int
+	}{
+		{
+			name: "Successful Creation",
+			body: `{"template_id": 3, "name": "Q2 Launch", "start_date": "2026-04-06T00:00:00Z"}`,
+			mockSetup: func(m *MockProjectTemplateService) {
+				m.On("CreateProjectFromTemplate", mock.Anything, uint(3), services.NewProjectOptions{Name: "Q2 Launch", StartDate: start}).
+					Return(&models.Project{BaseModel: models.BaseModel{ID: 7}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Template Not Found",
+			body: `{"template_id": 99, "start_date": "2026-04-06T00:00:00Z"}`,
+			mockSetup: func(m *MockProjectTemplateService) {
+				m.On("CreateProjectFromTemplate", mock.Anything, uint(99), mock.Anything).Return(nil, services.ErrProjectTemplateNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Missing Start Date",
+			body: `{"template_id": 3}`,
+			mockSetup: func(m *MockProjectTemplateService) {
+				m.On("CreateProjectFromTemplate", mock.Anything, uint(3), services.NewProjectOptions{}).Return(nil, services.ErrInvalidProjectTemplate)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid JSON",
+			body:           `{"template_id": }`,
+			mockSetup:      func(m *MockProjectTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockProjectTemplateService)
+			tc.mockSetup(mockService)
+			projects := &services.MockProjectService{
+				GetProjectByIDFunc: func(ctx context.Context, id uint) (*models.Project, error) {
+					return &models.Project{BaseModel: models.BaseModel{ID: id, Version: 1}}, nil
+				},
+			}
+
+			handler := NewProjectTemplateHandler(mockService, projects)
+
+			req := httptest.NewRequest(http.MethodPost, "/projects/from-template", bytes.NewBufferString(tc.body))
+			req = req.WithContext(middleware.WithUserID(req.Context(), 1))
+			w := httptest.NewRecorder()
+
+			handler.CreateProjectFromTemplate(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteTemplate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "Successful Deletion", expectedStatus: http.StatusOK},
+		{name: "Not The Owner", err: services.ErrNotTemplateOwner, expectedStatus: http.StatusForbidden},
+		{name: "Template Not Found", err: services.ErrProjectTemplateNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockProjectTemplateService)
+			mockService.On("DeleteTemplate", mock.Anything, uint(3)).Return(tc.err)
+
+			handler := NewProjectTemplateHandler(mockService, &services.MockProjectService{})
+
+			req := httptest.NewRequest(http.MethodDelete, "/project-templates/3", nil)
+			req.SetPathValue("id", "3")
+			req = req.WithContext(middleware.WithUserID(req.Context(), 1))
+			w := httptest.NewRecorder()
+
+			handler.DeleteTemplate(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+type ReactionHandler interface {
+	AddTaskReaction(w http.ResponseWriter, r *http.Request)
+	RemoveTaskReaction(w http.ResponseWriter, r *http.Request)
+	AddCommentReaction(w http.ResponseWriter, r *http.Request)
+	RemoveCommentReaction(w http.ResponseWriter, r *http.Request)
+}
+
+type ReactionHandlerImplementation struct {
+	service services.ReactionService
+}
+
+func NewReactionHandler(service services.ReactionService) *ReactionHandlerImplementation {
+	return &ReactionHandlerImplementation{service: service}
+}
+
+// ReactionRequest is the body used to react to a task or comment
+type ReactionRequest struct {
+	Emoji string `json:"emoji" example:"thumbsup"`
+}
+
+// AddTaskReaction godoc
+//	@Summary		React to a task
+//	@Description	Add an emoji reaction of the current user to a task
+//	@Tags			Reactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"Task ID"
+//	@Param			reaction	body		ReactionRequest			true	"Emoji shortcode"
+//	@Param			Idempotency-Key	header		string			false	"Key that makes retries of the request return its first response"
+//	@Success		201			{array}		models.ReactionCount	"Updated reaction counts"
+//	@Failure		400			{object}	response.Response		"Invalid input"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		409			{object}	response.Response		"Reaction already exists"
+//	@Failure		422			{object}	response.Response		"Idempotency-Key used for a different request"
+//	@Router			/tasks/{id}/reactions [post]
+func (h *ReactionHandlerImplementation) AddTaskReaction(w http.ResponseWriter, r *http.Request) {
+	h.addReaction(w, r, models.ReactionTargetTask)
+}
+
+// RemoveTaskReaction godoc
+//	@Summary		Remove a task reaction
+//	@Description	Remove an emoji reaction of the current user from a task
+//	@Tags			Reactions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Task ID"
+//	@Param			emoji	path		string					true	"Emoji shortcode"
+//	@Success		200		{array}		models.ReactionCount	"Updated reaction counts"
+//	@Failure		400		{object}	response.Response		"Invalid input"
+//	@Failure		401		{object}	response.Response		"Unauthenticated"
+//	@Failure		404		{object}	response.Response		"Reaction not found"
+//	@Router			/tasks/{id}/reactions/{emoji} [delete]
+func (h *ReactionHandlerImplementation) RemoveTaskReaction(w http.ResponseWriter, r *http.Request) {
+	h.removeReaction(w, r, models.ReactionTargetTask)
+}
+
+// AddCommentReaction godoc
+//	@Summary		React to a comment
+//	@Description	Add an emoji reaction of the current user to a comment
+//	@Tags			Reactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"Comment ID"
+//	@Param			reaction	body		ReactionRequest			true	"Emoji shortcode"
+//	@Param			Idempotency-Key	header		string			false	"Key that makes retries of the request return its first response"
+//	@Success		201			{array}		models.ReactionCount	"Updated reaction counts"
+//	@Failure		400			{object}	response.Response		"Invalid input"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		409			{object}	response.Response		"Reaction already exists"
+//	@Failure		422			{object}	response.Response		"Idempotency-Key used for a different request"
+//	@Router			/comments/{id}/reactions [post]
+func (h *ReactionHandlerImplementation) AddCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.addReaction(w, r, models.ReactionTargetComment)
+}
+
+// RemoveCommentReaction godoc
+//	@Summary		Remove a comment reaction
+//	@Description	Remove an emoji reaction of the current user from a comment
+//	@Tags			Reactions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Comment ID"
+//	@Param			emoji	path		string					true	"Emoji shortcode"
+//	@Success		200		{array}		models.ReactionCount	"Updated reaction counts"
+//	@Failure		400		{object}	response.Response		"Invalid input"
+//	@Failure		401		{object}	response.Response		"Unauthenticated"
+//	@Failure		404		{object}	response.Response		"Reaction not found"
+//	@Router			/comments/{id}/reactions/{emoji} [delete]
+func (h *ReactionHandlerImplementation) RemoveCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.removeReaction(w, r, models.ReactionTargetComment)
+}
+
+func (h *ReactionHandlerImplementation) addReaction(w http.ResponseWriter, r *http.Request, targetType string) {
+	targetID, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil || targetID <= 0 {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s", "invalid ID")))
+		return
+	}
+
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	reaction := models.Reaction{
+		UserID:     userID,
+		Emoji:      req.Emoji,
+		TargetType: targetType,
+		TargetID:   uint(targetID),
+	}
+	counts, err := h.service.AddReaction(r.Context(), &reaction)
+	if err != nil {
+		if errors.Is(err, services.ErrReactionAlreadyExists) {
+			response.WriteJson(w, http.StatusConflict, response.GeneralError(err))
+			return
+		}
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, counts)
+}
+
+func (h *ReactionHandlerImplementation) removeReaction(w http.ResponseWriter, r *http.Request, targetType string) {
+	targetID, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil || targetID <= 0 {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s", "invalid ID")))
+		return
+	}
+
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	counts, err := h.service.RemoveReaction(r.Context(), targetType, uint(targetID), userID, r.PathValue("emoji"))
+	if err != nil {
+		if errors.Is(err, services.ErrReactionNotFound) {
+			response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+			return
+		}
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, counts)
+}
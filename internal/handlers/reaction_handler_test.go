@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/pkg/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReactionService mocks the ReactionService for testing
+type MockReactionService struct {
+	mock.Mock
+}
+
+func (m *MockReactionService) AddReaction(ctx context.Context, reaction *models.Reaction) ([]models.ReactionCount, error) {
+	args := m.Called(ctx, reaction)
+	if counts, ok := args.Get(0).([]models.ReactionCount); ok {
+		return counts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReactionService) RemoveReaction(ctx context.Context, targetType string, targetID, userID uint, emoji string) ([]models.ReactionCount, error) {
+	args := m.Called(ctx, targetType, targetID, userID, emoji)
+	if counts, ok := args.Get(0).([]models.ReactionCount); ok {
+		return counts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestAddTaskReaction(t *testing.T) {
+	testCases := []struct {
+		name           string
+		taskID         string
+		userID         uint
+		body           string
+		mockSetup      func(*MockReactionService)
+		expectedStatus int
+	}{
+		{
+			name:   "Successful Reaction",
+			taskID: "1",
+			userID: 2,
+			body:   `{"emoji": "thumbsup"}`,
+			mockSetup: func(mrs *MockReactionService) {
+				expected := &models.Reaction{UserID: 2, Emoji: "thumbsup", TargetType: models.ReactionTargetTask, TargetID: 1}
+				mrs.On("AddReaction", mock.Anything, expected).
+					Return([]models.ReactionCount{{Emoji: "thumbsup", Count: 1}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Unauthenticated",
+			taskID:         "1",
+			body:           `{"emoji": "thumbsup"}`,
+			mockSetup:      func(mrs *MockReactionService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Invalid Task ID",
+			taskID:         "abc",
+			userID:         2,
+			body:           `{"emoji": "thumbsup"}`,
+			mockSetup:      func(mrs *MockReactionService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Duplicate Reaction",
+			taskID: "1",
+			userID: 2,
+			body:   `{"emoji": "thumbsup"}`,
+			mockSetup: func(mrs *MockReactionService) {
+				mrs.On("AddReaction", mock.Anything, mock.Anything).Return(nil, services.ErrReactionAlreadyExists)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockReactionService)
+			tc.mockSetup(mockService)
+
+			handler := NewReactionHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/tasks/"+tc.taskID+"/reactions", bytes.NewBufferString(tc.body))
+			req.SetPathValue("id", tc.taskID)
+			if tc.userID != 0 {
+				req = req.WithContext(middleware.WithUserID(req.Context(), tc.userID))
+			}
+			w := httptest.NewRecorder()
+
+			handler.AddTaskReaction(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRemoveCommentReaction(t *testing.T) {
+	testCases := []struct {
+		name           string
+		mockSetup      func(*MockReactionService)
+		expectedStatus int
+	}{
+		{
+			name: "Successful Removal",
+			mockSetup: func(mrs *MockReactionService) {
+				mrs.On("RemoveReaction", mock.Anything, models.ReactionTargetComment, uint(3), uint(2), "tada").
+					Return([]models.ReactionCount{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Reaction Not Found",
+			mockSetup: func(mrs *MockReactionService) {
+				mrs.On("RemoveReaction", mock.Anything, models.ReactionTargetComment, uint(3), uint(2), "tada").
+					Return(nil, services.ErrReactionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Invalid Emoji",
+			mockSetup: func(mrs *MockReactionService) {
+				mrs.On("RemoveReaction", mock.Anything, models.ReactionTargetComment, uint(3), uint(2), "tada").
+					Return(nil, errors.New("invalid emoji shortcode"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockReactionService)
+			tc.mockSetup(mockService)
+
+			handler := NewReactionHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/comments/3/reactions/tada", nil)
+			req.SetPathValue("id", "3")
+			req.SetPathValue("emoji", "tada")
+			req = req.WithContext(middleware.WithUserID(req.Context(), 2))
+			w := httptest.NewRecorder()
+
+			handler.RemoveCommentReaction(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/utils/markdown"
+	"net/http"
+)
+
+// wantsHTML reports whether the client asked for rendered markdown with ?render=html.
+func wantsHTML(r *http.Request) bool {
+	return r.URL.Query().Get("render") == markdown.RenderQueryValue
+}
+
+func renderTaskDescriptions(ctx context.Context, renderer *markdown.Renderer, tasks []models.Task) error {
+	sources := make([]string, len(tasks))
+	for i, task := range tasks {
+		sources[i] = task.Description
+	}
+
+	rendered, err := renderer.Render(ctx, sources...)
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		tasks[i].DescriptionHTML = rendered[i]
+	}
+	return nil
+}
+
+func renderProjectDescriptions(ctx context.Context, renderer *markdown.Renderer, projects []models.Project) error {
+	sources := make([]string, len(projects))
+	for i, project := range projects {
+		sources[i] = project.Description
+	}
+
+	rendered, err := renderer.Render(ctx, sources...)
+	if err != nil {
+		return err
+	}
+
+	for i := range projects {
+		projects[i].DescriptionHTML = rendered[i]
+	}
+	return nil
+}
+
+func renderCommentContents(ctx context.Context, renderer *markdown.Renderer, comments []models.Comment) error {
+	sources := make([]string, len(comments))
+	for i, comment := range comments {
+		sources[i] = comment.Content
+	}
+
+	rendered, err := renderer.Render(ctx, sources...)
+	if err != nil {
+		return err
+	}
+
+	for i := range comments {
+		comments[i].ContentHTML = rendered[i]
+	}
+	return nil
+}
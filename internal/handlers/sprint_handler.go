@@ -0,0 +1,420 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+	"time"
+)
+
+// SprintRequest is the body used to plan or change a sprint
+type SprintRequest struct {
+	Name      string    `json:"name" example:"Sprint 14"`
+	Goal      string    `json:"goal"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"` // last day of the sprint
+}
+
+// CloseSprintRequest says where the unfinished tasks of a closed sprint go
+type CloseSprintRequest struct {
+	// Planned or active sprint of the project, the backlog when null
+	CarryOverTo *uint `json:"carry_over_to"`
+}
+
+// SprintTasksRequest lists the tasks added to a sprint
+type SprintTasksRequest struct {
+	TaskIDs []uint `json:"task_ids"`
+}
+
+type SprintHandler interface {
+	CreateSprint(w http.ResponseWriter, r *http.Request)
+	GetProjectSprints(w http.ResponseWriter, r *http.Request)
+	GetSprintByID(w http.ResponseWriter, r *http.Request)
+	UpdateSprint(w http.ResponseWriter, r *http.Request)
+	DeleteSprint(w http.ResponseWriter, r *http.Request)
+	StartSprint(w http.ResponseWriter, r *http.Request)
+	CloseSprint(w http.ResponseWriter, r *http.Request)
+	AddSprintTasks(w http.ResponseWriter, r *http.Request)
+	RemoveSprintTask(w http.ResponseWriter, r *http.Request)
+	GetBurndown(w http.ResponseWriter, r *http.Request)
+}
+
+type SprintHandlerImplementation struct {
+	service services.SprintService
+}
+
+func NewSprintHandler(service services.SprintService) *SprintHandlerImplementation {
+	return &SprintHandlerImplementation{service: service}
+}
+
+// CreateSprint godoc
+//	@Summary		Plan a sprint
+//	@Description	Add a planned sprint to a project. The end date is the last day of the sprint.
+//	@Tags			Sprints
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int					true	"Project ID"
+//	@Param			sprint			body		SprintRequest		true	"Sprint"
+//	@Param			Idempotency-Key	header		string				false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.Sprint		"Sprint created"
+//	@Failure		400				{object}	response.Response	"Invalid sprint"
+//	@Failure		401				{object}	response.Response	"Unauthenticated"
+//	@Failure		404				{object}	response.Response	"Project not found"
+//	@Failure		409				{object}	response.Response	"Project archived"
+//	@Router			/projects/{id}/sprints [post]
+func (h *SprintHandlerImplementation) CreateSprint(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req SprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	sprint := models.Sprint{
+		ProjectID: projectID,
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+	if err := h.service.CreateSprint(r.Context(), &sprint); err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, sprint)
+}
+
+// GetProjectSprints godoc
+//	@Summary		Get the sprints of a project
+//	@Description	Retrieve the sprints of a project in the order they start, without their tasks
+//	@Tags			Sprints
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Project ID"
+//	@Success		200	{array}		models.Sprint		"Sprints"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Project not found"
+//	@Router			/projects/{id}/sprints [get]
+func (h *SprintHandlerImplementation) GetProjectSprints(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	sprints, err := h.service.GetSprintsByProject(r.Context(), projectID)
+	if err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, sprints)
+}
+
+// GetSprintByID godoc
+//	@Summary		Get a sprint
+//	@Description	Retrieve a sprint with its tasks
+//	@Tags			Sprints
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Sprint ID"
+//	@Success		200	{object}	models.Sprint		"Sprint"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Sprint not found"
+//	@Router			/sprints/{id} [get]
+func (h *SprintHandlerImplementation) GetSprintByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	sprint, err := h.service.GetSprintByID(r.Context(), id)
+	if err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, sprint)
+}
+
+// UpdateSprint godoc
+//	@Summary		Update a sprint
+//	@Description	Change the name, goal and dates of a sprint that is not closed
+//	@Tags			Sprints
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Sprint ID"
+//	@Param			sprint	body		SprintRequest		true	"Sprint"
+//	@Success		200		{object}	models.Sprint		"Sprint updated"
+//	@Failure		400		{object}	response.Response	"Invalid sprint"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		404		{object}	response.Response	"Sprint not found"
+//	@Failure		409		{object}	response.Response	"Sprint closed or project archived"
+//	@Router			/sprints/{id} [put]
+func (h *SprintHandlerImplementation) UpdateSprint(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req SprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	sprint := models.Sprint{
+		ID:        id,
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+	if err := h.service.UpdateSprint(r.Context(), &sprint); err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, sprint)
+}
+
+// DeleteSprint godoc
+//	@Summary		Delete a sprint
+//	@Description	Delete a planned or closed sprint, its tasks go back to the backlog
+//	@Tags			Sprints
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Sprint ID"
+//	@Success		200	{object}	map[string]string	"Sprint deleted"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Sprint not found"
+//	@Failure		409	{object}	response.Response	"Sprint active or project archived"
+//	@Router			/sprints/{id} [delete]
+func (h *SprintHandlerImplementation) DeleteSprint(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.DeleteSprint(r.Context(), id); err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": "sprint deleted successfully"})
+}
+
+// StartSprint godoc
+//	@Summary		Start a sprint
+//	@Description	Make a planned sprint the active sprint of its project, a project has one active sprint at a time
+//	@Tags			Sprints
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Sprint ID"
+//	@Success		200	{object}	models.Sprint		"Sprint started"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Sprint not found"
+//	@Failure		409	{object}	response.Response	"Sprint not planned, another sprint active or project archived"
+//	@Router			/sprints/{id}/start [post]
+func (h *SprintHandlerImplementation) StartSprint(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	sprint, err := h.service.StartSprint(r.Context(), id)
+	if err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, sprint)
+}
+
+// CloseSprint godoc
+//	@Summary		Close a sprint
+//	@Description	Close the active sprint. Its tasks that are not done are carried over to carry_over_to, or back to the backlog when it is left out.
+//	@Tags			Sprints
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Sprint ID"
+//	@Param			request	body		CloseSprintRequest	false	"Sprint the unfinished tasks are carried over to"
+//	@Success		200		{object}	models.Sprint		"Sprint closed, with its done tasks"
+//	@Failure		400		{object}	response.Response	"Invalid carry-over sprint"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		404		{object}	response.Response	"Sprint not found"
+//	@Failure		409		{object}	response.Response	"Sprint not active or project archived"
+//	@Router			/sprints/{id}/close [post]
+func (h *SprintHandlerImplementation) CloseSprint(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req CloseSprintRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+			return
+		}
+	}
+
+	sprint, err := h.service.CloseSprint(r.Context(), id, req.CarryOverTo)
+	if err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, sprint)
+}
+
+// AddSprintTasks godoc
+//	@Summary		Add tasks to a sprint
+//	@Description	Plan tasks of the project in a sprint that is not closed, moving them from the backlog or another sprint
+//	@Tags			Sprints
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Sprint ID"
+//	@Param			request	body		SprintTasksRequest	true	"Tasks"
+//	@Success		200		{object}	models.Sprint		"Sprint with its tasks"
+//	@Failure		400		{object}	response.Response	"Invalid input or task of another project"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		404		{object}	response.Response	"Sprint or task not found"
+//	@Failure		409		{object}	response.Response	"Sprint closed or project archived"
+//	@Router			/sprints/{id}/tasks [post]
+func (h *SprintHandlerImplementation) AddSprintTasks(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req SprintTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	sprint, err := h.service.AddTasks(r.Context(), id, req.TaskIDs)
+	if err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, sprint)
+}
+
+// RemoveSprintTask godoc
+//	@Summary		Remove a task from a sprint
+//	@Description	Move a task of a sprint that is not closed back to the backlog
+//	@Tags			Sprints
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Sprint ID"
+//	@Param			taskId	path		int					true	"Task ID"
+//	@Success		204		"Task removed"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		404		{object}	response.Response	"Sprint or task not found, or the task is not in the sprint"
+//	@Failure		409		{object}	response.Response	"Sprint closed or project archived"
+//	@Router			/sprints/{id}/tasks/{taskId} [delete]
+func (h *SprintHandlerImplementation) RemoveSprintTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	taskID, ok := parseIDParam(w, r, "taskId")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.RemoveTask(r.Context(), id, taskID); err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBurndown godoc
+//	@Summary		Get the burndown of a sprint
+//	@Description	Retrieve the tasks in the sprint and those not done at the end of each of its days up to today, computed from the task history, with the ideal line from the work of the first day to none on the last. The last day of a closed sprint is counted when it was closed, before its unfinished tasks were carried over.
+//	@Tags			Sprints
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Sprint ID"
+//	@Success		200	{object}	models.Burndown		"Burndown"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Sprint not found"
+//	@Router			/sprints/{id}/burndown [get]
+func (h *SprintHandlerImplementation) GetBurndown(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	burndown, err := h.service.GetBurndown(r.Context(), id)
+	if err != nil {
+		writeSprintError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, burndown)
+}
+
+func writeSprintError(w http.ResponseWriter, err error) {
+	if writeProjectStateError(w, err) {
+		return
+	}
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrSprintNotFound), errors.Is(err, services.ErrProjectNotFound),
+		errors.Is(err, services.ErrTaskNotFound), errors.Is(err, services.ErrTaskNotInSprint):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrSprintState), errors.Is(err, services.ErrActiveSprintExists):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrInvalidSprint), errors.Is(err, services.ErrInvalidSprintTask),
+		errors.Is(err, services.ErrInvalidCarryOver):
+		status = http.StatusBadRequest
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+)
+
+// BulkTaskRequest runs up to 100 task operations in one call. Atomic applies all
+// of them or none, otherwise each is applied on its own.
+type BulkTaskRequest struct {
+	Atomic     bool                         `json:"atomic"`
+	Operations []services.BulkTaskOperation `json:"operations"`
+}
+
+// BulkTaskItemResult is the outcome of one operation, with the status it would
+// have had as a request of its own
+type BulkTaskItemResult struct {
+	Index  int          `json:"index"`
+	Op     string       `json:"op"`
+	Status int          `json:"status"`
+	Task   *models.Task `json:"task,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+type TaskBulkHandler interface {
+	BulkTasks(w http.ResponseWriter, r *http.Request)
+}
+
+type TaskBulkHandlerImplementation struct {
+	service services.TaskBulkService
+}
+
+func NewTaskBulkHandler(service services.TaskBulkService) *TaskBulkHandlerImplementation {
+	return &TaskBulkHandlerImplementation{service: service}
+}
+
+// BulkTasks godoc
+//	@Summary		Change many tasks at once
+//	@Description	Create tasks, update their assignee, status, labels and due date, move them to another project or delete them, up to 100 operations per call. With atomic all operations are applied or none: when one fails the response is 422 and the others report 424. Otherwise each operation is applied on its own and its result tells whether it failed.
+//	@Tags			Tasks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request			body		BulkTaskRequest			true	"Operations"
+//	@Param			Idempotency-Key	header		string					false	"Key that makes retries of the request return its first response"
+//	@Success		200				{object}	map[string]interface{}	"Results of the operations, in order"
+//	@Failure		400				{object}	response.Response		"Invalid request"
+//	@Failure		401				{object}	response.Response		"Unauthenticated"
+//	@Failure		422				{object}	map[string]interface{}	"An atomic request failed and was rolled back"
+//	@Router			/tasks/bulk [post]
+func (h *TaskBulkHandlerImplementation) BulkTasks(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var request BulkTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	results, err := h.service.BulkTasks(r.Context(), request.Operations, request.Atomic)
+	status := http.StatusOK
+	switch {
+	case errors.Is(err, services.ErrBulkRolledBack):
+		status = http.StatusUnprocessableEntity
+	case err != nil:
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	items := make([]BulkTaskItemResult, len(results))
+	failed := 0
+	for i, result := range results {
+		items[i] = BulkTaskItemResult{Index: result.Index, Op: result.Op, Status: bulkResultStatus(result), Task: result.Task}
+		if result.Err != nil {
+			items[i].Error = result.Err.Error()
+			failed++
+		}
+	}
+
+	response.WriteJson(w, status, map[string]interface{}{
+		"atomic":    request.Atomic,
+		"succeeded": len(items) - failed,
+		"failed":    failed,
+		"results":   items,
+	})
+}
+
+// bulkResultStatus is the HTTP status of a single bulk operation
+func bulkResultStatus(result services.BulkTaskResult) int {
+	switch {
+	case result.Err == nil && result.Op == services.BulkOpCreate:
+		return http.StatusCreated
+	case result.Err == nil:
+		return http.StatusOK
+	case errors.Is(result.Err, services.ErrTaskNotFound), errors.Is(result.Err, services.ErrProjectNotFound):
+		return http.StatusNotFound
+	case errors.Is(result.Err, services.ErrVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(result.Err, services.ErrAssigneeNotMember):
+		return http.StatusUnprocessableEntity
+	case errors.Is(result.Err, services.ErrProjectArchived), errors.Is(result.Err, services.ErrChecklistIncomplete),
+		errors.Is(result.Err, services.ErrWorklogLocked):
+		return http.StatusConflict
+	case errors.Is(result.Err, services.ErrBulkRolledBack):
+		return http.StatusFailedDependency
+	default:
+		return http.StatusBadRequest
+	}
+}
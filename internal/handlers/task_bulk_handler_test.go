@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaskBulkService mocks the TaskBulkService for testing
+type MockTaskBulkService struct {
+	mock.Mock
+}
+
+func (m *MockTaskBulkService) BulkTasks(ctx context.Context, operations []services.BulkTaskOperation, atomic bool) ([]services.BulkTaskResult, error) {
+	args := m.Called(ctx, operations, atomic)
+	results, _ := args.Get(0).([]services.BulkTaskResult)
+	return results, args.Error(1)
+}
+
+func TestBulkTasks(t *testing.T) {
+	testCases := []struct {
+		name             string
+		body             string
+		mockSetup        func(*MockTaskBulkService)
+		expectedStatus   int
+		expectedStatuses []float64
+	}{
+		{
+			name: "Best Effort With A Failure",
+			body: `{"operations": [{"op": "create", "task": {"title": "New"}}, {"op": "delete", "id": 9}]}`,
+			mockSetup: func(mbs *MockTaskBulkService) {
+				mbs.On("BulkTasks", mock.Anything, mock.Anything, false).Return([]services.BulkTaskResult{
+					{Index: 0, Op: services.BulkOpCreate, Task: &models.Task{Title: "New"}},
+					{Index: 1, Op: services.BulkOpDelete, Err: services.ErrTaskNotFound},
+				}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []float64{http.StatusCreated, http.StatusNotFound},
+		},
+		{
+			name: "Atomic Rolled Back",
+			body: `{"atomic": true, "operations": [{"op": "update", "id": 1, "fields": {"status": "done"}}, {"op": "update", "id": 2, "version": 1, "fields": {"status": "done"}}]}`,
+			mockSetup: func(mbs *MockTaskBulkService) {
+				mbs.On("BulkTasks", mock.Anything, mock.Anything, true).Return([]services.BulkTaskResult{
+					{Index: 0, Op: services.BulkOpUpdate, Err: services.ErrBulkRolledBack},
+					{Index: 1, Op: services.BulkOpUpdate, Err: services.ErrVersionConflict},
+				}, services.ErrBulkRolledBack)
+			},
+			expectedStatus:   http.StatusUnprocessableEntity,
+			expectedStatuses: []float64{http.StatusFailedDependency, http.StatusPreconditionFailed},
+		},
+		{
+			name: "No Operations",
+			body: `{"operations": []}`,
+			mockSetup: func(mbs *MockTaskBulkService) {
+				mbs.On("BulkTasks", mock.Anything, mock.Anything, false).
+					Return(nil, fmt.Errorf("%w: no operations", services.ErrInvalidBulkOperation))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid JSON",
+			body:           `{"operations": `,
+			mockSetup:      func(mbs *MockTaskBulkService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockTaskBulkService)
+			tc.mockSetup(mockService)
+
+			handler := NewTaskBulkHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/tasks/bulk", bytes.NewBufferString(tc.body))
+			req = req.WithContext(middleware.WithUserID(req.Context(), 2))
+			w := httptest.NewRecorder()
+
+			handler.BulkTasks(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatuses != nil {
+				var body struct {
+					Results []map[string]interface{} `json:"results"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				var statuses []float64
+				for _, result := range body.Results {
+					statuses = append(statuses, result["status"].(float64))
+				}
+				assert.Equal(t, tc.expectedStatuses, statuses)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
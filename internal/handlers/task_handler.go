@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"example/project-management-system/internal/models"
 	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/markdown"
 	"example/project-management-system/internal/utils/response"
 	"net/http"
 
 	"strconv"
+	"strings"
 )
 
 type TaskHandler interface {
@@ -15,15 +18,17 @@ type TaskHandler interface {
 	GetTaskByID(w http.ResponseWriter, r *http.Request)
 	GetTasksByProject(w http.ResponseWriter, r *http.Request)
 	UpdateTask(w http.ResponseWriter, r *http.Request)
+	PatchTask(w http.ResponseWriter, r *http.Request)
 	DeleteTask(w http.ResponseWriter, r *http.Request)
 }
 
 type TaskHandlerImplementation struct {
-	service services.TaskService
+	service  services.TaskService
+	renderer *markdown.Renderer
 }
 
-func NewTaskHandler(service services.TaskService) *TaskHandlerImplementation {
-	return &TaskHandlerImplementation{service: service}
+func NewTaskHandler(service services.TaskService, renderer *markdown.Renderer) *TaskHandlerImplementation {
+	return &TaskHandlerImplementation{service: service, renderer: renderer}
 }
 
 // CreateProject godoc
@@ -34,8 +39,11 @@ func NewTaskHandler(service services.TaskService) *TaskHandlerImplementation {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			task	body		models.Task			true	"Task Creation Request"
+//	@Param			Idempotency-Key	header		string			false	"Key that makes retries of the request return its first response"
 //	@Success		201		{object}	map[string]int		"Task created successfully"
 //	@Failure		400		{object}	response.Response	"Invalid input"
+//	@Failure		409		{object}	response.Response	"Request with the Idempotency-Key in progress, or the project is archived"
+//	@Failure		422		{object}	response.Response	"Idempotency-Key used for a different request"
 //	@Failure		500		{object}	response.Response	"Server error"
 //	@Router			/tasks [post]
 func (h *TaskHandlerImplementation) CreateTask(w http.ResponseWriter, r *http.Request) {
@@ -45,7 +53,14 @@ func (h *TaskHandlerImplementation) CreateTask(w http.ResponseWriter, r *http.Re
 		return
 	}
 	if err := h.service.CreateTask(r.Context(), &task); err != nil {
-		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		if writeProjectStateError(w, err) {
+			return
+		}
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidParentTask) || errors.Is(err, services.ErrInvalidFieldValue) {
+			status = http.StatusBadRequest
+		}
+		response.WriteJson(w, status, response.GeneralError(err))
 		return
 	}
 
@@ -58,8 +73,10 @@ func (h *TaskHandlerImplementation) CreateTask(w http.ResponseWriter, r *http.Re
 //	@Tags			Tasks
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id	path		int					true	"User ID"
-//	@Success		200	{object}	models.Task			"Successful response"
+//	@Param			id		path		int					true	"User ID"
+//	@Param			render	query		string				false	"Set to html to include sanitized description_html"	Enums(html)
+//	@Success		200		{object}	models.Task			"Successful response"
+//	@Header			200		{string}	ETag				"Version of the task, for If-Match"
 //	@Failure		400	{object}	response.Response	"Bad request"
 //	@Failure		404	{object}	response.Response	"User not found"
 //	@Router			/tasks/{id} [get]
@@ -76,12 +93,22 @@ func (h *TaskHandlerImplementation) GetTaskByID(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	if wantsHTML(r) {
+		tasks := []models.Task{*task}
+		if err := renderTaskDescriptions(r.Context(), h.renderer, tasks); err != nil {
+			response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+			return
+		}
+		task = &tasks[0]
+	}
+
+	setETag(w, task.Version)
 	response.WriteJson(w, http.StatusOK, task)
 }
 
 // GetTasksByProject godoc
 //	@Summary		Retrieve tasks by project ID
-//	@Description	Retrieve paginated tasks associated with a specific project
+//	@Description	Retrieve paginated tasks associated with a specific project. Tasks are filtered by custom fields with cf.<key> parameters: comma separated values match any of them, and min..max matches the numbers or dates in a range where either bound can be left out.
 //	@Tags			Tasks
 //	@Accept			json
 //	@Produce		json
@@ -89,6 +116,8 @@ func (h *TaskHandlerImplementation) GetTaskByID(w http.ResponseWriter, r *http.R
 //	@Param			project_id	path		int						true	"Project ID"
 //	@Param			page		query		int						false	"Page number (default: 1)"
 //	@Param			page_size	query		int						false	"Page size (default: 10)"
+//	@Param			sort		query		string					false	"Comma separated id, title, status, due_date, created_at, updated_at or cf.<key>, descending when prefixed by -"	example(-cf.severity,title)
+//	@Param			render		query		string					false	"Set to html to include sanitized description_html"	Enums(html)
 //	@Success		200			{object}	map[string]interface{}	"Paginated list of tasks"
 //	@Failure		400			{object}	response.Response		"Invalid input"
 //	@Failure		500			{object}	response.Response		"Server error"
@@ -97,7 +126,11 @@ func (h *TaskHandlerImplementation) GetTasksByProject(w http.ResponseWriter, r *
 	pageStr := r.URL.Query().Get("page")
 	pageSizeStr := r.URL.Query().Get("page_size")
 
-	projectID, _ := strconv.ParseUint(r.PathValue("project_id"), 10, 64)
+	projectIDStr := r.PathValue("project_id")
+	if projectIDStr == "" {
+		projectIDStr = r.URL.Query().Get("project_id")
+	}
+	projectID, _ := strconv.ParseUint(projectIDStr, 10, 64)
 	page, _ := strconv.Atoi(pageStr)
 	pageSize, _ := strconv.Atoi(pageSizeStr)
 
@@ -108,12 +141,34 @@ func (h *TaskHandlerImplementation) GetTasksByProject(w http.ResponseWriter, r *
 		pageSize = 10
 	}
 
-	tasks, total, err := h.service.GetTasksByProject(r.Context(), uint(projectID), page, pageSize)
+	var query services.TaskListQuery
+	for name, values := range r.URL.Query() {
+		if key, ok := strings.CutPrefix(name, "cf."); ok {
+			if query.Filters == nil {
+				query.Filters = map[string]string{}
+			}
+			query.Filters[key] = values[0]
+		}
+	}
+	query.Sort = r.URL.Query().Get("sort")
+
+	tasks, total, err := h.service.GetTasksByProject(r.Context(), uint(projectID), query, page, pageSize)
 	if err != nil {
-		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidTaskQuery) {
+			status = http.StatusBadRequest
+		}
+		response.WriteJson(w, status, response.GeneralError(err))
 		return
 	}
 
+	if wantsHTML(r) {
+		if err := renderTaskDescriptions(r.Context(), h.renderer, tasks); err != nil {
+			response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+			return
+		}
+	}
+
 	response.WriteJson(w, http.StatusOK, map[string]interface{}{
 		"tasks": tasks,
 		"total": total,
@@ -128,23 +183,126 @@ func (h *TaskHandlerImplementation) GetTasksByProject(w http.ResponseWriter, r *
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			task	body		models.Task			true	"Task details to be updated"
-//	@Success		200		{object}	models.Task			"Task updated successfully"
-//	@Failure		400		{object}	response.Response	"Invalid input"
-//	@Failure		500		{object}	response.Response	"Server error"
-//	@Router			/api/v1/tasks [put]
+//	@Param			id			path		int					true	"Task ID"
+//	@Param			If-Match	header		string				true	"ETag of the task version being updated"
+//	@Param			task		body		models.Task			true	"Task details to be updated"
+//	@Success		200			{object}	models.Task			"Task updated successfully"
+//	@Header			200			{string}	ETag				"New version of the task"
+//	@Failure		400			{object}	response.Response	"Invalid input"
+//	@Failure		409			{object}	response.Response	"The project is archived, or the checklist is incomplete"
+//	@Failure		412			{object}	response.Response	"The task was changed since that version"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
+//	@Failure		500			{object}	response.Response	"Server error"
+//	@Router			/tasks/{id} [put]
 func (h *TaskHandlerImplementation) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
 	var task models.Task
 	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
 		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
 		return
 	}
+	task.ID = id
+	task.Version = version
 
 	if err := h.service.UpdateTask(r.Context(), &task); err != nil {
+		if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+			return
+		}
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrTaskProjectChange), errors.Is(err, services.ErrInvalidParentTask), errors.Is(err, services.ErrInvalidFieldValue):
+			status = http.StatusBadRequest
+		case errors.Is(err, services.ErrChecklistIncomplete):
+			status = http.StatusConflict
+		}
+		response.WriteJson(w, status, response.GeneralError(err))
+		return
+	}
+
+	setETag(w, task.Version)
+	response.WriteJson(w, http.StatusOK, task)
+}
+
+// PatchTask godoc
+//	@Summary		Partially update a task
+//	@Description	Apply a JSON merge patch (RFC 7396) to the title, description, status, assignee, labels, due date, estimates and custom fields of a task. Omitted fields are kept and null resets a field.
+//	@Tags			Tasks
+//	@Accept			json,application/merge-patch+json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int					true	"Task ID"
+//	@Param			If-Match	header		string				true	"ETag of the task version being updated"
+//	@Param			patch		body		models.Task		true	"Fields to change"
+//	@Success		200			{object}	models.Task		"The updated task"
+//	@Header			200			{string}	ETag				"New version of the task"
+//	@Failure		400			{object}	response.Response	"Invalid patch"
+//	@Failure		404			{object}	response.Response	"Task not found"
+//	@Failure		409			{object}	response.Response	"The project is archived, or the checklist is incomplete"
+//	@Failure		412			{object}	response.Response	"The task was changed since that version"
+//	@Failure		415			{object}	response.Response	"The body is not a merge patch"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
+//	@Router			/tasks/{id} [patch]
+func (h *TaskHandlerImplementation) PatchTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := h.service.GetTaskByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+		return
+	}
+	if !applyMergePatch(w, task, patch, taskPatchFields) {
+		return
+	}
+	// The merged custom fields replace those of the task, none when they were reset
+	if task.CustomFields == nil {
+		task.CustomFields = map[string]interface{}{}
+	}
+	if version != 0 {
+		task.Version = version
+	}
+
+	if err := h.service.UpdateTask(r.Context(), task); err != nil {
+		if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+			return
+		}
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrChecklistIncomplete) {
+			status = http.StatusConflict
+		}
+		response.WriteJson(w, status, response.GeneralError(err))
+		return
+	}
+
+	// Reload the task for its up to date relations
+	task, err = h.service.GetTaskByID(r.Context(), id)
+	if err != nil {
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
 
+	setETag(w, task.Version)
 	response.WriteJson(w, http.StatusOK, task)
 }
 
@@ -154,10 +312,14 @@ func (h *TaskHandlerImplementation) UpdateTask(w http.ResponseWriter, r *http.Re
 //	@Tags			Tasks
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id	path		int					true	"Task ID"
-//	@Success		200	{object}	map[string]string	"Task deleted successfully"
-//	@Failure		400	{object}	response.Response	"Bad request"
-//	@Failure		500	{object}	response.Response	"Server error"
+//	@Param			id			path		int					true	"Task ID"
+//	@Param			If-Match	header		string				true	"ETag of the task version being deleted"
+//	@Success		200			{object}	map[string]string	"Task deleted successfully"
+//	@Failure		400			{object}	response.Response	"Bad request"
+//	@Failure		409			{object}	response.Response	"The project is archived"
+//	@Failure		412			{object}	response.Response	"The task was changed since that version"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
+//	@Failure		500			{object}	response.Response	"Server error"
 //	@Router			/tasks/{id} [delete]
 func (h *TaskHandlerImplementation) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
@@ -166,7 +328,15 @@ func (h *TaskHandlerImplementation) DeleteTask(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if err := h.service.DeleteTask(r.Context(), uint(id)); err != nil {
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTask(r.Context(), uint(id), version); err != nil {
+		if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
@@ -14,6 +14,8 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/markdown"
 )
 
 // Mock Services and Repositories
@@ -36,8 +38,8 @@ func (m *MockTaskService) GetTaskByID(ctx context.Context, id uint) (*models.Tas
 
 }
 
-func (m *MockTaskService) GetTasksByProject(ctx context.Context, projectID uint, page, pageSize int) ([]models.Task, int64, error) {
-	args := m.Called(ctx, projectID, page, pageSize)
+func (m *MockTaskService) GetTasksByProject(ctx context.Context, projectID uint, query services.TaskListQuery, page, pageSize int) ([]models.Task, int64, error) {
+	args := m.Called(ctx, projectID, query, page, pageSize)
 	return args.Get(0).([]models.Task), args.Get(1).(int64), args.Error(2)
 }
 
@@ -46,15 +48,15 @@ func (m *MockTaskService) UpdateTask(ctx context.Context, task *models.Task) err
 	return args.Error(0)
 }
 
-func (m *MockTaskService) DeleteTask(ctx context.Context, id uint) error {
-	args := m.Called(ctx, id)
+func (m *MockTaskService) DeleteTask(ctx context.Context, id uint, version uint) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
 // Task Handler Tests
 func TestCreateTask(t *testing.T) {
 	mockService := new(MockTaskService)
-	handler := NewTaskHandler(mockService)
+	handler := NewTaskHandler(mockService, markdown.NewRenderer(nil))
 
 	t.Run("Successful Task Creation", func(t *testing.T) {
 		task := &models.Task{
@@ -73,7 +75,7 @@ func TestCreateTask(t *testing.T) {
 		handler.CreateTask(w, req)
 
 		assert.Equal(t, http.StatusCreated, w.Code)
-		assert.Contains(t, w.Body.String(), `{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"title":"Test Task","description":"Test Description","project_id":1,"project":{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"name":"","description":"","start_date":"0001-01-01T00:00:00Z","end_date":"0001-01-01T00:00:00Z","status":"","user_ids":null,"users":null,"tasks":null,"teams":null},"assigned_to":1,"assignee":{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"username":"","email":"","first_name":"","last_name":"","project_ids":null,"projects":null,"role":""}}`)
+		assert.Contains(t, w.Body.String(), `{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"version":0,"title":"Test Task","description":"Test Description","status":"","project_id":1,"project":{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"version":0,"name":"","description":"","start_date":"0001-01-01T00:00:00Z","end_date":"0001-01-01T00:00:00Z","status":"","user_ids":null,"users":null,"tasks":null,"teams":null},"assigned_to":1,"assignee":{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"version":0,"username":"","email":"","first_name":"","last_name":"","project_ids":null,"projects":null,"role":""}}`)
 	})
 
 	t.Run("Invalid Task Creation", func(t *testing.T) {
@@ -88,7 +90,7 @@ func TestCreateTask(t *testing.T) {
 
 func TestGetTaskByID(t *testing.T) {
 	mockService := new(MockTaskService)
-	handler := NewTaskHandler(mockService)
+	handler := NewTaskHandler(mockService, markdown.NewRenderer(nil))
 
 	t.Run("Successful Task Retrieval", func(t *testing.T) {
 		task := &models.Task{
@@ -119,11 +121,34 @@ func TestGetTaskByID(t *testing.T) {
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
+
+	t.Run("Rendered Description", func(t *testing.T) {
+		task := &models.Task{
+			BaseModel:   models.BaseModel{ID: 2},
+			Title:       "Markdown Task",
+			Description: "**done** <img src=x onerror=alert(1)>",
+		}
+
+		mockService.On("GetTaskByID", mock.Anything, uint(2)).Return(task, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/2?render=html", nil)
+		req.SetPathValue("id", "2")
+		w := httptest.NewRecorder()
+
+		handler.GetTaskByID(w, req)
+
+		var body models.Task
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "**done** <img src=x onerror=alert(1)>", body.Description)
+		assert.Contains(t, body.DescriptionHTML, "<strong>done</strong>")
+		assert.NotContains(t, body.DescriptionHTML, "onerror")
+	})
 }
 
 func TestGetTasksByProject(t *testing.T) {
 	mockService := new(MockTaskService)
-	handler := NewTaskHandler(mockService)
+	handler := NewTaskHandler(mockService, markdown.NewRenderer(nil))
 
 	t.Run("Successful Tasks Retrieval", func(t *testing.T) {
 		tasks := []models.Task{
@@ -139,7 +164,7 @@ func TestGetTasksByProject(t *testing.T) {
 			},
 		}
 
-		mockService.On("GetTasksByProject", mock.Anything, uint(1), 1, 10).Return(tasks, int64(2), nil)
+		mockService.On("GetTasksByProject", mock.Anything, uint(1), services.TaskListQuery{}, 1, 10).Return(tasks, int64(2), nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/projects/1/tasks?page=1&page_size=10", nil)
 		req.SetPathValue("project_id", "1")
@@ -150,11 +175,35 @@ func TestGetTasksByProject(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("Custom Field Filters And Sorting", func(t *testing.T) {
+		query := services.TaskListQuery{Filters: map[string]string{"severity": "high,critical", "points": "3.."}, Sort: "-cf.points,title"}
+		mockService.On("GetTasksByProject", mock.Anything, uint(2), query, 1, 10).Return([]models.Task{}, int64(0), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks?project_id=2&cf.severity=high,critical&cf.points=3..&sort=-cf.points,title", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetTasksByProject(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Query", func(t *testing.T) {
+		mockService.On("GetTasksByProject", mock.Anything, uint(3), mock.Anything, 1, 10).Return([]models.Task(nil), int64(0), services.ErrInvalidTaskQuery)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks?project_id=3&sort=password", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetTasksByProject(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestUpdateTask(t *testing.T) {
 	mockService := new(MockTaskService)
-	handler := NewTaskHandler(mockService)
+	handler := NewTaskHandler(mockService, markdown.NewRenderer(nil))
 
 	t.Run("Successful Task Update", func(t *testing.T) {
 		task := &models.Task{
@@ -166,7 +215,29 @@ func TestUpdateTask(t *testing.T) {
 		mockService.On("UpdateTask", mock.Anything, task).Return(nil)
 
 		jsonTask, _ := json.Marshal(task)
-		req := httptest.NewRequest(http.MethodPut, "/tasks", bytes.NewBuffer(jsonTask))
+		req := httptest.NewRequest(http.MethodPut, "/tasks/1", bytes.NewBuffer(jsonTask))
+		req.SetPathValue("id", "1")
+		w := httptest.NewRecorder()
+
+		handler.UpdateTask(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Path ID Wins Over The Body", func(t *testing.T) {
+		task := &models.Task{
+			BaseModel: models.BaseModel{ID: 2},
+			Title:     "Other Task",
+			ProjectID: 1,
+		}
+		mockService.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+			return task.ID == 3 && task.Title == "Other Task"
+		})).Return(nil)
+
+		jsonTask, _ := json.Marshal(task)
+		req := httptest.NewRequest(http.MethodPut, "/tasks/3", bytes.NewBuffer(jsonTask))
+		req.SetPathValue("id", "3")
 		w := httptest.NewRecorder()
 
 		handler.UpdateTask(w, req)
@@ -176,12 +247,103 @@ func TestUpdateTask(t *testing.T) {
 	})
 }
 
+func TestPatchTask(t *testing.T) {
+	existing := func() *models.Task {
+		return &models.Task{
+			BaseModel:   models.BaseModel{ID: 1, Version: 2},
+			Title:       "Task",
+			Description: "Description",
+			Status:      models.TaskStatusTodo,
+			ProjectID:   1,
+		}
+	}
+
+	t.Run("Patches The Given Fields", func(t *testing.T) {
+		mockService := new(MockTaskService)
+		handler := NewTaskHandler(mockService, markdown.NewRenderer(nil))
+
+		updated := existing()
+		updated.Status = models.TaskStatusDone
+		updated.Description = ""
+		updated.Version = 3
+		mockService.On("GetTaskByID", mock.Anything, uint(1)).Return(existing(), nil).Once()
+		mockService.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+			return task.ID == 1 && task.Title == "Task" && task.Description == "" &&
+				task.Status == models.TaskStatusDone && task.Version == 2
+		})).Return(nil)
+		mockService.On("GetTaskByID", mock.Anything, uint(1)).Return(updated, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/1", strings.NewReader(`{"status":"done","description":null}`))
+		req.SetPathValue("id", "1")
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", `"2"`)
+		w := httptest.NewRecorder()
+
+		handler.PatchTask(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+		assert.Contains(t, w.Body.String(), `"status":"done"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Rejects Fields Outside The Allowlist", func(t *testing.T) {
+		mockService := new(MockTaskService)
+		handler := NewTaskHandler(mockService, markdown.NewRenderer(nil))
+		mockService.On("GetTaskByID", mock.Anything, uint(1)).Return(existing(), nil)
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/1", strings.NewReader(`{"id":2,"title":"Moved"}`))
+		req.SetPathValue("id", "1")
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		w := httptest.NewRecorder()
+
+		handler.PatchTask(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejects Other Media Types", func(t *testing.T) {
+		mockService := new(MockTaskService)
+		handler := NewTaskHandler(mockService, markdown.NewRenderer(nil))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/1", strings.NewReader(`title=Moved`))
+		req.SetPathValue("id", "1")
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		handler.PatchTask(w, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("Stale If-Match", func(t *testing.T) {
+		mockService := new(MockTaskService)
+		handler := NewTaskHandler(mockService, markdown.NewRenderer(nil))
+		mockService.On("GetTaskByID", mock.Anything, uint(1)).Return(existing(), nil)
+		mockService.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+			return task.Version == 1
+		})).Return(services.ErrVersionConflict)
+
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/1", strings.NewReader(`{"title":"Late"}`))
+		req.SetPathValue("id", "1")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+
+		handler.PatchTask(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
 func TestDeleteTask(t *testing.T) {
 	mockService := new(MockTaskService)
-	handler := NewTaskHandler(mockService)
+	handler := NewTaskHandler(mockService, markdown.NewRenderer(nil))
 
 	t.Run("Successful Task Deletion", func(t *testing.T) {
-		mockService.On("DeleteTask", mock.Anything, uint(1)).Return(nil)
+		mockService.On("DeleteTask", mock.Anything, uint(1), uint(0)).Return(nil)
 
 		req := httptest.NewRequest(http.MethodDelete, "/tasks/1", nil)
 		req.SetPathValue("id", "1")
@@ -192,6 +354,31 @@ func TestDeleteTask(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("Stale If-Match", func(t *testing.T) {
+		mockService.On("DeleteTask", mock.Anything, uint(2), uint(3)).Return(services.ErrVersionConflict)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/2", nil)
+		req.SetPathValue("id", "2")
+		req.Header.Set("If-Match", `"3"`)
+		w := httptest.NewRecorder()
+
+		handler.DeleteTask(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Malformed If-Match", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/1", nil)
+		req.SetPathValue("id", "1")
+		req.Header.Set("If-Match", `W/"abc"`)
+		w := httptest.NewRecorder()
+
+		handler.DeleteTask(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 // // Service Layer Tests
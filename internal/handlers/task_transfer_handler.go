@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+)
+
+type TaskTransferHandler interface {
+	MoveTask(w http.ResponseWriter, r *http.Request)
+	CopyTask(w http.ResponseWriter, r *http.Request)
+}
+
+type TaskTransferHandlerImplementation struct {
+	service services.TaskTransferService
+	tasks   services.TaskService
+}
+
+func NewTaskTransferHandler(service services.TaskTransferService, tasks services.TaskService) *TaskTransferHandlerImplementation {
+	return &TaskTransferHandlerImplementation{service: service, tasks: tasks}
+}
+
+// MoveTask godoc
+//	@Summary		Move a task to another project
+//	@Description	Move a task, with its subtasks, comments and labels unless they are left out, to another project. The assignees must be members of that project. Subtasks left out become tasks of their own in the old project, comments left out go to the trash.
+//	@Tags			Tasks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int								true	"Task ID"
+//	@Param			If-Match	header		string							true	"ETag of the task version being moved"
+//	@Param			options		body		services.TaskTransferOptions	true	"Target project and what to carry along, all carried by default"
+//	@Success		200			{object}	models.Task						"The moved task"
+//	@Header			200			{string}	ETag							"New version of the task"
+//	@Failure		400			{object}	response.Response				"Invalid input"
+//	@Failure		404			{object}	response.Response				"Task or project not found"
+//	@Failure		409			{object}	response.Response				"The project is archived, or time logged on the task is in a submitted or approved timesheet"
+//	@Failure		412			{object}	response.Response				"The task was changed since that version"
+//	@Failure		422			{object}	response.Response				"An assignee is not a member of the project"
+//	@Failure		428			{object}	response.Response				"If-Match is missing"
+//	@Router			/tasks/{id}/move [post]
+func (h *TaskTransferHandlerImplementation) MoveTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	options, ok := readTransferOptions(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.service.MoveTask(r.Context(), id, version, options); err != nil {
+		writeTransferError(w, err)
+		return
+	}
+
+	task, ok := h.reloadTask(w, r, id)
+	if !ok {
+		return
+	}
+	setETag(w, task.Version)
+	response.WriteJson(w, http.StatusOK, task)
+}
+
+// CopyTask godoc
+//	@Summary		Copy a task
+//	@Description	Copy a task, with its subtasks, comments and labels unless they are left out, into a project, which may be its own. The assignees must be members of that project.
+//	@Tags			Tasks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int								true	"Task ID"
+//	@Param			options			body		services.TaskTransferOptions	true	"Target project and what to carry along, all carried by default"
+//	@Param			Idempotency-Key	header		string							false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.Task						"The copy"
+//	@Failure		400				{object}	response.Response				"Invalid input"
+//	@Failure		404				{object}	response.Response				"Task or project not found"
+//	@Failure		409				{object}	response.Response				"Request with the Idempotency-Key in progress, or the project is archived"
+//	@Failure		422				{object}	response.Response				"An assignee is not a member of the project"
+//	@Router			/tasks/{id}/copy [post]
+func (h *TaskTransferHandlerImplementation) CopyTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	options, ok := readTransferOptions(w, r)
+	if !ok {
+		return
+	}
+
+	copied, err := h.service.CopyTask(r.Context(), id, options)
+	if err != nil {
+		writeTransferError(w, err)
+		return
+	}
+
+	task, ok := h.reloadTask(w, r, copied.ID)
+	if !ok {
+		return
+	}
+	setETag(w, task.Version)
+	response.WriteJson(w, http.StatusCreated, task)
+}
+
+// reloadTask loads the task with its up to date relations, or writes a 500
+// response and returns false
+func (h *TaskTransferHandlerImplementation) reloadTask(w http.ResponseWriter, r *http.Request, id uint) (*models.Task, bool) {
+	task, err := h.tasks.GetTaskByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return nil, false
+	}
+	return task, true
+}
+
+// readTransferOptions reads the options of a move or copy, which carry everything
+// along unless the body leaves something out
+func readTransferOptions(w http.ResponseWriter, r *http.Request) (services.TaskTransferOptions, bool) {
+	options := services.DefaultTaskTransferOptions(0)
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return options, false
+	}
+	return options, true
+}
+
+func writeTransferError(w http.ResponseWriter, err error) {
+	if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrTaskNotFound), errors.Is(err, services.ErrProjectNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrAssigneeNotMember):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrInvalidTransfer):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrWorklogLocked):
+		status = http.StatusConflict
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaskTransferService mocks the TaskTransferService for testing
+type MockTaskTransferService struct {
+	mock.Mock
+}
+
+func (m *MockTaskTransferService) MoveTask(ctx context.Context, id, version uint, options services.TaskTransferOptions) (*models.Task, error) {
+	args := m.Called(ctx, id, version, options)
+	task, _ := args.Get(0).(*models.Task)
+	return task, args.Error(1)
+}
+
+func (m *MockTaskTransferService) CopyTask(ctx context.Context, id uint, options services.TaskTransferOptions) (*models.Task, error) {
+	args := m.Called(ctx, id, options)
+	task, _ := args.Get(0).(*models.Task)
+	return task, args.Error(1)
+}
+
+func TestMoveTask(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ifMatch        string
+		body           string
+		mockSetup      func(*MockTaskTransferService, *MockTaskService)
+		expectedStatus int
+	}{
+		{
+			name:    "Successful Move Carries Everything By Default",
+			ifMatch: `"2"`,
+			body:    `{"project_id": 5}`,
+			mockSetup: func(mts *MockTaskTransferService, ms *MockTaskService) {
+				mts.On("MoveTask", mock.Anything, uint(1), uint(2), services.DefaultTaskTransferOptions(5)).
+					Return(&models.Task{BaseModel: models.BaseModel{ID: 1}}, nil)
+				ms.On("GetTaskByID", mock.Anything, uint(1)).
+					Return(&models.Task{BaseModel: models.BaseModel{ID: 1, Version: 3}, ProjectID: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Comments Left Out",
+			ifMatch: "*",
+			body:    `{"project_id": 5, "comments": false}`,
+			mockSetup: func(mts *MockTaskTransferService, ms *MockTaskService) {
+				options := services.DefaultTaskTransferOptions(5)
+				options.Comments = false
+				mts.On("MoveTask", mock.Anything, uint(1), uint(0), options).
+					Return(&models.Task{BaseModel: models.BaseModel{ID: 1}}, nil)
+				ms.On("GetTaskByID", mock.Anything, uint(1)).Return(&models.Task{BaseModel: models.BaseModel{ID: 1}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Assignee Not A Member",
+			ifMatch: "*",
+			body:    `{"project_id": 5}`,
+			mockSetup: func(mts *MockTaskTransferService, ms *MockTaskService) {
+				mts.On("MoveTask", mock.Anything, uint(1), uint(0), mock.Anything).Return(nil, services.ErrAssigneeNotMember)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "Stale Version",
+			ifMatch: `"1"`,
+			body:    `{"project_id": 5}`,
+			mockSetup: func(mts *MockTaskTransferService, ms *MockTaskService) {
+				mts.On("MoveTask", mock.Anything, uint(1), uint(1), mock.Anything).Return(nil, services.ErrVersionConflict)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "Project Not Found",
+			ifMatch: "*",
+			body:    `{"project_id": 99}`,
+			mockSetup: func(mts *MockTaskTransferService, ms *MockTaskService) {
+				mts.On("MoveTask", mock.Anything, uint(1), uint(0), mock.Anything).Return(nil, services.ErrProjectNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTransfers := new(MockTaskTransferService)
+			mockTasks := new(MockTaskService)
+			tc.mockSetup(mockTransfers, mockTasks)
+
+			handler := NewTaskTransferHandler(mockTransfers, mockTasks)
+
+			req := httptest.NewRequest(http.MethodPost, "/tasks/1/move", bytes.NewBufferString(tc.body))
+			req.SetPathValue("id", "1")
+			req.Header.Set("If-Match", tc.ifMatch)
+			w := httptest.NewRecorder()
+
+			handler.MoveTask(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockTransfers.AssertExpectations(t)
+			mockTasks.AssertExpectations(t)
+		})
+	}
+}
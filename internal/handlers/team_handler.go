@@ -15,6 +15,7 @@ type TeamHandler interface {
 	GetTeamByID(w http.ResponseWriter, r *http.Request)
 	GetPaginatedTeams(w http.ResponseWriter, r *http.Request)
 	UpdateTeam(w http.ResponseWriter, r *http.Request)
+	PatchTeam(w http.ResponseWriter, r *http.Request)
 	DeleteTeam(w http.ResponseWriter, r *http.Request)
 }
 
@@ -34,8 +35,11 @@ func NewTeamHandler(service services.TeamService) *TeamHandlerImplementation {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			team	body		models.Team			true	"Team Creation Request"
+//	@Param			Idempotency-Key	header		string			false	"Key that makes retries of the request return its first response"
 //	@Success		201		{object}	map[string]int		"Team created successfully"
 //	@Failure		400		{object}	response.Response	"Invalid input"
+//	@Failure		409		{object}	response.Response	"Request with the Idempotency-Key in progress, or the project is archived"
+//	@Failure		422		{object}	response.Response	"Idempotency-Key used for a different request"
 //	@Failure		500		{object}	response.Response	"Server error"
 //	@Router			/teams [post]
 func (h *TeamHandlerImplementation) CreateTeam(w http.ResponseWriter, r *http.Request) {
@@ -46,6 +50,9 @@ func (h *TeamHandlerImplementation) CreateTeam(w http.ResponseWriter, r *http.Re
 	}
 
 	if err := h.service.CreateTeam(r.Context(), &team); err != nil {
+		if writeProjectStateError(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
@@ -98,6 +105,7 @@ func (h *TeamHandlerImplementation) CreateTeam(w http.ResponseWriter, r *http.Re
 //	@Security		BearerAuth
 //	@Param			id	path		int					true	"Team ID"
 //	@Success		200	{object}	models.Team			"Successful response"
+//	@Header			200	{string}	ETag				"Version of the team, for If-Match"
 //	@Failure		400	{object}	response.Response	"Bad request"
 //	@Failure		404	{object}	response.Response	"User not found"
 //	@Router			/teams/{id} [get]
@@ -114,6 +122,7 @@ func (h *TeamHandlerImplementation) GetTeamByID(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	setETag(w, team.Version)
 	response.WriteJson(w, http.StatusOK, team)
 }
 
@@ -164,38 +173,129 @@ func (h *TeamHandlerImplementation) GetPaginatedTeams(w http.ResponseWriter, r *
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id		path		int					true	"Team ID"
-//	@Param			team	body		models.Team			true	"Updated Team Details"
-//	@Success		200		{object}	models.Team			"Successful response"
-//	@Failure		400		{object}	response.Response	"Bad request"
-//	@Failure		404		{object}	response.Response	"User not found"
+//	@Param			id			path		int					true	"Team ID"
+//	@Param			If-Match	header		string				true	"ETag of the team version being updated"
+//	@Param			team		body		models.Team			true	"Updated Team Details"
+//	@Success		200			{object}	models.Team			"Successful response"
+//	@Header			200			{string}	ETag				"New version of the team"
+//	@Failure		400			{object}	response.Response	"Bad request"
+//	@Failure		404			{object}	response.Response	"User not found"
+//	@Failure		409			{object}	response.Response	"The project is archived"
+//	@Failure		412			{object}	response.Response	"The team was changed since that version"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
 //	@Router			/teams/{id} [put]
 func (h *TeamHandlerImplementation) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
 	var team models.Team
 	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
 		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
 		return
 	}
+	team.ID = id
+	team.Version = version
 
 	if err := h.service.UpdateTeam(r.Context(), &team); err != nil {
+		if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
 
+	setETag(w, team.Version)
 	response.WriteJson(w, http.StatusOK, team)
 }
 
 
+// PatchTeam godoc
+//	@Summary		Partially update a team
+//	@Description	Apply a JSON merge patch (RFC 7396) to the name and description of a team. Omitted fields are kept and null resets a field.
+//	@Tags			Teams
+//	@Accept			json,application/merge-patch+json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int					true	"Team ID"
+//	@Param			If-Match	header		string				true	"ETag of the team version being updated"
+//	@Param			patch		body		models.Team		true	"Fields to change"
+//	@Success		200			{object}	models.Team		"The updated team"
+//	@Header			200			{string}	ETag				"New version of the team"
+//	@Failure		400			{object}	response.Response	"Invalid patch"
+//	@Failure		404			{object}	response.Response	"Team not found"
+//	@Failure		409			{object}	response.Response	"The project is archived"
+//	@Failure		412			{object}	response.Response	"The team was changed since that version"
+//	@Failure		415			{object}	response.Response	"The body is not a merge patch"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
+//	@Router			/teams/{id} [patch]
+func (h *TeamHandlerImplementation) PatchTeam(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+
+	team, err := h.service.GetTeamByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+		return
+	}
+	if !applyMergePatch(w, team, patch, teamPatchFields) {
+		return
+	}
+	if version != 0 {
+		team.Version = version
+	}
+
+	if err := h.service.UpdateTeam(r.Context(), team); err != nil {
+		if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+			return
+		}
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	// Reload the team for its up to date relations
+	team, err = h.service.GetTeamByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	setETag(w, team.Version)
+	response.WriteJson(w, http.StatusOK, team)
+}
+
 // DeleteTeam godoc
 //	@Summary		Delete a team
 //	@Description	Remove a team from the system by their ID
 //	@Tags			Teams
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id	path		int					true	"Team ID to delete"
-//	@Success		200	{object}	map[string]string	"Team deleted successfully"
-//	@Failure		400	{object}	response.Response	"Bad request"
-//	@Failure		500	{object}	response.Response	"Server error"
+//	@Param			id			path		int					true	"Team ID to delete"
+//	@Param			If-Match	header		string				true	"ETag of the team version being deleted"
+//	@Success		200			{object}	map[string]string	"Team deleted successfully"
+//	@Failure		400			{object}	response.Response	"Bad request"
+//	@Failure		409			{object}	response.Response	"The project is archived"
+//	@Failure		412			{object}	response.Response	"The team was changed since that version"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
+//	@Failure		500			{object}	response.Response	"Server error"
 //	@Router			/teams/{id} [delete]
 func (h *TeamHandlerImplementation) DeleteTeam(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
@@ -204,7 +304,15 @@ func (h *TeamHandlerImplementation) DeleteTeam(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if err := h.service.DeleteTeam(r.Context(), uint(id)); err != nil {
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTeam(r.Context(), uint(id), version); err != nil {
+		if writePreconditionFailed(w, err) || writeProjectStateError(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
 		return
 	}
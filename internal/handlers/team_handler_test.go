@@ -45,8 +45,8 @@ func (m *MockTeamService) UpdateTeam(ctx context.Context, team *models.Team) err
 	return args.Error(0)
 }
 
-func (m *MockTeamService) DeleteTeam(ctx context.Context, id uint) error {
-	args := m.Called(ctx, id)
+func (m *MockTeamService) DeleteTeam(ctx context.Context, id uint, version uint) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
@@ -70,7 +70,7 @@ func TestCreateTeam(t *testing.T) {
 		handler.CreateTeam(w, req)
 
 		assert.Equal(t, http.StatusCreated, w.Code)
-		assert.Contains(t, w.Body.String(), `{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"name":"Test Team","description":"Test Description","project_id":1,"project":{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"name":"","description":"","start_date":"0001-01-01T00:00:00Z","end_date":"0001-01-01T00:00:00Z","status":"","user_ids":null,"users":null,"tasks":null,"teams":null},"users":null}`)
+		assert.Contains(t, w.Body.String(), `{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"version":0,"name":"Test Team","description":"Test Description","project_id":1,"project":{"id":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":null,"version":0,"name":"","description":"","start_date":"0001-01-01T00:00:00Z","end_date":"0001-01-01T00:00:00Z","status":"","user_ids":null,"users":null,"tasks":null,"teams":null},"users":null}`)
 	})
 }
 
@@ -129,6 +129,7 @@ func TestUpdateTeam(t *testing.T) {
 
 	t.Run("Successful Team Update", func(t *testing.T) {
 		team := &models.Team{
+			ID:          1,
 			Name:       "Updated Team",
 			Description: "Test Description",
 			ProjectID:   1,
@@ -137,7 +138,8 @@ func TestUpdateTeam(t *testing.T) {
 		mockService.On("UpdateTeam", mock.Anything, team).Return(nil)
 
 		jsonTeam, _ := json.Marshal(team)
-		req := httptest.NewRequest(http.MethodPut, "/teams", bytes.NewBuffer(jsonTeam))
+		req := httptest.NewRequest(http.MethodPut, "/teams/1", bytes.NewBuffer(jsonTeam))
+		req.SetPathValue("id", "1")
 		w := httptest.NewRecorder()
 
 		handler.UpdateTeam(w, req)
@@ -152,7 +154,7 @@ func TestDeleteTeam(t *testing.T) {
 	handler := NewTeamHandler(mockService)
 
 	t.Run("Successful Team Deletion", func(t *testing.T) {
-		mockService.On("DeleteTeam", mock.Anything, uint(1)).Return(nil)
+		mockService.On("DeleteTeam", mock.Anything, uint(1), uint(0)).Return(nil)
 
 		req := httptest.NewRequest(http.MethodDelete, "/teams/1", nil)
 		req.SetPathValue("id", "1")
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SubmitTimesheetRequest is the body used to submit a week of worklogs
+type SubmitTimesheetRequest struct {
+	// Monday of the week, YYYY-MM-DD. Another day submits the week it falls in.
+	WeekStart string `json:"week_start" example:"2026-03-02"`
+}
+
+// ReviewTimesheetRequest is the body used to approve or reject a timesheet
+type ReviewTimesheetRequest struct {
+	// Required to reject the timesheet
+	Comment string `json:"comment"`
+}
+
+type TimesheetApprovalHandler interface {
+	SubmitTimesheet(w http.ResponseWriter, r *http.Request)
+	GetTimesheetSubmission(w http.ResponseWriter, r *http.Request)
+	GetMyTimesheetSubmissions(w http.ResponseWriter, r *http.Request)
+	ApproveTimesheet(w http.ResponseWriter, r *http.Request)
+	RejectTimesheet(w http.ResponseWriter, r *http.Request)
+	GetPendingApprovals(w http.ResponseWriter, r *http.Request)
+}
+
+type TimesheetApprovalHandlerImplementation struct {
+	service services.TimesheetApprovalService
+}
+
+func NewTimesheetApprovalHandler(service services.TimesheetApprovalService) *TimesheetApprovalHandlerImplementation {
+	return &TimesheetApprovalHandlerImplementation{service: service}
+}
+
+// SubmitTimesheet godoc
+//	@Summary		Submit a weekly timesheet
+//	@Description	Send the time the current user logged on the tasks of a project in a week to the project owner for approval. The worklogs of the week are locked until the timesheet is rejected.
+//	@Tags			Time tracking
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int							true	"Project ID"
+//	@Param			timesheet		body		SubmitTimesheetRequest		true	"Week"
+//	@Param			Idempotency-Key	header		string						false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.TimesheetSubmission	"Timesheet submitted"
+//	@Failure		400				{object}	response.Response			"Invalid week or no time logged"
+//	@Failure		401				{object}	response.Response			"Unauthenticated"
+//	@Failure		404				{object}	response.Response			"Project not found"
+//	@Failure		409				{object}	response.Response			"Already submitted or project archived"
+//	@Router			/projects/{id}/timesheets [post]
+func (h *TimesheetApprovalHandlerImplementation) SubmitTimesheet(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req SubmitTimesheetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+	week, err := time.Parse(time.DateOnly, req.WeekStart)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s", "invalid week_start, expected a YYYY-MM-DD date")))
+		return
+	}
+
+	submission, err := h.service.SubmitTimesheet(r.Context(), projectID, week)
+	if err != nil {
+		writeTimesheetApprovalError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, submission)
+}
+
+// GetTimesheetSubmission godoc
+//	@Summary		Get a submitted timesheet
+//	@Description	Retrieve a submitted timesheet with the worklogs of its week
+//	@Tags			Time tracking
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int							true	"Timesheet ID"
+//	@Success		200	{object}	models.TimesheetSubmission	"Timesheet"
+//	@Failure		401	{object}	response.Response			"Unauthenticated"
+//	@Failure		404	{object}	response.Response			"Timesheet not found"
+//	@Router			/timesheets/{id} [get]
+func (h *TimesheetApprovalHandlerImplementation) GetTimesheetSubmission(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	submission, err := h.service.GetSubmissionByID(r.Context(), id)
+	if err != nil {
+		writeTimesheetApprovalError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, submission)
+}
+
+// GetMyTimesheetSubmissions godoc
+//	@Summary		Get my submitted timesheets
+//	@Description	Retrieve the timesheets the current user submitted with their review, oldest week first
+//	@Tags			Time tracking
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		models.TimesheetSubmission	"Timesheets"
+//	@Failure		401	{object}	response.Response			"Unauthenticated"
+//	@Router			/me/timesheets [get]
+func (h *TimesheetApprovalHandlerImplementation) GetMyTimesheetSubmissions(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	submissions, err := h.service.GetMySubmissions(r.Context())
+	if err != nil {
+		writeTimesheetApprovalError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, submissions)
+}
+
+// ApproveTimesheet godoc
+//	@Summary		Approve a timesheet
+//	@Description	Approve a submitted timesheet of a project owned by the current user. Its worklogs stay locked.
+//	@Tags			Time tracking
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int							true	"Timesheet ID"
+//	@Param			review	body		ReviewTimesheetRequest		false	"Review"
+//	@Success		200		{object}	models.TimesheetSubmission	"Timesheet approved"
+//	@Failure		401		{object}	response.Response			"Unauthenticated"
+//	@Failure		403		{object}	response.Response			"Not the project owner"
+//	@Failure		404		{object}	response.Response			"Timesheet not found"
+//	@Failure		409		{object}	response.Response			"Timesheet already reviewed"
+//	@Router			/timesheets/{id}/approve [post]
+func (h *TimesheetApprovalHandlerImplementation) ApproveTimesheet(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.service.ApproveTimesheet)
+}
+
+// RejectTimesheet godoc
+//	@Summary		Reject a timesheet
+//	@Description	Send a submitted timesheet of a project owned by the current user back with a comment, unlocking its worklogs
+//	@Tags			Time tracking
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int							true	"Timesheet ID"
+//	@Param			review	body		ReviewTimesheetRequest		true	"Review"
+//	@Success		200		{object}	models.TimesheetSubmission	"Timesheet rejected"
+//	@Failure		400		{object}	response.Response			"Comment missing"
+//	@Failure		401		{object}	response.Response			"Unauthenticated"
+//	@Failure		403		{object}	response.Response			"Not the project owner"
+//	@Failure		404		{object}	response.Response			"Timesheet not found"
+//	@Failure		409		{object}	response.Response			"Timesheet already reviewed"
+//	@Router			/timesheets/{id}/reject [post]
+func (h *TimesheetApprovalHandlerImplementation) RejectTimesheet(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.service.RejectTimesheet)
+}
+
+// GetPendingApprovals godoc
+//	@Summary		Get the pending approvals of a project
+//	@Description	Retrieve the submitted timesheets of a project waiting for review with their total hours, oldest week first
+//	@Tags			Time tracking
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int						true	"Project ID"
+//	@Success		200	{object}	models.PendingApprovals	"Pending approvals"
+//	@Failure		401	{object}	response.Response		"Unauthenticated"
+//	@Failure		404	{object}	response.Response		"Project not found"
+//	@Router			/projects/{id}/timesheets/pending [get]
+func (h *TimesheetApprovalHandlerImplementation) GetPendingApprovals(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	pending, err := h.service.GetPendingApprovals(r.Context(), projectID)
+	if err != nil {
+		writeTimesheetApprovalError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, pending)
+}
+
+func (h *TimesheetApprovalHandlerImplementation) review(w http.ResponseWriter, r *http.Request, review func(ctx context.Context, id uint, comment string) (*models.TimesheetSubmission, error)) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req ReviewTimesheetRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+			return
+		}
+	}
+
+	submission, err := review(r.Context(), id, req.Comment)
+	if err != nil {
+		writeTimesheetApprovalError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, submission)
+}
+
+func writeTimesheetApprovalError(w http.ResponseWriter, err error) {
+	if writeProjectStateError(w, err) {
+		return
+	}
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrTimesheetNotFound), errors.Is(err, services.ErrProjectNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrNotTimesheetApprover):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrTimesheetSubmitted), errors.Is(err, services.ErrTimesheetNotPending):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrInvalidTimesheetWeek):
+		status = http.StatusBadRequest
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+)
+
+type TrashHandler interface {
+	GetTrash(w http.ResponseWriter, r *http.Request)
+	RestoreUser(w http.ResponseWriter, r *http.Request)
+	RestoreProject(w http.ResponseWriter, r *http.Request)
+	RestoreTask(w http.ResponseWriter, r *http.Request)
+	RestoreTeam(w http.ResponseWriter, r *http.Request)
+	RestoreComment(w http.ResponseWriter, r *http.Request)
+}
+
+type TrashHandlerImplementation struct {
+	service services.TrashService
+}
+
+func NewTrashHandler(service services.TrashService) *TrashHandlerImplementation {
+	return &TrashHandlerImplementation{service: service}
+}
+
+// GetTrash godoc
+//	@Summary		Get the trash
+//	@Description	Retrieve the paginated deleted users, projects, tasks, teams and comments, most recently deleted first. They can be restored until they are purged.
+//	@Tags			Trash
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			type		query		string					false	"user, project, task, team or comment"
+//	@Param			page		query		int						false	"Page number"				default(1)
+//	@Param			page_size	query		int						false	"Number of items per page"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Successful response"
+//	@Failure		400			{object}	response.Response		"Invalid type"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Router			/trash [get]
+func (h *TrashHandlerImplementation) GetTrash(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+	page, pageSize := parsePagination(r)
+
+	var items []models.TrashItem
+	items, total, err := h.service.GetTrash(r.Context(), r.URL.Query().Get("type"), page, pageSize)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]interface{}{
+		"items": items,
+		"total": total,
+		"page":  page,
+	})
+}
+
+// RestoreUser godoc
+//	@Summary		Restore a user
+//	@Description	Take a deleted user out of the trash
+//	@Tags			Trash
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"User ID"
+//	@Success		200	{object}	map[string]string	"User restored"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Not in the trash"
+//	@Router			/users/{id}/restore [post]
+func (h *TrashHandlerImplementation) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	h.restore(w, r, models.AuditEntityUser)
+}
+
+// RestoreProject godoc
+//	@Summary		Restore a project
+//	@Description	Take a deleted project out of the trash, with the teams, tasks and comments deleted along with it
+//	@Tags			Trash
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Project ID"
+//	@Success		200	{object}	map[string]string	"Project restored"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Not in the trash"
+//	@Router			/projects/{id}/restore [post]
+func (h *TrashHandlerImplementation) RestoreProject(w http.ResponseWriter, r *http.Request) {
+	h.restore(w, r, models.AuditEntityProject)
+}
+
+// RestoreTask godoc
+//	@Summary		Restore a task
+//	@Description	Take a deleted task out of the trash, with the comments deleted along with it
+//	@Tags			Trash
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Task ID"
+//	@Success		200	{object}	map[string]string	"Task restored"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Not in the trash"
+//	@Failure		409	{object}	response.Response	"The project is in the trash"
+//	@Router			/tasks/{id}/restore [post]
+func (h *TrashHandlerImplementation) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	h.restore(w, r, models.AuditEntityTask)
+}
+
+// RestoreTeam godoc
+//	@Summary		Restore a team
+//	@Description	Take a deleted team out of the trash
+//	@Tags			Trash
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Team ID"
+//	@Success		200	{object}	map[string]string	"Team restored"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Not in the trash"
+//	@Failure		409	{object}	response.Response	"The project is in the trash"
+//	@Router			/teams/{id}/restore [post]
+func (h *TrashHandlerImplementation) RestoreTeam(w http.ResponseWriter, r *http.Request) {
+	h.restore(w, r, models.AuditEntityTeam)
+}
+
+// RestoreComment godoc
+//	@Summary		Restore a comment
+//	@Description	Take a deleted comment out of the trash
+//	@Tags			Trash
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Comment ID"
+//	@Success		200	{object}	map[string]string	"Comment restored"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Not in the trash"
+//	@Failure		409	{object}	response.Response	"The task is in the trash"
+//	@Router			/comments/{id}/restore [post]
+func (h *TrashHandlerImplementation) RestoreComment(w http.ResponseWriter, r *http.Request) {
+	h.restore(w, r, models.AuditEntityComment)
+}
+
+func (h *TrashHandlerImplementation) restore(w http.ResponseWriter, r *http.Request, entityType string) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Restore(r.Context(), entityType, id); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotInTrash):
+			response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+		case errors.Is(err, services.ErrParentInTrash):
+			response.WriteJson(w, http.StatusConflict, response.GeneralError(err))
+		default:
+			response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		}
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": entityType + " restored"})
+}
@@ -22,6 +22,7 @@ type UserHandler interface {
 	CreateUser(w http.ResponseWriter, r *http.Request)
 	GetAllUsers(w http.ResponseWriter, r *http.Request)
 	GetUserByID(w http.ResponseWriter, r *http.Request)
+	PatchUser(w http.ResponseWriter, r *http.Request)
 	DeleteUser(w http.ResponseWriter, r *http.Request)
 }
 
@@ -53,8 +54,11 @@ func NewUserHandler(userService services.UserService) *UserHandlerImplementation
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			user	body		models.User			true	"Username"
+//	@Param			Idempotency-Key	header		string			false	"Key that makes retries of the request return its first response"
 //	@Success		201		{object}	map[string]int		"User created successfully"
 //	@Failure		400		{object}	response.Response	"Invalid input"
+//	@Failure		409		{object}	response.Response	"Request with the Idempotency-Key in progress"
+//	@Failure		422		{object}	response.Response	"Idempotency-Key used for a different request"
 //	@Failure		500		{object}	response.Response	"Server error"
 //	@Router			/users [post]
 func (s *UserHandlerImplementation) CreateUser(w http.ResponseWriter, r *http.Request) {
@@ -139,6 +143,7 @@ func (s *UserHandlerImplementation) GetAllUsers(w http.ResponseWriter, r *http.R
 //	@Security		BearerAuth
 //	@Param			id	path		int					true	"User ID"
 //	@Success		200	{object}	models.User			"Successful response"
+//	@Header			200	{string}	ETag				"Version of the user, for If-Match"
 //	@Failure		400	{object}	response.Response	"Bad request"
 //	@Failure		404	{object}	response.Response	"User not found"
 //	@Router			/users/{id} [get]
@@ -162,6 +167,77 @@ func (s *UserHandlerImplementation) GetUserByID(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	setETag(w, user.Version)
+	response.WriteJson(w, http.StatusOK, user)
+}
+
+// PatchUser godoc
+//	@Summary		Partially update an user
+//	@Description	Apply a JSON merge patch (RFC 7396) to the email and names of an user. Omitted fields are kept and null resets a field.
+//	@Tags			Users
+//	@Accept			json,application/merge-patch+json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int					true	"User ID"
+//	@Param			If-Match	header		string				true	"ETag of the user version being updated"
+//	@Param			patch		body		models.User		true	"Fields to change"
+//	@Success		200			{object}	models.User		"The updated user"
+//	@Header			200			{string}	ETag				"New version of the user"
+//	@Failure		400			{object}	response.Response	"Invalid patch"
+//	@Failure		403			{object}	response.Response	"Only the user and administrators can change a user"
+//	@Failure		404			{object}	response.Response	"User not found"
+//	@Failure		412			{object}	response.Response	"The user was changed since that version"
+//	@Failure		415			{object}	response.Response	"The body is not a merge patch"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
+//	@Router			/users/{id} [patch]
+func (s *UserHandlerImplementation) PatchUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := s.userService.GetUserByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+		return
+	}
+	if !applyMergePatch(w, user, patch, userPatchFields) {
+		return
+	}
+	if version != 0 {
+		user.Version = version
+	}
+
+	if err := s.userService.UpdateUser(r.Context(), user); err != nil {
+		if writePreconditionFailed(w, err) {
+			return
+		}
+		if errors.Is(err, services.ErrUserChangeForbidden) {
+			response.WriteJson(w, http.StatusForbidden, response.GeneralError(err))
+			return
+		}
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	// Reload the user for its up to date relations
+	user, err = s.userService.GetUserByID(r.Context(), id)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	setETag(w, user.Version)
 	response.WriteJson(w, http.StatusOK, user)
 }
 
@@ -171,10 +247,13 @@ func (s *UserHandlerImplementation) GetUserByID(w http.ResponseWriter, r *http.R
 //	@Tags			Users
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id	path		int					true	"User ID to delete"
-//	@Success		200	{object}	map[string]string	"User deleted successfully"
-//	@Failure		400	{object}	response.Response	"Bad request"
-//	@Failure		500	{object}	response.Response	"Server error"
+//	@Param			id			path		int					true	"User ID to delete"
+//	@Param			If-Match	header		string				true	"ETag of the user version being deleted"
+//	@Success		200			{object}	map[string]string	"User deleted successfully"
+//	@Failure		400			{object}	response.Response	"Bad request"
+//	@Failure		412			{object}	response.Response	"The user was changed since that version"
+//	@Failure		428			{object}	response.Response	"If-Match is missing"
+//	@Failure		500			{object}	response.Response	"Server error"
 //	@Router			/users/{id} [delete]
 func (s *UserHandlerImplementation) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
@@ -190,8 +269,16 @@ func (s *UserHandlerImplementation) DeleteUser(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = s.userService.DeleteUser(r.Context(), uint(id))
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	err = s.userService.DeleteUser(r.Context(), uint(id), version)
 	if err != nil {
+		if writePreconditionFailed(w, err) {
+			return
+		}
 		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("could not delete user")))
 		return
 	}
@@ -34,7 +34,7 @@ func TestUserHandlers(t *testing.T) {
 		GetAllUsersFunc: func(ctx context.Context, page, pageSize int) ([]models.User, int64, error) {
 			return mockUsers, int64(len(mockUsers)), nil
 		},
-		DeleteUserFunc: func(ctx context.Context, id uint) error {
+		DeleteUserFunc: func(ctx context.Context, id uint, version uint) error {
 			return nil
 		},
 	}
@@ -11,6 +11,7 @@ import (
 
 type UserProjectHandler interface {
 	AddUserToProject(w http.ResponseWriter, r *http.Request)
+	RemoveUserFromProject(w http.ResponseWriter, r *http.Request)
 }
 
 type UserProjectImplementation struct {
@@ -47,7 +48,37 @@ func (handler *UserProjectImplementation) AddUserToProject(w http.ResponseWriter
 	}
 
 
-	if err := handler.userProjectService.AddUserToProject(uint(userId), uint(projectId)); err != nil {
+	if err := handler.userProjectService.AddUserToProject(r.Context(), uint(userId), uint(projectId)); err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(fmt.Errorf("%s", err.Error())))
+		return
+	}
+
+	response.WriteJson(w, http.StatusNoContent, response.StatusOK)
+}
+
+// RemoveUserFromProject removes a user from a project.
+//	@Summary		Remove a user from a project
+//	@Description	Remove a user from a specified project by their IDs
+//	@Tags			user_project
+//	@Security		BearerAuth
+//	@Param			userId		path	int	true	"User ID"
+//	@Param			projectId	path	int	true	"Project ID"
+//	@Success		204
+//	@Router			/projects/{projectId}/users/{userId} [delete]
+func (handler *UserProjectImplementation) RemoveUserFromProject(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseUint(r.PathValue("userId"), 10, 64)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s", "Invalid user ID")))
+		return
+	}
+
+	projectId, err := strconv.ParseUint(r.PathValue("projectId"), 10, 64)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s", "Invalid project ID")))
+		return
+	}
+
+	if err := handler.userProjectService.RemoveUserFromProject(r.Context(), uint(userId), uint(projectId)); err != nil {
 		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(fmt.Errorf("%s", err.Error())))
 		return
 	}
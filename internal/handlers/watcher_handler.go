@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"net/http"
+)
+
+type WatcherHandler interface {
+	WatchTask(w http.ResponseWriter, r *http.Request)
+	UnwatchTask(w http.ResponseWriter, r *http.Request)
+	GetTaskWatchers(w http.ResponseWriter, r *http.Request)
+	GetMyWatchedTasks(w http.ResponseWriter, r *http.Request)
+}
+
+type WatcherHandlerImplementation struct {
+	service services.WatcherService
+}
+
+func NewWatcherHandler(service services.WatcherService) *WatcherHandlerImplementation {
+	return &WatcherHandlerImplementation{service: service}
+}
+
+// WatchTask godoc
+//	@Summary		Watch a task
+//	@Description	Notify the current user about the changes and comments of a task
+//	@Tags			Watchers
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Task ID"
+//	@Success		200	{array}		models.User			"Watchers of the task"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Task not found"
+//	@Router			/tasks/{id}/watch [post]
+func (h *WatcherHandlerImplementation) WatchTask(w http.ResponseWriter, r *http.Request) {
+	h.watchers(w, r, h.service.Watch)
+}
+
+// UnwatchTask godoc
+//	@Summary		Stop watching a task
+//	@Description	Stop notifying the current user about the changes and comments of a task
+//	@Tags			Watchers
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Task ID"
+//	@Success		200	{array}		models.User			"Watchers of the task"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Task not found"
+//	@Router			/tasks/{id}/watch [delete]
+func (h *WatcherHandlerImplementation) UnwatchTask(w http.ResponseWriter, r *http.Request) {
+	h.watchers(w, r, h.service.Unwatch)
+}
+
+// GetTaskWatchers godoc
+//	@Summary		Get the watchers of a task
+//	@Description	Retrieve the users notified about the changes and comments of a task
+//	@Tags			Watchers
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Task ID"
+//	@Success		200	{array}		models.User			"Watchers of the task"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Task not found"
+//	@Router			/tasks/{id}/watchers [get]
+func (h *WatcherHandlerImplementation) GetTaskWatchers(w http.ResponseWriter, r *http.Request) {
+	h.watchers(w, r, h.service.GetWatchers)
+}
+
+// GetMyWatchedTasks godoc
+//	@Summary		Get the tasks I watch
+//	@Description	Retrieve the paginated tasks the current user watches, most recently updated first
+//	@Tags			Watchers
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int						false	"Page number"				default(1)
+//	@Param			page_size	query		int						false	"Number of tasks per page"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Successful response"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		500			{object}	response.Response		"Server error"
+//	@Router			/me/watching [get]
+func (h *WatcherHandlerImplementation) GetMyWatchedTasks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	page, pageSize := parsePagination(r)
+
+	tasks, total, err := h.service.GetWatchedTasks(r.Context(), userID, page, pageSize)
+	if err != nil {
+		response.WriteJson(w, http.StatusInternalServerError, response.GeneralError(err))
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]interface{}{
+		"tasks": tasks,
+		"total": total,
+		"page":  page,
+	})
+}
+
+// watchers runs watchersFn on the task in the path and writes the watchers it returns
+func (h *WatcherHandlerImplementation) watchers(w http.ResponseWriter, r *http.Request, watchersFn func(ctx context.Context, taskID uint) ([]models.User, error)) {
+	taskID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	watchers, err := watchersFn(r.Context(), taskID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrTaskNotFound) {
+			status = http.StatusNotFound
+		}
+		response.WriteJson(w, status, response.GeneralError(err))
+		return
+	}
+	if watchers == nil {
+		watchers = []models.User{}
+	}
+
+	response.WriteJson(w, http.StatusOK, watchers)
+}
@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+type WebhookHandler interface {
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	GetProjectWebhooks(w http.ResponseWriter, r *http.Request)
+	GetWebhookByID(w http.ResponseWriter, r *http.Request)
+	UpdateWebhook(w http.ResponseWriter, r *http.Request)
+	DeleteWebhook(w http.ResponseWriter, r *http.Request)
+	GetWebhookDeliveries(w http.ResponseWriter, r *http.Request)
+	RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request)
+}
+
+type WebhookHandlerImplementation struct {
+	service services.WebhookService
+}
+
+func NewWebhookHandler(service services.WebhookService) *WebhookHandlerImplementation {
+	return &WebhookHandlerImplementation{service: service}
+}
+
+// WebhookRequest is the body used to register or change a webhook
+type WebhookRequest struct {
+	URL string `json:"url" example:"https://ci.example.com/hooks/pms"`
+	// Generated when empty on creation, kept when empty on update
+	Secret string   `json:"secret"`
+	Events []string `json:"events" example:"task.created,task.updated"`
+	// Only used on update, re-enables a disabled webhook
+	Active *bool `json:"active"`
+}
+
+// CreateWebhook godoc
+//	@Summary		Register a webhook
+//	@Description	Register an endpoint receiving HMAC-SHA256 signed project events. The secret is only returned in this response.
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Project ID"
+//	@Param			webhook	body		WebhookRequest		true	"Webhook"
+//	@Param			Idempotency-Key	header		string			false	"Key that makes retries of the request return its first response"
+//	@Success		201		{object}	models.Webhook		"Webhook created"
+//	@Failure		400		{object}	response.Response	"Invalid input"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		403		{object}	response.Response	"Not the project owner"
+//	@Failure		404		{object}	response.Response	"Project not found"
+//	@Failure		409		{object}	response.Response	"Request with the Idempotency-Key in progress"
+//	@Failure		422		{object}	response.Response	"Idempotency-Key used for a different request"
+//	@Router			/projects/{id}/webhooks [post]
+func (h *WebhookHandlerImplementation) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	webhook := models.Webhook{
+		ProjectID: projectID,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    req.Events,
+	}
+	if err := h.service.CreateWebhook(r.Context(), userID, &webhook); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, webhook)
+}
+
+// GetProjectWebhooks godoc
+//	@Summary		Get project webhooks
+//	@Description	Retrieve the webhooks registered for a project
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Project ID"
+//	@Success		200	{array}		models.Webhook		"Webhooks"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Not the project owner"
+//	@Failure		404	{object}	response.Response	"Project not found"
+//	@Router			/projects/{id}/webhooks [get]
+func (h *WebhookHandlerImplementation) GetProjectWebhooks(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.service.GetWebhooksByProject(r.Context(), userID, projectID)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, webhooks)
+}
+
+// GetWebhookByID godoc
+//	@Summary		Get a webhook
+//	@Description	Retrieve a webhook, including whether it was disabled after repeated failures
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Webhook ID"
+//	@Success		200	{object}	models.Webhook		"Webhook"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Not the project owner"
+//	@Failure		404	{object}	response.Response	"Webhook not found"
+//	@Router			/webhooks/{id} [get]
+func (h *WebhookHandlerImplementation) GetWebhookByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.service.GetWebhookByID(r.Context(), userID, id)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, webhook)
+}
+
+// UpdateWebhook godoc
+//	@Summary		Update a webhook
+//	@Description	Change the URL, event filter, secret or active flag of a webhook
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Webhook ID"
+//	@Param			webhook	body		WebhookRequest		true	"Webhook"
+//	@Success		200		{object}	models.Webhook		"Updated webhook"
+//	@Failure		400		{object}	response.Response	"Invalid input"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		403		{object}	response.Response	"Not the project owner"
+//	@Failure		404		{object}	response.Response	"Webhook not found"
+//	@Router			/webhooks/{id} [put]
+func (h *WebhookHandlerImplementation) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	webhook := models.Webhook{
+		ID:     id,
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: req.Events,
+		Active: req.Active == nil || *req.Active,
+	}
+	if err := h.service.UpdateWebhook(r.Context(), userID, &webhook); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+//	@Summary		Delete a webhook
+//	@Description	Delete a webhook and its delivery log
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Webhook ID"
+//	@Success		200	{object}	map[string]string	"Webhook deleted"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Not the project owner"
+//	@Failure		404	{object}	response.Response	"Webhook not found"
+//	@Router			/webhooks/{id} [delete]
+func (h *WebhookHandlerImplementation) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteWebhook(r.Context(), userID, id); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]string{"message": "webhook deleted successfully"})
+}
+
+// GetWebhookDeliveries godoc
+//	@Summary		Get webhook deliveries
+//	@Description	Retrieve the paginated delivery log of a webhook, newest first
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"Webhook ID"
+//	@Param			page		query		int						false	"Page number"					default(1)
+//	@Param			page_size	query		int						false	"Number of deliveries per page"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Successful response"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		403			{object}	response.Response		"Not the project owner"
+//	@Failure		404			{object}	response.Response		"Webhook not found"
+//	@Router			/webhooks/{id}/deliveries [get]
+func (h *WebhookHandlerImplementation) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	deliveries, total, err := h.service.GetDeliveries(r.Context(), userID, id, page, pageSize)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, map[string]interface{}{
+		"deliveries": deliveries,
+		"total":      total,
+		"page":       page,
+	})
+}
+
+// RedeliverWebhookDelivery godoc
+//	@Summary		Redeliver a webhook delivery
+//	@Description	Send the payload of a previous delivery again, as a new delivery attempted right away
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"Webhook ID"
+//	@Param			deliveryId	path		int						true	"Delivery ID"
+//	@Success		201			{object}	models.WebhookDelivery	"New delivery"
+//	@Failure		401			{object}	response.Response		"Unauthenticated"
+//	@Failure		403			{object}	response.Response		"Not the project owner"
+//	@Failure		404			{object}	response.Response		"Webhook or delivery not found"
+//	@Router			/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandlerImplementation) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseIDParam(w, r, "deliveryId")
+	if !ok {
+		return
+	}
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), userID, id, deliveryID)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, delivery)
+}
+
+func parseIDParam(w http.ResponseWriter, r *http.Request, name string) (uint, bool) {
+	id, err := strconv.ParseUint(r.PathValue(name), 10, 64)
+	if err != nil || id <= 0 {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s", "invalid ID")))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotProjectOwner):
+		response.WriteJson(w, http.StatusForbidden, response.GeneralError(err))
+	case errors.Is(err, services.ErrProjectNotFound),
+		errors.Is(err, services.ErrWebhookNotFound),
+		errors.Is(err, services.ErrWebhookDeliveryNotFound):
+		response.WriteJson(w, http.StatusNotFound, response.GeneralError(err))
+	default:
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+	}
+}
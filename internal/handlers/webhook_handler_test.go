@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/pkg/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookService mocks the WebhookService for testing
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) HandleEvent(ctx context.Context, event events.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) CreateWebhook(ctx context.Context, userID uint, webhook *models.Webhook) error {
+	args := m.Called(ctx, userID, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) GetWebhooksByProject(ctx context.Context, userID, projectID uint) ([]models.Webhook, error) {
+	args := m.Called(ctx, userID, projectID)
+	webhooks, _ := args.Get(0).([]models.Webhook)
+	return webhooks, args.Error(1)
+}
+
+func (m *MockWebhookService) GetWebhookByID(ctx context.Context, userID, id uint) (*models.Webhook, error) {
+	args := m.Called(ctx, userID, id)
+	webhook, _ := args.Get(0).(*models.Webhook)
+	return webhook, args.Error(1)
+}
+
+func (m *MockWebhookService) UpdateWebhook(ctx context.Context, userID uint, webhook *models.Webhook) error {
+	args := m.Called(ctx, userID, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) DeleteWebhook(ctx context.Context, userID, id uint) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) GetDeliveries(ctx context.Context, userID, webhookID uint, page, pageSize int) ([]models.WebhookDelivery, int64, error) {
+	args := m.Called(ctx, userID, webhookID, page, pageSize)
+	deliveries, _ := args.Get(0).([]models.WebhookDelivery)
+	return deliveries, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWebhookService) Redeliver(ctx context.Context, userID, webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	args := m.Called(ctx, userID, webhookID, deliveryID)
+	delivery, _ := args.Get(0).(*models.WebhookDelivery)
+	return delivery, args.Error(1)
+}
+
+func (m *MockWebhookService) ProcessDueDeliveries(ctx context.Context, now time.Time) error {
+	args := m.Called(ctx, now)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) RunDeliveries(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	testCases := []struct {
+		name           string
+		projectID      string
+		userID         uint
+		body           string
+		mockSetup      func(*MockWebhookService)
+		expectedStatus int
+	}{
+		{
+			name:      "Successful Creation",
+			projectID: "1",
+			userID:    2,
+			body:      `{"url": "https://ci.example.com/hook", "events": ["task.created"]}`,
+			mockSetup: func(mws *MockWebhookService) {
+				expected := &models.Webhook{ProjectID: 1, URL: "https://ci.example.com/hook", Events: []string{"task.created"}}
+				mws.On("CreateWebhook", mock.Anything, uint(2), expected).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:      "Not The Owner",
+			projectID: "1",
+			userID:    3,
+			body:      `{"url": "https://ci.example.com/hook"}`,
+			mockSetup: func(mws *MockWebhookService) {
+				mws.On("CreateWebhook", mock.Anything, uint(3), mock.Anything).Return(services.ErrNotProjectOwner)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:      "Project Not Found",
+			projectID: "9",
+			userID:    2,
+			body:      `{"url": "https://ci.example.com/hook"}`,
+			mockSetup: func(mws *MockWebhookService) {
+				mws.On("CreateWebhook", mock.Anything, uint(2), mock.Anything).Return(services.ErrProjectNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Unauthenticated",
+			projectID:      "1",
+			body:           `{"url": "https://ci.example.com/hook"}`,
+			mockSetup:      func(mws *MockWebhookService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Invalid Project ID",
+			projectID:      "abc",
+			userID:         2,
+			body:           `{"url": "https://ci.example.com/hook"}`,
+			mockSetup:      func(mws *MockWebhookService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockWebhookService)
+			tc.mockSetup(mockService)
+
+			handler := NewWebhookHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/projects/"+tc.projectID+"/webhooks", bytes.NewBufferString(tc.body))
+			req.SetPathValue("id", tc.projectID)
+			if tc.userID != 0 {
+				req = req.WithContext(middleware.WithUserID(req.Context(), tc.userID))
+			}
+			w := httptest.NewRecorder()
+
+			handler.CreateWebhook(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRedeliverWebhookDelivery(t *testing.T) {
+	testCases := []struct {
+		name           string
+		mockSetup      func(*MockWebhookService)
+		expectedStatus int
+	}{
+		{
+			name: "Successful Redelivery",
+			mockSetup: func(mws *MockWebhookService) {
+				mws.On("Redeliver", mock.Anything, uint(2), uint(1), uint(4)).
+					Return(&models.WebhookDelivery{ID: 5, WebhookID: 1, Status: models.WebhookDeliverySucceeded}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Delivery Not Found",
+			mockSetup: func(mws *MockWebhookService) {
+				mws.On("Redeliver", mock.Anything, uint(2), uint(1), uint(4)).Return(nil, services.ErrWebhookDeliveryNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockWebhookService)
+			tc.mockSetup(mockService)
+
+			handler := NewWebhookHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/1/deliveries/4/redeliver", nil)
+			req.SetPathValue("id", "1")
+			req.SetPathValue("deliveryId", "4")
+			req = req.WithContext(middleware.WithUserID(req.Context(), 2))
+			w := httptest.NewRecorder()
+
+			handler.RedeliverWebhookDelivery(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
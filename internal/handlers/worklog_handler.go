@@ -0,0 +1,450 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// WorklogRequest is the body used to log time or change a worklog
+type WorklogRequest struct {
+	StartedAt       time.Time `json:"started_at"`
+	DurationMinutes int       `json:"duration_minutes" example:"90"`
+	Note            string    `json:"note"`
+}
+
+// TimerRequest is the body used to start a timer
+type TimerRequest struct {
+	TaskID uint   `json:"task_id"`
+	Note   string `json:"note"`
+}
+
+type WorklogHandler interface {
+	CreateWorklog(w http.ResponseWriter, r *http.Request)
+	GetTaskWorklogs(w http.ResponseWriter, r *http.Request)
+	UpdateWorklog(w http.ResponseWriter, r *http.Request)
+	DeleteWorklog(w http.ResponseWriter, r *http.Request)
+	GetTimer(w http.ResponseWriter, r *http.Request)
+	StartTimer(w http.ResponseWriter, r *http.Request)
+	StopTimer(w http.ResponseWriter, r *http.Request)
+	DiscardTimer(w http.ResponseWriter, r *http.Request)
+	GetProjectTimesheet(w http.ResponseWriter, r *http.Request)
+	GetUserTimesheet(w http.ResponseWriter, r *http.Request)
+}
+
+type WorklogHandlerImplementation struct {
+	service services.WorklogService
+}
+
+func NewWorklogHandler(service services.WorklogService) *WorklogHandlerImplementation {
+	return &WorklogHandlerImplementation{service: service}
+}
+
+// CreateWorklog godoc
+//	@Summary		Log time on a task
+//	@Description	Log time of the current user on a task. Worklogs of a user cannot overlap.
+//	@Tags			Time tracking
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int					true	"Task ID"
+//	@Param			worklog			body		WorklogRequest		true	"Worklog"
+//	@Param			Idempotency-Key	header		string				false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.Worklog		"Time logged"
+//	@Failure		400				{object}	response.Response	"Invalid worklog"
+//	@Failure		401				{object}	response.Response	"Unauthenticated"
+//	@Failure		404				{object}	response.Response	"Task not found"
+//	@Failure		409				{object}	response.Response	"Overlapping worklog, locked week or project archived"
+//	@Router			/tasks/{id}/worklogs [post]
+func (h *WorklogHandlerImplementation) CreateWorklog(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req WorklogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	worklog := models.Worklog{
+		TaskID:          taskID,
+		StartedAt:       req.StartedAt,
+		DurationMinutes: req.DurationMinutes,
+		Note:            req.Note,
+	}
+	if err := h.service.CreateWorklog(r.Context(), &worklog); err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, worklog)
+}
+
+// GetTaskWorklogs godoc
+//	@Summary		Get the worklogs of a task
+//	@Description	Retrieve the time logged on a task in the order it was started
+//	@Tags			Time tracking
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Task ID"
+//	@Success		200	{array}		models.Worklog		"Worklogs"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"Task not found"
+//	@Router			/tasks/{id}/worklogs [get]
+func (h *WorklogHandlerImplementation) GetTaskWorklogs(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	worklogs, err := h.service.GetWorklogsByTask(r.Context(), taskID)
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, worklogs)
+}
+
+// UpdateWorklog godoc
+//	@Summary		Update a worklog
+//	@Description	Change the start, duration and note of a worklog of the current user
+//	@Tags			Time tracking
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Worklog ID"
+//	@Param			worklog	body		WorklogRequest		true	"Worklog"
+//	@Success		200		{object}	models.Worklog		"Worklog updated"
+//	@Failure		400		{object}	response.Response	"Invalid worklog"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		403		{object}	response.Response	"Worklog of another user"
+//	@Failure		404		{object}	response.Response	"Worklog not found"
+//	@Failure		409		{object}	response.Response	"Overlapping worklog, locked week or project archived"
+//	@Router			/worklogs/{id} [put]
+func (h *WorklogHandlerImplementation) UpdateWorklog(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req WorklogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	worklog := models.Worklog{
+		ID:              id,
+		StartedAt:       req.StartedAt,
+		DurationMinutes: req.DurationMinutes,
+		Note:            req.Note,
+	}
+	if err := h.service.UpdateWorklog(r.Context(), &worklog); err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, worklog)
+}
+
+// DeleteWorklog godoc
+//	@Summary		Delete a worklog
+//	@Description	Delete a worklog of the current user
+//	@Tags			Time tracking
+//	@Security		BearerAuth
+//	@Param			id	path	int	true	"Worklog ID"
+//	@Success		204	"Worklog deleted"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		403	{object}	response.Response	"Worklog of another user"
+//	@Failure		404	{object}	response.Response	"Worklog not found"
+//	@Failure		409	{object}	response.Response	"Locked week or project archived"
+//	@Router			/worklogs/{id} [delete]
+func (h *WorklogHandlerImplementation) DeleteWorklog(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.DeleteWorklog(r.Context(), id); err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetTimer godoc
+//	@Summary		Get my timer
+//	@Description	Retrieve the running timer of the current user
+//	@Tags			Time tracking
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	models.Timer		"Running timer"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"No timer running"
+//	@Router			/me/timer [get]
+func (h *WorklogHandlerImplementation) GetTimer(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	timer, err := h.service.GetTimer(r.Context())
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusOK, timer)
+}
+
+// StartTimer godoc
+//	@Summary		Start my timer
+//	@Description	Start the timer of the current user on a task. A user runs one timer at a time.
+//	@Tags			Time tracking
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			timer			body		TimerRequest		true	"Timer"
+//	@Param			Idempotency-Key	header		string				false	"Key that makes retries of the request return its first response"
+//	@Success		201				{object}	models.Timer		"Timer started"
+//	@Failure		400				{object}	response.Response	"Invalid request"
+//	@Failure		401				{object}	response.Response	"Unauthenticated"
+//	@Failure		404				{object}	response.Response	"Task not found"
+//	@Failure		409				{object}	response.Response	"Timer already running or project archived"
+//	@Router			/me/timer [post]
+func (h *WorklogHandlerImplementation) StartTimer(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	var req TimerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	timer, err := h.service.StartTimer(r.Context(), req.TaskID, req.Note)
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, timer)
+}
+
+// StopTimer godoc
+//	@Summary		Stop my timer
+//	@Description	Stop the timer of the current user and log the time since it was started, at least a minute. The timer keeps running when the time cannot be logged.
+//	@Tags			Time tracking
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		201	{object}	models.Worklog		"Time logged"
+//	@Failure		400	{object}	response.Response	"Timer ran for more than a day"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"No timer running"
+//	@Failure		409	{object}	response.Response	"Overlapping worklog, locked week or project archived"
+//	@Router			/me/timer/stop [post]
+func (h *WorklogHandlerImplementation) StopTimer(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	worklog, err := h.service.StopTimer(r.Context())
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.WriteJson(w, http.StatusCreated, worklog)
+}
+
+// DiscardTimer godoc
+//	@Summary		Discard my timer
+//	@Description	Stop the timer of the current user without logging its time
+//	@Tags			Time tracking
+//	@Security		BearerAuth
+//	@Success		204	"Timer discarded"
+//	@Failure		401	{object}	response.Response	"Unauthenticated"
+//	@Failure		404	{object}	response.Response	"No timer running"
+//	@Router			/me/timer [delete]
+func (h *WorklogHandlerImplementation) DiscardTimer(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+
+	if err := h.service.DiscardTimer(r.Context()); err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetProjectTimesheet godoc
+//	@Summary		Get the timesheet of a project
+//	@Description	Total the time logged on the tasks of a project per day or week, user and task. Defaults to the current week.
+//	@Tags			Time tracking
+//	@Produce		json
+//	@Produce		text/csv
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Project ID"
+//	@Param			from	query		string				false	"First day, YYYY-MM-DD"
+//	@Param			to		query		string				false	"Last day, YYYY-MM-DD"
+//	@Param			group	query		string				false	"day or week"	default(day)
+//	@Param			format	query		string				false	"json or csv"	default(json)
+//	@Success		200		{object}	models.Timesheet	"Timesheet"
+//	@Failure		400		{object}	response.Response	"Invalid range"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Failure		404		{object}	response.Response	"Project not found"
+//	@Router			/projects/{id}/timesheet [get]
+func (h *WorklogHandlerImplementation) GetProjectTimesheet(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+	query, err := parseTimesheetQuery(r)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	timesheet, err := h.service.GetProjectTimesheet(r.Context(), projectID, query)
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	writeTimesheet(w, r, fmt.Sprintf("project-%d", projectID), timesheet)
+}
+
+// GetUserTimesheet godoc
+//	@Summary		Get the timesheet of a user
+//	@Description	Total the time logged by a user per day or week and task. Defaults to the current week.
+//	@Tags			Time tracking
+//	@Produce		json
+//	@Produce		text/csv
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"User ID"
+//	@Param			from	query		string				false	"First day, YYYY-MM-DD"
+//	@Param			to		query		string				false	"Last day, YYYY-MM-DD"
+//	@Param			group	query		string				false	"day or week"	default(day)
+//	@Param			format	query		string				false	"json or csv"	default(json)
+//	@Success		200		{object}	models.Timesheet	"Timesheet"
+//	@Failure		400		{object}	response.Response	"Invalid range"
+//	@Failure		401		{object}	response.Response	"Unauthenticated"
+//	@Router			/users/{id}/timesheet [get]
+func (h *WorklogHandlerImplementation) GetUserTimesheet(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, ok := requireUserID(w, r); !ok {
+		return
+	}
+	query, err := parseTimesheetQuery(r)
+	if err != nil {
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(err))
+		return
+	}
+
+	timesheet, err := h.service.GetUserTimesheet(r.Context(), userID, query)
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	writeTimesheet(w, r, fmt.Sprintf("user-%d", userID), timesheet)
+}
+
+func parseTimesheetQuery(r *http.Request) (services.TimesheetQuery, error) {
+	values := r.URL.Query()
+	query := services.TimesheetQuery{Group: values.Get("group")}
+
+	for name, target := range map[string]*time.Time{"from": &query.From, "to": &query.To} {
+		if value := values.Get(name); value != "" {
+			t, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				return query, fmt.Errorf("invalid %s, expected a YYYY-MM-DD date", name)
+			}
+			*target = t
+		}
+	}
+	return query, nil
+}
+
+// writeTimesheet writes a timesheet as JSON, or as a CSV file of its rows when the
+// format query parameter is csv
+func writeTimesheet(w http.ResponseWriter, r *http.Request, name string, timesheet *models.Timesheet) {
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		response.WriteJson(w, http.StatusOK, timesheet)
+		return
+	case "csv":
+	default:
+		response.WriteJson(w, http.StatusBadRequest, response.GeneralError(fmt.Errorf("%s", "format must be json or csv")))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="timesheet-%s-%s-%s.csv"`, name, timesheet.From, timesheet.To))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{timesheet.Group, "user_id", "project_id", "task_id", "task", "hours"})
+	for _, row := range timesheet.Rows {
+		writer.Write([]string{
+			row.Period,
+			strconv.FormatUint(uint64(row.UserID), 10),
+			strconv.FormatUint(uint64(row.ProjectID), 10),
+			strconv.FormatUint(uint64(row.TaskID), 10),
+			row.TaskTitle,
+			strconv.FormatFloat(row.Hours, 'f', 2, 64),
+		})
+	}
+	writer.Flush()
+}
+
+func writeWorklogError(w http.ResponseWriter, err error) {
+	if writeProjectStateError(w, err) {
+		return
+	}
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrWorklogNotFound), errors.Is(err, services.ErrTaskNotFound),
+		errors.Is(err, services.ErrProjectNotFound), errors.Is(err, services.ErrNoTimerRunning):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrNotWorklogAuthor):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrWorklogOverlap), errors.Is(err, services.ErrTimerRunning),
+		errors.Is(err, services.ErrWorklogLocked):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrInvalidWorklog), errors.Is(err, services.ErrInvalidTimesheet):
+		status = http.StatusBadRequest
+	}
+	response.WriteJson(w, status, response.GeneralError(err))
+}
@@ -0,0 +1,190 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureServer is a minimal SMTP server keeping every received message in memory
+type captureServer struct {
+	listener net.Listener
+	messages chan capturedMessage
+}
+
+type capturedMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newCaptureServer(t *testing.T) *captureServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	s := &captureServer{listener: listener, messages: make(chan capturedMessage, 10)}
+	go s.serve()
+	return s
+}
+
+func (s *captureServer) hostPort() (string, string) {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host, port
+}
+
+func (s *captureServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *captureServer) handle(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+
+	var msg capturedMessage
+	text.PrintfLine("220 localhost capture")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO", "HELO":
+			text.PrintfLine("250 localhost")
+		case "MAIL":
+			msg.from = strings.TrimPrefix(line, "MAIL FROM:")
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			msg.to = append(msg.to, strings.TrimPrefix(line, "RCPT TO:"))
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			s.messages <- msg
+			msg = capturedMessage{}
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("250 OK")
+		}
+	}
+}
+
+func testMessage() Message {
+	return Message{
+		From:    "Project Management <no-reply@example.com>",
+		To:      "Jane Doe <jane@example.com>",
+		Subject: "You were assigned to task \"Setup Server\"",
+		Text:    "Hi Jane,\n\nYou were assigned to task \"Setup Server\"\n",
+		HTML:    "<p>Hi Jane,</p><p>You were assigned to task &#34;Setup Server&#34;</p>",
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	server := newCaptureServer(t)
+	host, port := server.hostPort()
+
+	sender := NewSMTPSender(host, port, "", "")
+	err := sender.Send(context.Background(), testMessage())
+	require.NoError(t, err)
+
+	select {
+	case msg := <-server.messages:
+		assert.Equal(t, "<no-reply@example.com>", msg.from)
+		assert.Equal(t, []string{"<jane@example.com>"}, msg.to)
+		assert.Contains(t, msg.data, "To: Jane Doe <jane@example.com>")
+		assert.Contains(t, msg.data, "Content-Type: multipart/alternative")
+		assert.Contains(t, msg.data, "Content-Type: text/plain; charset=utf-8")
+		assert.Contains(t, msg.data, "Content-Type: text/html; charset=utf-8")
+		assert.Contains(t, msg.data, "Hi Jane,")
+	case <-time.After(5 * time.Second):
+		t.Fatal("capture server received no message")
+	}
+}
+
+func TestSMTPSender_InvalidRecipient(t *testing.T) {
+	msg := testMessage()
+	msg.To = "not an address"
+
+	err := NewSMTPSender("127.0.0.1", "1", "", "").Send(context.Background(), msg)
+
+	assert.ErrorContains(t, err, "invalid recipient address")
+}
+
+func TestFileSender(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+
+	sender := NewFileSender(dir)
+	require.NoError(t, sender.Send(context.Background(), testMessage()))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0].Name(), ".eml"))
+
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Subject: You were assigned to task \"Setup Server\"")
+	assert.Contains(t, string(data), "Hi Jane,")
+}
+
+func TestEncode_StripsHeaderInjection(t *testing.T) {
+	msg := testMessage()
+	msg.Subject = "Hello\r\nBcc: attacker@example.com"
+	msg.To = "jane@example.com\r\nBcc: attacker@example.com"
+
+	data, err := Encode(msg, time.Now())
+	require.NoError(t, err)
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(string(data))))
+	header, err := reader.ReadMIMEHeader()
+	require.NoError(t, err)
+	assert.Empty(t, header.Get("Bcc"))
+}
+
+func TestTemplates_Render(t *testing.T) {
+	templates, err := NewTemplates()
+	require.NoError(t, err)
+
+	data := TemplateData{
+		RecipientName: "Jane",
+		Frequency:     "daily",
+		SettingsURL:   "http://localhost:8080/api/v1/me/email-preferences",
+		Items: []TemplateItem{
+			{Message: "New comment on task \"<b>Setup</b>\"", URL: "http://localhost:8080/api/v1/tasks/1", CreatedAt: time.Now()},
+			{Message: "You were added to project #2", CreatedAt: time.Now()},
+		},
+	}
+
+	text, html, err := templates.Render(TemplateDigest, data)
+	require.NoError(t, err)
+
+	assert.Contains(t, text, "since your last daily digest")
+	assert.Contains(t, text, "- New comment on task \"<b>Setup</b>\"")
+	assert.Contains(t, text, "- You were added to project #2")
+	assert.Contains(t, html, "&lt;b&gt;Setup&lt;/b&gt;")
+	assert.Contains(t, html, `<a href="http://localhost:8080/api/v1/tasks/1">`)
+}
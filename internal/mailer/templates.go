@@ -0,0 +1,63 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+const (
+	TemplateNotification = "notification"
+	TemplateDigest       = "digest"
+)
+
+// TemplateItem is one notification listed in an email
+type TemplateItem struct {
+	Message   string
+	URL       string
+	CreatedAt time.Time
+}
+
+// TemplateData is passed to the email templates
+type TemplateData struct {
+	RecipientName string
+	Frequency     string
+	SettingsURL   string
+	Items         []TemplateItem
+}
+
+// Templates renders the plain text and HTML variants of every email
+type Templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+func NewTemplates() (*Templates, error) {
+	text, err := texttemplate.ParseFS(templateFS, "templates/*.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text email templates: %w", err)
+	}
+	html, err := htmltemplate.ParseFS(templateFS, "templates/*.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML email templates: %w", err)
+	}
+	return &Templates{text: text, html: html}, nil
+}
+
+// Render executes the named template, returning its text and HTML bodies.
+func (t *Templates) Render(name string, data TemplateData) (string, string, error) {
+	var text, html bytes.Buffer
+	if err := t.text.ExecuteTemplate(&text, name+".txt.tmpl", data); err != nil {
+		return "", "", fmt.Errorf("failed to render %s text email: %w", name, err)
+	}
+	if err := t.html.ExecuteTemplate(&html, name+".html.tmpl", data); err != nil {
+		return "", "", fmt.Errorf("failed to render %s HTML email: %w", name, err)
+	}
+	return text.String(), html.String(), nil
+}
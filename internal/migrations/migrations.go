@@ -0,0 +1,91 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// sources are hashed to detect migrations edited after they were applied
+//
+//go:embed v*.go
+var sources embed.FS
+
+// Migration is a versioned schema change that can be applied and rolled back
+type Migration struct {
+	Version  int
+	Name     string // from the file name, e.g. "add_table_comment"
+	Checksum string // SHA-256 of the migration's source file
+	Up       func(tx *gorm.DB) error
+	Down     func(tx *gorm.DB) error
+}
+
+// registry lists every migration by its source file. Version 3 was never
+// released and is left out, see v3_add_user_fields.go.
+var registry = []struct {
+	file string
+	up   func(tx *gorm.DB) error
+	down func(tx *gorm.DB) error
+}{
+	{"v1_initial_schema.go", MigrateV1, RollbackV1},
+	{"v2_add_table_comment.go", MigrateV2, RollbackV2},
+	{"v4_add_table_reaction.go", MigrateV4, RollbackV4},
+	{"v5_add_table_notification.go", MigrateV5, RollbackV5},
+	{"v6_add_table_email.go", MigrateV6, RollbackV6},
+	{"v7_add_table_webhook.go", MigrateV7, RollbackV7},
+	{"v8_add_table_outbox.go", MigrateV8, RollbackV8},
+	{"v9_add_table_audit.go", MigrateV9, RollbackV9},
+	{"v10_add_version_columns.go", MigrateV10, RollbackV10},
+	{"v11_add_table_idempotency_key.go", MigrateV11, RollbackV11},
+	{"v12_add_task_labels_and_due_date.go", MigrateV12, RollbackV12},
+	{"v13_add_task_parent.go", MigrateV13, RollbackV13},
+	{"v14_add_table_project_template.go", MigrateV14, RollbackV14},
+	{"v15_add_project_status_index.go", MigrateV15, RollbackV15},
+	{"v16_add_table_sprint.go", MigrateV16, RollbackV16},
+	{"v17_add_table_milestone.go", MigrateV17, RollbackV17},
+	{"v18_add_table_worklog.go", MigrateV18, RollbackV18},
+	{"v19_add_table_timesheet_submission.go", MigrateV19, RollbackV19},
+	{"v20_add_table_custom_field.go", MigrateV20, RollbackV20},
+	{"v21_add_table_checklist_item.go", MigrateV21, RollbackV21},
+	{"v22_add_table_attachment.go", MigrateV22, RollbackV22},
+	{"v23_add_table_task_watcher.go", MigrateV23, RollbackV23},
+}
+
+// All returns the migrations ordered by version.
+func All() ([]Migration, error) {
+	all := make([]Migration, 0, len(registry))
+	for _, entry := range registry {
+		var version int
+		var name string
+		if _, err := fmt.Sscanf(entry.file, "v%d_%s", &version, &name); err != nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.file)
+		}
+
+		source, err := sources.ReadFile(entry.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %v", entry.file, err)
+		}
+		sum := sha256.Sum256(source)
+
+		all = append(all, Migration{
+			Version:  version,
+			Name:     strings.TrimSuffix(name, ".go"),
+			Checksum: hex.EncodeToString(sum[:]),
+			Up:       entry.up,
+			Down:     entry.down,
+		})
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Version < all[j].Version })
+	for i := 1; i < len(all); i++ {
+		if all[i].Version == all[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", all[i].Version)
+		}
+	}
+	return all, nil
+}
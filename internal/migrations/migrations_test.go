@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	all, err := All()
+
+	assert.NoError(t, err)
+	assert.Len(t, all, len(registry))
+	for i, migration := range all {
+		if i > 0 {
+			assert.Greater(t, migration.Version, all[i-1].Version)
+		}
+		assert.NotEmpty(t, migration.Name)
+		assert.Len(t, migration.Checksum, 64)
+		assert.NotNil(t, migration.Up)
+		assert.NotNil(t, migration.Down)
+	}
+	assert.Equal(t, 1, all[0].Version)
+	assert.Equal(t, "initial_schema", all[0].Name)
+}
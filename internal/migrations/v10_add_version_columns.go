@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// versionedModels are the models whose rows carry a version for optimistic locking
+var versionedModels = []interface{}{&models.User{}, &models.Project{}, &models.Task{}, &models.Team{}}
+
+func MigrateV10(tx *gorm.DB) error {
+    for _, model := range versionedModels {
+        if !tx.Migrator().HasColumn(model, "Version") {
+            err := tx.Migrator().AddColumn(model, "Version")
+            if err != nil {
+                return fmt.Errorf("v10 migration failed to add version column: %v", err)
+            }
+        }
+    }
+
+    return nil
+}
+
+func RollbackV10(tx *gorm.DB) error {
+    for _, model := range versionedModels {
+        if tx.Migrator().HasColumn(model, "Version") {
+            err := tx.Migrator().DropColumn(model, "Version")
+            if err != nil {
+                return fmt.Errorf("v10 rollback failed to drop version column: %v", err)
+            }
+        }
+    }
+
+    return nil
+}
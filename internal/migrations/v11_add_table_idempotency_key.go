@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV11(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.IdempotencyKey{}) {
+        err := tx.Migrator().CreateTable(&models.IdempotencyKey{})
+        if err != nil {
+            return fmt.Errorf("v11 migration failed to create idempotency_keys table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV11(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.IdempotencyKey{})
+    if err != nil {
+        return fmt.Errorf("v11 rollback failed to drop idempotency_keys table: %v", err)
+    }
+
+    return nil
+}
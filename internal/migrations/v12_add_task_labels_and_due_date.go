@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// taskColumnsV12 are the task fields added in version 12
+var taskColumnsV12 = []string{"Labels", "DueDate"}
+
+func MigrateV12(tx *gorm.DB) error {
+    for _, column := range taskColumnsV12 {
+        if !tx.Migrator().HasColumn(&models.Task{}, column) {
+            err := tx.Migrator().AddColumn(&models.Task{}, column)
+            if err != nil {
+                return fmt.Errorf("v12 migration failed to add tasks.%s column: %v", column, err)
+            }
+        }
+    }
+
+    return nil
+}
+
+func RollbackV12(tx *gorm.DB) error {
+    for _, column := range taskColumnsV12 {
+        if tx.Migrator().HasColumn(&models.Task{}, column) {
+            err := tx.Migrator().DropColumn(&models.Task{}, column)
+            if err != nil {
+                return fmt.Errorf("v12 rollback failed to drop tasks.%s column: %v", column, err)
+            }
+        }
+    }
+
+    return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV13(tx *gorm.DB) error {
+    if !tx.Migrator().HasColumn(&models.Task{}, "ParentID") {
+        err := tx.Migrator().AddColumn(&models.Task{}, "ParentID")
+        if err != nil {
+            return fmt.Errorf("v13 migration failed to add tasks.parent_id column: %v", err)
+        }
+    }
+    if !tx.Migrator().HasIndex(&models.Task{}, "ParentID") {
+        err := tx.Migrator().CreateIndex(&models.Task{}, "ParentID")
+        if err != nil {
+            return fmt.Errorf("v13 migration failed to index tasks.parent_id: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV13(tx *gorm.DB) error {
+    if tx.Migrator().HasIndex(&models.Task{}, "ParentID") {
+        err := tx.Migrator().DropIndex(&models.Task{}, "ParentID")
+        if err != nil {
+            return fmt.Errorf("v13 rollback failed to drop the tasks.parent_id index: %v", err)
+        }
+    }
+    if tx.Migrator().HasColumn(&models.Task{}, "ParentID") {
+        err := tx.Migrator().DropColumn(&models.Task{}, "ParentID")
+        if err != nil {
+            return fmt.Errorf("v13 rollback failed to drop tasks.parent_id column: %v", err)
+        }
+    }
+
+    return nil
+}
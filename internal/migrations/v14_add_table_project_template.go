@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV14(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.ProjectTemplate{}) {
+        err := tx.Migrator().CreateTable(&models.ProjectTemplate{})
+        if err != nil {
+            return fmt.Errorf("v14 migration failed to create project_templates table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV14(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.ProjectTemplate{})
+    if err != nil {
+        return fmt.Errorf("v14 rollback failed to drop project_templates table: %v", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// projectStatusesV15 are the statuses of the project lifecycle, projects with any
+// other status are active
+var projectStatusesV15 = []string{
+	models.ProjectStatusPlanned,
+	models.ProjectStatusActive,
+	models.ProjectStatusOnHold,
+	models.ProjectStatusCompleted,
+	models.ProjectStatusArchived,
+}
+
+func MigrateV15(tx *gorm.DB) error {
+    err := tx.Unscoped().Model(&models.Project{}).
+        Where("status IS NULL OR status NOT IN ?", projectStatusesV15).
+        Update("status", models.ProjectStatusActive).Error
+    if err != nil {
+        return fmt.Errorf("v15 migration failed to set the project statuses: %v", err)
+    }
+    if !tx.Migrator().HasIndex(&models.Project{}, "Status") {
+        err := tx.Migrator().CreateIndex(&models.Project{}, "Status")
+        if err != nil {
+            return fmt.Errorf("v15 migration failed to index projects.status: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV15(tx *gorm.DB) error {
+    if tx.Migrator().HasIndex(&models.Project{}, "Status") {
+        err := tx.Migrator().DropIndex(&models.Project{}, "Status")
+        if err != nil {
+            return fmt.Errorf("v15 rollback failed to drop the projects.status index: %v", err)
+        }
+    }
+
+    return nil
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV16(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.Sprint{}) {
+        err := tx.Migrator().CreateTable(&models.Sprint{})
+        if err != nil {
+            return fmt.Errorf("v16 migration failed to create sprints table: %v", err)
+        }
+    }
+    if !tx.Migrator().HasColumn(&models.Task{}, "SprintID") {
+        err := tx.Migrator().AddColumn(&models.Task{}, "SprintID")
+        if err != nil {
+            return fmt.Errorf("v16 migration failed to add tasks.sprint_id column: %v", err)
+        }
+    }
+    if !tx.Migrator().HasIndex(&models.Task{}, "SprintID") {
+        err := tx.Migrator().CreateIndex(&models.Task{}, "SprintID")
+        if err != nil {
+            return fmt.Errorf("v16 migration failed to index tasks.sprint_id: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV16(tx *gorm.DB) error {
+    if tx.Migrator().HasIndex(&models.Task{}, "SprintID") {
+        err := tx.Migrator().DropIndex(&models.Task{}, "SprintID")
+        if err != nil {
+            return fmt.Errorf("v16 rollback failed to drop the tasks.sprint_id index: %v", err)
+        }
+    }
+    if tx.Migrator().HasColumn(&models.Task{}, "SprintID") {
+        err := tx.Migrator().DropColumn(&models.Task{}, "SprintID")
+        if err != nil {
+            return fmt.Errorf("v16 rollback failed to drop tasks.sprint_id column: %v", err)
+        }
+    }
+    err := tx.Migrator().DropTable(&models.Sprint{})
+    if err != nil {
+        return fmt.Errorf("v16 rollback failed to drop sprints table: %v", err)
+    }
+
+    return nil
+}
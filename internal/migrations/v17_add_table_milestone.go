@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// taskColumnsV17 are the task fields added in version 17
+var taskColumnsV17 = []string{"RemainingEstimate", "MilestoneID"}
+
+func MigrateV17(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.Milestone{}) {
+        err := tx.Migrator().CreateTable(&models.Milestone{})
+        if err != nil {
+            return fmt.Errorf("v17 migration failed to create milestones table: %v", err)
+        }
+    }
+    for _, column := range taskColumnsV17 {
+        if !tx.Migrator().HasColumn(&models.Task{}, column) {
+            err := tx.Migrator().AddColumn(&models.Task{}, column)
+            if err != nil {
+                return fmt.Errorf("v17 migration failed to add tasks.%s column: %v", column, err)
+            }
+        }
+    }
+    if !tx.Migrator().HasIndex(&models.Task{}, "MilestoneID") {
+        err := tx.Migrator().CreateIndex(&models.Task{}, "MilestoneID")
+        if err != nil {
+            return fmt.Errorf("v17 migration failed to index tasks.milestone_id: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV17(tx *gorm.DB) error {
+    if tx.Migrator().HasIndex(&models.Task{}, "MilestoneID") {
+        err := tx.Migrator().DropIndex(&models.Task{}, "MilestoneID")
+        if err != nil {
+            return fmt.Errorf("v17 rollback failed to drop the tasks.milestone_id index: %v", err)
+        }
+    }
+    for _, column := range taskColumnsV17 {
+        if tx.Migrator().HasColumn(&models.Task{}, column) {
+            err := tx.Migrator().DropColumn(&models.Task{}, column)
+            if err != nil {
+                return fmt.Errorf("v17 rollback failed to drop tasks.%s column: %v", column, err)
+            }
+        }
+    }
+    err := tx.Migrator().DropTable(&models.Milestone{})
+    if err != nil {
+        return fmt.Errorf("v17 rollback failed to drop milestones table: %v", err)
+    }
+
+    return nil
+}
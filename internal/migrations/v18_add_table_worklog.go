@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV18(tx *gorm.DB) error {
+    if !tx.Migrator().HasColumn(&models.Task{}, "OriginalEstimate") {
+        err := tx.Migrator().AddColumn(&models.Task{}, "OriginalEstimate")
+        if err != nil {
+            return fmt.Errorf("v18 migration failed to add tasks.original_estimate column: %v", err)
+        }
+    }
+    if !tx.Migrator().HasTable(&models.Worklog{}) {
+        err := tx.Migrator().CreateTable(&models.Worklog{})
+        if err != nil {
+            return fmt.Errorf("v18 migration failed to create worklogs table: %v", err)
+        }
+    }
+    if !tx.Migrator().HasTable(&models.Timer{}) {
+        err := tx.Migrator().CreateTable(&models.Timer{})
+        if err != nil {
+            return fmt.Errorf("v18 migration failed to create timers table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV18(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.Timer{}, &models.Worklog{})
+    if err != nil {
+        return fmt.Errorf("v18 rollback failed to drop the worklogs and timers tables: %v", err)
+    }
+    if tx.Migrator().HasColumn(&models.Task{}, "OriginalEstimate") {
+        err := tx.Migrator().DropColumn(&models.Task{}, "OriginalEstimate")
+        if err != nil {
+            return fmt.Errorf("v18 rollback failed to drop tasks.original_estimate column: %v", err)
+        }
+    }
+
+    return nil
+}
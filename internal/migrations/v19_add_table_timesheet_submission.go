@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV19(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.TimesheetSubmission{}) {
+        err := tx.Migrator().CreateTable(&models.TimesheetSubmission{})
+        if err != nil {
+            return fmt.Errorf("v19 migration failed to create timesheet_submissions table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV19(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.TimesheetSubmission{})
+    if err != nil {
+        return fmt.Errorf("v19 rollback failed to drop timesheet_submissions table: %v", err)
+    }
+
+    return nil
+}
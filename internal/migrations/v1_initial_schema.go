@@ -20,3 +20,12 @@ func MigrateV1(tx *gorm.DB) error {
 
     return nil
 }
+
+func RollbackV1(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable("user_teams", "user_projects", &models.Team{}, &models.Task{}, &models.Project{}, &models.User{})
+    if err != nil {
+        return fmt.Errorf("v1 rollback failed: %v", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV20(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.CustomField{}) {
+        err := tx.Migrator().CreateTable(&models.CustomField{})
+        if err != nil {
+            return fmt.Errorf("v20 migration failed to create custom_fields table: %v", err)
+        }
+    }
+    if !tx.Migrator().HasTable(&models.TaskFieldValue{}) {
+        err := tx.Migrator().CreateTable(&models.TaskFieldValue{})
+        if err != nil {
+            return fmt.Errorf("v20 migration failed to create task_field_values table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV20(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.TaskFieldValue{}, &models.CustomField{})
+    if err != nil {
+        return fmt.Errorf("v20 rollback failed to drop the custom_fields and task_field_values tables: %v", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV21(tx *gorm.DB) error {
+    if !tx.Migrator().HasColumn(&models.Project{}, "RequireChecklist") {
+        err := tx.Migrator().AddColumn(&models.Project{}, "RequireChecklist")
+        if err != nil {
+            return fmt.Errorf("v21 migration failed to add projects.require_checklist column: %v", err)
+        }
+    }
+    if !tx.Migrator().HasTable(&models.ChecklistItem{}) {
+        err := tx.Migrator().CreateTable(&models.ChecklistItem{})
+        if err != nil {
+            return fmt.Errorf("v21 migration failed to create checklist_items table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV21(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.ChecklistItem{})
+    if err != nil {
+        return fmt.Errorf("v21 rollback failed to drop checklist_items table: %v", err)
+    }
+    if tx.Migrator().HasColumn(&models.Project{}, "RequireChecklist") {
+        err := tx.Migrator().DropColumn(&models.Project{}, "RequireChecklist")
+        if err != nil {
+            return fmt.Errorf("v21 rollback failed to drop projects.require_checklist column: %v", err)
+        }
+    }
+
+    return nil
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV22(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.Attachment{}) {
+        err := tx.Migrator().CreateTable(&models.Attachment{})
+        if err != nil {
+            return fmt.Errorf("v22 migration failed to create attachments table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV22(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.Attachment{})
+    if err != nil {
+        return fmt.Errorf("v22 rollback failed to drop attachments table: %v", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV23(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.TaskWatcher{}) {
+        err := tx.Migrator().CreateTable(&models.TaskWatcher{})
+        if err != nil {
+            return fmt.Errorf("v23 migration failed to create task_watchers table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV23(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.TaskWatcher{})
+    if err != nil {
+        return fmt.Errorf("v23 rollback failed to drop task_watchers table: %v", err)
+    }
+
+    return nil
+}
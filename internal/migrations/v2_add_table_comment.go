@@ -17,3 +17,12 @@ func MigrateV2(tx *gorm.DB) error {
 
     return nil
 }
+
+func RollbackV2(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.Comment{})
+    if err != nil {
+        return fmt.Errorf("v2 rollback failed to drop comments table: %v", err)
+    }
+
+    return nil
+}
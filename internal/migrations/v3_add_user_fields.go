@@ -7,6 +7,11 @@ import (
 	"gorm.io/gorm"
 )
 
+// MigrateV3 was never released and is not registered in migrations.go, the
+// relations it adds columns for are join tables created by v1. It is kept so the
+// version stays taken.
+//
+// Deprecated: do not register it, version 3 is skipped.
 func MigrateV3(tx *gorm.DB) error {
     if !tx.Migrator().HasTable(&models.Task{}) {
         err := tx.Migrator().CreateTable(&models.Task{})
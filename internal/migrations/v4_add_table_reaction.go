@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV4(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.Reaction{}) {
+        err := tx.Migrator().CreateTable(&models.Reaction{})
+        if err != nil {
+            return fmt.Errorf("v4 migration failed to create reactions table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV4(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.Reaction{})
+    if err != nil {
+        return fmt.Errorf("v4 rollback failed to drop reactions table: %v", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV5(tx *gorm.DB) error {
+    if !tx.Migrator().HasColumn(&models.Task{}, "Status") {
+        err := tx.Migrator().AddColumn(&models.Task{}, "Status")
+        if err != nil {
+            return fmt.Errorf("v5 migration failed to add status column for tasks: %v", err)
+        }
+    }
+
+    if !tx.Migrator().HasTable(&models.Notification{}) {
+        err := tx.Migrator().CreateTable(&models.Notification{})
+        if err != nil {
+            return fmt.Errorf("v5 migration failed to create notifications table: %v", err)
+        }
+    }
+
+    if !tx.Migrator().HasTable(&models.NotificationPreference{}) {
+        err := tx.Migrator().CreateTable(&models.NotificationPreference{})
+        if err != nil {
+            return fmt.Errorf("v5 migration failed to create notification_preferences table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV5(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.NotificationPreference{}, &models.Notification{})
+    if err != nil {
+        return fmt.Errorf("v5 rollback failed to drop notification tables: %v", err)
+    }
+
+    if tx.Migrator().HasColumn(&models.Task{}, "Status") {
+        err := tx.Migrator().DropColumn(&models.Task{}, "Status")
+        if err != nil {
+            return fmt.Errorf("v5 rollback failed to drop status column for tasks: %v", err)
+        }
+    }
+
+    return nil
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV6(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.EmailPreference{}) {
+        err := tx.Migrator().CreateTable(&models.EmailPreference{})
+        if err != nil {
+            return fmt.Errorf("v6 migration failed to create email_preferences table: %v", err)
+        }
+    }
+
+    if !tx.Migrator().HasTable(&models.EmailDigestItem{}) {
+        err := tx.Migrator().CreateTable(&models.EmailDigestItem{})
+        if err != nil {
+            return fmt.Errorf("v6 migration failed to create email_digest_items table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV6(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.EmailDigestItem{}, &models.EmailPreference{})
+    if err != nil {
+        return fmt.Errorf("v6 rollback failed to drop email tables: %v", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV7(tx *gorm.DB) error {
+    if !tx.Migrator().HasColumn(&models.Project{}, "OwnerID") {
+        err := tx.Migrator().AddColumn(&models.Project{}, "OwnerID")
+        if err != nil {
+            return fmt.Errorf("v7 migration failed to add owner_id column for projects: %v", err)
+        }
+    }
+
+    if !tx.Migrator().HasTable(&models.Webhook{}) {
+        err := tx.Migrator().CreateTable(&models.Webhook{})
+        if err != nil {
+            return fmt.Errorf("v7 migration failed to create webhooks table: %v", err)
+        }
+    }
+
+    if !tx.Migrator().HasTable(&models.WebhookDelivery{}) {
+        err := tx.Migrator().CreateTable(&models.WebhookDelivery{})
+        if err != nil {
+            return fmt.Errorf("v7 migration failed to create webhook_deliveries table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV7(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.WebhookDelivery{}, &models.Webhook{})
+    if err != nil {
+        return fmt.Errorf("v7 rollback failed to drop webhook tables: %v", err)
+    }
+
+    if tx.Migrator().HasColumn(&models.Project{}, "OwnerID") {
+        err := tx.Migrator().DropColumn(&models.Project{}, "OwnerID")
+        if err != nil {
+            return fmt.Errorf("v7 rollback failed to drop owner_id column for projects: %v", err)
+        }
+    }
+
+    return nil
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func MigrateV8(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.OutboxEvent{}) {
+        err := tx.Migrator().CreateTable(&models.OutboxEvent{})
+        if err != nil {
+            return fmt.Errorf("v8 migration failed to create outbox_events table: %v", err)
+        }
+    }
+
+    if !tx.Migrator().HasTable(&models.OutboxCheckpoint{}) {
+        err := tx.Migrator().CreateTable(&models.OutboxCheckpoint{})
+        if err != nil {
+            return fmt.Errorf("v8 migration failed to create outbox_checkpoints table: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV8(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.OutboxCheckpoint{}, &models.OutboxEvent{})
+    if err != nil {
+        return fmt.Errorf("v8 rollback failed to drop outbox tables: %v", err)
+    }
+
+    return nil
+}
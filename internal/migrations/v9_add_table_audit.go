@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// auditImmutableTrigger rejects updates and deletes of audit entries on PostgreSQL
+const auditImmutableTrigger = `
+CREATE OR REPLACE FUNCTION audit_entries_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit entries are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_entries_immutable ON audit_entries;
+CREATE TRIGGER audit_entries_immutable BEFORE UPDATE OR DELETE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION audit_entries_immutable();
+`
+
+func MigrateV9(tx *gorm.DB) error {
+    if !tx.Migrator().HasTable(&models.AuditEntry{}) {
+        err := tx.Migrator().CreateTable(&models.AuditEntry{})
+        if err != nil {
+            return fmt.Errorf("v9 migration failed to create audit_entries table: %v", err)
+        }
+    }
+
+    if tx.Dialector.Name() == "postgres" {
+        if err := tx.Exec(auditImmutableTrigger).Error; err != nil {
+            return fmt.Errorf("v9 migration failed to create audit_entries trigger: %v", err)
+        }
+    }
+
+    return nil
+}
+
+func RollbackV9(tx *gorm.DB) error {
+    err := tx.Migrator().DropTable(&models.AuditEntry{})
+    if err != nil {
+        return fmt.Errorf("v9 rollback failed to drop audit_entries table: %v", err)
+    }
+
+    if tx.Dialector.Name() == "postgres" {
+        if err := tx.Exec("DROP FUNCTION IF EXISTS audit_entries_immutable()").Error; err != nil {
+            return fmt.Errorf("v9 rollback failed to drop audit_entries trigger: %v", err)
+        }
+    }
+
+    return nil
+}
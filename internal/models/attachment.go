@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Attachment Model (Many-to-One with Task, Comment), a file uploaded to a task or
+// to one of its comments. Its content is kept in the blob store under StorageKey.
+type Attachment struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	TaskID    uint      `json:"task_id" gorm:"not null;index"`
+	Task      Task      `json:"-" gorm:"foreignKey:TaskID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	// Set when the file is attached to a comment of the task
+	CommentID   *uint    `json:"comment_id,omitempty" gorm:"index"`
+	Comment     *Comment `json:"-" gorm:"foreignKey:CommentID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	Filename    string   `json:"filename" gorm:"not null"`
+	Size        int64    `json:"size"` // bytes
+	ContentType string   `json:"content_type"`
+	// Hex encoded SHA-256 of the content
+	Checksum   string `json:"checksum"`
+	StorageKey string `json:"-" gorm:"not null"`
+	UploadedBy uint   `json:"uploaded_by" gorm:"not null"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+const (
+	AuditActionCreate        = "create"
+	AuditActionUpdate        = "update"
+	AuditActionDelete        = "delete"
+	AuditActionRestore       = "restore"
+	AuditActionPurge         = "purge"
+	AuditActionMemberAdded   = "member_added"
+	AuditActionMemberRemoved = "member_removed"
+	AuditActionMove          = "move" // a task moved to another project
+	AuditActionCopy          = "copy" // a task copied from another, recorded for the copy
+)
+
+const (
+	AuditEntityUser        = "user"
+	AuditEntityProject     = "project"
+	AuditEntityTask        = "task"
+	AuditEntityTeam        = "team"
+	AuditEntityComment     = "comment"
+	AuditEntityWebhook     = "webhook"
+	AuditEntitySprint      = "sprint"
+	AuditEntityMilestone   = "milestone"
+	AuditEntityWorklog     = "worklog"
+	AuditEntityTimesheet   = "timesheet"
+	AuditEntityCustomField = "custom_field"
+	AuditEntityChecklist   = "checklist_item"
+	AuditEntityAttachment  = "attachment"
+)
+
+// FieldChange is the value of a field before and after a change, null when the
+// entity did not exist before a create or after a delete
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEntry Model, an append-only record of a change made to an entity
+type AuditEntry struct {
+	ID         uint                   `json:"id" gorm:"primaryKey"`
+	CreatedAt  time.Time              `json:"created_at" gorm:"index"`
+	ActorID    uint                   `json:"actor_id" gorm:"index"` // 0 for changes made by the system
+	Action     string                 `json:"action" gorm:"size:32;not null"`
+	EntityType string                 `json:"entity_type" gorm:"size:32;not null;index:idx_audit_entries_entity"`
+	EntityID   uint                   `json:"entity_id" gorm:"not null;index:idx_audit_entries_entity"`
+	Changes    map[string]FieldChange `json:"changes" gorm:"serializer:json;type:text"`
+	RequestID  string                 `json:"request_id" gorm:"size:128"`
+	IP         string                 `json:"ip" gorm:"size:64"`
+}
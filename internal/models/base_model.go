@@ -2,11 +2,23 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// BaseModel is embedded by soft deleted models, deleted rows are excluded from
+// queries unless they are Unscoped. Version counts the updates of a row, changes
+// are only saved when the row is still at the version they were based on.
 type BaseModel struct {
-	ID        uint       `json:"id" gorm:"primaryKey"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggertype:"string" format:"date-time"`
+	Version   uint           `json:"version" gorm:"not null;default:1"`
+}
+
+// BeforeCreate starts every row at version 1
+func (m *BaseModel) BeforeCreate(tx *gorm.DB) error {
+	m.Version = 1
+	return nil
 }
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ChecklistItem Model (Many-to-One with Task), a small acceptance item of a task
+type ChecklistItem struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	TaskID    uint      `json:"task_id" gorm:"not null;index"`
+	Task      Task      `json:"-" gorm:"foreignKey:TaskID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	Text      string    `json:"text" gorm:"not null"`
+	Done      bool      `json:"done"`
+	// User who checked the item and when, unset while it is unchecked
+	DoneBy *uint      `json:"done_by,omitempty"`
+	DoneAt *time.Time `json:"done_at,omitempty"`
+	// Order of the item in the checklist, from 1
+	Position int `json:"position"`
+}
+
+// ChecklistProgress counts the checked items of the checklist of a task
+type ChecklistProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
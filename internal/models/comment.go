@@ -2,18 +2,24 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Comment Model (Many-to-One with Task, User)
 type Comment struct {
-	ID        uint       `json:"id" gorm:"primaryKey"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
-	Content   string     `json:"content" gorm:"not null"`
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggertype:"string" format:"date-time"`
+	Content   string     `json:"content" gorm:"not null"` // CommonMark source
+	// Sanitized HTML rendering of Content, only set when requested with ?render=html
+	ContentHTML string   `json:"content_html,omitempty" gorm:"-"`
 	TaskID    uint       `json:"task_id"`
 	Task      Task       `json:"task" gorm:"foreignKey:TaskID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
 	UserID    uint       `json:"user_id"`
 	User      User       `json:"user" gorm:"foreignKey:UserID;constraint:onUpdate:CASCADE,onDelete:SET NULL;"`
+	// Aggregated emoji reactions, filled by the repository
+	Reactions []ReactionCount `json:"reactions,omitempty" gorm:"-"`
 
 }
\ No newline at end of file
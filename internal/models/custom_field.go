@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Types of custom fields
+const (
+	CustomFieldTypeText        = "text"
+	CustomFieldTypeNumber      = "number"
+	CustomFieldTypeDate        = "date"
+	CustomFieldTypeSelect      = "select"
+	CustomFieldTypeMultiSelect = "multi_select"
+	CustomFieldTypeUser        = "user"
+)
+
+// CustomField Model (Many-to-One with Project), a typed field the owner of a project
+// adds to its tasks. Tasks hold their values by Key.
+type CustomField struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ProjectID uint      `json:"project_id" gorm:"not null;uniqueIndex:idx_custom_fields_project_key"`
+	// Lowercase letters, digits and underscores, used in task values, filters and sorting
+	Key  string `json:"key" gorm:"column:field_key;not null;uniqueIndex:idx_custom_fields_project_key" example:"severity"`
+	Name string `json:"name" gorm:"not null"`
+	Type string `json:"type" gorm:"not null" enums:"text,number,date,select,multi_select,user"`
+	// Choices of select and multi_select fields
+	Options  []string `json:"options,omitempty" gorm:"serializer:json"`
+	Required bool     `json:"required"`
+	// Value of new tasks that set none, of the type of the field
+	Default  interface{} `json:"default,omitempty" gorm:"serializer:json" swaggertype:"object"`
+	Position int         `json:"position"`
+}
+
+// TaskFieldValue Model, the value of a custom field of a task in the column of its
+// type. A multi_select value takes a row per option.
+type TaskFieldValue struct {
+	ID      uint        `gorm:"primaryKey"`
+	TaskID  uint        `gorm:"not null;index"`
+	Task    Task        `gorm:"foreignKey:TaskID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	FieldID uint        `gorm:"not null;index"`
+	Field   CustomField `gorm:"foreignKey:FieldID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	// text, select and multi_select values
+	TextValue *string
+	// number values and the IDs of user values
+	NumberValue *float64
+	// date values, at midnight UTC
+	DateValue *time.Time
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	EmailFrequencyImmediate = "immediate"
+	EmailFrequencyHourly    = "hourly"
+	EmailFrequencyDaily     = "daily"
+	EmailFrequencyOff       = "off"
+)
+
+// EmailPreference stores how often a user receives notifications by email.
+// Users without a preference receive them immediately.
+type EmailPreference struct {
+	ID        uint   `json:"-" gorm:"primaryKey"`
+	UserID    uint   `json:"user_id" gorm:"not null;uniqueIndex"`
+	User      User   `json:"-" gorm:"foreignKey:UserID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	Frequency string `json:"frequency" gorm:"size:16;not null" example:"hourly"`
+}
+
+// EmailDigestItem is a notification waiting for the next digest email of its user
+type EmailDigestItem struct {
+	ID             uint         `gorm:"primaryKey"`
+	CreatedAt      time.Time
+	UserID         uint         `gorm:"not null;index"`
+	User           User         `gorm:"foreignKey:UserID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	NotificationID uint         `gorm:"not null"`
+	Notification   Notification `gorm:"foreignKey:NotificationID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+}
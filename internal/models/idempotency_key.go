@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// IdempotencyKey Model, the response to a create request sent with an
+// Idempotency-Key header. Retries of the request get the stored response instead
+// of creating a duplicate. StatusCode is 0 while the first request is processed.
+type IdempotencyKey struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	Key         string `gorm:"column:idempotency_key;size:255;not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	RequestHash string `gorm:"size:64;not null"` // SHA-256 of the method, path and body
+	StatusCode  int    `gorm:"not null;default:0"`
+	ContentType string `gorm:"size:255"`
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"index"`
+}
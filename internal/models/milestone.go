@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Milestone Model, a release of a project that tasks are needed for by its target date
+type Milestone struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	ProjectID   uint      `json:"project_id" gorm:"not null;index"`
+	Name        string    `json:"name" gorm:"not null"`
+	Description string    `json:"description"`
+	TargetDate  time.Time `json:"target_date"`
+	// Hours per working day the team spends on the milestone, 8 for each project
+	// member and assignee when 0
+	DailyCapacity float64            `json:"daily_capacity"`
+	Progress      *MilestoneProgress `json:"progress,omitempty" gorm:"-"`
+	Tasks         []Task             `json:"tasks,omitempty" gorm:"foreignKey:MilestoneID"`
+}
+
+// MilestoneProgress is how far the tasks of a milestone are, computed when it is read
+type MilestoneProgress struct {
+	TotalTasks int     `json:"total_tasks"`
+	DoneTasks  int     `json:"done_tasks"`
+	Percent    float64 `json:"percent"` // done tasks of all tasks
+	// Hours of work left on the tasks that are not done
+	RemainingEstimate float64 `json:"remaining_estimate"`
+	// Hours the team can spend on the milestone in the working days up to its target date
+	Capacity float64 `json:"capacity"`
+	// The remaining estimate exceeds the capacity
+	AtRisk bool `json:"at_risk"`
+}
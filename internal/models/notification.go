@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Notification Model (Many-to-One with User), an entry of a user's inbox
+type Notification struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time  `json:"created_at"`
+	UserID    uint       `json:"user_id" gorm:"not null;index:idx_notifications_user_read"`
+	User      User       `json:"-" gorm:"foreignKey:UserID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	Type      string     `json:"type" gorm:"size:64;not null"`
+	ActorID   uint       `json:"actor_id"`
+	ProjectID uint       `json:"project_id"`
+	TaskID    uint       `json:"task_id"`
+	CommentID uint       `json:"comment_id"`
+	Message   string     `json:"message"`
+	ReadAt    *time.Time `json:"read_at" gorm:"index:idx_notifications_user_read"`
+}
+
+// NotificationPreference stores whether a user receives an event type.
+// Users are subscribed to every type unless a preference disables it.
+type NotificationPreference struct {
+	ID        uint   `json:"-" gorm:"primaryKey"`
+	UserID    uint   `json:"user_id" gorm:"not null;uniqueIndex:idx_notification_preferences_user_type"`
+	User      User   `json:"-" gorm:"foreignKey:UserID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	EventType string `json:"event_type" gorm:"size:64;not null;uniqueIndex:idx_notification_preferences_user_type"`
+	Enabled   bool   `json:"enabled"`
+}
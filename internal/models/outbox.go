@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// OutboxEvent is a domain event written in the same transaction as the change it describes
+type OutboxEvent struct {
+	ID        uint      `gorm:"primaryKey"`
+	CreatedAt time.Time
+	Type      string    `gorm:"size:64;not null"`
+	Payload   string    `gorm:"type:text;not null"` // JSON encoded events.Event
+}
+
+// OutboxCheckpoint is the last outbox event a subscriber has handled
+type OutboxCheckpoint struct {
+	Subscriber  string    `gorm:"primaryKey;size:64"`
+	LastEventID uint      `gorm:"not null"`
+	UpdatedAt   time.Time
+}
@@ -4,14 +4,30 @@ import (
 	"time"
 )
 
+// Lifecycle of a project. Archived projects are read-only and hidden from the
+// project list unless asked for.
+const (
+	ProjectStatusPlanned   = "planned"
+	ProjectStatusActive    = "active"
+	ProjectStatusOnHold    = "on_hold"
+	ProjectStatusCompleted = "completed"
+	ProjectStatusArchived  = "archived"
+)
+
 // Project Model (Many-to-Many with User, One-to-Many with Task, Team)
 type Project struct {
 	BaseModel
 	Name        string    `json:"name" gorm:"not null"`
-	Description string    `json:"description"`
+	Description string    `json:"description"` // CommonMark source
+	// Sanitized HTML rendering of Description, only set when requested with ?render=html
+	DescriptionHTML string `json:"description_html,omitempty" gorm:"-"`
 	StartDate   time.Time `json:"start_date"`
 	EndDate     time.Time `json:"end_date"`
-	Status      string    `json:"status"`
+	Status      string    `json:"status" gorm:"index" enums:"planned,active,on_hold,completed,archived"`
+	// User who created the project, allowed to manage its webhooks
+	OwnerID     uint      `json:"owner_id,omitempty"`
+	// Tasks cannot be done while their checklist has unchecked items
+	RequireChecklist bool `json:"require_checklist,omitempty"`
 	UserIDs		[]uint	  `json:"user_ids" gorm:"-"`
 	Users       []User    `json:"users" gorm:"many2many:user_projects;"`
 	// One-to-Many with Tasks
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// ProjectTemplate Model, the outline of a project that new projects are created
+// from. Dates are days relative to the start date of the new project.
+type ProjectTemplate struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name" gorm:"not null"`
+	Description string    `json:"description"`
+	OwnerID     uint      `json:"owner_id" gorm:"index"` // user who created the template
+	// Days from the start to the end of a project, no end date when null
+	EndOffsetDays *int `json:"end_offset_days"`
+	// Task statuses the project works through in order, new tasks start in the first
+	Workflow []string       `json:"workflow" gorm:"serializer:json;type:text"`
+	Teams    []TemplateTeam `json:"teams" gorm:"serializer:json;type:text"`
+	Tasks    []TemplateTask `json:"tasks" gorm:"serializer:json;type:text"`
+}
+
+// TemplateTeam is a team created with every project of a template
+type TemplateTeam struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TemplateTask is the skeleton of a task created with every project of a template,
+// due DueOffsetDays after the project starts. Subtasks have no subtasks.
+type TemplateTask struct {
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	Status        string         `json:"status"`
+	Labels        []string       `json:"labels,omitempty"`
+	DueOffsetDays *int           `json:"due_offset_days,omitempty"`
+	Subtasks      []TemplateTask `json:"subtasks,omitempty"`
+}
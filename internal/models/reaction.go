@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+const (
+	ReactionTargetTask    = "task"
+	ReactionTargetComment = "comment"
+)
+
+// Reaction Model (Many-to-One with User, and with a Task or Comment as target)
+type Reaction struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt  time.Time `json:"created_at"`
+	UserID     uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_reactions_user_emoji_target"`
+	User       User      `json:"-" gorm:"foreignKey:UserID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	Emoji      string    `json:"emoji" gorm:"size:32;not null;uniqueIndex:idx_reactions_user_emoji_target"`
+	TargetType string    `json:"target_type" gorm:"size:16;not null;uniqueIndex:idx_reactions_user_emoji_target;index:idx_reactions_target"`
+	TargetID   uint      `json:"target_id" gorm:"not null;uniqueIndex:idx_reactions_user_emoji_target;index:idx_reactions_target"`
+}
+
+// ReactionCount is the number of reactions with the same emoji on a single target
+type ReactionCount struct {
+	Emoji string `json:"emoji"`
+	Count int64  `json:"count"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+const (
+	SprintStatePlanned = "planned"
+	SprintStateActive  = "active"
+	SprintStateClosed  = "closed"
+)
+
+// Sprint Model, a time-box of a project that tasks are planned in. A project has
+// at most one active sprint.
+type Sprint struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ProjectID uint      `json:"project_id" gorm:"not null;index"`
+	Name      string    `json:"name" gorm:"not null"`
+	Goal      string    `json:"goal"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"` // last day of the sprint
+	State     string    `json:"state" gorm:"size:16;not null" enums:"planned,active,closed"`
+	// When the sprint was closed, its unfinished tasks were carried over then
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+	Tasks    []Task     `json:"tasks,omitempty" gorm:"foreignKey:SprintID"`
+}
+
+// Burndown is the work left in a sprint at the end of each of its days, up to today
+type Burndown struct {
+	SprintID uint            `json:"sprint_id"`
+	Unit     string          `json:"unit"` // what the work is counted in, tasks
+	Points   []BurndownPoint `json:"points"`
+}
+
+// BurndownPoint is the work of a sprint at the end of a day, or at the time the
+// sprint was closed on its last day
+type BurndownPoint struct {
+	Date      string `json:"date"`      // YYYY-MM-DD, in UTC
+	Total     int    `json:"total"`     // tasks in the sprint
+	Remaining int    `json:"remaining"` // tasks in the sprint that are not done
+	// Remaining work on a straight line from the first day to none on the last
+	Ideal float64 `json:"ideal"`
+}
@@ -1,11 +1,39 @@
 package models
 
+import "time"
+
+const (
+	TaskStatusTodo       = "todo"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+)
+
 type Task struct {
 	BaseModel
 	Title       string  `json:"title"`
-	Description string  `json:"description"`
+	Description string  `json:"description"` // CommonMark source
+	// Sanitized HTML rendering of Description, only set when requested with ?render=html
+	DescriptionHTML string `json:"description_html,omitempty" gorm:"-"`
+	Status      string  `json:"status"` // todo, in_progress or done
 	ProjectID   uint    `json:"project_id"` // Many-to-One với Project
 	Project     Project `gorm:"foreignKey:ProjectID" json:"project"`
 	AssignedTo  uint    `json:"assigned_to"` // Many-to-One với User
 	Assignee    User    `gorm:"foreignKey:AssignedTo" json:"assignee"`
+	Labels      []string   `json:"labels,omitempty" gorm:"serializer:json"` // stored as a JSON array
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	// Hours of work the task was estimated at, and hours of work left on it
+	OriginalEstimate  float64 `json:"original_estimate,omitempty"`
+	RemainingEstimate float64 `json:"remaining_estimate,omitempty"`
+	// Task this is a subtask of, in the same project. Subtasks have no subtasks.
+	ParentID    *uint      `json:"parent_id,omitempty" gorm:"index"`
+	// Sprint of the project the task is planned in, in the backlog when null
+	SprintID    *uint      `json:"sprint_id,omitempty" gorm:"index"`
+	// Milestone of the project the task is needed for
+	MilestoneID *uint      `json:"milestone_id,omitempty" gorm:"index"`
+	// Values of the custom fields of the project by their key, filled by the repository
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty" gorm:"-"`
+	// Checked and total items of the checklist of the task, filled by the repository
+	Checklist *ChecklistProgress `json:"checklist,omitempty" gorm:"-"`
+	// Aggregated emoji reactions, filled by the repository
+	Reactions   []ReactionCount `json:"reactions,omitempty" gorm:"-"`
 }
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// TrashItem is a soft deleted entity that can still be restored until PurgeAt
+type TrashItem struct {
+	EntityType string    `json:"entity_type"` // user, project, task, team or comment
+	EntityID   uint      `json:"entity_id"`
+	Name       string    `json:"name"` // name, title, username or the start of the content
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAt    time.Time `json:"purge_at" gorm:"-"`
+}
@@ -2,14 +2,17 @@ package models
 
 // internal/models/user.go
 
+// UserRoleAdmin is the role of users allowed to administer the system, e.g. read the audit log
+const UserRoleAdmin = "admin"
+
 // User represents a user in the system
 // @Description User model with basic information and relationships
 type User struct {
 	BaseModel
 	// @Description Unique username for the user
-	Username    string `json:"username" gorm:"unique;not null"`
+	Username    string `json:"username" gorm:"size:255;unique;not null"`
 	// @Description Unique email address of the user
-	Email       string `json:"email" gorm:"unique;not null"`
+	Email       string `json:"email" gorm:"size:255;unique;not null"`
 	Password    string `json:"-" gorm:"not null"`
 	// @Description User's first name
 	FirstName   string `json:"first_name" `
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// TaskWatcher Model (Many-to-Many between Task and User), a user notified about the
+// changes of a task
+type TaskWatcher struct {
+	TaskID    uint      `json:"task_id" gorm:"primaryKey;autoIncrement:false"`
+	Task      Task      `json:"-" gorm:"foreignKey:TaskID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	UserID    uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false;index"`
+	User      User      `json:"-" gorm:"foreignKey:UserID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook Model (Many-to-One with Project), an endpoint receiving signed project events
+type Webhook struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ProjectID uint      `json:"project_id" gorm:"not null;index"`
+	Project   Project   `json:"-" gorm:"foreignKey:ProjectID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	URL       string    `json:"url" gorm:"size:2048;not null"`
+	// Shared secret for the X-Webhook-Signature header, only returned when the webhook is created
+	Secret string `json:"secret,omitempty" gorm:"not null"`
+	// Event types sent to the endpoint, every webhook event type when empty
+	Events []string `json:"events" gorm:"serializer:json"`
+	Active bool     `json:"active"`
+	// Failed deliveries since the last successful one, the webhook is disabled after too many
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at"`
+}
+
+// WebhookDelivery Model (Many-to-One with Webhook), one event sent to a webhook and its outcome
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	CreatedAt      time.Time  `json:"created_at"`
+	WebhookID      uint       `json:"webhook_id" gorm:"not null;index"`
+	Webhook        Webhook    `json:"-" gorm:"foreignKey:WebhookID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	EventType      string     `json:"event_type" gorm:"size:64;not null"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"size:16;not null"` // pending, succeeded or failed
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status"`
+	ResponseBody   string     `json:"response_body"`
+	Error          string     `json:"error"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at" gorm:"index"`
+	CompletedAt    *time.Time `json:"completed_at"`
+}
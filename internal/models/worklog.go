@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+const (
+	TimesheetGroupDay  = "day"
+	TimesheetGroupWeek = "week"
+)
+
+// Worklog Model (Many-to-One with Task), time a user spent on a task. Worklogs of
+// a user do not overlap.
+type Worklog struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UserID          uint      `json:"user_id" gorm:"not null;index:idx_worklogs_user_started"`
+	TaskID          uint      `json:"task_id" gorm:"not null;index"`
+	Task            Task      `json:"-" gorm:"foreignKey:TaskID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	StartedAt       time.Time `json:"started_at" gorm:"not null;index:idx_worklogs_user_started"`
+	DurationMinutes int       `json:"duration_minutes" gorm:"not null"`
+	// StartedAt plus the duration, set when the worklog is saved
+	EndedAt time.Time `json:"ended_at" gorm:"not null"`
+	Note    string    `json:"note"`
+}
+
+// Timer Model, the running timer of a user. Stopping it logs the time since it
+// was started on its task.
+type Timer struct {
+	UserID    uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	TaskID    uint      `json:"task_id" gorm:"not null;index"`
+	Task      Task      `json:"-" gorm:"foreignKey:TaskID;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	StartedAt time.Time `json:"started_at"`
+	Note      string    `json:"note"`
+}
+
+// Timesheet is the time logged in a date range, totalled per day or week, user
+// and task
+type Timesheet struct {
+	From         string         `json:"from"` // YYYY-MM-DD, in UTC
+	To           string         `json:"to"`   // last day, YYYY-MM-DD
+	Group        string         `json:"group" enums:"day,week"`
+	Rows         []TimesheetRow `json:"rows"`
+	TotalMinutes int            `json:"total_minutes"`
+	TotalHours   float64        `json:"total_hours"`
+}
+
+// TimesheetRow is the time a user logged on a task in a day, or in the week
+// starting on the Monday Period
+type TimesheetRow struct {
+	Period    string  `json:"period"` // YYYY-MM-DD
+	UserID    uint    `json:"user_id"`
+	ProjectID uint    `json:"project_id"`
+	TaskID    uint    `json:"task_id"`
+	TaskTitle string  `json:"task_title"`
+	Minutes   int     `json:"minutes"`
+	Hours     float64 `json:"hours"`
+}
+
+// Review states of a submitted timesheet
+const (
+	TimesheetStatusSubmitted = "submitted"
+	TimesheetStatusApproved  = "approved"
+	TimesheetStatusRejected  = "rejected"
+)
+
+// TimesheetSubmission Model, the worklogs a user logged on the tasks of a project in
+// a week, sent to the project owner for approval. The worklogs of the week are
+// locked while it is submitted or approved, a rejected week can be changed and
+// submitted again.
+type TimesheetSubmission struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_timesheet_submissions_week"`
+	ProjectID uint      `json:"project_id" gorm:"not null;uniqueIndex:idx_timesheet_submissions_week;index"`
+	// Monday the week starts on, in UTC
+	WeekStart    time.Time  `json:"week_start" gorm:"not null;uniqueIndex:idx_timesheet_submissions_week"`
+	Status       string     `json:"status" gorm:"not null;index" enums:"submitted,approved,rejected"`
+	TotalMinutes int        `json:"total_minutes"`
+	SubmittedAt  time.Time  `json:"submitted_at"`
+	ReviewerID   *uint      `json:"reviewer_id"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+	// Comment of the reviewer, required to reject the timesheet
+	Comment  string    `json:"comment"`
+	Worklogs []Worklog `json:"worklogs,omitempty" gorm:"-"`
+}
+
+// PendingApprovals are the timesheets of a project waiting for the review of its owner
+type PendingApprovals struct {
+	ProjectID    uint                  `json:"project_id"`
+	Count        int                   `json:"count"`
+	TotalMinutes int                   `json:"total_minutes"`
+	TotalHours   float64               `json:"total_hours"`
+	Timesheets   []TimesheetSubmission `json:"timesheets"`
+}
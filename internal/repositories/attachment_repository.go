@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *models.Attachment) error
+	GetAttachmentByID(ctx context.Context, id uint) (*models.Attachment, error)
+	GetAttachmentsByTask(ctx context.Context, taskID uint) ([]models.Attachment, error)
+	GetAttachmentsByComment(ctx context.Context, commentID uint) ([]models.Attachment, error)
+	DeleteAttachment(ctx context.Context, id uint) error
+}
+
+type AttachmentRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &AttachmentRepositoryImplementation{db: db}
+}
+
+func (r *AttachmentRepositoryImplementation) CreateAttachment(ctx context.Context, attachment *models.Attachment) error {
+	return dbFromContext(ctx, r.db).Omit("Task", "Comment").Create(attachment).Error
+}
+
+func (r *AttachmentRepositoryImplementation) GetAttachmentByID(ctx context.Context, id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	if err := dbFromContext(ctx, r.db).First(&attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetAttachmentsByTask returns the files attached to the task and to its comments,
+// oldest first.
+func (r *AttachmentRepositoryImplementation) GetAttachmentsByTask(ctx context.Context, taskID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := dbFromContext(ctx, r.db).Where("task_id = ?", taskID).Order("id").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *AttachmentRepositoryImplementation) GetAttachmentsByComment(ctx context.Context, commentID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := dbFromContext(ctx, r.db).Where("comment_id = ?", commentID).Order("id").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *AttachmentRepositoryImplementation) DeleteAttachment(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Delete(&models.Attachment{}, id).Error
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditFilter narrows the audit log, zero values match every entry
+type AuditFilter struct {
+	ActorID    uint
+	Action     string
+	EntityType string
+	EntityID   uint
+	From       time.Time
+	To         time.Time
+}
+
+// AuditRepository appends to the audit log, entries are never updated or deleted
+type AuditRepository interface {
+	CreateEntry(ctx context.Context, entry *models.AuditEntry) error
+	GetEntries(ctx context.Context, filter AuditFilter, page, pageSize int) ([]models.AuditEntry, int64, error)
+	GetEntriesSince(ctx context.Context, entityType string, entityIDs []uint, from time.Time) ([]models.AuditEntry, error)
+}
+
+type AuditRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &AuditRepositoryImplementation{db: db}
+}
+
+func (r *AuditRepositoryImplementation) CreateEntry(ctx context.Context, entry *models.AuditEntry) error {
+	return dbFromContext(ctx, r.db).Create(entry).Error
+}
+
+// GetEntries returns the matching entries, newest first.
+func (r *AuditRepositoryImplementation) GetEntries(ctx context.Context, filter AuditFilter, page, pageSize int) ([]models.AuditEntry, int64, error) {
+	var entries []models.AuditEntry
+	var total int64
+
+	query := dbFromContext(ctx, r.db).Model(&models.AuditEntry{})
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&entries).Error
+
+	return entries, total, err
+}
+
+// GetEntriesSince returns the entries of the entities made from the given time on,
+// oldest first.
+func (r *AuditRepositoryImplementation) GetEntriesSince(ctx context.Context, entityType string, entityIDs []uint, from time.Time) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	if len(entityIDs) == 0 {
+		return entries, nil
+	}
+
+	err := dbFromContext(ctx, r.db).
+		Where("entity_type = ? AND entity_id IN ? AND created_at >= ?", entityType, entityIDs, from).
+		Order("created_at, id").
+		Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ChecklistRepository interface {
+	CreateItem(ctx context.Context, item *models.ChecklistItem) error
+	GetItemByID(ctx context.Context, id uint) (*models.ChecklistItem, error)
+	GetItemsByTask(ctx context.Context, taskID uint) ([]models.ChecklistItem, error)
+	UpdateItem(ctx context.Context, item *models.ChecklistItem) error
+	ReorderItems(ctx context.Context, taskID uint, itemIDs []uint) error
+	DeleteItem(ctx context.Context, id uint) error
+}
+
+type ChecklistRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewChecklistRepository(db *gorm.DB) ChecklistRepository {
+	return &ChecklistRepositoryImplementation{db: db}
+}
+
+func (r *ChecklistRepositoryImplementation) CreateItem(ctx context.Context, item *models.ChecklistItem) error {
+	return dbFromContext(ctx, r.db).Create(item).Error
+}
+
+func (r *ChecklistRepositoryImplementation) GetItemByID(ctx context.Context, id uint) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	if err := dbFromContext(ctx, r.db).First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetItemsByTask returns the checklist of the task in order.
+func (r *ChecklistRepositoryImplementation) GetItemsByTask(ctx context.Context, taskID uint) ([]models.ChecklistItem, error) {
+	var items []models.ChecklistItem
+	err := dbFromContext(ctx, r.db).Where("task_id = ?", taskID).Order("position, id").Find(&items).Error
+	return items, err
+}
+
+func (r *ChecklistRepositoryImplementation) UpdateItem(ctx context.Context, item *models.ChecklistItem) error {
+	return dbFromContext(ctx, r.db).Omit("Task").Save(item).Error
+}
+
+// ReorderItems numbers the items of the task from 1 in the given order.
+func (r *ChecklistRepositoryImplementation) ReorderItems(ctx context.Context, taskID uint, itemIDs []uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for i, id := range itemIDs {
+			err := tx.Model(&models.ChecklistItem{}).
+				Where("id = ? AND task_id = ?", id, taskID).
+				Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ChecklistRepositoryImplementation) DeleteItem(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Delete(&models.ChecklistItem{}, id).Error
+}
+
+// attachTaskChecklists fills the checklist progress of each task with a checklist.
+func attachTaskChecklists(db *gorm.DB, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	var rows []struct {
+		TaskID uint
+		Done   int
+		Total  int
+	}
+	err := db.Model(&models.ChecklistItem{}).
+		Select("task_id, SUM(CASE WHEN done = ? THEN 1 ELSE 0 END) AS done, COUNT(*) AS total", true).
+		Where("task_id IN ?", ids).
+		Group("task_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	progress := make(map[uint]*models.ChecklistProgress, len(rows))
+	for _, row := range rows {
+		progress[row.TaskID] = &models.ChecklistProgress{Done: row.Done, Total: row.Total}
+	}
+	for i := range tasks {
+		tasks[i].Checklist = progress[tasks[i].ID]
+	}
+	return nil
+}
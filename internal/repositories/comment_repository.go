@@ -5,13 +5,17 @@ import (
 	"example/project-management-system/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CommentRepository interface {
 	CreateComment(ctx context.Context, comment *models.Comment) error
 	GetCommentByID(ctx context.Context, id uint) (*models.Comment, error)
 	GetCommentsByTask(ctx context.Context, taskID uint, page, pageSize int) ([]models.Comment, int64, error)
+	UpdateComment(ctx context.Context, comment *models.Comment) error
 	DeleteComment(ctx context.Context, id uint) error
+	ListCommentsByTask(ctx context.Context, taskID uint) ([]models.Comment, error)
+	DeleteCommentsByTask(ctx context.Context, taskID uint) error
 }
 
 type CommentRepositoryImplementation struct {
@@ -23,15 +27,21 @@ func NewCommentRepository(db *gorm.DB) CommentRepository {
 }
 
 func (r *CommentRepositoryImplementation) CreateComment(ctx context.Context, comment *models.Comment) error {
-	return r.db.WithContext(ctx).Create(comment).Error
+	return dbFromContext(ctx, r.db).Create(comment).Error
 }
 
 func (r *CommentRepositoryImplementation) GetCommentByID(ctx context.Context, id uint) (*models.Comment, error) {
 	var comment models.Comment
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("User").
 		Preload("Task").
 		First(&comment, id).Error
+	if err != nil {
+		return &comment, err
+	}
+
+	counts, err := loadReactionCounts(dbFromContext(ctx, r.db), models.ReactionTargetComment, []uint{comment.ID})
+	comment.Reactions = counts[comment.ID]
 	return &comment, err
 }
 
@@ -40,23 +50,57 @@ func (r *CommentRepositoryImplementation) GetCommentsByTask(ctx context.Context,
 	var total int64
 
 	// Count total records for the task
-	if err := r.db.WithContext(ctx).Model(&models.Comment{}).Where("task_id = ?", taskID).Count(&total).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Model(&models.Comment{}).Where("task_id = ?", taskID).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Fetch paginated comments
 	offset := (page - 1) * pageSize
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Where("task_id = ?", taskID).
 		Offset(offset).
 		Limit(pageSize).
 		Preload("User").
 		Preload("Task").
 		Find(&comments).Error
+	if err != nil {
+		return comments, total, err
+	}
+
+	ids := make([]uint, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
 
-	return comments, total, err
+	counts, err := loadReactionCounts(dbFromContext(ctx, r.db), models.ReactionTargetComment, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range comments {
+		comments[i].Reactions = counts[comments[i].ID]
+	}
+
+	return comments, total, nil
+}
+
+// UpdateComment saves the fields of the comment, but not its task or user.
+func (r *CommentRepositoryImplementation) UpdateComment(ctx context.Context, comment *models.Comment) error {
+	return dbFromContext(ctx, r.db).Select("*").Omit(clause.Associations).Save(comment).Error
 }
 
 func (r *CommentRepositoryImplementation) DeleteComment(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.Comment{}, id).Error
+	return dbFromContext(ctx, r.db).Delete(&models.Comment{}, id).Error
+}
+
+// ListCommentsByTask returns all comments of the task, oldest first.
+func (r *CommentRepositoryImplementation) ListCommentsByTask(ctx context.Context, taskID uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := dbFromContext(ctx, r.db).Where("task_id = ?", taskID).Order("id").Find(&comments).Error
+	return comments, err
+}
+
+// DeleteCommentsByTask moves the comments of the task to the trash.
+func (r *CommentRepositoryImplementation) DeleteCommentsByTask(ctx context.Context, taskID uint) error {
+	return dbFromContext(ctx, r.db).Where("task_id = ?", taskID).Delete(&models.Comment{}).Error
 }
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Columns of task_field_values holding the values of each type of custom field
+const (
+	FieldValueText   = "text_value"
+	FieldValueNumber = "number_value"
+	FieldValueDate   = "date_value"
+)
+
+type CustomFieldRepository interface {
+	CreateField(ctx context.Context, field *models.CustomField) error
+	GetFieldByID(ctx context.Context, id uint) (*models.CustomField, error)
+	GetFieldsByProject(ctx context.Context, projectID uint) ([]models.CustomField, error)
+	UpdateField(ctx context.Context, field *models.CustomField) error
+	DeleteField(ctx context.Context, id uint) error
+	SetTaskValues(ctx context.Context, taskID uint, values []models.TaskFieldValue) error
+	DeleteOptionValues(ctx context.Context, fieldID uint, options []string) error
+	GetExistingUserIDs(ctx context.Context, ids []uint) ([]uint, error)
+}
+
+type CustomFieldRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepository(db *gorm.DB) CustomFieldRepository {
+	return &CustomFieldRepositoryImplementation{db: db}
+}
+
+func (r *CustomFieldRepositoryImplementation) CreateField(ctx context.Context, field *models.CustomField) error {
+	return dbFromContext(ctx, r.db).Create(field).Error
+}
+
+func (r *CustomFieldRepositoryImplementation) GetFieldByID(ctx context.Context, id uint) (*models.CustomField, error) {
+	var field models.CustomField
+	if err := dbFromContext(ctx, r.db).First(&field, id).Error; err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// GetFieldsByProject returns the custom fields of the project by position.
+func (r *CustomFieldRepositoryImplementation) GetFieldsByProject(ctx context.Context, projectID uint) ([]models.CustomField, error) {
+	var fields []models.CustomField
+	err := dbFromContext(ctx, r.db).Where("project_id = ?", projectID).Order("position, id").Find(&fields).Error
+	return fields, err
+}
+
+func (r *CustomFieldRepositoryImplementation) UpdateField(ctx context.Context, field *models.CustomField) error {
+	return dbFromContext(ctx, r.db).Save(field).Error
+}
+
+// DeleteField deletes the custom field with the values tasks have for it.
+func (r *CustomFieldRepositoryImplementation) DeleteField(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", id).Delete(&models.TaskFieldValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.CustomField{}, id).Error
+	})
+}
+
+// SetTaskValues replaces the custom field values of the task.
+func (r *CustomFieldRepositoryImplementation) SetTaskValues(ctx context.Context, taskID uint, values []models.TaskFieldValue) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&models.TaskFieldValue{}).Error; err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		for i := range values {
+			values[i].TaskID = taskID
+		}
+		return tx.Omit("Task", "Field").Create(&values).Error
+	})
+}
+
+// DeleteOptionValues removes the given options of a select or multi_select field from
+// the tasks that have them.
+func (r *CustomFieldRepositoryImplementation) DeleteOptionValues(ctx context.Context, fieldID uint, options []string) error {
+	if len(options) == 0 {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).
+		Where("field_id = ? AND "+FieldValueText+" IN ?", fieldID, options).
+		Delete(&models.TaskFieldValue{}).Error
+}
+
+// GetExistingUserIDs returns the subset of ids that belong to existing users.
+func (r *CustomFieldRepositoryImplementation) GetExistingUserIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	var existing []uint
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	err := dbFromContext(ctx, r.db).Model(&models.User{}).Where("id IN ?", ids).Pluck("id", &existing).Error
+	return existing, err
+}
+
+// attachTaskFields fills the custom field values of each task by field key. Numbers
+// are float64, dates YYYY-MM-DD strings, users IDs and multi_select values lists of
+// options.
+func attachTaskFields(db *gorm.DB, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	var rows []struct {
+		TaskID      uint
+		FieldKey    string
+		Type        string
+		TextValue   *string
+		NumberValue *float64
+		DateValue   *time.Time
+	}
+	err := db.Table("task_field_values").
+		Select("task_field_values.task_id, custom_fields.field_key, custom_fields.type, task_field_values.text_value, task_field_values.number_value, task_field_values.date_value").
+		Joins("JOIN custom_fields ON custom_fields.id = task_field_values.field_id").
+		Joins("JOIN tasks ON tasks.id = task_field_values.task_id").
+		// Only the fields of the project the task is in
+		Where("task_field_values.task_id IN ? AND custom_fields.project_id = tasks.project_id", ids).
+		Order("task_field_values.id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	values := make(map[uint]map[string]interface{})
+	for _, row := range rows {
+		if values[row.TaskID] == nil {
+			values[row.TaskID] = make(map[string]interface{})
+		}
+		taskValues := values[row.TaskID]
+		switch {
+		case row.Type == models.CustomFieldTypeMultiSelect && row.TextValue != nil:
+			options, _ := taskValues[row.FieldKey].([]string)
+			taskValues[row.FieldKey] = append(options, *row.TextValue)
+		case row.Type == models.CustomFieldTypeUser && row.NumberValue != nil:
+			taskValues[row.FieldKey] = uint(*row.NumberValue)
+		case row.TextValue != nil:
+			taskValues[row.FieldKey] = *row.TextValue
+		case row.NumberValue != nil:
+			taskValues[row.FieldKey] = *row.NumberValue
+		case row.DateValue != nil:
+			taskValues[row.FieldKey] = row.DateValue.UTC().Format(time.DateOnly)
+		}
+	}
+
+	for i := range tasks {
+		tasks[i].CustomFields = values[tasks[i].ID]
+	}
+	return nil
+}
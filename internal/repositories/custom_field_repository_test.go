@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"example/project-management-system/internal/models"
+)
+
+func TestCustomFieldRepository_FilterAndSortTasks(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	fieldRepo := NewCustomFieldRepository(db)
+	taskRepo := NewTaskRepository(db)
+
+	user := models.User{Username: "owner", Email: "owner@example.com", Password: "password"}
+	require.NoError(t, db.Create(&user).Error)
+	project := models.Project{Name: "Apollo", Users: []models.User{user}}
+	require.NoError(t, db.Create(&project).Error)
+	components := models.CustomField{ProjectID: project.ID, Key: "components", Name: "Components", Type: models.CustomFieldTypeMultiSelect, Options: []string{"api", "db", "ui"}}
+	points := models.CustomField{ProjectID: project.ID, Key: "points", Name: "Points", Type: models.CustomFieldTypeNumber}
+	launch := models.CustomField{ProjectID: project.ID, Key: "launch", Name: "Launch", Type: models.CustomFieldTypeDate}
+	for _, field := range []*models.CustomField{&components, &points, &launch} {
+		require.NoError(t, fieldRepo.CreateField(ctx, field))
+	}
+
+	text := func(s string) *string { return &s }
+	number := func(n float64) *float64 { return &n }
+	date := func(day int) *time.Time {
+		d := time.Date(2026, time.May, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	values := map[string][]models.TaskFieldValue{
+		"Login":  {{FieldID: components.ID, TextValue: text("api")}, {FieldID: components.ID, TextValue: text("ui")}, {FieldID: points.ID, NumberValue: number(3)}, {FieldID: launch.ID, DateValue: date(4)}},
+		"Schema": {{FieldID: components.ID, TextValue: text("db")}, {FieldID: points.ID, NumberValue: number(8)}, {FieldID: launch.ID, DateValue: date(20)}},
+		"Docs":   nil,
+	}
+	ids := map[string]uint{}
+	for _, title := range []string{"Login", "Schema", "Docs"} {
+		task := models.Task{Title: title, ProjectID: project.ID, AssignedTo: user.ID}
+		require.NoError(t, db.Create(&task).Error)
+		require.NoError(t, fieldRepo.SetTaskValues(ctx, task.ID, values[title]))
+		ids[title] = task.ID
+	}
+
+	titles := func(query TaskQuery) []string {
+		tasks, total, err := taskRepo.GetTaskByProject(ctx, project.ID, query, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(tasks)), total)
+		result := make([]string, len(tasks))
+		for i, task := range tasks {
+			result[i] = task.Title
+		}
+		return result
+	}
+
+	t.Run("Values", func(t *testing.T) {
+		assert.Equal(t, []string{"Login"}, titles(TaskQuery{Fields: []TaskFieldFilter{{FieldID: components.ID, Column: FieldValueText, Values: []interface{}{"ui", "docs"}}}}))
+	})
+
+	t.Run("Ranges", func(t *testing.T) {
+		assert.Equal(t, []string{"Schema"}, titles(TaskQuery{Fields: []TaskFieldFilter{{FieldID: points.ID, Column: FieldValueNumber, Min: 4.0}}}))
+		assert.Equal(t, []string{"Login"}, titles(TaskQuery{Fields: []TaskFieldFilter{{FieldID: launch.ID, Column: FieldValueDate, Max: *date(10)}}}))
+	})
+
+	t.Run("Sorts Tasks Without A Value Last", func(t *testing.T) {
+		assert.Equal(t, []string{"Schema", "Login", "Docs"}, titles(TaskQuery{Sort: []TaskOrder{{FieldID: points.ID, Column: FieldValueNumber, Desc: true}}}))
+		assert.Equal(t, []string{"Login", "Schema", "Docs"}, titles(TaskQuery{Sort: []TaskOrder{{FieldID: launch.ID, Column: FieldValueDate}}}))
+		assert.Equal(t, []string{"Schema", "Login", "Docs"}, titles(TaskQuery{Sort: []TaskOrder{{Column: "title", Desc: true}}}))
+	})
+
+	t.Run("Loads The Values", func(t *testing.T) {
+		task, err := taskRepo.GetTaskByID(ctx, ids["Login"])
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"components": []string{"api", "ui"}, "points": 3.0, "launch": "2026-05-04"}, task.CustomFields)
+	})
+
+	t.Run("Deleting A Field Deletes Its Values", func(t *testing.T) {
+		require.NoError(t, fieldRepo.DeleteField(ctx, points.ID))
+		task, err := taskRepo.GetTaskByID(ctx, ids["Schema"])
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"components": []string{"db"}, "launch": "2026-05-20"}, task.CustomFields)
+	})
+}
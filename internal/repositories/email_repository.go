@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EmailRepository interface {
+	GetPreference(ctx context.Context, userID uint) (*models.EmailPreference, error)
+	SavePreference(ctx context.Context, preference *models.EmailPreference) error
+	QueueDigestItem(ctx context.Context, item *models.EmailDigestItem) error
+	GetDigestItems(ctx context.Context, frequency string) ([]models.EmailDigestItem, error)
+	DeleteDigestItems(ctx context.Context, ids []uint) error
+	ClearDigestItems(ctx context.Context, userID uint) error
+}
+
+type EmailRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewEmailRepository(db *gorm.DB) EmailRepository {
+	return &EmailRepositoryImplementation{db: db}
+}
+
+// GetPreference returns the email preference of a user, or nil when none was saved.
+func (r *EmailRepositoryImplementation) GetPreference(ctx context.Context, userID uint) (*models.EmailPreference, error) {
+	var preference models.EmailPreference
+	if err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).First(&preference).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &preference, nil
+}
+
+func (r *EmailRepositoryImplementation) SavePreference(ctx context.Context, preference *models.EmailPreference) error {
+	return dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"frequency"}),
+	}).Create(preference).Error
+}
+
+func (r *EmailRepositoryImplementation) QueueDigestItem(ctx context.Context, item *models.EmailDigestItem) error {
+	return dbFromContext(ctx, r.db).Create(item).Error
+}
+
+// GetDigestItems returns the queued items of every user currently receiving digests
+// at the given frequency, grouped by user and oldest first.
+func (r *EmailRepositoryImplementation) GetDigestItems(ctx context.Context, frequency string) ([]models.EmailDigestItem, error) {
+	var items []models.EmailDigestItem
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN email_preferences ON email_preferences.user_id = email_digest_items.user_id").
+		Where("email_preferences.frequency = ?", frequency).
+		Preload("User").
+		Preload("Notification").
+		Order("email_digest_items.user_id, email_digest_items.created_at, email_digest_items.id").
+		Find(&items).Error
+	return items, err
+}
+
+func (r *EmailRepositoryImplementation) DeleteDigestItems(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).Delete(&models.EmailDigestItem{}, ids).Error
+}
+
+func (r *EmailRepositoryImplementation) ClearDigestItems(ctx context.Context, userID uint) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID).Delete(&models.EmailDigestItem{}).Error
+}
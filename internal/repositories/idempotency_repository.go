@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IdempotencyRepository interface {
+	CreateKey(ctx context.Context, key *models.IdempotencyKey) error
+	GetKey(ctx context.Context, userID uint, key string) (*models.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, key *models.IdempotencyKey) error
+	DeleteKey(ctx context.Context, id uint) error
+	DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+type IdempotencyRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &IdempotencyRepositoryImplementation{db: db}
+}
+
+// CreateKey reserves the key, it fails when the user already has a row for it.
+func (r *IdempotencyRepositoryImplementation) CreateKey(ctx context.Context, key *models.IdempotencyKey) error {
+	return dbFromContext(ctx, r.db).Create(key).Error
+}
+
+func (r *IdempotencyRepositoryImplementation) GetKey(ctx context.Context, userID uint, key string) (*models.IdempotencyKey, error) {
+	var idempotencyKey models.IdempotencyKey
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND idempotency_key = ?", userID, key).
+		First(&idempotencyKey).Error
+	if err != nil {
+		return nil, err
+	}
+	return &idempotencyKey, nil
+}
+
+// SaveResponse stores the response of the request the key was reserved for.
+func (r *IdempotencyRepositoryImplementation) SaveResponse(ctx context.Context, key *models.IdempotencyKey) error {
+	return dbFromContext(ctx, r.db).
+		Model(&models.IdempotencyKey{}).
+		Where("id = ?", key.ID).
+		Updates(map[string]interface{}{
+			"status_code":  key.StatusCode,
+			"content_type": key.ContentType,
+			"body":         key.Body,
+		}).Error
+}
+
+func (r *IdempotencyRepositoryImplementation) DeleteKey(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Delete(&models.IdempotencyKey{}, id).Error
+}
+
+// DeleteExpiredKeys deletes the keys that expired before now and returns how many.
+func (r *IdempotencyRepositoryImplementation) DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).Where("expires_at < ?", now).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
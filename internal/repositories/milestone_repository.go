@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MilestoneRepository interface {
+	CreateMilestone(ctx context.Context, milestone *models.Milestone) error
+	GetMilestoneByID(ctx context.Context, id uint) (*models.Milestone, error)
+	GetMilestonesByProject(ctx context.Context, projectID uint) ([]models.Milestone, error)
+	UpdateMilestone(ctx context.Context, milestone *models.Milestone) error
+	DeleteMilestone(ctx context.Context, id uint) error
+}
+
+type MilestoneRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewMilestoneRepository(db *gorm.DB) MilestoneRepository {
+	return &MilestoneRepositoryImplementation{db: db}
+}
+
+func (r *MilestoneRepositoryImplementation) CreateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	return dbFromContext(ctx, r.db).Omit(clause.Associations).Create(milestone).Error
+}
+
+// GetMilestoneByID returns the milestone with its tasks.
+func (r *MilestoneRepositoryImplementation) GetMilestoneByID(ctx context.Context, id uint) (*models.Milestone, error) {
+	var milestone models.Milestone
+	err := dbFromContext(ctx, r.db).
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&milestone, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &milestone, nil
+}
+
+// GetMilestonesByProject returns the milestones of a project with their tasks, by
+// target date.
+func (r *MilestoneRepositoryImplementation) GetMilestonesByProject(ctx context.Context, projectID uint) ([]models.Milestone, error) {
+	var milestones []models.Milestone
+	err := dbFromContext(ctx, r.db).
+		Preload("Tasks").
+		Where("project_id = ?", projectID).
+		Order("target_date, id").
+		Find(&milestones).Error
+	return milestones, err
+}
+
+func (r *MilestoneRepositoryImplementation) UpdateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	return dbFromContext(ctx, r.db).Omit(clause.Associations).Save(milestone).Error
+}
+
+func (r *MilestoneRepositoryImplementation) DeleteMilestone(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Delete(&models.Milestone{}, id).Error
+}
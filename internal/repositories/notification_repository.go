@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	CreateNotification(ctx context.Context, notification *models.Notification) error
+	GetNotificationsByUser(ctx context.Context, userID uint, unreadOnly bool, page, pageSize int) ([]models.Notification, int64, error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+	MarkAsRead(ctx context.Context, userID, id uint) (int64, error)
+	MarkAllAsRead(ctx context.Context, userID uint) (int64, error)
+	GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error)
+	SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error
+}
+
+type NotificationRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &NotificationRepositoryImplementation{db: db}
+}
+
+func (r *NotificationRepositoryImplementation) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	return dbFromContext(ctx, r.db).Create(notification).Error
+}
+
+func (r *NotificationRepositoryImplementation) GetNotificationsByUser(ctx context.Context, userID uint, unreadOnly bool, page, pageSize int) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+
+	query := dbFromContext(ctx, r.db).Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	// Count total records for the user
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch paginated notifications, newest first
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&notifications).Error
+
+	return notifications, total, err
+}
+
+func (r *NotificationRepositoryImplementation) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	var unread int64
+	err := dbFromContext(ctx, r.db).
+		Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&unread).Error
+	return unread, err
+}
+
+// MarkAsRead marks one of the user's notifications as read and reports how many rows matched.
+func (r *NotificationRepositoryImplementation) MarkAsRead(ctx context.Context, userID, id uint) (int64, error) {
+	var notification models.Notification
+	if err := dbFromContext(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if notification.ReadAt != nil {
+		return 1, nil
+	}
+
+	err := dbFromContext(ctx, r.db).Model(&notification).Update("read_at", time.Now()).Error
+	return 1, err
+}
+
+func (r *NotificationRepositoryImplementation) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+func (r *NotificationRepositoryImplementation) GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Find(&preferences).Error
+	return preferences, err
+}
+
+// SavePreferences inserts or updates preferences keyed by user and event type.
+func (r *NotificationRepositoryImplementation) SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	return dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(&preferences).Error
+}
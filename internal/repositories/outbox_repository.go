@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxRepository stores published events and subscriber checkpoints for the dispatcher
+type OutboxRepository interface {
+	events.Store
+}
+
+type OutboxRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &OutboxRepositoryImplementation{db: db}
+}
+
+func (r *OutboxRepositoryImplementation) Append(ctx context.Context, eventType events.Type, payload []byte) error {
+	event := models.OutboxEvent{Type: string(eventType), Payload: string(payload)}
+	return dbFromContext(ctx, r.db).Create(&event).Error
+}
+
+func (r *OutboxRepositoryImplementation) After(ctx context.Context, afterID uint, limit int) ([]events.Record, error) {
+	var outboxEvents []models.OutboxEvent
+	err := dbFromContext(ctx, r.db).
+		Where("id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Find(&outboxEvents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]events.Record, 0, len(outboxEvents))
+	for _, event := range outboxEvents {
+		records = append(records, events.Record{ID: event.ID, Payload: []byte(event.Payload)})
+	}
+	return records, nil
+}
+
+// Checkpoint returns the last event handled by subscriber, or 0 when it has not handled any.
+func (r *OutboxRepositoryImplementation) Checkpoint(ctx context.Context, subscriber string) (uint, error) {
+	var checkpoint models.OutboxCheckpoint
+	if err := dbFromContext(ctx, r.db).Where("subscriber = ?", subscriber).First(&checkpoint).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return checkpoint.LastEventID, nil
+}
+
+func (r *OutboxRepositoryImplementation) SaveCheckpoint(ctx context.Context, subscriber string, eventID uint) error {
+	checkpoint := models.OutboxCheckpoint{Subscriber: subscriber, LastEventID: eventID}
+	return dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscriber"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_event_id", "updated_at"}),
+	}).Create(&checkpoint).Error
+}
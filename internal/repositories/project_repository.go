@@ -4,6 +4,7 @@ import (
 	"context"
 	"example/project-management-system/internal/models"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -11,7 +12,8 @@ import (
 type ProjectRepository interface {
 	CreateProject(ctx context.Context, project *models.Project) error
 	GetProjectByID(ctx context.Context, id uint) (*models.Project, error)
-	GetPaginatedProjects(ctx context.Context, page, pageSize int) ([]models.Project, int64, error)
+	GetPaginatedProjects(ctx context.Context, page, pageSize int, includeArchived bool) ([]models.Project, int64, error)
+	GetProjectStatus(ctx context.Context, id uint) (string, error)
 	UpdateProject(ctx context.Context, project *models.Project) error
 	DeleteProject(ctx context.Context, id uint) error
 	GetTaskByProjectID(ctx context.Context, projectID uint) ([]models.Task, error)
@@ -26,13 +28,13 @@ func NewProjectRepository(db *gorm.DB) ProjectRepository {
 }
 
 func (r *ProjectRepositoryImplementation) CreateProject(ctx context.Context, project *models.Project) error {
-	return r.db.WithContext(ctx).Create(project).Error
+	return dbFromContext(ctx, r.db).Create(project).Error
 }
 
 func (r *ProjectRepositoryImplementation) GetProjectByID(ctx context.Context, id uint) (*models.Project, error) {
 	var project models.Project
 
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Preload("Users").
 		Preload("Tasks").
 		Preload("Teams").
@@ -48,18 +50,28 @@ func (r *ProjectRepositoryImplementation) GetProjectByID(ctx context.Context, id
 	return &project, nil
 }
 
-func (r *ProjectRepositoryImplementation) GetPaginatedProjects(ctx context.Context, page, pageSize int) ([]models.Project, int64, error) {
+// GetPaginatedProjects returns a page of the projects, leaving out the archived
+// ones unless includeArchived is set.
+func (r *ProjectRepositoryImplementation) GetPaginatedProjects(ctx context.Context, page, pageSize int, includeArchived bool) ([]models.Project, int64, error) {
 	var projects []models.Project
 	var total int64
 
+	visible := func(db *gorm.DB) *gorm.DB {
+		if includeArchived {
+			return db
+		}
+		return db.Where("status <> ?", models.ProjectStatusArchived)
+	}
+
 	// Count total records
-	if err := r.db.WithContext(ctx).Model(&models.Project{}).Count(&total).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Model(&models.Project{}).Scopes(visible).Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count projects: %w", err)
 	}
 
 	// Fetch paginated records
 	offset := (page - 1) * pageSize
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
+		Scopes(visible).
 		Preload("Users").
 		Preload("Tasks").
 		Preload("Teams").
@@ -80,27 +92,52 @@ func (r *ProjectRepositoryImplementation) GetPaginatedProjects(ctx context.Conte
 
 }
 
+// GetProjectStatus returns the lifecycle status of the project without loading its
+// relations.
+func (r *ProjectRepositoryImplementation) GetProjectStatus(ctx context.Context, id uint) (string, error) {
+	var project models.Project
+	if err := dbFromContext(ctx, r.db).Select("id", "status").First(&project, id).Error; err != nil {
+		return "", err
+	}
+	return project.Status, nil
+}
+
+// UpdateProject saves the project when it is still at project.Version, which is then incremented.
 func (r *ProjectRepositoryImplementation) UpdateProject(ctx context.Context, project *models.Project) error {
-	return r.db.WithContext(ctx).Save(project).Error
+	return saveVersion(dbFromContext(ctx, r.db), project, &project.Version)
 }
 
+// DeleteProject moves the project, its teams, its tasks and their comments to the trash.
 func (r *ProjectRepositoryImplementation) DeleteProject(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.Project{}, id).Error
+	deletedAt := time.Now()
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := softDelete(tx, &models.Project{}, deletedAt, "id = ?", id); err != nil {
+			return err
+		}
+		if err := softDelete(tx, &models.Team{}, deletedAt, "project_id = ?", id); err != nil {
+			return err
+		}
+		projectTasks := tx.Unscoped().Model(&models.Task{}).Select("id").Where("project_id = ?", id)
+		if err := softDelete(tx, &models.Comment{}, deletedAt, "task_id IN (?)", projectTasks); err != nil {
+			return err
+		}
+		return softDelete(tx, &models.Task{}, deletedAt, "project_id = ?", id)
+	})
 }
 
 // AddUsersToProject add user to project
 func (r *ProjectRepositoryImplementation) AddUsersToProject(ctx context.Context, projectID uint, userIDs []uint) error {
 	var project models.Project
-	if err := r.db.WithContext(ctx).First(&project, projectID).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&project, projectID).Error; err != nil {
 		return fmt.Errorf("project not found: %w", err)
 	}
 
 	var users []models.User
-	if err := r.db.WithContext(ctx).Find(&users, userIDs).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Find(&users, userIDs).Error; err != nil {
 		return fmt.Errorf("error finding users: %w", err)
 	}
 
-	if err := r.db.WithContext(ctx).Model(&project).Association("Users").Append(users); err != nil {
+	if err := dbFromContext(ctx, r.db).Model(&project).Association("Users").Append(users); err != nil {
 		return fmt.Errorf("failed to add users to project: %w", err)
 	}
 
@@ -110,16 +147,16 @@ func (r *ProjectRepositoryImplementation) AddUsersToProject(ctx context.Context,
 // RemoveUsersFromProject remove user from project
 func (r *ProjectRepositoryImplementation) RemoveUsersFromProject(ctx context.Context, projectID uint, userIDs []uint) error {
 	var project models.Project
-	if err := r.db.WithContext(ctx).First(&project, projectID).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&project, projectID).Error; err != nil {
 		return fmt.Errorf("project not found: %w", err)
 	}
 
 	var users []models.User
-	if err := r.db.WithContext(ctx).Find(&users, userIDs).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Find(&users, userIDs).Error; err != nil {
 		return fmt.Errorf("error finding users: %w", err)
 	}
 
-	if err := r.db.WithContext(ctx).Model(&project).Association("Users").Delete(users); err != nil {
+	if err := dbFromContext(ctx, r.db).Model(&project).Association("Users").Delete(users); err != nil {
 		return fmt.Errorf("failed to remove users from project: %w", err)
 	}
 
@@ -130,7 +167,7 @@ func (r *ProjectRepositoryImplementation) SearchProjects(ctx context.Context, qu
 	var projects []models.Project
 	var total int64
 
-	searchQuery := r.db.WithContext(ctx).
+	searchQuery := dbFromContext(ctx, r.db).
 		Where("name LIKE ? OR description LIKE ?", "%"+query+"%", "%"+query+"%")
 
 	if err := searchQuery.Model(&models.Project{}).Count(&total).Error; err != nil {
@@ -162,7 +199,13 @@ func (r *ProjectRepositoryImplementation) SearchProjects(ctx context.Context, qu
 func (r *ProjectRepositoryImplementation) GetTaskByProjectID(ctx context.Context, projectID uint) ([]models.Task, error) {
 	var tasks []models.Task
 
-	err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Find(&tasks).Error
+	err := dbFromContext(ctx, r.db).Where("project_id = ?", projectID).Find(&tasks).Error
+	if err != nil {
+		return tasks, err
+	}
 
-	return tasks, err
+	if err := attachTaskFields(dbFromContext(ctx, r.db), tasks); err != nil {
+		return tasks, err
+	}
+	return tasks, attachTaskChecklists(dbFromContext(ctx, r.db), tasks)
 }
\ No newline at end of file
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ProjectTemplateRepository interface {
+	CreateTemplate(ctx context.Context, template *models.ProjectTemplate) error
+	GetTemplateByID(ctx context.Context, id uint) (*models.ProjectTemplate, error)
+	GetTemplates(ctx context.Context, page, pageSize int) ([]models.ProjectTemplate, int64, error)
+	DeleteTemplate(ctx context.Context, id uint) error
+}
+
+type ProjectTemplateRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewProjectTemplateRepository(db *gorm.DB) ProjectTemplateRepository {
+	return &ProjectTemplateRepositoryImplementation{db: db}
+}
+
+func (r *ProjectTemplateRepositoryImplementation) CreateTemplate(ctx context.Context, template *models.ProjectTemplate) error {
+	return dbFromContext(ctx, r.db).Create(template).Error
+}
+
+func (r *ProjectTemplateRepositoryImplementation) GetTemplateByID(ctx context.Context, id uint) (*models.ProjectTemplate, error) {
+	var template models.ProjectTemplate
+	if err := dbFromContext(ctx, r.db).First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetTemplates returns a page of the templates ordered by name.
+func (r *ProjectTemplateRepositoryImplementation) GetTemplates(ctx context.Context, page, pageSize int) ([]models.ProjectTemplate, int64, error) {
+	var templates []models.ProjectTemplate
+	var total int64
+
+	if err := dbFromContext(ctx, r.db).Model(&models.ProjectTemplate{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := dbFromContext(ctx, r.db).
+		Order("name, id").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&templates).Error
+	return templates, total, err
+}
+
+func (r *ProjectTemplateRepositoryImplementation) DeleteTemplate(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Delete(&models.ProjectTemplate{}, id).Error
+}
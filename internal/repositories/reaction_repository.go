@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ReactionRepository interface {
+	CreateReaction(ctx context.Context, reaction *models.Reaction) error
+	DeleteReaction(ctx context.Context, targetType string, targetID, userID uint, emoji string) (int64, error)
+	GetReactionCounts(ctx context.Context, targetType string, targetID uint) ([]models.ReactionCount, error)
+}
+
+type ReactionRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) ReactionRepository {
+	return &ReactionRepositoryImplementation{db: db}
+}
+
+func (r *ReactionRepositoryImplementation) CreateReaction(ctx context.Context, reaction *models.Reaction) error {
+	return dbFromContext(ctx, r.db).Create(reaction).Error
+}
+
+// DeleteReaction removes a single user's reaction and reports how many rows were deleted.
+func (r *ReactionRepositoryImplementation) DeleteReaction(ctx context.Context, targetType string, targetID, userID uint, emoji string) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Where("target_type = ? AND target_id = ? AND user_id = ? AND emoji = ?", targetType, targetID, userID, emoji).
+		Delete(&models.Reaction{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *ReactionRepositoryImplementation) GetReactionCounts(ctx context.Context, targetType string, targetID uint) ([]models.ReactionCount, error) {
+	counts, err := loadReactionCounts(dbFromContext(ctx, r.db), targetType, []uint{targetID})
+	if err != nil {
+		return nil, err
+	}
+	return counts[targetID], nil
+}
+
+// loadReactionCounts aggregates reactions per emoji for each of the given targets.
+func loadReactionCounts(db *gorm.DB, targetType string, targetIDs []uint) (map[uint][]models.ReactionCount, error) {
+	counts := make(map[uint][]models.ReactionCount)
+	if len(targetIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		TargetID uint
+		Emoji    string
+		Count    int64
+	}
+	err := db.Model(&models.Reaction{}).
+		Select("target_id, emoji, COUNT(*) AS count").
+		Where("target_type = ? AND target_id IN ?", targetType, targetIDs).
+		Group("target_id, emoji").
+		Order("target_id, emoji").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.TargetID] = append(counts[row.TargetID], models.ReactionCount{Emoji: row.Emoji, Count: row.Count})
+	}
+	return counts, nil
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// softDelete marks the rows of model matched by query as deleted. Rows deleted
+// together, e.g. a task and its comments, share deletedAt so they can be restored
+// together without bringing back rows that were deleted on their own before.
+func softDelete(db *gorm.DB, model interface{}, deletedAt time.Time, query interface{}, args ...interface{}) error {
+	return db.Model(model).Where(query, args...).Update("deleted_at", deletedAt).Error
+}
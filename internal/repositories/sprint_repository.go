@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SprintRepository interface {
+	CreateSprint(ctx context.Context, sprint *models.Sprint) error
+	GetSprintByID(ctx context.Context, id uint) (*models.Sprint, error)
+	GetSprintsByProject(ctx context.Context, projectID uint) ([]models.Sprint, error)
+	GetActiveSprint(ctx context.Context, projectID uint) (*models.Sprint, error)
+	UpdateSprint(ctx context.Context, sprint *models.Sprint) error
+	DeleteSprint(ctx context.Context, id uint) error
+	GetProjectTasks(ctx context.Context, projectID uint) ([]models.Task, error)
+}
+
+type SprintRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewSprintRepository(db *gorm.DB) SprintRepository {
+	return &SprintRepositoryImplementation{db: db}
+}
+
+func (r *SprintRepositoryImplementation) CreateSprint(ctx context.Context, sprint *models.Sprint) error {
+	return dbFromContext(ctx, r.db).Omit(clause.Associations).Create(sprint).Error
+}
+
+// GetSprintByID returns the sprint with its tasks.
+func (r *SprintRepositoryImplementation) GetSprintByID(ctx context.Context, id uint) (*models.Sprint, error) {
+	var sprint models.Sprint
+	err := dbFromContext(ctx, r.db).
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&sprint, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sprint, nil
+}
+
+// GetSprintsByProject returns the sprints of a project in the order they start.
+func (r *SprintRepositoryImplementation) GetSprintsByProject(ctx context.Context, projectID uint) ([]models.Sprint, error) {
+	var sprints []models.Sprint
+	err := dbFromContext(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("start_date, id").
+		Find(&sprints).Error
+	return sprints, err
+}
+
+// GetActiveSprint returns the active sprint of a project, gorm.ErrRecordNotFound
+// when it has none.
+func (r *SprintRepositoryImplementation) GetActiveSprint(ctx context.Context, projectID uint) (*models.Sprint, error) {
+	var sprint models.Sprint
+	err := dbFromContext(ctx, r.db).
+		Where("project_id = ? AND state = ?", projectID, models.SprintStateActive).
+		First(&sprint).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sprint, nil
+}
+
+func (r *SprintRepositoryImplementation) UpdateSprint(ctx context.Context, sprint *models.Sprint) error {
+	return dbFromContext(ctx, r.db).Omit(clause.Associations).Save(sprint).Error
+}
+
+func (r *SprintRepositoryImplementation) DeleteSprint(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Delete(&models.Sprint{}, id).Error
+}
+
+// GetProjectTasks returns every task of a project, including those in the trash,
+// without their relations.
+func (r *SprintRepositoryImplementation) GetProjectTasks(ctx context.Context, projectID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := dbFromContext(ctx, r.db).Unscoped().
+		Where("project_id = ?", projectID).
+		Find(&tasks).Error
+	return tasks, err
+}
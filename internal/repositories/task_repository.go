@@ -3,16 +3,46 @@ package repositories
 import (
 	"context"
 	"example/project-management-system/internal/models"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// TaskFieldFilter matches the tasks with a value of a custom field in Column that is
+// one of Values, or within Min and Max when Values is empty. Nil bounds are open.
+type TaskFieldFilter struct {
+	FieldID uint
+	Column  string
+	Values  []interface{}
+	Min     interface{}
+	Max     interface{}
+}
+
+// TaskOrder sorts tasks by one of their columns, or by the value of a custom field in
+// Column when FieldID is set. Tasks without a value for the field come last.
+type TaskOrder struct {
+	Column  string
+	FieldID uint
+	Desc    bool
+}
+
+// TaskQuery narrows and sorts the tasks of a project. Columns are trusted, they are
+// not escaped.
+type TaskQuery struct {
+	Fields []TaskFieldFilter
+	Sort   []TaskOrder
+}
+
 type TaskRepository interface {
 	CreateTask(ctx context.Context, task *models.Task) error
 	GetTaskByID(ctx context.Context, id uint) (*models.Task, error)
-	GetTaskByProject(ctx context.Context, projectID uint, page, pageSize int) ([]models.Task, int64, error)
+	GetTaskByProject(ctx context.Context, projectID uint, query TaskQuery, page, pageSize int) ([]models.Task, int64, error)
 	UpdateTask(ctx context.Context, task *models.Task) error
 	DeleteTask(ctx context.Context, id uint) error
+	GetExistingTaskIDs(ctx context.Context, ids []uint) ([]uint, error)
+	GetSubtasks(ctx context.Context, parentID uint) ([]models.Task, error)
 }
 
 type TaskRepositoryImplementation struct {
@@ -24,44 +54,172 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 }
 
 func (r *TaskRepositoryImplementation) CreateTask(ctx context.Context, task *models.Task) error {
-	return r.db.WithContext(ctx).Create(task).Error
+	return dbFromContext(ctx, r.db).Create(task).Error
 }
 
 func (r *TaskRepositoryImplementation) GetTaskByID(ctx context.Context, id uint) (*models.Task, error) {
 	var task models.Task
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 	Preload("Assignee").
 	Preload("Project").
 	First(&task, id).Error
-	return &task, err
+	if err != nil {
+		return &task, err
+	}
+
+	counts, err := loadReactionCounts(dbFromContext(ctx, r.db), models.ReactionTargetTask, []uint{task.ID})
+	if err != nil {
+		return &task, err
+	}
+	task.Reactions = counts[task.ID]
+
+	tasks := []models.Task{task}
+	if err := attachTaskFields(dbFromContext(ctx, r.db), tasks); err != nil {
+		return &tasks[0], err
+	}
+	err = attachTaskChecklists(dbFromContext(ctx, r.db), tasks)
+	return &tasks[0], err
 }
 
-func (r *TaskRepositoryImplementation) GetTaskByProject(ctx context.Context, projectID uint, page, pageSize int) ([]models.Task, int64, error) {
+func (r *TaskRepositoryImplementation) GetTaskByProject(ctx context.Context, projectID uint, query TaskQuery, page, pageSize int) ([]models.Task, int64, error) {
 	var tasks []models.Task
 	var total int64
 
+	filtered := func() *gorm.DB {
+		db := dbFromContext(ctx, r.db).Model(&models.Task{}).Where("project_id = ?", projectID)
+		for _, filter := range query.Fields {
+			db = filterTaskField(db, filter)
+		}
+		return db
+	}
+
 	// Count total records for the project
-	if err := r.db.WithContext(ctx).Model(&models.Task{}).Where("project_id = ?", projectID).Count(&total).Error; err != nil {
+	if err := filtered().Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Fetch paginated tasks
 	offset := (page - 1) * pageSize
-	err := r.db.WithContext(ctx).
-		Where("project_id = ?", projectID).
+	err := filtered().
+		Order(orderTasks(query.Sort)).
 		Offset(offset).
 		Limit(pageSize).
-		Preload("User").
+		Preload("Assignee").
 		Preload("Project").
 		Find(&tasks).Error
+	if err != nil {
+		return tasks, total, err
+	}
 
-	return tasks, total, err
+	if err := attachTaskReactions(dbFromContext(ctx, r.db), tasks); err != nil {
+		return nil, 0, err
+	}
+	if err := attachTaskFields(dbFromContext(ctx, r.db), tasks); err != nil {
+		return nil, 0, err
+	}
+	if err := attachTaskChecklists(dbFromContext(ctx, r.db), tasks); err != nil {
+		return nil, 0, err
+	}
+
+	return tasks, total, nil
 }
 
+// UpdateTask saves the task when it is still at task.Version, which is then incremented.
 func (r *TaskRepositoryImplementation) UpdateTask(ctx context.Context, task *models.Task) error {
-	return r.db.WithContext(ctx).Save(task).Error
+	return saveVersion(dbFromContext(ctx, r.db), task, &task.Version)
 }
 
+// DeleteTask moves the task and its comments to the trash.
 func (r *TaskRepositoryImplementation) DeleteTask(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.Task{}, id).Error
+	deletedAt := time.Now()
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := softDelete(tx, &models.Task{}, deletedAt, "id = ?", id); err != nil {
+			return err
+		}
+		return softDelete(tx, &models.Comment{}, deletedAt, "task_id = ?", id)
+	})
+}
+
+// GetExistingTaskIDs returns the subset of ids that belong to existing tasks.
+func (r *TaskRepositoryImplementation) GetExistingTaskIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	var existing []uint
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	err := dbFromContext(ctx, r.db).Model(&models.Task{}).Where("id IN ?", ids).Pluck("id", &existing).Error
+	return existing, err
+}
+
+// GetSubtasks returns the subtasks of the task, oldest first.
+func (r *TaskRepositoryImplementation) GetSubtasks(ctx context.Context, parentID uint) ([]models.Task, error) {
+	var subtasks []models.Task
+	if err := dbFromContext(ctx, r.db).Where("parent_id = ?", parentID).Order("id").Find(&subtasks).Error; err != nil {
+		return nil, err
+	}
+	if err := attachTaskFields(dbFromContext(ctx, r.db), subtasks); err != nil {
+		return nil, err
+	}
+	return subtasks, nil
+}
+
+// attachTaskReactions fills the aggregated reaction counts of each task.
+func attachTaskReactions(db *gorm.DB, tasks []models.Task) error {
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	counts, err := loadReactionCounts(db, models.ReactionTargetTask, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		tasks[i].Reactions = counts[tasks[i].ID]
+	}
+	return nil
+}
+
+// filterTaskField keeps the tasks with a matching value of the custom field.
+func filterTaskField(db *gorm.DB, filter TaskFieldFilter) *gorm.DB {
+	condition := "SELECT 1 FROM task_field_values WHERE task_field_values.task_id = tasks.id AND task_field_values.field_id = ?"
+	vars := []interface{}{filter.FieldID}
+	if len(filter.Values) > 0 {
+		condition += " AND task_field_values." + filter.Column + " IN ?"
+		vars = append(vars, filter.Values)
+	}
+	if filter.Min != nil {
+		condition += " AND task_field_values." + filter.Column + " >= ?"
+		vars = append(vars, filter.Min)
+	}
+	if filter.Max != nil {
+		condition += " AND task_field_values." + filter.Column + " <= ?"
+		vars = append(vars, filter.Max)
+	}
+	return db.Where("EXISTS ("+condition+")", vars...)
+}
+
+// orderTasks sorts by the given orders, then by ID. Tasks are sorted by the lowest
+// value they have for a field, which matters for multi_select fields.
+func orderTasks(orders []TaskOrder) clause.OrderBy {
+	var terms []string
+	var vars []interface{}
+	for _, order := range orders {
+		direction := ""
+		if order.Desc {
+			direction = " DESC"
+		}
+		if order.FieldID == 0 {
+			terms = append(terms, "tasks."+order.Column+direction)
+			continue
+		}
+
+		value := "(SELECT MIN(task_field_values." + order.Column + ") FROM task_field_values WHERE task_field_values.task_id = tasks.id AND task_field_values.field_id = ?)"
+		terms = append(terms, "CASE WHEN "+value+" IS NULL THEN 1 ELSE 0 END", value+direction)
+		vars = append(vars, order.FieldID, order.FieldID)
+	}
+	terms = append(terms, "tasks.id")
+
+	return clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(terms, ", "), Vars: vars, WithoutParentheses: true}}
 }
@@ -54,10 +54,19 @@ func TestCreateTask(t *testing.T) {
 						sqlmock.AnyArg(),  // CreatedAt
 						sqlmock.AnyArg(),  // UpdatedAt
 						nil,  // DeletedAt
+						uint(1),  // Version
 						"Test Task",
 						"Test Description",
+						"",       // Status
 						uint(1),  // ProjectID
 						uint(2),  // AssignedTo
+						sqlmock.AnyArg(),  // Labels
+						nil,      // DueDate
+						float64(0), // OriginalEstimate
+						float64(0), // RemainingEstimate
+						nil,      // ParentID
+						nil,      // SprintID
+						nil,      // MilestoneID
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
@@ -235,9 +244,10 @@ func TestUpdateTask(t *testing.T) {
 		{
 			name: "Successful Task Update",
 			task: &models.Task{
-				BaseModel: models.BaseModel{ID: 1},
+				BaseModel: models.BaseModel{ID: 1, Version: 3},
 				Title:     "Updated Task",
 				Description: "Updated Description",
+				Status:      models.TaskStatusDone,
 				ProjectID:   1,
 				AssignedTo:  2,
 			},
@@ -248,10 +258,20 @@ func TestUpdateTask(t *testing.T) {
 						sqlmock.AnyArg(), // UpdatedAt,
 						sqlmock.AnyArg(),
 						nil,
+						uint(4),  // Version
 						"Updated Task",
 						"Updated Description",
+						models.TaskStatusDone,
 						uint(1),  // ProjectID
 						uint(2),  // AssignedTo
+						sqlmock.AnyArg(),  // Labels
+						nil,      // DueDate
+						float64(0), // OriginalEstimate
+						float64(0), // RemainingEstimate
+						nil,      // ParentID
+						nil,      // SprintID
+						nil,      // MilestoneID
+						uint(3),  // expected Version
 						1,        // ID
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -259,6 +279,20 @@ func TestUpdateTask(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "Version Conflict",
+			task: &models.Task{
+				BaseModel: models.BaseModel{ID: 1, Version: 3},
+				Title:     "Updated Task",
+			},
+			mockExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `tasks`")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expectedError: true,
+		},
 		{
 			name: "Update Error",
 			task: &models.Task{
@@ -317,10 +351,14 @@ func TestDeleteTask(t *testing.T) {
 			name:   "Successful Task Deletion",
 			taskID: 1,
 			mockExpectFunc: func(mock sqlmock.Sqlmock) {
+				// The task and its comments are moved to the trash together
 				mock.ExpectBegin()
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tasks`")).
-					WithArgs(1).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `tasks` SET `deleted_at`=?,`updated_at`=? WHERE id = ? AND `tasks`.`deleted_at` IS NULL")).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `comments` SET `deleted_at`=?,`updated_at`=? WHERE task_id = ? AND `comments`.`deleted_at` IS NULL")).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
 				mock.ExpectCommit()
 			},
 			expectedError: false,
@@ -330,7 +368,7 @@ func TestDeleteTask(t *testing.T) {
 			taskID: 1,
 			mockExpectFunc: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tasks`")).
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `tasks` SET `deleted_at`")).
 					WillReturnError(errors.New("database delete error"))
 				mock.ExpectRollback()
 			},
@@ -24,12 +24,12 @@ func NewTeamRepository(db *gorm.DB) TeamRepository {
 }
 
 func (r *TeamRepositoryImplementation) CreateTeam(ctx context.Context, team *models.Team) error {
-	return r.db.WithContext(ctx).Create(team).Error
+	return dbFromContext(ctx, r.db).Create(team).Error
 }
 
 func (r *TeamRepositoryImplementation) GetTeamByID(ctx context.Context, id uint) (*models.Team, error) {
 	var team models.Team
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("Users").
 		Preload("Project").
 		First(&team, id).Error
@@ -41,13 +41,13 @@ func (r *TeamRepositoryImplementation) GetAllTeams(ctx context.Context, page, pa
 	var total int64
 
 	// Count total records
-	if err := r.db.WithContext(ctx).Model(&models.Team{}).Count(&total).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Model(&models.Team{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Fetch paginated records
 	offset := (page - 1) * pageSize
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Offset(offset).
 		Limit(pageSize).
 		Preload("Users").
@@ -57,10 +57,11 @@ func (r *TeamRepositoryImplementation) GetAllTeams(ctx context.Context, page, pa
 	return teams, total, err
 }
 
+// UpdateTeam saves the team when it is still at team.Version, which is then incremented.
 func (r *TeamRepositoryImplementation) UpdateTeam(ctx context.Context, team *models.Team) error {
-	return r.db.WithContext(ctx).Save(team).Error
+	return saveVersion(dbFromContext(ctx, r.db), team, &team.Version)
 }
 
 func (r *TeamRepositoryImplementation) DeleteTeam(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.Team{}, id).Error
+	return dbFromContext(ctx, r.db).Delete(&models.Team{}, id).Error
 }
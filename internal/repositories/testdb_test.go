@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/config"
+	"example/project-management-system/internal/database"
+	"example/project-management-system/internal/migrations"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupTestDB returns a migrated in-process SQLite database for the test
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := database.Open(config.DbConfig{Driver: database.DriverSQLite, Path: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+
+	all, err := migrations.All()
+	require.NoError(t, err)
+	_, err = database.NewMigrator(db, all).Up(context.Background())
+	require.NoError(t, err)
+	return db
+}
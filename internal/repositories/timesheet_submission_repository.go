@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TimesheetSubmissionFilter narrows submitted timesheets, zero values match every
+// user, project and status
+type TimesheetSubmissionFilter struct {
+	UserID    uint
+	ProjectID uint
+	Status    string
+}
+
+type TimesheetSubmissionRepository interface {
+	CreateSubmission(ctx context.Context, submission *models.TimesheetSubmission) error
+	GetSubmissionByID(ctx context.Context, id uint) (*models.TimesheetSubmission, error)
+	GetSubmission(ctx context.Context, userID, projectID uint, weekStart time.Time) (*models.TimesheetSubmission, error)
+	GetSubmissions(ctx context.Context, filter TimesheetSubmissionFilter) ([]models.TimesheetSubmission, error)
+	UpdateSubmission(ctx context.Context, submission *models.TimesheetSubmission) error
+}
+
+type TimesheetSubmissionRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewTimesheetSubmissionRepository(db *gorm.DB) TimesheetSubmissionRepository {
+	return &TimesheetSubmissionRepositoryImplementation{db: db}
+}
+
+func (r *TimesheetSubmissionRepositoryImplementation) CreateSubmission(ctx context.Context, submission *models.TimesheetSubmission) error {
+	return dbFromContext(ctx, r.db).Create(submission).Error
+}
+
+func (r *TimesheetSubmissionRepositoryImplementation) GetSubmissionByID(ctx context.Context, id uint) (*models.TimesheetSubmission, error) {
+	var submission models.TimesheetSubmission
+	if err := dbFromContext(ctx, r.db).First(&submission, id).Error; err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// GetSubmission returns the timesheet a user submitted for a project and week,
+// gorm.ErrRecordNotFound when there is none.
+func (r *TimesheetSubmissionRepositoryImplementation) GetSubmission(ctx context.Context, userID, projectID uint, weekStart time.Time) (*models.TimesheetSubmission, error) {
+	var submission models.TimesheetSubmission
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND project_id = ? AND week_start = ?", userID, projectID, weekStart).
+		First(&submission).Error
+	if err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// GetSubmissions returns the matching timesheets by week, oldest first.
+func (r *TimesheetSubmissionRepositoryImplementation) GetSubmissions(ctx context.Context, filter TimesheetSubmissionFilter) ([]models.TimesheetSubmission, error) {
+	var submissions []models.TimesheetSubmission
+
+	query := dbFromContext(ctx, r.db)
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.ProjectID != 0 {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	err := query.Order("week_start, user_id, id").Find(&submissions).Error
+	return submissions, err
+}
+
+func (r *TimesheetSubmissionRepositoryImplementation) UpdateSubmission(ctx context.Context, submission *models.TimesheetSubmission) error {
+	return dbFromContext(ctx, r.db).Save(submission).Error
+}
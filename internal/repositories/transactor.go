@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txContextKey struct{}
+
+// Transactor runs a function in a database transaction. Repositories called with
+// the context passed to the function take part in that transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type TransactorImplementation struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &TransactorImplementation{db: db}
+}
+
+// WithinTransaction commits when fn succeeds and rolls back when it fails. Nested
+// calls use savepoints of the outer transaction.
+func (t *TransactorImplementation) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return dbFromContext(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
+// dbFromContext returns the transaction carried by ctx, or db bound to ctx outside of one.
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,253 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// trashSource is a table of soft deleted entities and the column naming its rows
+type trashSource struct {
+	entityType string
+	table      string
+	name       string
+}
+
+var trashSources = []trashSource{
+	{models.AuditEntityUser, "users", "username"},
+	{models.AuditEntityProject, "projects", "name"},
+	{models.AuditEntityTask, "tasks", "title"},
+	{models.AuditEntityTeam, "teams", "name"},
+	{models.AuditEntityComment, "comments", "content"},
+}
+
+// TrashEntityTypes are the entity types that are soft deleted
+var TrashEntityTypes = []string{
+	models.AuditEntityUser,
+	models.AuditEntityProject,
+	models.AuditEntityTask,
+	models.AuditEntityTeam,
+	models.AuditEntityComment,
+}
+
+type TrashRepository interface {
+	GetTrash(ctx context.Context, entityType string, page, pageSize int) ([]models.TrashItem, int64, error)
+	FindWithDeleted(ctx context.Context, dest interface{}, id uint) error
+	Restore(ctx context.Context, entityType string, id uint, deletedAt time.Time) error
+	Purge(ctx context.Context, deletedBefore time.Time) ([]models.TrashItem, []string, error)
+}
+
+type TrashRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &TrashRepositoryImplementation{db: db}
+}
+
+// GetTrash returns the soft deleted entities of one type, or of every type when
+// entityType is empty, most recently deleted first.
+func (r *TrashRepositoryImplementation) GetTrash(ctx context.Context, entityType string, page, pageSize int) ([]models.TrashItem, int64, error) {
+	query, args := trashQuery(entityType, time.Time{})
+	db := dbFromContext(ctx, r.db)
+
+	var total int64
+	if err := db.Raw("SELECT count(*) FROM ("+query+") AS trash", args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []models.TrashItem
+	offset := (page - 1) * pageSize
+	err := db.Raw(query+" ORDER BY deleted_at DESC, entity_type, entity_id LIMIT ? OFFSET ?", append(args, pageSize, offset)...).
+		Scan(&items).Error
+	return items, total, err
+}
+
+// FindWithDeleted loads an entity whether it is in the trash or not.
+func (r *TrashRepositoryImplementation) FindWithDeleted(ctx context.Context, dest interface{}, id uint) error {
+	return dbFromContext(ctx, r.db).Unscoped().First(dest, id).Error
+}
+
+// Restore takes an entity out of the trash, together with the children that were
+// deleted along with it, i.e. at the same deletedAt.
+func (r *TrashRepositoryImplementation) Restore(ctx context.Context, entityType string, id uint, deletedAt time.Time) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		restore := func(model interface{}, query interface{}, args ...interface{}) error {
+			return tx.Unscoped().Model(model).
+				Where(query, args...).
+				Where("deleted_at = ?", deletedAt).
+				Update("deleted_at", nil).Error
+		}
+
+		switch entityType {
+		case models.AuditEntityUser:
+			return restore(&models.User{}, "id = ?", id)
+		case models.AuditEntityTeam:
+			return restore(&models.Team{}, "id = ?", id)
+		case models.AuditEntityComment:
+			return restore(&models.Comment{}, "id = ?", id)
+		case models.AuditEntityTask:
+			if err := restore(&models.Task{}, "id = ?", id); err != nil {
+				return err
+			}
+			return restore(&models.Comment{}, "task_id = ?", id)
+		case models.AuditEntityProject:
+			if err := restore(&models.Project{}, "id = ?", id); err != nil {
+				return err
+			}
+			if err := restore(&models.Team{}, "project_id = ?", id); err != nil {
+				return err
+			}
+			projectTasks := tx.Unscoped().Model(&models.Task{}).Select("id").Where("project_id = ?", id)
+			if err := restore(&models.Comment{}, "task_id IN (?)", projectTasks); err != nil {
+				return err
+			}
+			return restore(&models.Task{}, "project_id = ?", id)
+		}
+		return fmt.Errorf("invalid entity type %q", entityType)
+	})
+}
+
+// Purge permanently deletes the entities deleted before deletedBefore, along with
+// their reactions, attachments and memberships. It returns what was purged and the
+// storage keys of the attachments, whose blobs the caller deletes once committed.
+func (r *TrashRepositoryImplementation) Purge(ctx context.Context, deletedBefore time.Time) ([]models.TrashItem, []string, error) {
+	var purged []models.TrashItem
+	var blobKeys []string
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		query, args := trashQuery("", deletedBefore)
+		if err := tx.Raw(query, args...).Scan(&purged).Error; err != nil {
+			return err
+		}
+		if len(purged) == 0 {
+			return nil
+		}
+
+		ids := map[string][]uint{}
+		for _, item := range purged {
+			ids[item.EntityType] = append(ids[item.EntityType], item.EntityID)
+		}
+		// Children of purged parents go too, even if they were restored on their own
+		taskIDs := ids[models.AuditEntityTask]
+		if projectIDs := ids[models.AuditEntityProject]; len(projectIDs) > 0 {
+			var projectTaskIDs []uint
+			if err := tx.Unscoped().Model(&models.Task{}).Where("project_id IN ?", projectIDs).Pluck("id", &projectTaskIDs).Error; err != nil {
+				return err
+			}
+			taskIDs = append(taskIDs, projectTaskIDs...)
+		}
+		commentIDs := ids[models.AuditEntityComment]
+		if len(taskIDs) > 0 {
+			var taskCommentIDs []uint
+			if err := tx.Unscoped().Model(&models.Comment{}).Where("task_id IN ?", taskIDs).Pluck("id", &taskCommentIDs).Error; err != nil {
+				return err
+			}
+			commentIDs = append(commentIDs, taskCommentIDs...)
+		}
+
+		deleteAttachments := func(column string, ids []uint) error {
+			var keys []string
+			if err := tx.Model(&models.Attachment{}).Where(column+" IN ?", ids).Pluck("storage_key", &keys).Error; err != nil {
+				return err
+			}
+			blobKeys = append(blobKeys, keys...)
+			return tx.Where(column+" IN ?", ids).Delete(&models.Attachment{}).Error
+		}
+
+		steps := []struct {
+			ids    []uint
+			delete func(ids []uint) error
+		}{
+			{commentIDs, func(ids []uint) error {
+				if err := tx.Where("target_type = ? AND target_id IN ?", models.ReactionTargetComment, ids).Delete(&models.Reaction{}).Error; err != nil {
+					return err
+				}
+				if err := deleteAttachments("comment_id", ids); err != nil {
+					return err
+				}
+				return tx.Unscoped().Delete(&models.Comment{}, ids).Error
+			}},
+			{taskIDs, func(ids []uint) error {
+				if err := tx.Where("target_type = ? AND target_id IN ?", models.ReactionTargetTask, ids).Delete(&models.Reaction{}).Error; err != nil {
+					return err
+				}
+				if err := deleteAttachments("task_id", ids); err != nil {
+					return err
+				}
+				return tx.Unscoped().Delete(&models.Task{}, ids).Error
+			}},
+			{ids[models.AuditEntityTeam], func(ids []uint) error {
+				if err := tx.Exec("DELETE FROM user_teams WHERE team_id IN ?", ids).Error; err != nil {
+					return err
+				}
+				return tx.Unscoped().Delete(&models.Team{}, ids).Error
+			}},
+			{ids[models.AuditEntityProject], func(ids []uint) error {
+				if err := tx.Exec("DELETE FROM user_projects WHERE project_id IN ?", ids).Error; err != nil {
+					return err
+				}
+				if err := tx.Model(&models.Team{}).Unscoped().Where("project_id IN ?", ids).Update("project_id", nil).Error; err != nil {
+					return err
+				}
+				if err := tx.Where("project_id IN ?", ids).Delete(&models.Webhook{}).Error; err != nil {
+					return err
+				}
+				return tx.Unscoped().Delete(&models.Project{}, ids).Error
+			}},
+			{ids[models.AuditEntityUser], func(ids []uint) error {
+				if err := tx.Exec("DELETE FROM user_projects WHERE user_id IN ?", ids).Error; err != nil {
+					return err
+				}
+				if err := tx.Exec("DELETE FROM user_teams WHERE user_id IN ?", ids).Error; err != nil {
+					return err
+				}
+				if err := tx.Model(&models.Task{}).Unscoped().Where("assigned_to IN ?", ids).Update("assigned_to", nil).Error; err != nil {
+					return err
+				}
+				if err := tx.Model(&models.Comment{}).Unscoped().Where("user_id IN ?", ids).Update("user_id", nil).Error; err != nil {
+					return err
+				}
+				return tx.Unscoped().Delete(&models.User{}, ids).Error
+			}},
+		}
+		for _, step := range steps {
+			if len(step.ids) == 0 {
+				continue
+			}
+			if err := step.delete(step.ids); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return purged, blobKeys, nil
+}
+
+// trashQuery selects the deleted rows of entityType, or of every type when empty,
+// optionally only those deleted before deletedBefore.
+func trashQuery(entityType string, deletedBefore time.Time) (string, []interface{}) {
+	var parts []string
+	var args []interface{}
+	for _, source := range trashSources {
+		if entityType != "" && entityType != source.entityType {
+			continue
+		}
+		part := fmt.Sprintf(
+			"SELECT '%s' AS entity_type, id AS entity_id, %s AS name, deleted_at FROM %s WHERE deleted_at IS NOT NULL",
+			source.entityType, source.name, source.table,
+		)
+		if !deletedBefore.IsZero() {
+			part += " AND deleted_at < ?"
+			args = append(args, deletedBefore)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " UNION ALL "), args
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"example/project-management-system/internal/models"
+)
+
+func TestTrashRepository_DeleteRestoreAndPurgeProject(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	trash := NewTrashRepository(db)
+
+	user := models.User{Username: "owner", Email: "owner@example.com", Password: "password"}
+	require.NoError(t, db.Create(&user).Error)
+	project := models.Project{Name: "Apollo", Users: []models.User{user}}
+	require.NoError(t, db.Create(&project).Error)
+	task := models.Task{Title: "Launch", ProjectID: project.ID, AssignedTo: user.ID}
+	require.NoError(t, db.Create(&task).Error)
+	comment := models.Comment{Content: "Go", TaskID: task.ID, UserID: user.ID}
+	require.NoError(t, db.Create(&comment).Error)
+	reaction := models.Reaction{UserID: user.ID, Emoji: "rocket", TargetType: models.ReactionTargetTask, TargetID: task.ID}
+	require.NoError(t, db.Create(&reaction).Error)
+	for _, attachment := range []models.Attachment{
+		{TaskID: task.ID, Filename: "plan.pdf", StorageKey: "tasks/plan", UploadedBy: user.ID},
+		{TaskID: task.ID, CommentID: &comment.ID, Filename: "go.png", StorageKey: "comments/go", UploadedBy: user.ID},
+	} {
+		require.NoError(t, db.Create(&attachment).Error)
+	}
+
+	require.NoError(t, NewProjectRepository(db).DeleteProject(ctx, project.ID))
+
+	items, total, err := trash.GetTrash(ctx, "", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, items[0].DeletedAt.Unix(), items[2].DeletedAt.Unix())
+
+	var deleted models.Project
+	require.NoError(t, trash.FindWithDeleted(ctx, &deleted, project.ID))
+	require.True(t, deleted.DeletedAt.Valid)
+
+	t.Run("Restore", func(t *testing.T) {
+		require.NoError(t, trash.Restore(ctx, models.AuditEntityProject, project.ID, deleted.DeletedAt.Time))
+
+		var restored models.Comment
+		assert.NoError(t, db.First(&restored, comment.ID).Error)
+		_, total, err := trash.GetTrash(ctx, "", 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		require.NoError(t, NewProjectRepository(db).DeleteProject(ctx, project.ID))
+
+		purged, blobKeys, err := trash.Purge(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Len(t, purged, 3)
+		assert.ElementsMatch(t, []string{"tasks/plan", "comments/go"}, blobKeys)
+
+		var count int64
+		db.Unscoped().Model(&models.Task{}).Count(&count)
+		assert.Zero(t, count)
+		db.Model(&models.Reaction{}).Count(&count)
+		assert.Zero(t, count)
+		db.Model(&models.Attachment{}).Count(&count)
+		assert.Zero(t, count)
+		db.Table("user_projects").Count(&count)
+		assert.Zero(t, count)
+	})
+}
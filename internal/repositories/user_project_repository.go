@@ -1,13 +1,15 @@
 package repositories
 
 import (
+	"context"
 	"example/project-management-system/internal/models"
 
 	"gorm.io/gorm"
 )
 
 type UserProjectRepository interface {
-	AddUserToProject(userID uint, projectID uint) error
+	AddUserToProject(ctx context.Context, userID uint, projectID uint) error
+	RemoveUserFromProject(ctx context.Context, userID uint, projectID uint) error
 }
 
 type UserProjectRepositoryImplementation struct {
@@ -19,16 +21,31 @@ func NewUserProjectRepository(db *gorm.DB) UserProjectRepository {
 }
 
 // AddUserToProject adds a user to a project.
-func (repo *UserProjectRepositoryImplementation) AddUserToProject(userID uint, projectID uint) error {
+func (repo *UserProjectRepositoryImplementation) AddUserToProject(ctx context.Context, userID uint, projectID uint) error {
 	user := &models.User{}
 	project := &models.Project{}
 
-	if err := repo.db.First(user, userID).Error; err != nil {
+	if err := dbFromContext(ctx, repo.db).First(user, userID).Error; err != nil {
 		return err
 	}
-	if err := repo.db.First(project, projectID).Error; err != nil {
+	if err := dbFromContext(ctx, repo.db).First(project, projectID).Error; err != nil {
 		return err
 	}
 
-	return repo.db.Model(user).Association("Projects").Append(project)
-}
\ No newline at end of file
+	return dbFromContext(ctx, repo.db).Model(user).Association("Projects").Append(project)
+}
+
+// RemoveUserFromProject removes a user from a project.
+func (repo *UserProjectRepositoryImplementation) RemoveUserFromProject(ctx context.Context, userID uint, projectID uint) error {
+	user := &models.User{}
+	project := &models.Project{}
+
+	if err := dbFromContext(ctx, repo.db).First(user, userID).Error; err != nil {
+		return err
+	}
+	if err := dbFromContext(ctx, repo.db).First(project, projectID).Error; err != nil {
+		return err
+	}
+
+	return dbFromContext(ctx, repo.db).Model(user).Association("Projects").Delete(project)
+}
@@ -13,7 +13,9 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByID(ctx context.Context, id uint) (*models.User, error)
 	GetAllUsers(ctx context.Context, page, pageSize int) ([]models.User, int64, error)
+	UpdateUser(ctx context.Context, user *models.User) error
 	DeleteUser(ctx context.Context, id uint) error
+	GetUsersByUsernames(ctx context.Context, usernames []string) ([]models.User, error)
 }
 
 // UserRepositoryImplementation is an implementation of the UserRepository using Gorm.
@@ -27,33 +29,23 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 }
 
 func (r *UserRepositoryImplementation) CreateUser(ctx context.Context, user *models.User) error {
-    // Begin transaction for additional safety
-    tx := r.db.WithContext(ctx).Begin()
+	db := dbFromContext(ctx, r.db)
 
 	if len(user.ProjectIDs) > 0 {
-		if err := tx.Find(&user.Projects, user.ProjectIDs).Error; err != nil {
-            return fmt.Errorf("error loading projects: %w", err)
-        }
+		if err := db.Find(&user.Projects, user.ProjectIDs).Error; err != nil {
+			return fmt.Errorf("error loading projects: %w", err)
+		}
 	}
 
-    // Attempt to create user
-    if err := tx.Create(user).Error; err != nil {
-        tx.Rollback()
-        return fmt.Errorf("failed to create user: %w", err)
-    }
-
-    // Commit transaction
-    if err := tx.Commit().Error; err != nil {
-        return fmt.Errorf("failed to commit transaction: %w", err)
-    }
-
-    return nil
+	if err := db.Create(user).Error; err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	return nil
 }
 
-
 func (r *UserRepositoryImplementation) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
 	var user models.User
-	if err := r.db.WithContext(ctx).Preload("Projects").First(&user, id).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Preload("Projects").First(&user, id).Error; err != nil {
 		return nil, err
 	}
 	// Create ProjectIDs from Projects
@@ -70,13 +62,13 @@ func (r *UserRepositoryImplementation) GetAllUsers(ctx context.Context, page, pa
 	var total int64
 
 	// Count total users
-	if err := r.db.WithContext(ctx).Model(&models.User{}).Count(&total).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Model(&models.User{}).Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count users: %w", err)
 	}
 
 	// Fetch users with pagination
 	offset := (page - 1) * pageSize
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Preload("Projects").
 		Offset(offset).
 		Limit(pageSize).
@@ -94,6 +86,21 @@ func (r *UserRepositoryImplementation) GetAllUsers(ctx context.Context, page, pa
 	return users, total, nil
 }
 
+// UpdateUser saves the user when it is still at user.Version, which is then incremented.
+func (r *UserRepositoryImplementation) UpdateUser(ctx context.Context, user *models.User) error {
+	return saveVersion(dbFromContext(ctx, r.db), user, &user.Version)
+}
+
 func (r *UserRepositoryImplementation) DeleteUser(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.User{}, id).Error
+	return dbFromContext(ctx, r.db).Delete(&models.User{}, id).Error
+}
+
+func (r *UserRepositoryImplementation) GetUsersByUsernames(ctx context.Context, usernames []string) ([]models.User, error) {
+	var users []models.User
+	if len(usernames) == 0 {
+		return users, nil
+	}
+
+	err := dbFromContext(ctx, r.db).Where("username IN ?", usernames).Find(&users).Error
+	return users, err
 }
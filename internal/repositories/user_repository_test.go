@@ -43,15 +43,8 @@ type MockUserRepository struct {
 
 // Repository Tests
 func TestUserRepository_CreateUser(t *testing.T) {
-	var repo UserRepository
+	repo := NewUserRepository(setupTestDB(t))
 	t.Run("Successful User Creation", func(t *testing.T) {
-		// Setup mock DB
-		// mockDB, mockSQL := repositories.NewMockDB()
-		// mockSQL.ExpectBegin()
-		// mockSQL.ExpectCreate().WillReturnResult(mock.NewResult(1, 1))
-		// mockSQL.ExpectCommit()
-
-		// repo := repo.NewUserRepository(mockDB)
 		user := &models.User{
 			Username:  "testuser",
 			Email:     "test@example.com",
@@ -62,5 +55,16 @@ func TestUserRepository_CreateUser(t *testing.T) {
 		}
 		err := repo.CreateUser(context.Background(), user)
 		assert.NoError(t, err)
+		assert.NotZero(t, user.ID)
+
+		created, err := repo.GetUserByID(context.Background(), user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "testuser", created.Username)
+	})
+
+	t.Run("Duplicate Username", func(t *testing.T) {
+		user := &models.User{Username: "testuser", Email: "other@example.com", Password: "password"}
+		err := repo.CreateUser(context.Background(), user)
+		assert.Error(t, err)
 	})
 }
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrVersionConflict is returned when a row was changed since the version a change is based on
+var ErrVersionConflict = errors.New("the entity was changed by someone else, reload it and try again")
+
+// saveVersion saves all fields of model when its row is still at *version, and
+// increments the version. Associations are left alone, they have their own endpoints.
+func saveVersion(db *gorm.DB, model interface{}, version *uint) error {
+	expected := *version
+	*version = expected + 1
+
+	// Selecting the fields keeps Save from inserting the row when no row matched
+	result := db.Select("*").Omit(clause.Associations).Where("version = ?", expected).Save(model)
+	if result.Error != nil || result.RowsAffected == 0 {
+		*version = expected
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
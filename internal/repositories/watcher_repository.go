@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WatcherRepository interface {
+	AddWatchers(ctx context.Context, taskID uint, userIDs []uint) error
+	RemoveWatcher(ctx context.Context, taskID, userID uint) error
+	GetWatcherIDs(ctx context.Context, taskID uint) ([]uint, error)
+	GetWatchers(ctx context.Context, taskID uint) ([]models.User, error)
+	GetWatchedTasks(ctx context.Context, userID uint, page, pageSize int) ([]models.Task, int64, error)
+}
+
+type WatcherRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewWatcherRepository(db *gorm.DB) WatcherRepository {
+	return &WatcherRepositoryImplementation{db: db}
+}
+
+// AddWatchers adds the users to the watchers of the task. Users who already watch
+// it or do not exist are skipped.
+func (r *WatcherRepositoryImplementation) AddWatchers(ctx context.Context, taskID uint, userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	db := dbFromContext(ctx, r.db)
+
+	var existing []uint
+	if err := db.Model(&models.User{}).Where("id IN ?", userIDs).Pluck("id", &existing).Error; err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+	watchers := make([]models.TaskWatcher, len(existing))
+	for i, userID := range existing {
+		watchers[i] = models.TaskWatcher{TaskID: taskID, UserID: userID}
+	}
+	return db.Omit("Task", "User").Clauses(clause.OnConflict{DoNothing: true}).Create(&watchers).Error
+}
+
+func (r *WatcherRepositoryImplementation) RemoveWatcher(ctx context.Context, taskID, userID uint) error {
+	return dbFromContext(ctx, r.db).Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskWatcher{}).Error
+}
+
+func (r *WatcherRepositoryImplementation) GetWatcherIDs(ctx context.Context, taskID uint) ([]uint, error) {
+	var userIDs []uint
+	err := dbFromContext(ctx, r.db).Model(&models.TaskWatcher{}).Where("task_id = ?", taskID).Order("user_id").Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *WatcherRepositoryImplementation) GetWatchers(ctx context.Context, taskID uint) ([]models.User, error) {
+	var users []models.User
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN task_watchers ON task_watchers.user_id = users.id").
+		Where("task_watchers.task_id = ?", taskID).
+		Order("users.id").
+		Find(&users).Error
+	return users, err
+}
+
+// GetWatchedTasks returns the tasks the user watches, most recently updated first.
+// Deleted tasks are left out.
+func (r *WatcherRepositoryImplementation) GetWatchedTasks(ctx context.Context, userID uint, page, pageSize int) ([]models.Task, int64, error) {
+	var tasks []models.Task
+	var total int64
+
+	watched := func() *gorm.DB {
+		return dbFromContext(ctx, r.db).Model(&models.Task{}).
+			Joins("JOIN task_watchers ON task_watchers.task_id = tasks.id").
+			Where("task_watchers.user_id = ?", userID)
+	}
+
+	if err := watched().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := watched().
+		Order("tasks.updated_at DESC, tasks.id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Preload("Assignee").
+		Preload("Project").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := attachTaskReactions(dbFromContext(ctx, r.db), tasks); err != nil {
+		return nil, 0, err
+	}
+	if err := attachTaskFields(dbFromContext(ctx, r.db), tasks); err != nil {
+		return nil, 0, err
+	}
+	if err := attachTaskChecklists(dbFromContext(ctx, r.db), tasks); err != nil {
+		return nil, 0, err
+	}
+	return tasks, total, nil
+}
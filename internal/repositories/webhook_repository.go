@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetWebhookByID(ctx context.Context, id uint) (*models.Webhook, error)
+	GetWebhooksByProject(ctx context.Context, projectID uint, activeOnly bool) ([]models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
+	RecordWebhookFailure(ctx context.Context, id uint, disableAfter int, now time.Time) (bool, error)
+	ResetWebhookFailures(ctx context.Context, id uint) error
+	DeleteWebhook(ctx context.Context, id uint) error
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, webhookID, id uint) (*models.WebhookDelivery, error)
+	GetDeliveriesByWebhook(ctx context.Context, webhookID uint, page, pageSize int) ([]models.WebhookDelivery, int64, error)
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+type WebhookRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &WebhookRepositoryImplementation{db: db}
+}
+
+func (r *WebhookRepositoryImplementation) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	return dbFromContext(ctx, r.db).Create(webhook).Error
+}
+
+func (r *WebhookRepositoryImplementation) GetWebhookByID(ctx context.Context, id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := dbFromContext(ctx, r.db).First(&webhook, id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepositoryImplementation) GetWebhooksByProject(ctx context.Context, projectID uint, activeOnly bool) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	query := dbFromContext(ctx, r.db).Where("project_id = ?", projectID)
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *WebhookRepositoryImplementation) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	return dbFromContext(ctx, r.db).Save(webhook).Error
+}
+
+// RecordWebhookFailure counts a failed delivery of the webhook and disables it once
+// disableAfter deliveries in a row have failed. It reports whether it disabled the
+// webhook. Only the failure columns are written, so concurrent deliveries and edits
+// by the owner are not lost.
+func (r *WebhookRepositoryImplementation) RecordWebhookFailure(ctx context.Context, id uint, disableAfter int, now time.Time) (bool, error) {
+	db := dbFromContext(ctx, r.db)
+	err := db.Model(&models.Webhook{}).
+		Where("id = ?", id).
+		UpdateColumn("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error
+	if err != nil {
+		return false, err
+	}
+
+	result := db.Model(&models.Webhook{}).
+		Where("id = ? AND active = ? AND consecutive_failures >= ?", id, true, disableAfter).
+		UpdateColumns(map[string]interface{}{"active": false, "disabled_at": now})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ResetWebhookFailures clears the failure count of the webhook after a successful delivery
+func (r *WebhookRepositoryImplementation) ResetWebhookFailures(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Model(&models.Webhook{}).
+		Where("id = ? AND consecutive_failures > 0", id).
+		UpdateColumn("consecutive_failures", 0).Error
+}
+
+func (r *WebhookRepositoryImplementation) DeleteWebhook(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Delete(&models.Webhook{}, id).Error
+}
+
+func (r *WebhookRepositoryImplementation) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return dbFromContext(ctx, r.db).Create(delivery).Error
+}
+
+func (r *WebhookRepositoryImplementation) GetDeliveryByID(ctx context.Context, webhookID, id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := dbFromContext(ctx, r.db).Where("webhook_id = ?", webhookID).First(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepositoryImplementation) GetDeliveriesByWebhook(ctx context.Context, webhookID uint, page, pageSize int) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+
+	query := dbFromContext(ctx, r.db).Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the delivery log, newest first
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&deliveries).Error
+
+	return deliveries, total, err
+}
+
+// GetDueDeliveries returns the pending deliveries of active webhooks whose next attempt is due, oldest first.
+func (r *WebhookRepositoryImplementation) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := dbFromContext(ctx, r.db).
+		InnerJoins("Webhook", r.db.Where(&models.Webhook{Active: true})).
+		Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+		Order("webhook_deliveries.next_attempt_at, webhook_deliveries.id").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *WebhookRepositoryImplementation) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return dbFromContext(ctx, r.db).Omit("Webhook").Save(delivery).Error
+}
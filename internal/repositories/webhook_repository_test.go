@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"example/project-management-system/internal/models"
+)
+
+func TestWebhookRepository_RecordWebhookFailure(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := NewWebhookRepository(db)
+
+	project := models.Project{Name: "Apollo"}
+	require.NoError(t, db.Create(&project).Error)
+	webhook := models.Webhook{ProjectID: project.ID, URL: "https://example.com/hook", Secret: "s3cret", Active: true}
+	require.NoError(t, repo.CreateWebhook(ctx, &webhook))
+
+	disabled, err := repo.RecordWebhookFailure(ctx, webhook.ID, 2, time.Now())
+	require.NoError(t, err)
+	assert.False(t, disabled)
+
+	// The owner changes the URL between two failures, the change is kept
+	require.NoError(t, db.Model(&models.Webhook{}).Where("id = ?", webhook.ID).Update("url", "https://example.com/new").Error)
+	disabled, err = repo.RecordWebhookFailure(ctx, webhook.ID, 2, time.Now())
+	require.NoError(t, err)
+	assert.True(t, disabled)
+
+	stored, err := repo.GetWebhookByID(ctx, webhook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.ConsecutiveFailures)
+	assert.False(t, stored.Active)
+	assert.NotNil(t, stored.DisabledAt)
+	assert.Equal(t, "https://example.com/new", stored.URL)
+
+	// A disabled webhook is not disabled again
+	disabled, err = repo.RecordWebhookFailure(ctx, webhook.ID, 2, time.Now())
+	require.NoError(t, err)
+	assert.False(t, disabled)
+
+	require.NoError(t, repo.ResetWebhookFailures(ctx, webhook.ID))
+	stored, err = repo.GetWebhookByID(ctx, webhook.ID)
+	require.NoError(t, err)
+	assert.Zero(t, stored.ConsecutiveFailures)
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WorklogFilter narrows the worklogs of a timesheet to those started in [From, To),
+// zero IDs match every user or project
+type WorklogFilter struct {
+	UserID    uint
+	ProjectID uint
+	From      time.Time
+	To        time.Time
+}
+
+type WorklogRepository interface {
+	CreateWorklog(ctx context.Context, worklog *models.Worklog) error
+	GetWorklogByID(ctx context.Context, id uint) (*models.Worklog, error)
+	GetWorklogsByTask(ctx context.Context, taskID uint) ([]models.Worklog, error)
+	GetWorklogs(ctx context.Context, filter WorklogFilter) ([]models.Worklog, error)
+	GetOverlappingWorklogs(ctx context.Context, userID uint, start, end time.Time, excludeID uint) ([]models.Worklog, error)
+	UpdateWorklog(ctx context.Context, worklog *models.Worklog) error
+	DeleteWorklog(ctx context.Context, id uint) error
+	GetTimer(ctx context.Context, userID uint) (*models.Timer, error)
+	CreateTimer(ctx context.Context, timer *models.Timer) error
+	DeleteTimer(ctx context.Context, userID uint) error
+}
+
+type WorklogRepositoryImplementation struct {
+	db *gorm.DB
+}
+
+func NewWorklogRepository(db *gorm.DB) WorklogRepository {
+	return &WorklogRepositoryImplementation{db: db}
+}
+
+func (r *WorklogRepositoryImplementation) CreateWorklog(ctx context.Context, worklog *models.Worklog) error {
+	return dbFromContext(ctx, r.db).Omit(clause.Associations).Create(worklog).Error
+}
+
+func (r *WorklogRepositoryImplementation) GetWorklogByID(ctx context.Context, id uint) (*models.Worklog, error) {
+	var worklog models.Worklog
+	if err := dbFromContext(ctx, r.db).First(&worklog, id).Error; err != nil {
+		return nil, err
+	}
+	return &worklog, nil
+}
+
+// GetWorklogsByTask returns the worklogs of a task in the order they started.
+func (r *WorklogRepositoryImplementation) GetWorklogsByTask(ctx context.Context, taskID uint) ([]models.Worklog, error) {
+	var worklogs []models.Worklog
+	err := dbFromContext(ctx, r.db).
+		Where("task_id = ?", taskID).
+		Order("started_at, id").
+		Find(&worklogs).Error
+	return worklogs, err
+}
+
+// GetWorklogs returns the matching worklogs with their tasks, including those in
+// the trash, in the order they started.
+func (r *WorklogRepositoryImplementation) GetWorklogs(ctx context.Context, filter WorklogFilter) ([]models.Worklog, error) {
+	var worklogs []models.Worklog
+
+	query := dbFromContext(ctx, r.db).
+		Preload("Task", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("worklogs.started_at >= ? AND worklogs.started_at < ?", filter.From, filter.To)
+	if filter.UserID != 0 {
+		query = query.Where("worklogs.user_id = ?", filter.UserID)
+	}
+	if filter.ProjectID != 0 {
+		query = query.Joins("JOIN tasks ON tasks.id = worklogs.task_id").Where("tasks.project_id = ?", filter.ProjectID)
+	}
+
+	err := query.Order("worklogs.started_at, worklogs.id").Find(&worklogs).Error
+	return worklogs, err
+}
+
+// GetOverlappingWorklogs returns the worklogs of a user that overlap [start, end),
+// other than the worklog excludeID.
+func (r *WorklogRepositoryImplementation) GetOverlappingWorklogs(ctx context.Context, userID uint, start, end time.Time, excludeID uint) ([]models.Worklog, error) {
+	var worklogs []models.Worklog
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND started_at < ? AND ended_at > ? AND id <> ?", userID, end, start, excludeID).
+		Find(&worklogs).Error
+	return worklogs, err
+}
+
+func (r *WorklogRepositoryImplementation) UpdateWorklog(ctx context.Context, worklog *models.Worklog) error {
+	return dbFromContext(ctx, r.db).Omit(clause.Associations).Save(worklog).Error
+}
+
+func (r *WorklogRepositoryImplementation) DeleteWorklog(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Delete(&models.Worklog{}, id).Error
+}
+
+// GetTimer returns the running timer of a user, gorm.ErrRecordNotFound when none runs.
+func (r *WorklogRepositoryImplementation) GetTimer(ctx context.Context, userID uint) (*models.Timer, error) {
+	var timer models.Timer
+	if err := dbFromContext(ctx, r.db).First(&timer, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &timer, nil
+}
+
+func (r *WorklogRepositoryImplementation) CreateTimer(ctx context.Context, timer *models.Timer) error {
+	return dbFromContext(ctx, r.db).Omit(clause.Associations).Create(timer).Error
+}
+
+func (r *WorklogRepositoryImplementation) DeleteTimer(ctx context.Context, userID uint) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID).Delete(&models.Timer{}).Error
+}
@@ -17,10 +17,28 @@ func RegisterRoutes(
 	teamHandler handlers.TeamHandler,
 	commentHandler handlers.CommentHandler,
 	userProjectHandler handlers.UserProjectHandler,
+	reactionHandler handlers.ReactionHandler,
+	notificationHandler handlers.NotificationHandler,
+	emailHandler handlers.EmailHandler,
+	webhookHandler handlers.WebhookHandler,
+	auditHandler handlers.AuditHandler,
+	trashHandler handlers.TrashHandler,
+	idempotencyHandler handlers.IdempotencyHandler,
+	taskBulkHandler handlers.TaskBulkHandler,
+	taskTransferHandler handlers.TaskTransferHandler,
+	projectTemplateHandler handlers.ProjectTemplateHandler,
+	sprintHandler handlers.SprintHandler,
+	milestoneHandler handlers.MilestoneHandler,
+	worklogHandler handlers.WorklogHandler,
+	timesheetApprovalHandler handlers.TimesheetApprovalHandler,
+	customFieldHandler handlers.CustomFieldHandler,
+	checklistHandler handlers.ChecklistHandler,
+	attachmentHandler handlers.AttachmentHandler,
+	watcherHandler handlers.WatcherHandler,
 ) http.Handler {
 
 	router.HandleFunc("POST /api/v1/users",
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, userHandler.CreateUser),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(userHandler.CreateUser)),
 	)
 	router.HandleFunc("GET /api/v1/users",
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, userHandler.GetAllUsers),
@@ -28,15 +46,21 @@ func RegisterRoutes(
 	router.HandleFunc("GET /api/v1/users/{id}",
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, userHandler.GetUserByID),
 	)
+	router.HandleFunc("PATCH /api/v1/users/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, userHandler.PatchUser)),
+	)
 	router.HandleFunc("DELETE /api/v1/users/{id}",
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, userHandler.DeleteUser),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, userHandler.DeleteUser)),
 	)
 	router.HandleFunc("POST /api/v1/projects/{projectId}/users/{userId}", 
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, userProjectHandler.AddUserToProject),
 	)
+	router.HandleFunc("DELETE /api/v1/projects/{projectId}/users/{userId}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, userProjectHandler.RemoveUserFromProject),
+	)
 
 	router.HandleFunc("POST /api/v1/projects",
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, projectHandler.CreateProject),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(projectHandler.CreateProject)),
 	)
 	// router.HandleFunc("POST /api/v1/projects",
 	// 	projectHandler.CreateProject,
@@ -48,18 +72,49 @@ func RegisterRoutes(
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, projectHandler.GetProjectByID),
 	)
 	router.HandleFunc("PUT /api/v1/projects/{id}",
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, projectHandler.UpdateProject),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, projectHandler.UpdateProject)),
+	)
+	router.HandleFunc("PATCH /api/v1/projects/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, projectHandler.PatchProject)),
 	)
 	router.HandleFunc("DELETE /api/v1/projects/{id}",
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, projectHandler.DeleteProject),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, projectHandler.DeleteProject)),
+	)
+	router.HandleFunc("POST /api/v1/projects/{id}/status",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, projectHandler.ChangeProjectStatus)),
+	)
+	router.HandleFunc("GET /api/v1/projects/{project_id}/tasks",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, taskHandler.GetTasksByProject),
+	)
+	router.HandleFunc("POST /api/v1/projects/{id}/clone",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(projectTemplateHandler.CloneProject)),
+	)
+	router.HandleFunc("POST /api/v1/projects/from-template",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(projectTemplateHandler.CreateProjectFromTemplate)),
 	)
-	router.HandleFunc("GET /api/v1/projects/{projectID}/tasks",
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, projectHandler.GetTaskByProjectID),
+	router.HandleFunc("POST /api/v1/projects/{id}/templates",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(projectTemplateHandler.CreateTemplateFromProject)),
+	)
+
+	router.HandleFunc("POST /api/v1/project-templates",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(projectTemplateHandler.CreateTemplate)),
+	)
+	router.HandleFunc("GET /api/v1/project-templates",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, projectTemplateHandler.GetTemplates),
+	)
+	router.HandleFunc("GET /api/v1/project-templates/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, projectTemplateHandler.GetTemplateByID),
+	)
+	router.HandleFunc("DELETE /api/v1/project-templates/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, projectTemplateHandler.DeleteTemplate),
 	)
 
 
 	router.HandleFunc("POST /api/v1/tasks", 
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, taskHandler.CreateTask),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(taskHandler.CreateTask)),
+	)
+	router.HandleFunc("POST /api/v1/tasks/bulk",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(taskBulkHandler.BulkTasks)),
 	)
 	router.HandleFunc("GET /api/v1/tasks/{id}", 
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, taskHandler.GetTaskByID),
@@ -68,15 +123,24 @@ func RegisterRoutes(
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, taskHandler.GetTasksByProject),
 	)
 	router.HandleFunc("PUT /api/v1/tasks/{id}", 
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, taskHandler.UpdateTask),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, taskHandler.UpdateTask)),
+	)
+	router.HandleFunc("PATCH /api/v1/tasks/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, taskHandler.PatchTask)),
 	)
 	router.HandleFunc("DELETE /api/v1/tasks/{id}", 
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, taskHandler.DeleteTask),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, taskHandler.DeleteTask)),
+	)
+	router.HandleFunc("POST /api/v1/tasks/{id}/move",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, taskTransferHandler.MoveTask)),
+	)
+	router.HandleFunc("POST /api/v1/tasks/{id}/copy",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(taskTransferHandler.CopyTask)),
 	)
 
 
 	router.HandleFunc("POST /api/v1/teams", 
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, teamHandler.CreateTeam),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(teamHandler.CreateTeam)),
 	)
 	router.HandleFunc("GET /api/v1/teams/{id}", 
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, teamHandler.GetTeamByID),
@@ -85,15 +149,18 @@ func RegisterRoutes(
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, teamHandler.GetPaginatedTeams),
 	)
 	router.HandleFunc("PUT /api/v1/teams/{id}", 
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, teamHandler.UpdateTeam),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, teamHandler.UpdateTeam)),
+	)
+	router.HandleFunc("PATCH /api/v1/teams/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, teamHandler.PatchTeam)),
 	)
 	router.HandleFunc("DELETE /api/v1/teams/{id}", 
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, teamHandler.DeleteTeam),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, middleware.RequireIfMatch(cfg.RequireIfMatch, teamHandler.DeleteTeam)),
 	)
 
 
 	router.HandleFunc("POST /api/v1/comments", 
-		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, commentHandler.CreateComment),
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(commentHandler.CreateComment)),
 	)
 	router.HandleFunc("GET /api/v1/comments/{id}", 
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, commentHandler.GetCommentByID),
@@ -101,16 +168,306 @@ func RegisterRoutes(
 	router.HandleFunc("GET /api/v1/comments", 
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, commentHandler.GetCommentsByTask),
 	)
+	router.HandleFunc("PATCH /api/v1/comments/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, commentHandler.PatchComment),
+	)
 	router.HandleFunc("DELETE /api/v1/comments/{id}", 
 		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, commentHandler.DeleteComment),
 	)
 
 
+	router.HandleFunc("POST /api/v1/tasks/{id}/reactions",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(reactionHandler.AddTaskReaction)),
+	)
+	router.HandleFunc("DELETE /api/v1/tasks/{id}/reactions/{emoji}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, reactionHandler.RemoveTaskReaction),
+	)
+	router.HandleFunc("POST /api/v1/comments/{id}/reactions",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(reactionHandler.AddCommentReaction)),
+	)
+	router.HandleFunc("DELETE /api/v1/comments/{id}/reactions/{emoji}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, reactionHandler.RemoveCommentReaction),
+	)
+
+
+	router.HandleFunc("GET /api/v1/me/notifications",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, notificationHandler.GetMyNotifications),
+	)
+	router.HandleFunc("POST /api/v1/me/notifications/{id}/read",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, notificationHandler.MarkNotificationAsRead),
+	)
+	router.HandleFunc("POST /api/v1/me/notifications/read-all",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, notificationHandler.MarkAllNotificationsAsRead),
+	)
+	router.HandleFunc("GET /api/v1/me/notification-preferences",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, notificationHandler.GetMyNotificationPreferences),
+	)
+	router.HandleFunc("PUT /api/v1/me/notification-preferences",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, notificationHandler.UpdateMyNotificationPreferences),
+	)
+	router.HandleFunc("GET /api/v1/me/email-preferences",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, emailHandler.GetMyEmailPreference),
+	)
+	router.HandleFunc("PUT /api/v1/me/email-preferences",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, emailHandler.UpdateMyEmailPreference),
+	)
+
+
+	router.HandleFunc("POST /api/v1/projects/{id}/sprints",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(sprintHandler.CreateSprint)),
+	)
+	router.HandleFunc("GET /api/v1/projects/{id}/sprints",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, sprintHandler.GetProjectSprints),
+	)
+	router.HandleFunc("GET /api/v1/sprints/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, sprintHandler.GetSprintByID),
+	)
+	router.HandleFunc("PUT /api/v1/sprints/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, sprintHandler.UpdateSprint),
+	)
+	router.HandleFunc("DELETE /api/v1/sprints/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, sprintHandler.DeleteSprint),
+	)
+	router.HandleFunc("POST /api/v1/sprints/{id}/start",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, sprintHandler.StartSprint),
+	)
+	router.HandleFunc("POST /api/v1/sprints/{id}/close",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, sprintHandler.CloseSprint),
+	)
+	router.HandleFunc("POST /api/v1/sprints/{id}/tasks",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, sprintHandler.AddSprintTasks),
+	)
+	router.HandleFunc("DELETE /api/v1/sprints/{id}/tasks/{taskId}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, sprintHandler.RemoveSprintTask),
+	)
+	router.HandleFunc("GET /api/v1/sprints/{id}/burndown",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, sprintHandler.GetBurndown),
+	)
+
+	router.HandleFunc("POST /api/v1/projects/{id}/milestones",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(milestoneHandler.CreateMilestone)),
+	)
+	router.HandleFunc("GET /api/v1/projects/{id}/milestones",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, milestoneHandler.GetProjectMilestones),
+	)
+	router.HandleFunc("GET /api/v1/milestones/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, milestoneHandler.GetMilestoneByID),
+	)
+	router.HandleFunc("PUT /api/v1/milestones/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, milestoneHandler.UpdateMilestone),
+	)
+	router.HandleFunc("DELETE /api/v1/milestones/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, milestoneHandler.DeleteMilestone),
+	)
+	router.HandleFunc("POST /api/v1/milestones/{id}/tasks",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, milestoneHandler.AddMilestoneTasks),
+	)
+	router.HandleFunc("DELETE /api/v1/milestones/{id}/tasks/{taskId}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, milestoneHandler.RemoveMilestoneTask),
+	)
+
+	router.HandleFunc("POST /api/v1/tasks/{id}/worklogs",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(worklogHandler.CreateWorklog)),
+	)
+
+	router.HandleFunc("GET /api/v1/tasks/{id}/worklogs",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, worklogHandler.GetTaskWorklogs),
+	)
+
+	router.HandleFunc("PUT /api/v1/worklogs/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, worklogHandler.UpdateWorklog),
+	)
+
+	router.HandleFunc("DELETE /api/v1/worklogs/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, worklogHandler.DeleteWorklog),
+	)
+
+	router.HandleFunc("GET /api/v1/me/timer",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, worklogHandler.GetTimer),
+	)
+
+	router.HandleFunc("POST /api/v1/me/timer",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(worklogHandler.StartTimer)),
+	)
+
+	router.HandleFunc("POST /api/v1/me/timer/stop",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, worklogHandler.StopTimer),
+	)
+
+	router.HandleFunc("DELETE /api/v1/me/timer",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, worklogHandler.DiscardTimer),
+	)
+
+	router.HandleFunc("GET /api/v1/projects/{id}/timesheet",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, worklogHandler.GetProjectTimesheet),
+	)
+
+	router.HandleFunc("GET /api/v1/users/{id}/timesheet",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, worklogHandler.GetUserTimesheet),
+	)
+
+	router.HandleFunc("POST /api/v1/projects/{id}/timesheets",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(timesheetApprovalHandler.SubmitTimesheet)),
+	)
+
+	router.HandleFunc("GET /api/v1/projects/{id}/timesheets/pending",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, timesheetApprovalHandler.GetPendingApprovals),
+	)
+
+	router.HandleFunc("GET /api/v1/me/timesheets",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, timesheetApprovalHandler.GetMyTimesheetSubmissions),
+	)
+
+	router.HandleFunc("GET /api/v1/timesheets/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, timesheetApprovalHandler.GetTimesheetSubmission),
+	)
+
+	router.HandleFunc("POST /api/v1/timesheets/{id}/approve",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, timesheetApprovalHandler.ApproveTimesheet),
+	)
+
+	router.HandleFunc("POST /api/v1/timesheets/{id}/reject",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, timesheetApprovalHandler.RejectTimesheet),
+	)
+
+	router.HandleFunc("POST /api/v1/projects/{id}/custom-fields",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(customFieldHandler.CreateCustomField)),
+	)
+
+	router.HandleFunc("GET /api/v1/projects/{id}/custom-fields",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, customFieldHandler.GetCustomFields),
+	)
+
+	router.HandleFunc("PUT /api/v1/custom-fields/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, customFieldHandler.UpdateCustomField),
+	)
+
+	router.HandleFunc("DELETE /api/v1/custom-fields/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, customFieldHandler.DeleteCustomField),
+	)
+
+	router.HandleFunc("POST /api/v1/tasks/{id}/checklist",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(checklistHandler.AddChecklistItem)),
+	)
+
+	router.HandleFunc("GET /api/v1/tasks/{id}/checklist",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, checklistHandler.GetChecklist),
+	)
+
+	router.HandleFunc("PUT /api/v1/tasks/{id}/checklist/order",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, checklistHandler.ReorderChecklist),
+	)
+
+	router.HandleFunc("POST /api/v1/checklist-items/{id}/toggle",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, checklistHandler.ToggleChecklistItem),
+	)
+
+	router.HandleFunc("DELETE /api/v1/checklist-items/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, checklistHandler.DeleteChecklistItem),
+	)
+
+	router.HandleFunc("POST /api/v1/tasks/{id}/attachments",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, attachmentHandler.AttachToTask),
+	)
+
+	router.HandleFunc("GET /api/v1/tasks/{id}/attachments",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, attachmentHandler.GetTaskAttachments),
+	)
+
+	router.HandleFunc("POST /api/v1/comments/{id}/attachments",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, attachmentHandler.AttachToComment),
+	)
+
+	router.HandleFunc("GET /api/v1/comments/{id}/attachments",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, attachmentHandler.GetCommentAttachments),
+	)
+
+	router.HandleFunc("GET /api/v1/attachments/{id}/content",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, attachmentHandler.DownloadAttachment),
+	)
+
+	router.HandleFunc("DELETE /api/v1/attachments/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, attachmentHandler.DeleteAttachment),
+	)
+
+	router.HandleFunc("POST /api/v1/tasks/{id}/watch",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, watcherHandler.WatchTask),
+	)
+
+	router.HandleFunc("DELETE /api/v1/tasks/{id}/watch",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, watcherHandler.UnwatchTask),
+	)
+
+	router.HandleFunc("GET /api/v1/tasks/{id}/watchers",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, watcherHandler.GetTaskWatchers),
+	)
+
+	router.HandleFunc("GET /api/v1/me/watching",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, watcherHandler.GetMyWatchedTasks),
+	)
+
+	router.HandleFunc("POST /api/v1/projects/{id}/webhooks",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, idempotencyHandler.Idempotent(webhookHandler.CreateWebhook)),
+	)
+	router.HandleFunc("GET /api/v1/projects/{id}/webhooks",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, webhookHandler.GetProjectWebhooks),
+	)
+	router.HandleFunc("GET /api/v1/webhooks/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, webhookHandler.GetWebhookByID),
+	)
+	router.HandleFunc("PUT /api/v1/webhooks/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, webhookHandler.UpdateWebhook),
+	)
+	router.HandleFunc("DELETE /api/v1/webhooks/{id}",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, webhookHandler.DeleteWebhook),
+	)
+	router.HandleFunc("GET /api/v1/webhooks/{id}/deliveries",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, webhookHandler.GetWebhookDeliveries),
+	)
+	router.HandleFunc("POST /api/v1/webhooks/{id}/deliveries/{deliveryId}/redeliver",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, webhookHandler.RedeliverWebhookDelivery),
+	)
+
+
+	router.HandleFunc("GET /api/v1/audit",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, auditHandler.GetAuditLog),
+	)
+	router.HandleFunc("GET /api/v1/tasks/{id}/history",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, auditHandler.GetTaskHistory),
+	)
+	router.HandleFunc("GET /api/v1/projects/{id}/history",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, auditHandler.GetProjectHistory),
+	)
+	router.HandleFunc("GET /api/v1/teams/{id}/history",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, auditHandler.GetTeamHistory),
+	)
+
+
+	router.HandleFunc("GET /api/v1/trash",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, trashHandler.GetTrash),
+	)
+	router.HandleFunc("POST /api/v1/users/{id}/restore",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, trashHandler.RestoreUser),
+	)
+	router.HandleFunc("POST /api/v1/projects/{id}/restore",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, trashHandler.RestoreProject),
+	)
+	router.HandleFunc("POST /api/v1/tasks/{id}/restore",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, trashHandler.RestoreTask),
+	)
+	router.HandleFunc("POST /api/v1/teams/{id}/restore",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, trashHandler.RestoreTeam),
+	)
+	router.HandleFunc("POST /api/v1/comments/{id}/restore",
+		middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, cfg.ENVIRONMENT, trashHandler.RestoreComment),
+	)
+
+
 	// router.HandleFunc("POST /api/v1/users-projects/{projectId}/users/{userId}", 
 	// 	middleware.ValidateJWT(cfg.AUTH0_AUDIENCE, cfg.AUTH0_DOMAIN, userProjectHandler.AddUserToProject),
 	// )
 	
 	// return middleware.HandleCacheControl(router)
-	return router
+	return middleware.RequestInfo(cfg.TrustProxy, router)
 }
 
@@ -1,10 +1,17 @@
 package server
 
 import (
+	"context"
 	"example/project-management-system/internal/config"
+	"example/project-management-system/internal/events"
 	"example/project-management-system/internal/handlers"
+	"example/project-management-system/internal/mailer"
 	"example/project-management-system/internal/repositories"
 	"example/project-management-system/internal/services"
+	"example/project-management-system/internal/storage"
+	"example/project-management-system/internal/utils/markdown"
+	"example/project-management-system/pkg/logger"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -12,7 +19,7 @@ import (
 	"gorm.io/gorm"
 )
 
-func NewHTTPServer(db *gorm.DB, cfg *config.Config) (*http.Server, error) {
+func NewHTTPServer(db *gorm.DB, cfg *config.Config, log logger.Logger) (*http.Server, error) {
 	router := http.NewServeMux()
 
 	// Set up the api repositories
@@ -22,22 +29,146 @@ func NewHTTPServer(db *gorm.DB, cfg *config.Config) (*http.Server, error) {
 	teamRepository := repositories.NewTeamRepository(db)
 	commentRepository := repositories.NewCommentRepository(db)
 	userProjectRepository := repositories.NewUserProjectRepository(db)
+	reactionRepository := repositories.NewReactionRepository(db)
+	notificationRepository := repositories.NewNotificationRepository(db)
+	emailRepository := repositories.NewEmailRepository(db)
+	webhookRepository := repositories.NewWebhookRepository(db)
+	outboxRepository := repositories.NewOutboxRepository(db)
+	auditRepository := repositories.NewAuditRepository(db)
+	trashRepository := repositories.NewTrashRepository(db)
+	idempotencyRepository := repositories.NewIdempotencyRepository(db)
+	projectTemplateRepository := repositories.NewProjectTemplateRepository(db)
+	sprintRepository := repositories.NewSprintRepository(db)
+	milestoneRepository := repositories.NewMilestoneRepository(db)
+	worklogRepository := repositories.NewWorklogRepository(db)
+	timesheetSubmissionRepository := repositories.NewTimesheetSubmissionRepository(db)
+	customFieldRepository := repositories.NewCustomFieldRepository(db)
+	checklistRepository := repositories.NewChecklistRepository(db)
+	attachmentRepository := repositories.NewAttachmentRepository(db)
+	watcherRepository := repositories.NewWatcherRepository(db)
 
-	// Set up the api services
-	userService := services.NewUserService(userRepository)
-	projectService := services.NewProjectService(projectRepository)
-	taskService := services.NewTaskService(taskRepository)
-	teamService := services.NewTeamService(teamRepository)
-	commentService := services.NewCommentService(commentRepository)
-	userProjectService := services.NewUserProjectService(userProjectRepository)
+	// Set up the email transport, email delivery is disabled without one
+	var emailSender mailer.Sender
+	switch cfg.Email.Transport {
+	case "smtp":
+		emailSender = mailer.NewSMTPSender(cfg.Email.SMTPHost, cfg.Email.SMTPPort, cfg.Email.SMTPUsername, cfg.Email.SMTPPassword)
+	case "file":
+		emailSender = mailer.NewFileSender(cfg.Email.FileDir)
+	case "":
+	default:
+		return nil, fmt.Errorf("unknown email transport %q", cfg.Email.Transport)
+	}
+	emailTemplates, err := mailer.NewTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	// Set up where attached files are stored
+	var blobStore storage.BlobStore
+	switch cfg.Attachments.Storage {
+	case "local":
+		blobStore = storage.NewLocalStore(cfg.Attachments.Dir)
+	case "s3":
+		blobStore = storage.NewS3Store(storage.S3Settings{
+			Endpoint:  cfg.Attachments.S3Endpoint,
+			Region:    cfg.Attachments.S3Region,
+			Bucket:    cfg.Attachments.S3Bucket,
+			AccessKey: cfg.Attachments.S3AccessKey,
+			SecretKey: cfg.Attachments.S3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown attachment storage %q", cfg.Attachments.Storage)
+	}
+
+	// Services publish their changes to the outbox, in the transaction of the change
+	transactor := repositories.NewTransactor(db)
+	outbox := events.NewOutbox(outboxRepository)
+
+	// Set up the api services, every change is recorded in the audit log
+	auditService := services.NewAuditService(auditRepository, userRepository, projectRepository, taskRepository, teamRepository)
+	userService := services.NewUserService(userRepository, transactor, auditService)
+	projectService := services.NewProjectService(projectRepository, transactor, outbox, auditService)
+	taskService := services.NewTaskService(taskRepository, projectRepository, customFieldRepository, watcherRepository, transactor, outbox, auditService)
+	taskTransferService := services.NewTaskTransferService(taskRepository, projectRepository, commentRepository, customFieldRepository, watcherRepository, worklogRepository, timesheetSubmissionRepository, transactor, outbox, auditService)
+	taskBulkService := services.NewTaskBulkService(taskService, taskTransferService, transactor)
+	projectTemplateService := services.NewProjectTemplateService(projectTemplateRepository, projectRepository, teamRepository, taskRepository, userProjectRepository, watcherRepository, transactor, outbox, auditService)
+	sprintService := services.NewSprintService(sprintRepository, taskRepository, projectRepository, auditRepository, watcherRepository, transactor, outbox, auditService)
+	milestoneService := services.NewMilestoneService(milestoneRepository, taskRepository, projectRepository, watcherRepository, transactor, outbox, auditService)
+	worklogService := services.NewWorklogService(worklogRepository, timesheetSubmissionRepository, taskRepository, projectRepository, transactor, auditService)
+	timesheetApprovalService := services.NewTimesheetApprovalService(timesheetSubmissionRepository, worklogRepository, projectRepository, transactor, auditService)
+	customFieldService := services.NewCustomFieldService(customFieldRepository, projectRepository, transactor, auditService)
+	checklistService := services.NewChecklistService(checklistRepository, taskRepository, projectRepository, transactor, auditService)
+	attachmentService := services.NewAttachmentService(attachmentRepository, taskRepository, commentRepository, projectRepository, blobStore, transactor, auditService, services.AttachmentSettings{
+		MaxSize:      cfg.Attachments.MaxSize,
+		AllowedTypes: cfg.Attachments.AllowedTypes,
+	})
+	watcherService := services.NewWatcherService(watcherRepository, taskRepository)
+	teamService := services.NewTeamService(teamRepository, projectRepository, transactor, outbox, auditService)
+	commentService := services.NewCommentService(commentRepository, taskRepository, projectRepository, userRepository, watcherRepository, transactor, outbox, auditService)
+	userProjectService := services.NewUserProjectService(userProjectRepository, transactor, outbox, auditService)
+	trashService := services.NewTrashService(trashRepository, blobStore, transactor, auditService, services.TrashSettings{
+		Retention:     cfg.Trash.Retention,
+		PurgeInterval: cfg.Trash.PurgeInterval,
+	}, log)
+	reactionService := services.NewReactionService(reactionRepository, taskRepository, commentRepository)
+	emailService := services.NewEmailService(emailRepository, userRepository, emailSender, emailTemplates, services.EmailSettings{
+		From:            cfg.Email.From,
+		BaseURL:         cfg.BaseURL,
+		DailyDigestHour: cfg.Email.DailyDigestHour,
+	}, log)
+	var notificationChannels []services.NotificationChannel
+	if emailSender != nil {
+		notificationChannels = append(notificationChannels, emailService)
+	}
+	idempotencyService := services.NewIdempotencyService(idempotencyRepository, services.IdempotencySettings{
+		TTL:             cfg.Idempotency.TTL,
+		CleanupInterval: cfg.Idempotency.CleanupInterval,
+	}, log)
+	notificationService := services.NewNotificationService(notificationRepository, log, notificationChannels...)
+	webhookService := services.NewWebhookService(webhookRepository, projectRepository, transactor, auditService, services.WebhookSettings{
+		MaxAttempts:  cfg.Webhooks.MaxAttempts,
+		BackoffBase:  cfg.Webhooks.BackoffBase,
+		DisableAfter: cfg.Webhooks.DisableAfter,
+		Timeout:      cfg.Webhooks.Timeout,
+	}, log)
+
+	// Subscribe the event consumers, the names key their progress through the outbox
+	dispatcher := events.NewDispatcher(outboxRepository, events.DispatcherSettings{
+		PollInterval: cfg.Outbox.PollInterval,
+		GapTimeout:   cfg.Outbox.GapTimeout,
+		BatchSize:    cfg.Outbox.BatchSize,
+	}, log)
+	dispatcher.Subscribe("notifications", notificationService.HandleEvent)
+	dispatcher.Subscribe("webhooks", webhookService.HandleEvent)
+
+	// Set up the markdown renderer, linking #123 references to existing tasks
+	markdownRenderer := markdown.NewRenderer(taskRepository)
 
 	// Set up the api handlers
 	userHandler := handlers.NewUserHandler(userService)
-	projectHandler := handlers.NewProjectHandler(projectService)
-	taskHandler := handlers.NewTaskHandler(taskService)
+	projectHandler := handlers.NewProjectHandler(projectService, markdownRenderer)
+	taskHandler := handlers.NewTaskHandler(taskService, markdownRenderer)
 	teamHandler := handlers.NewTeamHandler(teamService)
-	commentHandler := handlers.NewCommentHandler(commentService)
+	commentHandler := handlers.NewCommentHandler(commentService, markdownRenderer)
 	userProjectHandler := handlers.NewUserProjectHandler(userProjectService)
+	reactionHandler := handlers.NewReactionHandler(reactionService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	emailHandler := handlers.NewEmailHandler(emailService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	idempotencyHandler := handlers.NewIdempotencyHandler(idempotencyService)
+	taskBulkHandler := handlers.NewTaskBulkHandler(taskBulkService)
+	taskTransferHandler := handlers.NewTaskTransferHandler(taskTransferService, taskService)
+	projectTemplateHandler := handlers.NewProjectTemplateHandler(projectTemplateService, projectService)
+	sprintHandler := handlers.NewSprintHandler(sprintService)
+	milestoneHandler := handlers.NewMilestoneHandler(milestoneService)
+	worklogHandler := handlers.NewWorklogHandler(worklogService)
+	timesheetApprovalHandler := handlers.NewTimesheetApprovalHandler(timesheetApprovalService)
+	customFieldHandler := handlers.NewCustomFieldHandler(customFieldService)
+	checklistHandler := handlers.NewChecklistHandler(checklistService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	watcherHandler := handlers.NewWatcherHandler(watcherService)
 
 	// Set up API routes
 	handler := RegisterRoutes(
@@ -49,6 +180,24 @@ func NewHTTPServer(db *gorm.DB, cfg *config.Config) (*http.Server, error) {
 		teamHandler,
 		commentHandler,
 		userProjectHandler,
+		reactionHandler,
+		notificationHandler,
+		emailHandler,
+		webhookHandler,
+		auditHandler,
+		trashHandler,
+		idempotencyHandler,
+		taskBulkHandler,
+		taskTransferHandler,
+		projectTemplateHandler,
+		sprintHandler,
+		milestoneHandler,
+		worklogHandler,
+		timesheetApprovalHandler,
+		customFieldHandler,
+		checklistHandler,
+		attachmentHandler,
+		watcherHandler,
 	)
 
 	router.Handle("/swagger/", httpSwagger.Handler(
@@ -64,5 +213,16 @@ func NewHTTPServer(db *gorm.DB, cfg *config.Config) (*http.Server, error) {
 		WriteTimeout: 30 * time.Second,
 	}
 
+	// Run the background jobs until the server shuts down
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	server.RegisterOnShutdown(stopJobs)
+	if emailSender != nil {
+		go emailService.RunDigests(jobsCtx)
+	}
+	go webhookService.RunDeliveries(jobsCtx)
+	go dispatcher.Run(jobsCtx)
+	go trashService.RunPurge(jobsCtx)
+	go idempotencyService.RunCleanup(jobsCtx)
+
 	return server, nil
 }
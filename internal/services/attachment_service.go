@@ -0,0 +1,318 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/storage"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrInvalidAttachment        = errors.New("invalid attachment")
+	ErrAttachmentTooLarge       = errors.New("attachment too large")
+	ErrAttachmentTypeNotAllowed = errors.New("attachment type not allowed")
+	ErrNotProjectMember         = errors.New("only members of the project can access its attachments")
+	ErrNotAttachmentUploader    = errors.New("only the uploader or the project owner can delete an attachment")
+)
+
+const maxAttachmentFilenameLength = 255
+
+// AttachmentSettings limits the files that can be attached. AllowedTypes are
+// media types like application/pdf, or image/* for every image type.
+type AttachmentSettings struct {
+	MaxSize      int64 // bytes
+	AllowedTypes []string
+}
+
+// AttachmentUpload is a file being attached, its Size bytes are read from Content
+type AttachmentUpload struct {
+	Filename    string
+	ContentType string // as declared by the client
+	Size        int64
+	Content     io.Reader
+}
+
+type AttachmentService interface {
+	AttachToTask(ctx context.Context, taskID uint, upload AttachmentUpload) (*models.Attachment, error)
+	AttachToComment(ctx context.Context, commentID uint, upload AttachmentUpload) (*models.Attachment, error)
+	GetTaskAttachments(ctx context.Context, taskID uint) ([]models.Attachment, error)
+	GetCommentAttachments(ctx context.Context, commentID uint) ([]models.Attachment, error)
+	OpenAttachment(ctx context.Context, id uint) (*models.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, id uint) error
+	MaxSize() int64
+}
+
+type AttachmentServiceImplementation struct {
+	repo        repositories.AttachmentRepository
+	taskRepo    repositories.TaskRepository
+	commentRepo repositories.CommentRepository
+	projectRepo repositories.ProjectRepository
+	store       storage.BlobStore
+	transactor  repositories.Transactor
+	audit       AuditRecorder
+	settings    AttachmentSettings
+}
+
+func NewAttachmentService(
+	repo repositories.AttachmentRepository,
+	taskRepo repositories.TaskRepository,
+	commentRepo repositories.CommentRepository,
+	projectRepo repositories.ProjectRepository,
+	store storage.BlobStore,
+	transactor repositories.Transactor,
+	audit AuditRecorder,
+	settings AttachmentSettings,
+) AttachmentService {
+	return &AttachmentServiceImplementation{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		commentRepo: commentRepo,
+		projectRepo: projectRepo,
+		store:       store,
+		transactor:  transactor,
+		audit:       audit,
+		settings:    settings,
+	}
+}
+
+// AttachToTask stores the file and attaches it to the task
+func (s *AttachmentServiceImplementation) AttachToTask(ctx context.Context, taskID uint, upload AttachmentUpload) (*models.Attachment, error) {
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+	return s.attach(ctx, &models.Attachment{TaskID: task.ID}, task.ProjectID, upload)
+}
+
+// AttachToComment stores the file and attaches it to the comment, and so to its task
+func (s *AttachmentServiceImplementation) AttachToComment(ctx context.Context, commentID uint, upload AttachmentUpload) (*models.Attachment, error) {
+	comment, err := s.commentRepo.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, ErrCommentNotFound
+	}
+	task, err := s.taskRepo.GetTaskByID(ctx, comment.TaskID)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+	return s.attach(ctx, &models.Attachment{TaskID: task.ID, CommentID: &comment.ID}, task.ProjectID, upload)
+}
+
+// GetTaskAttachments returns the files attached to the task and to its comments
+func (s *AttachmentServiceImplementation) GetTaskAttachments(ctx context.Context, taskID uint) ([]models.Attachment, error) {
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+	if _, err := s.requireMember(ctx, task.ProjectID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAttachmentsByTask(ctx, taskID)
+}
+
+func (s *AttachmentServiceImplementation) GetCommentAttachments(ctx context.Context, commentID uint) ([]models.Attachment, error) {
+	comment, err := s.commentRepo.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, ErrCommentNotFound
+	}
+	task, err := s.taskRepo.GetTaskByID(ctx, comment.TaskID)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+	if _, err := s.requireMember(ctx, task.ProjectID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAttachmentsByComment(ctx, commentID)
+}
+
+// OpenAttachment returns the attachment with its content, which the caller closes
+func (s *AttachmentServiceImplementation) OpenAttachment(ctx context.Context, id uint) (*models.Attachment, io.ReadCloser, error) {
+	attachment, err := s.repo.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	task, err := s.taskRepo.GetTaskByID(ctx, attachment.TaskID)
+	if err != nil {
+		return nil, nil, ErrTaskNotFound
+	}
+	if _, err := s.requireMember(ctx, task.ProjectID); err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, nil, fmt.Errorf("%w: its content is missing", ErrAttachmentNotFound)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment deletes the attachment and its content, which only its uploader
+// or the project owner may do
+func (s *AttachmentServiceImplementation) DeleteAttachment(ctx context.Context, id uint) error {
+	attachment, err := s.repo.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return ErrAttachmentNotFound
+	}
+	task, err := s.taskRepo.GetTaskByID(ctx, attachment.TaskID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, task.ProjectID); err != nil {
+		return err
+	}
+	project, err := s.requireMember(ctx, task.ProjectID)
+	if err != nil {
+		return err
+	}
+	userID, _ := middleware.UserIDFromContext(ctx)
+	if attachment.UploadedBy != userID && !isProjectOwner(project, userID) {
+		return ErrNotAttachmentUploader
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteAttachment(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityAttachment, id, attachment, nil)
+	})
+	if err != nil {
+		return err
+	}
+	// The attachment is gone either way, a blob left behind is never served
+	_ = s.store.Delete(ctx, attachment.StorageKey)
+	return nil
+}
+
+func (s *AttachmentServiceImplementation) MaxSize() int64 {
+	return s.settings.MaxSize
+}
+
+// attach checks the upload against the limits, stores its content and saves the
+// attachment. The content type is sniffed from the content, the declared one is
+// only used when sniffing cannot tell.
+func (s *AttachmentServiceImplementation) attach(ctx context.Context, attachment *models.Attachment, projectID uint, upload AttachmentUpload) (*models.Attachment, error) {
+	if err := ensureProjectWritable(ctx, s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireMember(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	filename, err := cleanFilename(upload.Filename)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Size <= 0 {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidAttachment)
+	}
+	if upload.Size > s.settings.MaxSize {
+		return nil, fmt.Errorf("%w: files can be at most %d bytes", ErrAttachmentTooLarge, s.settings.MaxSize)
+	}
+
+	content := bufio.NewReaderSize(upload.Content, 512)
+	head, _ := content.Peek(512)
+	contentType := mediaType(http.DetectContentType(head))
+	if declared := mediaType(upload.ContentType); contentType == "application/octet-stream" && declared != "" {
+		contentType = declared
+	}
+	if !s.typeAllowed(contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentTypeNotAllowed, contentType)
+	}
+
+	userID, _ := middleware.UserIDFromContext(ctx)
+	attachment.Filename = filename
+	attachment.Size = upload.Size
+	attachment.ContentType = contentType
+	attachment.UploadedBy = userID
+	attachment.StorageKey = fmt.Sprintf("tasks/%d/%s", attachment.TaskID, randomKey())
+
+	checksum := sha256.New()
+	if err := s.store.Put(ctx, attachment.StorageKey, io.TeeReader(io.LimitReader(content, upload.Size), checksum), upload.Size, contentType); err != nil {
+		return nil, err
+	}
+	attachment.Checksum = hex.EncodeToString(checksum.Sum(nil))
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateAttachment(ctx, attachment); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityAttachment, attachment.ID, nil, attachment)
+	})
+	if err != nil {
+		_ = s.store.Delete(ctx, attachment.StorageKey)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// requireMember returns the project when the current user is one of its members
+func (s *AttachmentServiceImplementation) requireMember(ctx context.Context, projectID uint) (*models.Project, error) {
+	project, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+	userID, _ := middleware.UserIDFromContext(ctx)
+	if !isProjectMember(project, userID) {
+		return nil, ErrNotProjectMember
+	}
+	return project, nil
+}
+
+func (s *AttachmentServiceImplementation) typeAllowed(contentType string) bool {
+	for _, allowed := range s.settings.AllowedTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == contentType || strings.HasSuffix(allowed, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFilename keeps the base name of an uploaded file without control characters
+func cleanFilename(filename string) (string, error) {
+	filename = path.Base(strings.ReplaceAll(filename, `\`, "/"))
+	filename = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filename))
+	if filename == "" || filename == "." || filename == "/" || utf8.RuneCountInString(filename) > maxAttachmentFilenameLength {
+		return "", fmt.Errorf("%w: a file name of at most %d characters is required", ErrInvalidAttachment, maxAttachmentFilenameLength)
+	}
+	return filename, nil
+}
+
+// mediaType returns the media type of a Content-Type without its parameters
+func mediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+func randomKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate a storage key: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/storage"
+	"example/project-management-system/pkg/middleware"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAttachmentRepository mocks the AttachmentRepository for testing
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) CreateAttachment(ctx context.Context, attachment *models.Attachment) error {
+	args := m.Called(ctx, attachment)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) GetAttachmentByID(ctx context.Context, id uint) (*models.Attachment, error) {
+	args := m.Called(ctx, id)
+	attachment, _ := args.Get(0).(*models.Attachment)
+	return attachment, args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetAttachmentsByTask(ctx context.Context, taskID uint) ([]models.Attachment, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetAttachmentsByComment(ctx context.Context, commentID uint) ([]models.Attachment, error) {
+	args := m.Called(ctx, commentID)
+	return args.Get(0).([]models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) DeleteAttachment(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+var pngHeader = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+func TestAttachToTask(t *testing.T) {
+	testCases := []struct {
+		name                string
+		userID              uint
+		upload              AttachmentUpload
+		expectedErr         error
+		expectedFilename    string
+		expectedContentType string
+	}{
+		{
+			name:                "Member Uploads A Screenshot",
+			userID:              7,
+			upload:              AttachmentUpload{Filename: `C:\shots\login.png`, ContentType: "application/octet-stream", Content: strings.NewReader(pngHeader)},
+			expectedFilename:    "login.png",
+			expectedContentType: "image/png",
+		},
+		{
+			name:                "Declared Type When Sniffing Cannot Tell",
+			userID:              8,
+			upload:              AttachmentUpload{Filename: "spec.pdf", ContentType: "application/pdf", Content: strings.NewReader("\x00\x01binary")},
+			expectedFilename:    "spec.pdf",
+			expectedContentType: "application/pdf",
+		},
+		{
+			name:        "Not A Member",
+			userID:      9,
+			upload:      AttachmentUpload{Filename: "login.png", Content: strings.NewReader(pngHeader)},
+			expectedErr: ErrNotProjectMember,
+		},
+		{
+			name:        "Type Not Allowed",
+			userID:      7,
+			upload:      AttachmentUpload{Filename: "page.png", ContentType: "image/png", Content: strings.NewReader("<html><script>alert(1)</script></html>")},
+			expectedErr: ErrAttachmentTypeNotAllowed,
+		},
+		{
+			name:        "Too Large",
+			userID:      7,
+			upload:      AttachmentUpload{Filename: "huge.png", Content: strings.NewReader(pngHeader + strings.Repeat("x", 64))},
+			expectedErr: ErrAttachmentTooLarge,
+		},
+		{
+			name:        "No Filename",
+			userID:      7,
+			upload:      AttachmentUpload{Filename: " ", Content: strings.NewReader(pngHeader)},
+			expectedErr: ErrInvalidAttachment,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, _ := io.ReadAll(tc.upload.Content)
+			tc.upload.Content = strings.NewReader(string(content))
+			tc.upload.Size = int64(len(content))
+
+			attachments := new(MockAttachmentRepository)
+			attachments.On("CreateAttachment", mock.Anything, mock.Anything).Return(nil).Maybe()
+			tasks := new(MockTaskRepository)
+			tasks.On("GetTaskByID", mock.Anything, uint(3)).Return(&models.Task{BaseModel: models.BaseModel{ID: 3}, ProjectID: 1}, nil)
+			projects := activeProjects()
+			projects.On("GetProjectByID", mock.Anything, uint(1)).Return(&models.Project{BaseModel: models.BaseModel{ID: 1}, OwnerID: 7, UserIDs: []uint{8}}, nil)
+			dir := t.TempDir()
+
+			service := NewAttachmentService(attachments, tasks, nil, projects, storage.NewLocalStore(dir), new(MockTransactor), new(MockAuditRecorder), AttachmentSettings{
+				MaxSize:      64,
+				AllowedTypes: []string{"image/*", "application/pdf"},
+			})
+
+			attachment, err := service.AttachToTask(middleware.WithUserID(context.Background(), tc.userID), 3, tc.upload)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				attachments.AssertNotCalled(t, "CreateAttachment", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFilename, attachment.Filename)
+			assert.Equal(t, tc.expectedContentType, attachment.ContentType)
+			assert.Equal(t, tc.userID, attachment.UploadedBy)
+			assert.Len(t, attachment.Checksum, 64)
+
+			stored, err := storage.NewLocalStore(dir).Get(context.Background(), attachment.StorageKey)
+			require.NoError(t, err)
+			defer stored.Close()
+			data, _ := io.ReadAll(stored)
+			assert.Equal(t, content, data)
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		userID      uint
+		expectedErr error
+	}{
+		{name: "Uploader", userID: 8},
+		{name: "Project Owner", userID: 7},
+		{name: "Other Member", userID: 9, expectedErr: ErrNotAttachmentUploader},
+		{name: "Not A Member", userID: 10, expectedErr: ErrNotProjectMember},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attachments := new(MockAttachmentRepository)
+			attachments.On("GetAttachmentByID", mock.Anything, uint(5)).Return(&models.Attachment{ID: 5, TaskID: 3, StorageKey: "tasks/3/blob", UploadedBy: 8}, nil)
+			attachments.On("DeleteAttachment", mock.Anything, uint(5)).Return(nil).Maybe()
+			tasks := new(MockTaskRepository)
+			tasks.On("GetTaskByID", mock.Anything, uint(3)).Return(&models.Task{BaseModel: models.BaseModel{ID: 3}, ProjectID: 1}, nil)
+			projects := activeProjects()
+			projects.On("GetProjectByID", mock.Anything, uint(1)).Return(&models.Project{BaseModel: models.BaseModel{ID: 1}, OwnerID: 7, UserIDs: []uint{8, 9}}, nil)
+			store := storage.NewLocalStore(t.TempDir())
+			require.NoError(t, store.Put(context.Background(), "tasks/3/blob", strings.NewReader("x"), 1, "text/plain"))
+
+			service := NewAttachmentService(attachments, tasks, nil, projects, store, new(MockTransactor), new(MockAuditRecorder), AttachmentSettings{MaxSize: 64})
+
+			err := service.DeleteAttachment(middleware.WithUserID(context.Background(), tc.userID), 5)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				attachments.AssertNotCalled(t, "DeleteAttachment", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			_, err = store.Get(context.Background(), "tasks/3/blob")
+			assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrAdminRequired     = errors.New("only administrators can read the audit log")
+	ErrHistoryNotAllowed = errors.New("only members of the project can read its history")
+)
+
+// auditIgnoredFields change on every write and are left out of the diff
+var auditIgnoredFields = map[string]bool{"created_at": true, "updated_at": true, "version": true}
+
+// auditRedactedFields are recorded as changed without their values
+var auditRedactedFields = map[string]bool{"password": true, "secret": true}
+
+const auditRedacted = "[redacted]"
+
+// AuditRecorder is used by services to record their changes, with the context of
+// the change's transaction so the entry is only kept if the change commits.
+type AuditRecorder interface {
+	Record(ctx context.Context, action, entityType string, entityID uint, before, after interface{}) error
+}
+
+type AuditService interface {
+	AuditRecorder
+	GetEntries(ctx context.Context, userID uint, filter repositories.AuditFilter, page, pageSize int) ([]models.AuditEntry, int64, error)
+	GetHistory(ctx context.Context, userID uint, entityType string, entityID uint, page, pageSize int) ([]models.AuditEntry, int64, error)
+}
+
+type AuditServiceImplementation struct {
+	repo        repositories.AuditRepository
+	userRepo    repositories.UserRepository
+	projectRepo repositories.ProjectRepository
+	taskRepo    repositories.TaskRepository
+	teamRepo    repositories.TeamRepository
+}
+
+func NewAuditService(
+	repo repositories.AuditRepository,
+	userRepo repositories.UserRepository,
+	projectRepo repositories.ProjectRepository,
+	taskRepo repositories.TaskRepository,
+	teamRepo repositories.TeamRepository,
+) AuditService {
+	return &AuditServiceImplementation{repo: repo, userRepo: userRepo, projectRepo: projectRepo, taskRepo: taskRepo, teamRepo: teamRepo}
+}
+
+// Record appends an entry with the field-level diff between before and after. Either
+// is nil for creates and deletes. The actor, request ID and IP come from ctx.
+func (s *AuditServiceImplementation) Record(ctx context.Context, action, entityType string, entityID uint, before, after interface{}) error {
+	changes, err := DiffFields(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to diff %s %d: %w", entityType, entityID, err)
+	}
+
+	actorID, _ := middleware.UserIDFromContext(ctx)
+	return s.repo.CreateEntry(ctx, &models.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		RequestID:  middleware.RequestIDFromContext(ctx),
+		IP:         middleware.ClientIPFromContext(ctx),
+	})
+}
+
+// GetEntries returns the filtered audit log, newest first. Only admins may read it.
+func (s *AuditServiceImplementation) GetEntries(ctx context.Context, userID uint, filter repositories.AuditFilter, page, pageSize int) ([]models.AuditEntry, int64, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil || user.Role != models.UserRoleAdmin {
+		return nil, 0, ErrAdminRequired
+	}
+	return s.repo.GetEntries(ctx, filter, page, pageSize)
+}
+
+// GetHistory returns the changes made to one entity, newest first. Admins may read
+// the history of any entity, other users that of the projects they are members of
+// and of the tasks and teams of those projects.
+func (s *AuditServiceImplementation) GetHistory(ctx context.Context, userID uint, entityType string, entityID uint, page, pageSize int) ([]models.AuditEntry, int64, error) {
+	if err := s.requireHistoryAccess(ctx, userID, entityType, entityID); err != nil {
+		return nil, 0, err
+	}
+	filter := repositories.AuditFilter{EntityType: entityType, EntityID: entityID}
+	return s.repo.GetEntries(ctx, filter, page, pageSize)
+}
+
+// requireHistoryAccess checks that the user is an admin or a member of the project
+// the entity belongs to
+func (s *AuditServiceImplementation) requireHistoryAccess(ctx context.Context, userID uint, entityType string, entityID uint) error {
+	if user, err := s.userRepo.GetUserByID(ctx, userID); err == nil && user.Role == models.UserRoleAdmin {
+		return nil
+	}
+
+	var projectID uint
+	switch entityType {
+	case models.AuditEntityProject:
+		projectID = entityID
+	case models.AuditEntityTask:
+		task, err := s.taskRepo.GetTaskByID(ctx, entityID)
+		if err != nil {
+			return ErrTaskNotFound
+		}
+		projectID = task.ProjectID
+	case models.AuditEntityTeam:
+		team, err := s.teamRepo.GetTeamByID(ctx, entityID)
+		if err != nil {
+			return ErrTeamNotFound
+		}
+		projectID = team.ProjectID
+	default:
+		return ErrAdminRequired
+	}
+
+	project, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if entityType == models.AuditEntityProject {
+			return ErrProjectNotFound
+		}
+		// A team without a project has no members besides the admins
+		return ErrHistoryNotAllowed
+	}
+	if !isProjectMember(project, userID) {
+		return ErrHistoryNotAllowed
+	}
+	return nil
+}
+
+// DiffFields compares the JSON fields of two snapshots of an entity. Nested objects
+// and lists of them, i.e. relations, are skipped since they are not always loaded.
+func DiffFields(before, after interface{}) (map[string]models.FieldChange, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]models.FieldChange{}
+	for _, fields := range []map[string]interface{}{beforeFields, afterFields} {
+		for name := range fields {
+			if _, done := changes[name]; done || auditIgnoredFields[name] {
+				continue
+			}
+
+			oldValue, newValue := normalize(beforeFields[name]), normalize(afterFields[name])
+			if isRelation(oldValue) || isRelation(newValue) || reflect.DeepEqual(oldValue, newValue) {
+				continue
+			}
+			if auditRedactedFields[name] {
+				oldValue, newValue = redact(oldValue), redact(newValue)
+			}
+			changes[name] = models.FieldChange{Before: oldValue, After: newValue}
+		}
+	}
+	return changes, nil
+}
+
+func jsonFields(entity interface{}) (map[string]interface{}, error) {
+	if entity == nil || reflect.ValueOf(entity).Kind() == reflect.Ptr && reflect.ValueOf(entity).IsNil() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func isRelation(value interface{}) bool {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return true
+	case []interface{}:
+		_, ok := value[0].(map[string]interface{})
+		return ok
+	}
+	return false
+}
+
+// normalize treats an empty list like a missing one
+func normalize(value interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok && len(list) == 0 {
+		return nil
+	}
+	return value
+}
+
+func redact(value interface{}) interface{} {
+	if value == nil || value == "" {
+		return value
+	}
+	return auditRedacted
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAuditRepository mocks the AuditRepository for testing
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) CreateEntry(ctx context.Context, entry *models.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) GetEntries(ctx context.Context, filter repositories.AuditFilter, page, pageSize int) ([]models.AuditEntry, int64, error) {
+	args := m.Called(ctx, filter, page, pageSize)
+	entries, _ := args.Get(0).([]models.AuditEntry)
+	return entries, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAuditRepository) GetEntriesSince(ctx context.Context, entityType string, entityIDs []uint, from time.Time) ([]models.AuditEntry, error) {
+	args := m.Called(ctx, entityType, entityIDs, from)
+	return args.Get(0).([]models.AuditEntry), args.Error(1)
+}
+
+func TestDiffFields(t *testing.T) {
+	before := &models.Webhook{ID: 1, ProjectID: 2, URL: "https://old.example.com", Secret: "old", Active: true,
+		Project: models.Project{Name: "Loaded relation"}}
+	after := &models.Webhook{ID: 1, ProjectID: 2, URL: "https://new.example.com", Secret: "new", Active: true}
+
+	t.Run("Update", func(t *testing.T) {
+		changes, err := DiffFields(before, after)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]models.FieldChange{
+			"url":    {Before: "https://old.example.com", After: "https://new.example.com"},
+			"secret": {Before: auditRedacted, After: auditRedacted},
+		}, changes)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		changes, err := DiffFields(nil, after)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.FieldChange{Before: nil, After: "https://new.example.com"}, changes["url"])
+		assert.Equal(t, models.FieldChange{Before: nil, After: float64(1)}, changes["id"])
+		assert.NotContains(t, changes, "events")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		var deleted *models.Webhook
+		changes, err := DiffFields(before, deleted)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.FieldChange{Before: true, After: nil}, changes["active"])
+	})
+}
+
+func TestAuditRecord(t *testing.T) {
+	repo := new(MockAuditRepository)
+	repo.On("CreateEntry", mock.Anything, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+		return entry.ActorID == 7 &&
+			entry.Action == models.AuditActionDelete &&
+			entry.EntityType == models.AuditEntityProject &&
+			entry.EntityID == 3 &&
+			entry.RequestID == "req-1" &&
+			entry.IP == "192.0.2.10" &&
+			entry.Changes["name"] == models.FieldChange{Before: "Apollo", After: nil}
+	})).Return(nil)
+	service := NewAuditService(repo, new(MockUserRepository), nil, nil, nil)
+
+	// Carry the request ID and client IP the way the middleware does
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/projects/3", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	req.RemoteAddr = "192.0.2.10:51000"
+	var ctx context.Context
+	middleware.RequestInfo(false, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = middleware.WithUserID(r.Context(), 7)
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	err := service.Record(ctx, models.AuditActionDelete, models.AuditEntityProject, 3, &models.Project{Name: "Apollo"}, nil)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestGetAuditEntries(t *testing.T) {
+	testCases := []struct {
+		name        string
+		user        *models.User
+		userErr     error
+		expectedErr error
+	}{
+		{name: "Admin", user: &models.User{Role: models.UserRoleAdmin}},
+		{name: "Not An Admin", user: &models.User{Role: "member"}, expectedErr: ErrAdminRequired},
+		{name: "Unknown User", userErr: errors.New("record not found"), expectedErr: ErrAdminRequired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(MockAuditRepository)
+			userRepo := new(MockUserRepository)
+			userRepo.On("GetUserByID", mock.Anything, uint(1)).Return(tc.user, tc.userErr)
+			filter := repositories.AuditFilter{EntityType: models.AuditEntityProject}
+			if tc.expectedErr == nil {
+				repo.On("GetEntries", mock.Anything, filter, 1, 10).Return([]models.AuditEntry{{ID: 1}}, int64(1), nil)
+			}
+			service := NewAuditService(repo, userRepo, nil, nil, nil)
+
+			entries, _, err := service.GetEntries(context.Background(), 1, filter, 1, 10)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, entries)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, entries, 1)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetHistory(t *testing.T) {
+	testCases := []struct {
+		name        string
+		userID      uint
+		entityType  string
+		entityID    uint
+		expectedErr error
+	}{
+		{name: "Task Of A Member", userID: 8, entityType: models.AuditEntityTask, entityID: 5},
+		{name: "Project Of Its Owner", userID: 7, entityType: models.AuditEntityProject, entityID: 1},
+		{name: "Admin", userID: 1, entityType: models.AuditEntityTask, entityID: 5},
+		{name: "Not A Member", userID: 9, entityType: models.AuditEntityTask, entityID: 5, expectedErr: ErrHistoryNotAllowed},
+		{name: "Unknown Task", userID: 8, entityType: models.AuditEntityTask, entityID: 6, expectedErr: ErrTaskNotFound},
+		{name: "Unknown Project", userID: 8, entityType: models.AuditEntityProject, entityID: 2, expectedErr: ErrProjectNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(MockAuditRepository)
+			filter := repositories.AuditFilter{EntityType: tc.entityType, EntityID: tc.entityID}
+			repo.On("GetEntries", mock.Anything, filter, 1, 10).Return([]models.AuditEntry{{ID: 1}}, int64(1), nil).Maybe()
+			userRepo := new(MockUserRepository)
+			userRepo.On("GetUserByID", mock.Anything, uint(1)).Return(&models.User{Role: models.UserRoleAdmin}, nil).Maybe()
+			userRepo.On("GetUserByID", mock.Anything, mock.Anything).Return(&models.User{Role: "member"}, nil).Maybe()
+			tasks := new(MockTaskRepository)
+			tasks.On("GetTaskByID", mock.Anything, uint(5)).Return(&models.Task{BaseModel: models.BaseModel{ID: 5}, ProjectID: 1}, nil).Maybe()
+			tasks.On("GetTaskByID", mock.Anything, uint(6)).Return((*models.Task)(nil), errors.New("record not found")).Maybe()
+			projects := new(MockProjectRepository)
+			projects.On("GetProjectByID", mock.Anything, uint(1)).Return(&models.Project{BaseModel: models.BaseModel{ID: 1}, OwnerID: 7, UserIDs: []uint{8}}, nil).Maybe()
+			projects.On("GetProjectByID", mock.Anything, uint(2)).Return((*models.Project)(nil), errors.New("record not found")).Maybe()
+			service := NewAuditService(repo, userRepo, projects, tasks, nil)
+
+			entries, _, err := service.GetHistory(context.Background(), tc.userID, tc.entityType, tc.entityID, 1, 10)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				repo.AssertNotCalled(t, "GetEntries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, entries, 1)
+		})
+	}
+}
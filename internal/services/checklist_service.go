@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrInvalidChecklistItem  = errors.New("invalid checklist item")
+	ErrChecklistIncomplete   = errors.New("the task cannot be done while its checklist has unchecked items")
+)
+
+const maxChecklistTextLength = 500
+
+type ChecklistService interface {
+	AddItem(ctx context.Context, taskID uint, text string) (*models.ChecklistItem, error)
+	GetChecklist(ctx context.Context, taskID uint) ([]models.ChecklistItem, error)
+	ReorderItems(ctx context.Context, taskID uint, itemIDs []uint) ([]models.ChecklistItem, error)
+	ToggleItem(ctx context.Context, id uint) (*models.ChecklistItem, error)
+	DeleteItem(ctx context.Context, id uint) error
+}
+
+type ChecklistServiceImplementation struct {
+	repo        repositories.ChecklistRepository
+	taskRepo    repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+	transactor  repositories.Transactor
+	audit       AuditRecorder
+	now         func() time.Time
+}
+
+func NewChecklistService(repo repositories.ChecklistRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, transactor repositories.Transactor, audit AuditRecorder) ChecklistService {
+	return &ChecklistServiceImplementation{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		transactor:  transactor,
+		audit:       audit,
+		now:         time.Now,
+	}
+}
+
+// AddItem adds an unchecked item at the end of the checklist of a task
+func (s *ChecklistServiceImplementation) AddItem(ctx context.Context, taskID uint, text string) (*models.ChecklistItem, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxChecklistTextLength {
+		return nil, fmt.Errorf("%w: text is required and at most %d characters", ErrInvalidChecklistItem, maxChecklistTextLength)
+	}
+	if err := s.ensureTaskWritable(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	item := &models.ChecklistItem{TaskID: taskID, Text: text}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := s.repo.GetItemsByTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		item.Position = 1
+		if len(items) > 0 {
+			item.Position = items[len(items)-1].Position + 1
+		}
+
+		if err := s.repo.CreateItem(ctx, item); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityChecklist, item.ID, nil, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetChecklist returns the checklist of a task in order
+func (s *ChecklistServiceImplementation) GetChecklist(ctx context.Context, taskID uint) ([]models.ChecklistItem, error) {
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+	return s.repo.GetItemsByTask(ctx, taskID)
+}
+
+// ReorderItems orders the checklist of a task as the given item IDs, which list
+// each of its items once
+func (s *ChecklistServiceImplementation) ReorderItems(ctx context.Context, taskID uint, itemIDs []uint) ([]models.ChecklistItem, error) {
+	if err := s.ensureTaskWritable(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	var items []models.ChecklistItem
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetItemsByTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if len(itemIDs) != len(current) {
+			return fmt.Errorf("%w: item_ids must list the %d items of the checklist", ErrInvalidChecklistItem, len(current))
+		}
+		listed := make(map[uint]bool, len(itemIDs))
+		for _, id := range itemIDs {
+			listed[id] = true
+		}
+		for _, item := range current {
+			if !listed[item.ID] {
+				return fmt.Errorf("%w: item_ids must list each item of the checklist once, %d is missing", ErrInvalidChecklistItem, item.ID)
+			}
+		}
+
+		if err := s.repo.ReorderItems(ctx, taskID, itemIDs); err != nil {
+			return err
+		}
+		items, err = s.repo.GetItemsByTask(ctx, taskID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ToggleItem checks an unchecked item as done by the current user, or unchecks a
+// checked one
+func (s *ChecklistServiceImplementation) ToggleItem(ctx context.Context, id uint) (*models.ChecklistItem, error) {
+	item, err := s.repo.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, ErrChecklistItemNotFound
+	}
+	if err := s.ensureTaskWritable(ctx, item.TaskID); err != nil {
+		return nil, err
+	}
+
+	before := *item
+	item.Done = !item.Done
+	item.DoneBy = nil
+	item.DoneAt = nil
+	if item.Done {
+		userID, _ := middleware.UserIDFromContext(ctx)
+		doneAt := s.now().UTC()
+		item.DoneBy = &userID
+		item.DoneAt = &doneAt
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateItem(ctx, item); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityChecklist, item.ID, &before, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteItem removes an item from its checklist
+func (s *ChecklistServiceImplementation) DeleteItem(ctx context.Context, id uint) error {
+	item, err := s.repo.GetItemByID(ctx, id)
+	if err != nil {
+		return ErrChecklistItemNotFound
+	}
+	if err := s.ensureTaskWritable(ctx, item.TaskID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteItem(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityChecklist, id, item, nil)
+	})
+}
+
+func (s *ChecklistServiceImplementation) ensureTaskWritable(ctx context.Context, taskID uint) error {
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	return ensureProjectWritable(ctx, s.projectRepo, task.ProjectID)
+}
+
+// checklistComplete tells whether a task of the project may be done, which needs
+// every item of its checklist checked when the project requires it
+func checklistComplete(project models.Project, task *models.Task) bool {
+	if !project.RequireChecklist || task.Checklist == nil {
+		return true
+	}
+	return task.Checklist.Done == task.Checklist.Total
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/pkg/middleware"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockChecklistRepository mocks the ChecklistRepository for testing
+type MockChecklistRepository struct {
+	mock.Mock
+}
+
+func (m *MockChecklistRepository) CreateItem(ctx context.Context, item *models.ChecklistItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockChecklistRepository) GetItemByID(ctx context.Context, id uint) (*models.ChecklistItem, error) {
+	args := m.Called(ctx, id)
+	item, _ := args.Get(0).(*models.ChecklistItem)
+	return item, args.Error(1)
+}
+
+func (m *MockChecklistRepository) GetItemsByTask(ctx context.Context, taskID uint) ([]models.ChecklistItem, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]models.ChecklistItem), args.Error(1)
+}
+
+func (m *MockChecklistRepository) UpdateItem(ctx context.Context, item *models.ChecklistItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockChecklistRepository) ReorderItems(ctx context.Context, taskID uint, itemIDs []uint) error {
+	args := m.Called(ctx, taskID, itemIDs)
+	return args.Error(0)
+}
+
+func (m *MockChecklistRepository) DeleteItem(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestAddChecklistItem(t *testing.T) {
+	checklists := new(MockChecklistRepository)
+	checklists.On("GetItemsByTask", mock.Anything, uint(3)).Return([]models.ChecklistItem{{ID: 1, Position: 1}, {ID: 2, Position: 4}}, nil)
+	checklists.On("CreateItem", mock.Anything, mock.Anything).Return(nil)
+	tasks := new(MockTaskRepository)
+	tasks.On("GetTaskByID", mock.Anything, uint(3)).Return(&models.Task{BaseModel: models.BaseModel{ID: 3}, ProjectID: 1}, nil)
+
+	service := NewChecklistService(checklists, tasks, activeProjects(), new(MockTransactor), new(MockAuditRecorder))
+
+	item, err := service.AddItem(context.Background(), 3, "  Screenshots attached ")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Screenshots attached", item.Text)
+	assert.Equal(t, 5, item.Position)
+	assert.False(t, item.Done)
+
+	_, err = service.AddItem(context.Background(), 3, " ")
+	assert.ErrorIs(t, err, ErrInvalidChecklistItem)
+}
+
+func TestReorderChecklist(t *testing.T) {
+	current := []models.ChecklistItem{{ID: 1, Position: 1}, {ID: 2, Position: 2}, {ID: 3, Position: 3}}
+
+	testCases := []struct {
+		name        string
+		itemIDs     []uint
+		expectedErr error
+	}{
+		{name: "Every Item Once", itemIDs: []uint{3, 1, 2}},
+		{name: "Missing Item", itemIDs: []uint{3, 1}, expectedErr: ErrInvalidChecklistItem},
+		{name: "Repeated Item", itemIDs: []uint{3, 3, 1}, expectedErr: ErrInvalidChecklistItem},
+		{name: "Item Of Another Task", itemIDs: []uint{3, 1, 9}, expectedErr: ErrInvalidChecklistItem},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checklists := new(MockChecklistRepository)
+			checklists.On("GetItemsByTask", mock.Anything, uint(5)).Return(current, nil)
+			checklists.On("ReorderItems", mock.Anything, uint(5), tc.itemIDs).Return(nil).Maybe()
+			tasks := new(MockTaskRepository)
+			tasks.On("GetTaskByID", mock.Anything, uint(5)).Return(&models.Task{BaseModel: models.BaseModel{ID: 5}, ProjectID: 1}, nil)
+
+			service := NewChecklistService(checklists, tasks, activeProjects(), new(MockTransactor), new(MockAuditRecorder))
+
+			_, err := service.ReorderItems(context.Background(), 5, tc.itemIDs)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				checklists.AssertNotCalled(t, "ReorderItems", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			checklists.AssertCalled(t, "ReorderItems", mock.Anything, uint(5), tc.itemIDs)
+		})
+	}
+}
+
+func TestToggleChecklistItem(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 11, 0, 0, 0, time.UTC)
+	doneBy, checker := uint(2), uint(7)
+
+	testCases := []struct {
+		name     string
+		item     models.ChecklistItem
+		expected models.ChecklistItem
+	}{
+		{
+			name:     "Checks An Item",
+			item:     models.ChecklistItem{ID: 1, TaskID: 3, Text: "Docs"},
+			expected: models.ChecklistItem{ID: 1, TaskID: 3, Text: "Docs", Done: true, DoneBy: &checker, DoneAt: &now},
+		},
+		{
+			name:     "Unchecks An Item",
+			item:     models.ChecklistItem{ID: 1, TaskID: 3, Text: "Docs", Done: true, DoneBy: &doneBy, DoneAt: &now},
+			expected: models.ChecklistItem{ID: 1, TaskID: 3, Text: "Docs"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := tc.item
+			checklists := new(MockChecklistRepository)
+			checklists.On("GetItemByID", mock.Anything, uint(1)).Return(&item, nil)
+			checklists.On("UpdateItem", mock.Anything, mock.Anything).Return(nil)
+			tasks := new(MockTaskRepository)
+			tasks.On("GetTaskByID", mock.Anything, uint(3)).Return(&models.Task{BaseModel: models.BaseModel{ID: 3}, ProjectID: 1}, nil)
+
+			service := NewChecklistService(checklists, tasks, activeProjects(), new(MockTransactor), new(MockAuditRecorder)).(*ChecklistServiceImplementation)
+			service.now = func() time.Time { return now }
+
+			toggled, err := service.ToggleItem(middleware.WithUserID(context.Background(), 7), 1)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, *toggled)
+		})
+	}
+}
+
+func TestUpdateTaskChecklistGuard(t *testing.T) {
+	testCases := []struct {
+		name        string
+		project     models.Project
+		checklist   *models.ChecklistProgress
+		expectedErr error
+	}{
+		{name: "Unchecked Items Block Done", project: models.Project{RequireChecklist: true}, checklist: &models.ChecklistProgress{Done: 1, Total: 2}, expectedErr: ErrChecklistIncomplete},
+		{name: "Checked Items", project: models.Project{RequireChecklist: true}, checklist: &models.ChecklistProgress{Done: 2, Total: 2}},
+		{name: "No Checklist", project: models.Project{RequireChecklist: true}},
+		{name: "Guard Off", checklist: &models.ChecklistProgress{Done: 0, Total: 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := &models.Task{BaseModel: models.BaseModel{ID: 1, Version: 1}, Title: "Task", Status: models.TaskStatusInProgress, ProjectID: 1, Project: tc.project, Checklist: tc.checklist}
+			tasks := new(MockTaskRepository)
+			tasks.On("GetTaskByID", mock.Anything, uint(1)).Return(existing, nil)
+			tasks.On("UpdateTask", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			service := NewTaskService(tasks, activeProjects(), noCustomFields(), noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
+
+			err := service.UpdateTask(context.Background(), &models.Task{BaseModel: models.BaseModel{ID: 1}, Title: "Task", Status: models.TaskStatusDone})
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				tasks.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -2,24 +2,60 @@ package services
 
 import (
 	"context"
+	"errors"
+	"example/project-management-system/internal/events"
 	"example/project-management-system/internal/models"
 	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/utils/helpers"
+	"example/project-management-system/pkg/middleware"
 	"fmt"
+	"slices"
+)
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrNotCommentAuthor = errors.New("only the author of a comment can change it")
 )
 
 type CommentService interface {
 	CreateComment(ctx context.Context, comment *models.Comment) error
 	GetCommentByID(ctx context.Context, id uint) (*models.Comment, error)
 	GetCommentsByTask(ctx context.Context, taskID uint, page, pageSize int) ([]models.Comment, int64, error)
+	UpdateComment(ctx context.Context, comment *models.Comment) error
 	DeleteComment(ctx context.Context, id uint) error
 }
 
 type CommentServiceImplementation struct {
-	repo repositories.CommentRepository
+	repo        repositories.CommentRepository
+	taskRepo    repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+	userRepo    repositories.UserRepository
+	watcherRepo repositories.WatcherRepository
+	transactor  repositories.Transactor
+	events      events.Publisher
+	audit       AuditRecorder
 }
 
-func NewCommentService(repo repositories.CommentRepository) CommentService {
-	return &CommentServiceImplementation{repo: repo}
+func NewCommentService(
+	repo repositories.CommentRepository,
+	taskRepo repositories.TaskRepository,
+	projectRepo repositories.ProjectRepository,
+	userRepo repositories.UserRepository,
+	watcherRepo repositories.WatcherRepository,
+	transactor repositories.Transactor,
+	publisher events.Publisher,
+	audit AuditRecorder,
+) CommentService {
+	return &CommentServiceImplementation{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+		watcherRepo: watcherRepo,
+		transactor:  transactor,
+		events:      publisher,
+		audit:       audit,
+	}
 }
 
 func (s *CommentServiceImplementation) CreateComment(ctx context.Context, comment *models.Comment) error {
@@ -30,13 +66,59 @@ func (s *CommentServiceImplementation) CreateComment(ctx context.Context, commen
 	if comment.TaskID == 0 {
 		return fmt.Errorf("task ID is required")
 	}
-	return s.repo.CreateComment(ctx, comment)
+
+	task, err := s.taskRepo.GetTaskByID(ctx, comment.TaskID)
+	if err != nil {
+		return fmt.Errorf("task not found")
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, task.ProjectID); err != nil {
+		return err
+	}
+
+	mentioned, err := s.userRepo.GetUsersByUsernames(ctx, helpers.ExtractMentions(comment.Content))
+	if err != nil {
+		return fmt.Errorf("failed to resolve mentions: %w", err)
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateComment(ctx, comment); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityComment, comment.ID, nil, comment); err != nil {
+			return err
+		}
+
+		// The commenter and the mentioned users watch the task. The watchers hear about
+		// the comment, except the mentioned users who hear about the mention.
+		mentionedIDs := make([]uint, len(mentioned))
+		for i, user := range mentioned {
+			mentionedIDs[i] = user.ID
+		}
+		if err := s.watcherRepo.AddWatchers(ctx, task.ID, nonZero(append([]uint{comment.UserID}, mentionedIDs...)...)); err != nil {
+			return err
+		}
+		watchers, err := s.watcherRepo.GetWatcherIDs(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		watchers = slices.DeleteFunc(watchers, func(userID uint) bool { return slices.Contains(mentionedIDs, userID) })
+
+		if err := s.publish(ctx, events.CommentCreated, comment, task, 0, watchers); err != nil {
+			return err
+		}
+		for _, userID := range mentionedIDs {
+			if err := s.publish(ctx, events.UserMentioned, comment, task, userID, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (s *CommentServiceImplementation) GetCommentByID(ctx context.Context, id uint) (*models.Comment, error) {
 	comment, err := s.repo.GetCommentByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("comment not found")
+		return nil, ErrCommentNotFound
 	}
 	return comment, nil
 }
@@ -45,6 +127,90 @@ func (s *CommentServiceImplementation) GetCommentsByTask(ctx context.Context, ta
 	return s.repo.GetCommentsByTask(ctx, taskID, page, pageSize)
 }
 
+// UpdateComment saves the content of the comment, which only its author may change.
+func (s *CommentServiceImplementation) UpdateComment(ctx context.Context, comment *models.Comment) error {
+	if comment.Content == "" {
+		return fmt.Errorf("content is required")
+	}
+
+	existing, err := s.repo.GetCommentByID(ctx, comment.ID)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+	actorID, ok := middleware.UserIDFromContext(ctx)
+	if !ok || actorID != existing.UserID {
+		return ErrNotCommentAuthor
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, existing.Task.ProjectID); err != nil {
+		return err
+	}
+	comment.TaskID = existing.TaskID
+	comment.UserID = existing.UserID
+	comment.CreatedAt = existing.CreatedAt
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateComment(ctx, comment); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityComment, comment.ID, existing, comment); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, events.Event{
+			Type:      events.CommentUpdated,
+			ActorID:   actorID,
+			ProjectID: existing.Task.ProjectID,
+			TaskID:    comment.TaskID,
+			CommentID: comment.ID,
+			Payload:   comment,
+		})
+	})
+}
+
 func (s *CommentServiceImplementation) DeleteComment(ctx context.Context, id uint) error {
-	return s.repo.DeleteComment(ctx, id)
+	comment, err := s.repo.GetCommentByID(ctx, id)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, comment.Task.ProjectID); err != nil {
+		return err
+	}
+
+	actorID, _ := middleware.UserIDFromContext(ctx)
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteComment(ctx, id); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityComment, id, comment, nil); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, events.Event{
+			Type:      events.CommentDeleted,
+			ActorID:   actorID,
+			ProjectID: comment.Task.ProjectID,
+			TaskID:    comment.TaskID,
+			CommentID: comment.ID,
+			Payload:   comment,
+		})
+	})
+}
+
+func (s *CommentServiceImplementation) publish(ctx context.Context, eventType events.Type, comment *models.Comment, task *models.Task, userID uint, recipients []uint) error {
+	actorID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		actorID = comment.UserID
+	}
+
+	return s.events.Publish(ctx, events.Event{
+		Type:       eventType,
+		ActorID:    actorID,
+		UserID:     userID,
+		Recipients: recipients,
+		ProjectID:  task.ProjectID,
+		TaskID:     task.ID,
+		CommentID:  comment.ID,
+		Data:       map[string]string{"task_title": task.Title},
+		Payload:    comment,
+	})
 }
@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/utils/helpers"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+	ErrInvalidCustomField  = errors.New("invalid custom field")
+	ErrInvalidFieldValue   = errors.New("invalid custom field value")
+	ErrNotFieldManager     = errors.New("only the project owner can manage its custom fields")
+)
+
+var (
+	customFieldTypes = []string{
+		models.CustomFieldTypeText,
+		models.CustomFieldTypeNumber,
+		models.CustomFieldTypeDate,
+		models.CustomFieldTypeSelect,
+		models.CustomFieldTypeMultiSelect,
+		models.CustomFieldTypeUser,
+	}
+	customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+)
+
+type CustomFieldService interface {
+	CreateField(ctx context.Context, projectID uint, field *models.CustomField) error
+	GetFieldsByProject(ctx context.Context, projectID uint) ([]models.CustomField, error)
+	UpdateField(ctx context.Context, id uint, field *models.CustomField) error
+	DeleteField(ctx context.Context, id uint) error
+}
+
+type CustomFieldServiceImplementation struct {
+	repo        repositories.CustomFieldRepository
+	projectRepo repositories.ProjectRepository
+	transactor  repositories.Transactor
+	audit       AuditRecorder
+}
+
+func NewCustomFieldService(repo repositories.CustomFieldRepository, projectRepo repositories.ProjectRepository, transactor repositories.Transactor, audit AuditRecorder) CustomFieldService {
+	return &CustomFieldServiceImplementation{repo: repo, projectRepo: projectRepo, transactor: transactor, audit: audit}
+}
+
+// CreateField adds a custom field to the tasks of a project owned by the current user
+func (s *CustomFieldServiceImplementation) CreateField(ctx context.Context, projectID uint, field *models.CustomField) error {
+	if err := s.authorize(ctx, projectID); err != nil {
+		return err
+	}
+	field.ID = 0
+	field.ProjectID = projectID
+	if !customFieldKeyPattern.MatchString(field.Key) {
+		return fmt.Errorf("%w: key must start with a lowercase letter and hold at most 50 lowercase letters, digits and underscores", ErrInvalidCustomField)
+	}
+	if !helpers.Contains(customFieldTypes, field.Type) {
+		return fmt.Errorf("%w: type must be one of %s", ErrInvalidCustomField, strings.Join(customFieldTypes, ", "))
+	}
+	if err := validateFieldDefinition(field); err != nil {
+		return err
+	}
+
+	fields, err := s.repo.GetFieldsByProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	for _, other := range fields {
+		if other.Key == field.Key {
+			return fmt.Errorf("%w: the project already has a field %q", ErrInvalidCustomField, field.Key)
+		}
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateField(ctx, field); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityCustomField, field.ID, nil, field)
+	})
+}
+
+// GetFieldsByProject returns the custom fields of a project by position
+func (s *CustomFieldServiceImplementation) GetFieldsByProject(ctx context.Context, projectID uint) ([]models.CustomField, error) {
+	if _, err := s.projectRepo.GetProjectStatus(ctx, projectID); err != nil {
+		return nil, ErrProjectNotFound
+	}
+	return s.repo.GetFieldsByProject(ctx, projectID)
+}
+
+// UpdateField changes the name, options, default, position and whether a custom field
+// is required. Its key and type cannot change. The removed options of a select field
+// are cleared from the tasks that have them.
+func (s *CustomFieldServiceImplementation) UpdateField(ctx context.Context, id uint, field *models.CustomField) error {
+	existing, err := s.repo.GetFieldByID(ctx, id)
+	if err != nil {
+		return ErrCustomFieldNotFound
+	}
+	if err := s.authorize(ctx, existing.ProjectID); err != nil {
+		return err
+	}
+	if field.Key != "" && field.Key != existing.Key {
+		return fmt.Errorf("%w: the key of a field cannot change", ErrInvalidCustomField)
+	}
+	if field.Type != "" && field.Type != existing.Type {
+		return fmt.Errorf("%w: the type of a field cannot change", ErrInvalidCustomField)
+	}
+	field.ID = existing.ID
+	field.CreatedAt = existing.CreatedAt
+	field.ProjectID = existing.ProjectID
+	field.Key = existing.Key
+	field.Type = existing.Type
+	if err := validateFieldDefinition(field); err != nil {
+		return err
+	}
+
+	var removed []string
+	for _, option := range existing.Options {
+		if !helpers.Contains(field.Options, option) {
+			removed = append(removed, option)
+		}
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateField(ctx, field); err != nil {
+			return err
+		}
+		if err := s.repo.DeleteOptionValues(ctx, field.ID, removed); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityCustomField, field.ID, existing, field)
+	})
+}
+
+// DeleteField deletes a custom field with the values tasks have for it
+func (s *CustomFieldServiceImplementation) DeleteField(ctx context.Context, id uint) error {
+	field, err := s.repo.GetFieldByID(ctx, id)
+	if err != nil {
+		return ErrCustomFieldNotFound
+	}
+	if err := s.authorize(ctx, field.ProjectID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteField(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityCustomField, id, field, nil)
+	})
+}
+
+// authorize checks that the current user owns the project and that it can change
+func (s *CustomFieldServiceImplementation) authorize(ctx context.Context, projectID uint) error {
+	if err := ensureProjectWritable(ctx, s.projectRepo, projectID); err != nil {
+		return err
+	}
+	project, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return ErrProjectNotFound
+	}
+	userID, _ := middleware.UserIDFromContext(ctx)
+	if !isProjectOwner(project, userID) {
+		return ErrNotFieldManager
+	}
+	return nil
+}
+
+// validateFieldDefinition checks the name, options and default of a field of a known type
+func validateFieldDefinition(field *models.CustomField) error {
+	field.Name = strings.TrimSpace(field.Name)
+	if field.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCustomField)
+	}
+
+	selectable := field.Type == models.CustomFieldTypeSelect || field.Type == models.CustomFieldTypeMultiSelect
+	if !selectable && len(field.Options) > 0 {
+		return fmt.Errorf("%w: only select and multi_select fields have options", ErrInvalidCustomField)
+	}
+	if selectable && len(field.Options) == 0 {
+		return fmt.Errorf("%w: %s fields need options", ErrInvalidCustomField, field.Type)
+	}
+	seen := make(map[string]bool, len(field.Options))
+	for _, option := range field.Options {
+		if strings.TrimSpace(option) == "" || seen[option] {
+			return fmt.Errorf("%w: options must be distinct and not empty", ErrInvalidCustomField)
+		}
+		seen[option] = true
+	}
+
+	if field.Default != nil {
+		value, err := normalizeFieldValue(field, field.Default)
+		if err != nil {
+			return fmt.Errorf("%w: invalid default: %v", ErrInvalidCustomField, err)
+		}
+		field.Default = value
+	}
+	return nil
+}
+
+// normalizeFieldValue checks that a value decoded from JSON suits the field and returns
+// it in the form tasks hold it: strings for text, select and date fields (YYYY-MM-DD),
+// float64 for numbers, the user ID for users and sorted lists of options for
+// multi_select fields. A nil or empty value is nil.
+func normalizeFieldValue(field *models.CustomField, raw interface{}) (interface{}, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	invalid := fmt.Errorf("%w: %s expects %s", ErrInvalidFieldValue, field.Key, fieldValueDescription(field.Type))
+
+	switch field.Type {
+	case models.CustomFieldTypeText, models.CustomFieldTypeSelect:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, invalid
+		}
+		if text == "" {
+			return nil, nil
+		}
+		if field.Type == models.CustomFieldTypeSelect && !helpers.Contains(field.Options, text) {
+			return nil, fmt.Errorf("%w: %q is not an option of %s", ErrInvalidFieldValue, text, field.Key)
+		}
+		return text, nil
+	case models.CustomFieldTypeNumber:
+		number, ok := toFloat(raw)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, invalid
+		}
+		return number, nil
+	case models.CustomFieldTypeDate:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, invalid
+		}
+		if text == "" {
+			return nil, nil
+		}
+		date, err := time.Parse(time.DateOnly, text)
+		if err != nil {
+			return nil, invalid
+		}
+		return date.Format(time.DateOnly), nil
+	case models.CustomFieldTypeUser:
+		number, ok := toFloat(raw)
+		if !ok || number < 1 || number != math.Trunc(number) {
+			return nil, invalid
+		}
+		return uint(number), nil
+	case models.CustomFieldTypeMultiSelect:
+		var options []string
+		switch list := raw.(type) {
+		case []string:
+			options = append(options, list...)
+		case []interface{}:
+			for _, item := range list {
+				option, ok := item.(string)
+				if !ok {
+					return nil, invalid
+				}
+				options = append(options, option)
+			}
+		default:
+			return nil, invalid
+		}
+		if len(options) == 0 {
+			return nil, nil
+		}
+		sort.Strings(options)
+		for i, option := range options {
+			if !helpers.Contains(field.Options, option) {
+				return nil, fmt.Errorf("%w: %q is not an option of %s", ErrInvalidFieldValue, option, field.Key)
+			}
+			if i > 0 && options[i-1] == option {
+				return nil, fmt.Errorf("%w: %q is repeated in %s", ErrInvalidFieldValue, option, field.Key)
+			}
+		}
+		return options, nil
+	}
+	return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidCustomField, field.Type)
+}
+
+// fieldValueRows returns the rows storing a normalized value of the field
+func fieldValueRows(field *models.CustomField, value interface{}) []models.TaskFieldValue {
+	row := models.TaskFieldValue{FieldID: field.ID}
+	switch v := value.(type) {
+	case string:
+		if field.Type == models.CustomFieldTypeDate {
+			date, _ := time.Parse(time.DateOnly, v)
+			row.DateValue = &date
+		} else {
+			row.TextValue = &v
+		}
+	case float64:
+		row.NumberValue = &v
+	case uint:
+		number := float64(v)
+		row.NumberValue = &number
+	case []string:
+		rows := make([]models.TaskFieldValue, len(v))
+		for i := range v {
+			rows[i] = models.TaskFieldValue{FieldID: field.ID, TextValue: &v[i]}
+		}
+		return rows
+	default:
+		return nil
+	}
+	return []models.TaskFieldValue{row}
+}
+
+// fieldValueColumn returns the column of task_field_values holding the values of the type
+func fieldValueColumn(fieldType string) string {
+	switch fieldType {
+	case models.CustomFieldTypeNumber, models.CustomFieldTypeUser:
+		return repositories.FieldValueNumber
+	case models.CustomFieldTypeDate:
+		return repositories.FieldValueDate
+	default:
+		return repositories.FieldValueText
+	}
+}
+
+func fieldValueDescription(fieldType string) string {
+	switch fieldType {
+	case models.CustomFieldTypeNumber:
+		return "a number"
+	case models.CustomFieldTypeDate:
+		return "a YYYY-MM-DD date"
+	case models.CustomFieldTypeUser:
+		return "a user ID"
+	case models.CustomFieldTypeMultiSelect:
+		return "a list of options"
+	default:
+		return "a string"
+	}
+}
+
+func toFloat(raw interface{}) (float64, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	}
+	return 0, false
+}
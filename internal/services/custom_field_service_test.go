@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockCustomFieldRepository mocks the CustomFieldRepository for testing
+type MockCustomFieldRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomFieldRepository) CreateField(ctx context.Context, field *models.CustomField) error {
+	args := m.Called(ctx, field)
+	return args.Error(0)
+}
+
+func (m *MockCustomFieldRepository) GetFieldByID(ctx context.Context, id uint) (*models.CustomField, error) {
+	args := m.Called(ctx, id)
+	field, _ := args.Get(0).(*models.CustomField)
+	return field, args.Error(1)
+}
+
+func (m *MockCustomFieldRepository) GetFieldsByProject(ctx context.Context, projectID uint) ([]models.CustomField, error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]models.CustomField), args.Error(1)
+}
+
+func (m *MockCustomFieldRepository) UpdateField(ctx context.Context, field *models.CustomField) error {
+	args := m.Called(ctx, field)
+	return args.Error(0)
+}
+
+func (m *MockCustomFieldRepository) DeleteField(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCustomFieldRepository) SetTaskValues(ctx context.Context, taskID uint, values []models.TaskFieldValue) error {
+	args := m.Called(ctx, taskID, values)
+	return args.Error(0)
+}
+
+func (m *MockCustomFieldRepository) DeleteOptionValues(ctx context.Context, fieldID uint, options []string) error {
+	args := m.Called(ctx, fieldID, options)
+	return args.Error(0)
+}
+
+func (m *MockCustomFieldRepository) GetExistingUserIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+// noCustomFields is a custom field repository of projects without custom fields
+func noCustomFields() *MockCustomFieldRepository {
+	fields := new(MockCustomFieldRepository)
+	fields.On("GetFieldsByProject", mock.Anything, mock.Anything).Return([]models.CustomField{}, nil).Maybe()
+	fields.On("SetTaskValues", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return fields
+}
+
+func TestCreateField(t *testing.T) {
+	project := &models.Project{BaseModel: models.BaseModel{ID: 1}, OwnerID: 7}
+
+	testCases := []struct {
+		name        string
+		userID      uint
+		field       models.CustomField
+		expectedErr error
+	}{
+		{name: "Select With Default", userID: 7, field: models.CustomField{Key: "severity", Name: "Severity", Type: models.CustomFieldTypeSelect, Options: []string{"low", "high"}, Default: "low"}},
+		{name: "Not The Owner", userID: 8, field: models.CustomField{Key: "severity", Name: "Severity", Type: models.CustomFieldTypeText}, expectedErr: ErrNotFieldManager},
+		{name: "Invalid Key", userID: 7, field: models.CustomField{Key: "Severity", Name: "Severity", Type: models.CustomFieldTypeText}, expectedErr: ErrInvalidCustomField},
+		{name: "Unknown Type", userID: 7, field: models.CustomField{Key: "severity", Name: "Severity", Type: "color"}, expectedErr: ErrInvalidCustomField},
+		{name: "Select Without Options", userID: 7, field: models.CustomField{Key: "severity", Name: "Severity", Type: models.CustomFieldTypeSelect}, expectedErr: ErrInvalidCustomField},
+		{name: "Default Outside The Options", userID: 7, field: models.CustomField{Key: "severity", Name: "Severity", Type: models.CustomFieldTypeSelect, Options: []string{"low"}, Default: "high"}, expectedErr: ErrInvalidCustomField},
+		{name: "Key Taken", userID: 7, field: models.CustomField{Key: "customer", Name: "Client", Type: models.CustomFieldTypeText}, expectedErr: ErrInvalidCustomField},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projects := activeProjects()
+			projects.On("GetProjectByID", mock.Anything, uint(1)).Return(project, nil)
+			fields := new(MockCustomFieldRepository)
+			fields.On("GetFieldsByProject", mock.Anything, uint(1)).Return([]models.CustomField{{ID: 1, ProjectID: 1, Key: "customer", Type: models.CustomFieldTypeText}}, nil).Maybe()
+			fields.On("CreateField", mock.Anything, mock.Anything).Return(nil).Maybe()
+			audit := new(MockAuditRecorder)
+
+			service := NewCustomFieldService(fields, projects, new(MockTransactor), audit)
+
+			field := tc.field
+			err := service.CreateField(middleware.WithUserID(context.Background(), tc.userID), 1, &field)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				fields.AssertNotCalled(t, "CreateField", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint(1), field.ProjectID)
+			fields.AssertCalled(t, "CreateField", mock.Anything, &field)
+			assert.Len(t, audit.Entries, 1)
+		})
+	}
+}
+
+func TestCreateTaskCustomFields(t *testing.T) {
+	projectFields := []models.CustomField{
+		{ID: 1, Key: "severity", Type: models.CustomFieldTypeSelect, Options: []string{"low", "high"}, Required: true, Default: "low"},
+		{ID: 2, Key: "points", Type: models.CustomFieldTypeNumber},
+		{ID: 3, Key: "components", Type: models.CustomFieldTypeMultiSelect, Options: []string{"api", "ui", "db"}},
+		{ID: 4, Key: "reviewer", Type: models.CustomFieldTypeUser},
+		{ID: 5, Key: "launch", Type: models.CustomFieldTypeDate},
+	}
+	high, api, ui := "high", "api", "ui"
+	points, reviewer := 5.0, 9.0
+	launch := time.Date(2026, time.May, 4, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		values       map[string]interface{}
+		expected     map[string]interface{}
+		expectedRows []models.TaskFieldValue
+		expectedErr  error
+	}{
+		{
+			name:   "Stores Each Type",
+			values: map[string]interface{}{"severity": "high", "points": 5.0, "components": []interface{}{"ui", "api"}, "reviewer": 9.0, "launch": "2026-05-04"},
+			expected: map[string]interface{}{
+				"severity": "high", "points": 5.0, "components": []string{"api", "ui"}, "reviewer": uint(9), "launch": "2026-05-04",
+			},
+			expectedRows: []models.TaskFieldValue{
+				{FieldID: 1, TextValue: &high},
+				{FieldID: 2, NumberValue: &points},
+				{FieldID: 3, TextValue: &api},
+				{FieldID: 3, TextValue: &ui},
+				{FieldID: 4, NumberValue: &reviewer},
+				{FieldID: 5, DateValue: &launch},
+			},
+		},
+		{
+			name:     "Applies Defaults",
+			expected: map[string]interface{}{"severity": "low"},
+		},
+		{name: "Clears A Required Field", values: map[string]interface{}{"severity": nil}, expectedErr: ErrInvalidFieldValue},
+		{name: "Unknown Field", values: map[string]interface{}{"customer": "Acme"}, expectedErr: ErrInvalidFieldValue},
+		{name: "Unknown Option", values: map[string]interface{}{"components": []interface{}{"docs"}}, expectedErr: ErrInvalidFieldValue},
+		{name: "Wrong Type", values: map[string]interface{}{"points": "five"}, expectedErr: ErrInvalidFieldValue},
+		{name: "Unknown User", values: map[string]interface{}{"reviewer": 10.0}, expectedErr: ErrInvalidFieldValue},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields := new(MockCustomFieldRepository)
+			fields.On("GetFieldsByProject", mock.Anything, uint(1)).Return(projectFields, nil)
+			fields.On("GetExistingUserIDs", mock.Anything, mock.Anything).Return([]uint{9}, nil).Maybe()
+			fields.On("SetTaskValues", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			tasks := new(MockTaskRepository)
+			tasks.On("CreateTask", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			service := NewTaskService(tasks, activeProjects(), fields, noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
+
+			task := &models.Task{Title: "Task", ProjectID: 1, CustomFields: tc.values}
+			err := service.CreateTask(context.Background(), task)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				tasks.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, task.CustomFields)
+			if tc.expectedRows != nil {
+				fields.AssertCalled(t, "SetTaskValues", mock.Anything, mock.Anything, tc.expectedRows)
+			}
+		})
+	}
+}
+
+func TestGetTasksByProjectCustomFields(t *testing.T) {
+	projectFields := []models.CustomField{
+		{ID: 1, Key: "severity", Type: models.CustomFieldTypeSelect, Options: []string{"low", "high"}},
+		{ID: 2, Key: "points", Type: models.CustomFieldTypeNumber},
+		{ID: 3, Key: "launch", Type: models.CustomFieldTypeDate},
+	}
+
+	testCases := []struct {
+		name        string
+		query       TaskListQuery
+		expected    repositories.TaskQuery
+		expectedErr error
+	}{
+		{
+			name:  "Values And Ranges",
+			query: TaskListQuery{Filters: map[string]string{"severity": "low,high", "points": "3..", "launch": "..2026-05-31"}},
+			expected: repositories.TaskQuery{Fields: []repositories.TaskFieldFilter{
+				{FieldID: 3, Column: repositories.FieldValueDate, Max: time.Date(2026, time.May, 31, 0, 0, 0, 0, time.UTC)},
+				{FieldID: 2, Column: repositories.FieldValueNumber, Min: 3.0},
+				{FieldID: 1, Column: repositories.FieldValueText, Values: []interface{}{"low", "high"}},
+			}},
+		},
+		{
+			name:  "Sorting",
+			query: TaskListQuery{Sort: "-cf.points,title"},
+			expected: repositories.TaskQuery{Sort: []repositories.TaskOrder{
+				{FieldID: 2, Column: repositories.FieldValueNumber, Desc: true},
+				{Column: "title"},
+			}},
+		},
+		{name: "Unknown Field", query: TaskListQuery{Filters: map[string]string{"customer": "Acme"}}, expectedErr: ErrInvalidTaskQuery},
+		{name: "Range Of Text", query: TaskListQuery{Filters: map[string]string{"severity": "a..z"}}, expectedErr: ErrInvalidTaskQuery},
+		{name: "Invalid Number", query: TaskListQuery{Filters: map[string]string{"points": "many"}}, expectedErr: ErrInvalidTaskQuery},
+		{name: "Unknown Column", query: TaskListQuery{Sort: "password"}, expectedErr: ErrInvalidTaskQuery},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields := new(MockCustomFieldRepository)
+			fields.On("GetFieldsByProject", mock.Anything, uint(1)).Return(projectFields, nil)
+			tasks := new(MockTaskRepository)
+			tasks.On("GetTaskByProject", mock.Anything, uint(1), tc.expected, 1, 10).Return([]models.Task{}, int64(0), nil).Maybe()
+
+			service := NewTaskService(tasks, activeProjects(), fields, noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
+
+			_, _, err := service.GetTasksByProject(context.Background(), 1, tc.query, 1, 10)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				tasks.AssertNotCalled(t, "GetTaskByProject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			tasks.AssertExpectations(t)
+		})
+	}
+}
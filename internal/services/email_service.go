@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/mailer"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/logger"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// EmailFrequencies are the accepted email preference values
+var EmailFrequencies = []string{
+	models.EmailFrequencyImmediate,
+	models.EmailFrequencyHourly,
+	models.EmailFrequencyDaily,
+	models.EmailFrequencyOff,
+}
+
+type EmailService interface {
+	Deliver(ctx context.Context, notification *models.Notification) error
+	SendDigests(ctx context.Context, frequency string) error
+	RunDigests(ctx context.Context)
+	GetPreference(ctx context.Context, userID uint) (*models.EmailPreference, error)
+	UpdatePreference(ctx context.Context, userID uint, frequency string) (*models.EmailPreference, error)
+}
+
+// EmailSettings configures the sender address, the links in emails and when daily digests go out
+type EmailSettings struct {
+	From            string
+	BaseURL         string
+	DailyDigestHour int // UTC
+}
+
+type EmailServiceImplementation struct {
+	repo      repositories.EmailRepository
+	userRepo  repositories.UserRepository
+	sender    mailer.Sender
+	templates *mailer.Templates
+	settings  EmailSettings
+	log       logger.Logger
+}
+
+func NewEmailService(repo repositories.EmailRepository, userRepo repositories.UserRepository, sender mailer.Sender, templates *mailer.Templates, settings EmailSettings, log logger.Logger) EmailService {
+	return &EmailServiceImplementation{
+		repo:      repo,
+		userRepo:  userRepo,
+		sender:    sender,
+		templates: templates,
+		settings:  settings,
+		log:       log,
+	}
+}
+
+// Deliver emails a notification right away or queues it for the next digest,
+// depending on the recipient's preference. Sending failures are logged and do
+// not fail the notification.
+func (s *EmailServiceImplementation) Deliver(ctx context.Context, notification *models.Notification) error {
+	preference, err := s.GetPreference(ctx, notification.UserID)
+	if err != nil {
+		return err
+	}
+
+	switch preference.Frequency {
+	case models.EmailFrequencyOff:
+		return nil
+	case models.EmailFrequencyHourly, models.EmailFrequencyDaily:
+		return s.repo.QueueDigestItem(ctx, &models.EmailDigestItem{
+			UserID:         notification.UserID,
+			NotificationID: notification.ID,
+		})
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, notification.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load notification recipient: %w", err)
+	}
+
+	err = s.send(ctx, user, mailer.TemplateNotification, notification.Message, mailer.TemplateData{
+		Frequency: models.EmailFrequencyImmediate,
+		Items:     []mailer.TemplateItem{s.templateItem(notification)},
+	})
+	if err != nil {
+		s.log.Error("Failed to send notification email", "userId", user.ID, "notificationId", notification.ID, "error", err)
+	}
+	return nil
+}
+
+// SendDigests emails every user with the given digest frequency the notifications
+// queued for them. Items of a failed email stay queued for the next run.
+func (s *EmailServiceImplementation) SendDigests(ctx context.Context, frequency string) error {
+	items, err := s.repo.GetDigestItems(ctx, frequency)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(items); {
+		end := start
+		for end < len(items) && items[end].UserID == items[start].UserID {
+			end++
+		}
+		batch := items[start:end]
+		start = end
+
+		data := mailer.TemplateData{Frequency: frequency}
+		ids := make([]uint, 0, len(batch))
+		for _, item := range batch {
+			data.Items = append(data.Items, s.templateItem(&item.Notification))
+			ids = append(ids, item.ID)
+		}
+
+		subject := fmt.Sprintf("Your %s digest: %d new notifications", frequency, len(batch))
+		if err := s.send(ctx, &batch[0].User, mailer.TemplateDigest, subject, data); err != nil {
+			s.log.Error("Failed to send digest email", "userId", batch[0].UserID, "frequency", frequency, "error", err)
+			continue
+		}
+
+		if err := s.repo.DeleteDigestItems(ctx, ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunDigests sends the hourly digests at the start of every hour and the daily
+// digests at the configured hour, until ctx is cancelled.
+func (s *EmailServiceImplementation) RunDigests(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	lastHour := time.Now().UTC().Truncate(time.Hour)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			hour := now.UTC().Truncate(time.Hour)
+			if !hour.After(lastHour) {
+				continue
+			}
+			lastHour = hour
+
+			if err := s.SendDigests(ctx, models.EmailFrequencyHourly); err != nil {
+				s.log.Error("Failed to send hourly digests", "error", err)
+			}
+			if hour.Hour() == s.settings.DailyDigestHour {
+				if err := s.SendDigests(ctx, models.EmailFrequencyDaily); err != nil {
+					s.log.Error("Failed to send daily digests", "error", err)
+				}
+			}
+		}
+	}
+}
+
+// GetPreference returns the email preference of a user, immediate by default.
+func (s *EmailServiceImplementation) GetPreference(ctx context.Context, userID uint) (*models.EmailPreference, error) {
+	preference, err := s.repo.GetPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if preference == nil {
+		return &models.EmailPreference{UserID: userID, Frequency: models.EmailFrequencyImmediate}, nil
+	}
+	return preference, nil
+}
+
+func (s *EmailServiceImplementation) UpdatePreference(ctx context.Context, userID uint, frequency string) (*models.EmailPreference, error) {
+	if !isEmailFrequency(frequency) {
+		return nil, fmt.Errorf("frequency must be one of %s", strings.Join(EmailFrequencies, ", "))
+	}
+
+	preference := &models.EmailPreference{UserID: userID, Frequency: frequency}
+	if err := s.repo.SavePreference(ctx, preference); err != nil {
+		return nil, err
+	}
+
+	// Queued notifications are dropped once the user stops receiving digests
+	if frequency == models.EmailFrequencyImmediate || frequency == models.EmailFrequencyOff {
+		if err := s.repo.ClearDigestItems(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return preference, nil
+}
+
+func (s *EmailServiceImplementation) send(ctx context.Context, user *models.User, template, subject string, data mailer.TemplateData) error {
+	data.RecipientName = user.FirstName
+	if data.RecipientName == "" {
+		data.RecipientName = user.Username
+	}
+	data.SettingsURL = s.settings.BaseURL + "/api/v1/me/email-preferences"
+
+	text, html, err := s.templates.Render(template, data)
+	if err != nil {
+		return err
+	}
+
+	to := (&mail.Address{Name: strings.TrimSpace(user.FirstName + " " + user.LastName), Address: user.Email}).String()
+	return s.sender.Send(ctx, mailer.Message{
+		From:    s.settings.From,
+		To:      to,
+		Subject: subject,
+		Text:    text,
+		HTML:    html,
+	})
+}
+
+func (s *EmailServiceImplementation) templateItem(notification *models.Notification) mailer.TemplateItem {
+	item := mailer.TemplateItem{Message: notification.Message, CreatedAt: notification.CreatedAt}
+	switch {
+	case notification.TaskID != 0:
+		item.URL = fmt.Sprintf("%s/api/v1/tasks/%d", s.settings.BaseURL, notification.TaskID)
+	case notification.ProjectID != 0:
+		item.URL = fmt.Sprintf("%s/api/v1/projects/%d", s.settings.BaseURL, notification.ProjectID)
+	}
+	return item
+}
+
+func isEmailFrequency(frequency string) bool {
+	for _, f := range EmailFrequencies {
+		if f == frequency {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/mailer"
+	"example/project-management-system/internal/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockEmailRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailRepository) GetPreference(ctx context.Context, userID uint) (*models.EmailPreference, error) {
+	args := m.Called(ctx, userID)
+	preference, _ := args.Get(0).(*models.EmailPreference)
+	return preference, args.Error(1)
+}
+
+func (m *MockEmailRepository) SavePreference(ctx context.Context, preference *models.EmailPreference) error {
+	args := m.Called(ctx, preference)
+	return args.Error(0)
+}
+
+func (m *MockEmailRepository) QueueDigestItem(ctx context.Context, item *models.EmailDigestItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockEmailRepository) GetDigestItems(ctx context.Context, frequency string) ([]models.EmailDigestItem, error) {
+	args := m.Called(ctx, frequency)
+	return args.Get(0).([]models.EmailDigestItem), args.Error(1)
+}
+
+func (m *MockEmailRepository) DeleteDigestItems(ctx context.Context, ids []uint) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockEmailRepository) ClearDigestItems(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, user *models.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	args := m.Called(ctx, id)
+	user, _ := args.Get(0).(*models.User)
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) GetAllUsers(ctx context.Context, page, pageSize int) ([]models.User, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) UpdateUser(ctx context.Context, user *models.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUsersByUsernames(ctx context.Context, usernames []string) ([]models.User, error) {
+	args := m.Called(ctx, usernames)
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+// MockLogger records the logged errors
+type MockLogger struct {
+	Errors []string
+}
+
+func (l *MockLogger) Info(msg string, keysAndValues ...interface{})  {}
+func (l *MockLogger) Debug(msg string, keysAndValues ...interface{}) {}
+func (l *MockLogger) Warn(msg string, keysAndValues ...interface{})  {}
+func (l *MockLogger) Fatal(msg string, keysAndValues ...interface{}) {}
+func (l *MockLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.Errors = append(l.Errors, msg)
+}
+
+func newTestEmailService(t *testing.T, repo *MockEmailRepository, userRepo *MockUserRepository, mailDir string, log *MockLogger) EmailService {
+	templates, err := mailer.NewTemplates()
+	require.NoError(t, err)
+
+	return NewEmailService(repo, userRepo, mailer.NewFileSender(mailDir), templates, EmailSettings{
+		From:    "Project Management <no-reply@example.com>",
+		BaseURL: "http://localhost:8080",
+	}, log)
+}
+
+func readMails(t *testing.T, dir string) []string {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+
+	var mails []string
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		require.NoError(t, err)
+		mails = append(mails, string(data))
+	}
+	return mails
+}
+
+func TestEmailDeliver(t *testing.T) {
+	notification := &models.Notification{
+		ID:      7,
+		UserID:  2,
+		TaskID:  1,
+		Message: "You were assigned to task \"Setup Server\"",
+	}
+	user := &models.User{BaseModel: models.BaseModel{ID: 2}, Username: "jane", Email: "jane@example.com", FirstName: "Jane"}
+
+	testCases := []struct {
+		name          string
+		preference    *models.EmailPreference
+		mockSetup     func(*MockEmailRepository, *MockUserRepository)
+		expectedMails int
+	}{
+		{
+			name: "Immediate By Default",
+			mockSetup: func(mer *MockEmailRepository, mur *MockUserRepository) {
+				mur.On("GetUserByID", mock.Anything, uint(2)).Return(user, nil)
+			},
+			expectedMails: 1,
+		},
+		{
+			name:       "Hourly Digest",
+			preference: &models.EmailPreference{UserID: 2, Frequency: models.EmailFrequencyHourly},
+			mockSetup: func(mer *MockEmailRepository, mur *MockUserRepository) {
+				mer.On("QueueDigestItem", mock.Anything, &models.EmailDigestItem{UserID: 2, NotificationID: 7}).Return(nil)
+			},
+		},
+		{
+			name:       "Off",
+			preference: &models.EmailPreference{UserID: 2, Frequency: models.EmailFrequencyOff},
+			mockSetup:  func(mer *MockEmailRepository, mur *MockUserRepository) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mailDir := filepath.Join(t.TempDir(), "mail")
+			repo := new(MockEmailRepository)
+			userRepo := new(MockUserRepository)
+			repo.On("GetPreference", mock.Anything, uint(2)).Return(tc.preference, nil)
+			tc.mockSetup(repo, userRepo)
+
+			service := newTestEmailService(t, repo, userRepo, mailDir, &MockLogger{})
+			err := service.Deliver(context.Background(), notification)
+
+			assert.NoError(t, err)
+			mails := readMails(t, mailDir)
+			assert.Len(t, mails, tc.expectedMails)
+			if tc.expectedMails > 0 {
+				assert.Contains(t, mails[0], "To: \"Jane\" <jane@example.com>")
+				assert.Contains(t, mails[0], "http://localhost:8080/api/v1/tasks/1")
+			}
+			repo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmailDeliver_LogsSendFailure(t *testing.T) {
+	// A file where the mail directory should be makes the file sink fail
+	mailDir := filepath.Join(t.TempDir(), "mail")
+	require.NoError(t, os.WriteFile(mailDir, nil, 0o644))
+
+	repo := new(MockEmailRepository)
+	userRepo := new(MockUserRepository)
+	repo.On("GetPreference", mock.Anything, uint(2)).Return(nil, nil)
+	userRepo.On("GetUserByID", mock.Anything, uint(2)).
+		Return(&models.User{BaseModel: models.BaseModel{ID: 2}, Email: "jane@example.com"}, nil)
+
+	log := &MockLogger{}
+	service := newTestEmailService(t, repo, userRepo, mailDir, log)
+	err := service.Deliver(context.Background(), &models.Notification{ID: 7, UserID: 2, Message: "Hello"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Failed to send notification email"}, log.Errors)
+}
+
+func TestSendDigests(t *testing.T) {
+	jane := models.User{BaseModel: models.BaseModel{ID: 2}, Username: "jane", Email: "jane@example.com"}
+	john := models.User{BaseModel: models.BaseModel{ID: 3}, Username: "john", Email: "john@example.com"}
+	items := []models.EmailDigestItem{
+		{ID: 1, UserID: 2, User: jane, Notification: models.Notification{Message: "You were assigned to task \"A\"", TaskID: 1}},
+		{ID: 2, UserID: 2, User: jane, Notification: models.Notification{Message: "New comment on task \"A\"", TaskID: 1}},
+		{ID: 3, UserID: 3, User: john, Notification: models.Notification{Message: "You were added to project #4", ProjectID: 4}},
+	}
+
+	mailDir := filepath.Join(t.TempDir(), "mail")
+	repo := new(MockEmailRepository)
+	repo.On("GetDigestItems", mock.Anything, models.EmailFrequencyDaily).Return(items, nil)
+	repo.On("DeleteDigestItems", mock.Anything, []uint{1, 2}).Return(nil)
+	repo.On("DeleteDigestItems", mock.Anything, []uint{3}).Return(nil)
+
+	service := newTestEmailService(t, repo, new(MockUserRepository), mailDir, &MockLogger{})
+	err := service.SendDigests(context.Background(), models.EmailFrequencyDaily)
+
+	assert.NoError(t, err)
+	mails := readMails(t, mailDir)
+	require.Len(t, mails, 2)
+
+	var janeMail string
+	for _, mail := range mails {
+		if strings.Contains(mail, "jane@example.com") {
+			janeMail = mail
+		}
+	}
+	assert.Contains(t, janeMail, "Subject: Your daily digest: 2 new notifications")
+	assert.Contains(t, janeMail, "- New comment on task \"A\"")
+	repo.AssertExpectations(t)
+}
+
+func TestUpdateEmailPreference(t *testing.T) {
+	t.Run("Switching Off Clears Queued Digest", func(t *testing.T) {
+		repo := new(MockEmailRepository)
+		repo.On("SavePreference", mock.Anything, &models.EmailPreference{UserID: 2, Frequency: models.EmailFrequencyOff}).Return(nil)
+		repo.On("ClearDigestItems", mock.Anything, uint(2)).Return(nil)
+
+		service := newTestEmailService(t, repo, new(MockUserRepository), t.TempDir(), &MockLogger{})
+		preference, err := service.UpdatePreference(context.Background(), 2, models.EmailFrequencyOff)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.EmailFrequencyOff, preference.Frequency)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Frequency", func(t *testing.T) {
+		repo := new(MockEmailRepository)
+
+		service := newTestEmailService(t, repo, new(MockUserRepository), t.TempDir(), &MockLogger{})
+		_, err := service.UpdatePreference(context.Background(), 2, "weekly")
+
+		assert.ErrorContains(t, err, "frequency must be one of")
+		repo.AssertNotCalled(t, "SavePreference", mock.Anything, mock.Anything)
+	})
+}
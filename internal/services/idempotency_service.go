@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("the Idempotency-Key was already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this Idempotency-Key is still being processed, retry it later")
+)
+
+// idempotencyLockTimeout is how long a request holds its key before a retry may take
+// it over, longer than any request runs
+const idempotencyLockTimeout = time.Minute
+
+// IdempotencySettings configures how long responses are replayed
+type IdempotencySettings struct {
+	TTL             time.Duration
+	CleanupInterval time.Duration
+}
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, userID uint, key, requestHash string) (*models.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, key *models.IdempotencyKey) error
+	Release(ctx context.Context, key *models.IdempotencyKey) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+	RunCleanup(ctx context.Context)
+}
+
+type IdempotencyServiceImplementation struct {
+	repo     repositories.IdempotencyRepository
+	settings IdempotencySettings
+	log      logger.Logger
+}
+
+func NewIdempotencyService(repo repositories.IdempotencyRepository, settings IdempotencySettings, log logger.Logger) IdempotencyService {
+	return &IdempotencyServiceImplementation{repo: repo, settings: settings, log: log}
+}
+
+// Begin reserves the key of the user for a request. When the key was used for the
+// same request before, it returns the stored response to replay with replay set.
+func (s *IdempotencyServiceImplementation) Begin(ctx context.Context, userID uint, key, requestHash string) (*models.IdempotencyKey, bool, error) {
+	now := time.Now()
+
+	// The second attempt runs when a concurrent request reserved the key first
+	for attempt := 0; attempt < 2; attempt++ {
+		existing, err := s.repo.GetKey(ctx, userID, key)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+		case err != nil:
+			return nil, false, err
+		case existing.ExpiresAt.Before(now),
+			existing.StatusCode == 0 && existing.CreatedAt.Before(now.Add(-idempotencyLockTimeout)):
+			// Expired, or left behind by a request that never finished
+			if err := s.repo.DeleteKey(ctx, existing.ID); err != nil {
+				return nil, false, err
+			}
+		case existing.RequestHash != requestHash:
+			return nil, false, ErrIdempotencyKeyReused
+		case existing.StatusCode == 0:
+			return nil, false, ErrIdempotencyKeyInProgress
+		default:
+			return existing, true, nil
+		}
+
+		reserved := &models.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash,
+			ExpiresAt:   now.Add(s.settings.TTL),
+		}
+		if err := s.repo.CreateKey(ctx, reserved); err == nil {
+			return reserved, false, nil
+		}
+	}
+	return nil, false, ErrIdempotencyKeyInProgress
+}
+
+// Complete stores the response of the request the key was reserved for.
+func (s *IdempotencyServiceImplementation) Complete(ctx context.Context, key *models.IdempotencyKey) error {
+	return s.repo.SaveResponse(ctx, key)
+}
+
+// Release frees the key after a failed request, so a retry runs it again.
+func (s *IdempotencyServiceImplementation) Release(ctx context.Context, key *models.IdempotencyKey) error {
+	return s.repo.DeleteKey(ctx, key.ID)
+}
+
+// DeleteExpired deletes the keys whose responses are no longer replayed.
+func (s *IdempotencyServiceImplementation) DeleteExpired(ctx context.Context, now time.Time) error {
+	deleted, err := s.repo.DeleteExpiredKeys(ctx, now)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		s.log.Info("Deleted expired idempotency keys", "count", deleted)
+	}
+	return nil
+}
+
+// RunCleanup deletes expired keys every cleanup interval until ctx is cancelled.
+func (s *IdempotencyServiceImplementation) RunCleanup(ctx context.Context) {
+	ticker := time.NewTicker(s.settings.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.DeleteExpired(ctx, now); err != nil {
+				s.log.Error("Failed to delete expired idempotency keys", "error", err)
+			}
+		}
+	}
+}
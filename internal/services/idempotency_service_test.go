@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockIdempotencyRepository mocks the IdempotencyRepository for testing
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) CreateKey(ctx context.Context, key *models.IdempotencyKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) GetKey(ctx context.Context, userID uint, key string) (*models.IdempotencyKey, error) {
+	args := m.Called(ctx, userID, key)
+	stored, _ := args.Get(0).(*models.IdempotencyKey)
+	return stored, args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) SaveResponse(ctx context.Context, key *models.IdempotencyKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) DeleteKey(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestIdempotencyBegin(t *testing.T) {
+	now := time.Now()
+	completed := &models.IdempotencyKey{ID: 1, UserID: 2, Key: "key", RequestHash: "hash", StatusCode: 201, Body: []byte(`{"id":5}`), CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+
+	testCases := []struct {
+		name           string
+		mockSetup      func(*MockIdempotencyRepository)
+		expectedReplay bool
+		expectedErr    error
+	}{
+		{
+			name: "New Key",
+			mockSetup: func(mir *MockIdempotencyRepository) {
+				mir.On("GetKey", mock.Anything, uint(2), "key").Return(nil, gorm.ErrRecordNotFound)
+				mir.On("CreateKey", mock.Anything, mock.MatchedBy(func(key *models.IdempotencyKey) bool {
+					return key.UserID == 2 && key.Key == "key" && key.RequestHash == "hash" && key.ExpiresAt.After(now)
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Replay",
+			mockSetup: func(mir *MockIdempotencyRepository) {
+				mir.On("GetKey", mock.Anything, uint(2), "key").Return(completed, nil)
+			},
+			expectedReplay: true,
+		},
+		{
+			name: "Key Reused For A Different Request",
+			mockSetup: func(mir *MockIdempotencyRepository) {
+				reused := *completed
+				reused.RequestHash = "other"
+				mir.On("GetKey", mock.Anything, uint(2), "key").Return(&reused, nil)
+			},
+			expectedErr: ErrIdempotencyKeyReused,
+		},
+		{
+			name: "Request In Progress",
+			mockSetup: func(mir *MockIdempotencyRepository) {
+				inProgress := *completed
+				inProgress.StatusCode = 0
+				mir.On("GetKey", mock.Anything, uint(2), "key").Return(&inProgress, nil)
+			},
+			expectedErr: ErrIdempotencyKeyInProgress,
+		},
+		{
+			name: "Expired Key Is Reserved Again",
+			mockSetup: func(mir *MockIdempotencyRepository) {
+				expired := *completed
+				expired.ExpiresAt = now.Add(-time.Minute)
+				mir.On("GetKey", mock.Anything, uint(2), "key").Return(&expired, nil)
+				mir.On("DeleteKey", mock.Anything, uint(1)).Return(nil)
+				mir.On("CreateKey", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockIdempotencyRepository)
+			tc.mockSetup(mockRepo)
+			service := NewIdempotencyService(mockRepo, IdempotencySettings{TTL: time.Hour, CleanupInterval: time.Hour}, &MockLogger{})
+
+			key, replay, err := service.Begin(context.Background(), 2, "key", "hash")
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, key)
+			}
+			assert.Equal(t, tc.expectedReplay, replay)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
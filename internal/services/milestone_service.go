@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	ErrMilestoneNotFound    = errors.New("milestone not found")
+	ErrInvalidMilestone     = errors.New("invalid milestone")
+	ErrInvalidMilestoneTask = errors.New("only tasks of the project of the milestone can be linked to it")
+	ErrTaskNotInMilestone   = errors.New("the task is not linked to the milestone")
+)
+
+// workdayHours is the daily capacity of one person when a milestone sets none
+const workdayHours = 8
+
+type MilestoneService interface {
+	CreateMilestone(ctx context.Context, milestone *models.Milestone) error
+	GetMilestoneByID(ctx context.Context, id uint) (*models.Milestone, error)
+	GetMilestonesByProject(ctx context.Context, projectID uint) ([]models.Milestone, error)
+	UpdateMilestone(ctx context.Context, milestone *models.Milestone) error
+	DeleteMilestone(ctx context.Context, id uint) error
+	AddTasks(ctx context.Context, id uint, taskIDs []uint) (*models.Milestone, error)
+	RemoveTask(ctx context.Context, id, taskID uint) error
+}
+
+type MilestoneServiceImplementation struct {
+	repo        repositories.MilestoneRepository
+	taskRepo    repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+	watcherRepo repositories.WatcherRepository
+	transactor  repositories.Transactor
+	events      events.Publisher
+	audit       AuditRecorder
+	now         func() time.Time
+}
+
+func NewMilestoneService(repo repositories.MilestoneRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, watcherRepo repositories.WatcherRepository, transactor repositories.Transactor, publisher events.Publisher, audit AuditRecorder) MilestoneService {
+	return &MilestoneServiceImplementation{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		watcherRepo: watcherRepo,
+		transactor:  transactor,
+		events:      publisher,
+		audit:       audit,
+		now:         time.Now,
+	}
+}
+
+func (s *MilestoneServiceImplementation) CreateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	if err := validateMilestone(milestone); err != nil {
+		return err
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, milestone.ProjectID); err != nil {
+		return err
+	}
+	milestone.ID = 0
+	milestone.Tasks = nil
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateMilestone(ctx, milestone); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityMilestone, milestone.ID, nil, milestone)
+	})
+	if err != nil {
+		return err
+	}
+	return s.withProgress(ctx, milestone.ProjectID, milestone)
+}
+
+// GetMilestoneByID returns the milestone with its tasks and progress
+func (s *MilestoneServiceImplementation) GetMilestoneByID(ctx context.Context, id uint) (*models.Milestone, error) {
+	milestone, err := s.repo.GetMilestoneByID(ctx, id)
+	if err != nil {
+		return nil, ErrMilestoneNotFound
+	}
+	if err := s.withProgress(ctx, milestone.ProjectID, milestone); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// GetMilestonesByProject returns the milestones of a project with their progress,
+// without their tasks
+func (s *MilestoneServiceImplementation) GetMilestonesByProject(ctx context.Context, projectID uint) ([]models.Milestone, error) {
+	if _, err := s.projectRepo.GetProjectStatus(ctx, projectID); err != nil {
+		return nil, ErrProjectNotFound
+	}
+	milestones, err := s.repo.GetMilestonesByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	listed := make([]*models.Milestone, len(milestones))
+	for i := range milestones {
+		listed[i] = &milestones[i]
+	}
+	if err := s.withProgress(ctx, projectID, listed...); err != nil {
+		return nil, err
+	}
+	for i := range milestones {
+		milestones[i].Tasks = nil
+	}
+	return milestones, nil
+}
+
+// UpdateMilestone changes the name, description, target date and capacity of a milestone
+func (s *MilestoneServiceImplementation) UpdateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	existing, err := s.repo.GetMilestoneByID(ctx, milestone.ID)
+	if err != nil {
+		return ErrMilestoneNotFound
+	}
+	milestone.ProjectID = existing.ProjectID
+	if err := validateMilestone(milestone); err != nil {
+		return err
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, milestone.ProjectID); err != nil {
+		return err
+	}
+	milestone.CreatedAt = existing.CreatedAt
+	milestone.Tasks = nil
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateMilestone(ctx, milestone); err != nil {
+			return err
+		}
+		before := *existing
+		before.Tasks = nil
+		return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityMilestone, milestone.ID, &before, milestone)
+	})
+	if err != nil {
+		return err
+	}
+	milestone.Tasks = existing.Tasks
+	return s.withProgress(ctx, milestone.ProjectID, milestone)
+}
+
+// DeleteMilestone deletes a milestone, its tasks are kept without it
+func (s *MilestoneServiceImplementation) DeleteMilestone(ctx context.Context, id uint) error {
+	milestone, err := s.repo.GetMilestoneByID(ctx, id)
+	if err != nil {
+		return ErrMilestoneNotFound
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, milestone.ProjectID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i := range milestone.Tasks {
+			if err := s.setTaskMilestone(ctx, &milestone.Tasks[i], nil); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.DeleteMilestone(ctx, id); err != nil {
+			return err
+		}
+		milestone.Tasks = nil
+		return s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityMilestone, id, milestone, nil)
+	})
+}
+
+// AddTasks links tasks of the project to a milestone, a task is needed for one
+// milestone at a time
+func (s *MilestoneServiceImplementation) AddTasks(ctx context.Context, id uint, taskIDs []uint) (*models.Milestone, error) {
+	milestone, err := s.repo.GetMilestoneByID(ctx, id)
+	if err != nil {
+		return nil, ErrMilestoneNotFound
+	}
+	if len(taskIDs) == 0 {
+		return nil, fmt.Errorf("%w: task_ids is required", ErrInvalidMilestone)
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, milestone.ProjectID); err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, taskID := range taskIDs {
+			task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+			if err != nil {
+				return fmt.Errorf("%w: %d", ErrTaskNotFound, taskID)
+			}
+			if task.ProjectID != milestone.ProjectID {
+				return fmt.Errorf("%w: task %d", ErrInvalidMilestoneTask, taskID)
+			}
+			if task.MilestoneID != nil && *task.MilestoneID == milestone.ID {
+				continue
+			}
+			if err := s.setTaskMilestone(ctx, task, &milestone.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetMilestoneByID(ctx, id)
+}
+
+// RemoveTask unlinks a task from a milestone
+func (s *MilestoneServiceImplementation) RemoveTask(ctx context.Context, id, taskID uint) error {
+	milestone, err := s.repo.GetMilestoneByID(ctx, id)
+	if err != nil {
+		return ErrMilestoneNotFound
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, milestone.ProjectID); err != nil {
+		return err
+	}
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	if task.MilestoneID == nil || *task.MilestoneID != milestone.ID {
+		return ErrTaskNotInMilestone
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.setTaskMilestone(ctx, task, nil)
+	})
+}
+
+// withProgress sets the progress of milestones of a project. Without a daily
+// capacity of its own, everyone on the project, its members and the assignees of
+// the open tasks of the milestone, is counted for a workday.
+func (s *MilestoneServiceImplementation) withProgress(ctx context.Context, projectID uint, milestones ...*models.Milestone) error {
+	project, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return ErrProjectNotFound
+	}
+
+	for _, milestone := range milestones {
+		dailyCapacity := milestone.DailyCapacity
+		if dailyCapacity == 0 {
+			people := make(map[uint]bool)
+			for _, userID := range project.UserIDs {
+				people[userID] = true
+			}
+			for _, task := range milestone.Tasks {
+				if task.Status != models.TaskStatusDone && task.AssignedTo != 0 {
+					people[task.AssignedTo] = true
+				}
+			}
+			dailyCapacity = float64(len(people) * workdayHours)
+		}
+		milestone.Progress = milestoneProgress(milestone.Tasks, dailyCapacity*float64(workdaysUntil(s.now(), milestone.TargetDate)))
+	}
+	return nil
+}
+
+// milestoneProgress counts the done tasks and compares the hours left on the others
+// with the capacity
+func milestoneProgress(tasks []models.Task, capacity float64) *models.MilestoneProgress {
+	progress := &models.MilestoneProgress{TotalTasks: len(tasks), Capacity: capacity}
+	for _, task := range tasks {
+		if task.Status == models.TaskStatusDone {
+			progress.DoneTasks++
+			continue
+		}
+		progress.RemainingEstimate += task.RemainingEstimate
+	}
+	if progress.TotalTasks > 0 {
+		progress.Percent = math.Round(float64(progress.DoneTasks)/float64(progress.TotalTasks)*10000) / 100
+	}
+	progress.AtRisk = progress.RemainingEstimate > progress.Capacity
+	return progress
+}
+
+// workdaysUntil counts the days from Monday to Friday from today through the target
+// date, none once it has passed
+func workdaysUntil(now, target time.Time) int {
+	days := 0
+	for day := utcDate(now); !day.After(utcDate(target)); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			days++
+		}
+	}
+	return days
+}
+
+// setTaskMilestone links a task to a milestone, or unlinks it when milestoneID is nil
+func (s *MilestoneServiceImplementation) setTaskMilestone(ctx context.Context, task *models.Task, milestoneID *uint) error {
+	before := *task
+	task.MilestoneID = milestoneID
+	if err := s.taskRepo.UpdateTask(ctx, task); err != nil {
+		return err
+	}
+	if err := s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityTask, task.ID, &before, task); err != nil {
+		return err
+	}
+
+	return publishTaskChange(ctx, s.events, s.watcherRepo, events.TaskUpdated, task)
+}
+
+func validateMilestone(milestone *models.Milestone) error {
+	if milestone.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidMilestone)
+	}
+	if milestone.ProjectID == 0 {
+		return fmt.Errorf("%w: the milestone must be associated with a project", ErrInvalidMilestone)
+	}
+	if milestone.TargetDate.IsZero() {
+		return fmt.Errorf("%w: target_date is required", ErrInvalidMilestone)
+	}
+	if milestone.DailyCapacity < 0 {
+		return fmt.Errorf("%w: daily_capacity cannot be negative", ErrInvalidMilestone)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockMilestoneRepository mocks the MilestoneRepository for testing
+type MockMilestoneRepository struct {
+	mock.Mock
+}
+
+func (m *MockMilestoneRepository) CreateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	args := m.Called(ctx, milestone)
+	return args.Error(0)
+}
+
+func (m *MockMilestoneRepository) GetMilestoneByID(ctx context.Context, id uint) (*models.Milestone, error) {
+	args := m.Called(ctx, id)
+	milestone, _ := args.Get(0).(*models.Milestone)
+	return milestone, args.Error(1)
+}
+
+func (m *MockMilestoneRepository) GetMilestonesByProject(ctx context.Context, projectID uint) ([]models.Milestone, error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]models.Milestone), args.Error(1)
+}
+
+func (m *MockMilestoneRepository) UpdateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	args := m.Called(ctx, milestone)
+	return args.Error(0)
+}
+
+func (m *MockMilestoneRepository) DeleteMilestone(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestWorkdaysUntil(t *testing.T) {
+	// Wednesday, 4 March 2026
+	now := time.Date(2026, time.March, 4, 15, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 1, workdaysUntil(now, now))
+	assert.Equal(t, 3, workdaysUntil(now, time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 8, workdaysUntil(now, time.Date(2026, time.March, 13, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 0, workdaysUntil(now, time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestGetMilestoneProgress(t *testing.T) {
+	// Wednesday to the Friday of the next week, 8 working days
+	now := time.Date(2026, time.March, 4, 9, 0, 0, 0, time.UTC)
+	target := time.Date(2026, time.March, 13, 0, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{Status: models.TaskStatusDone, AssignedTo: 7, RemainingEstimate: 30},
+		{Status: models.TaskStatusInProgress, AssignedTo: 8, RemainingEstimate: 50},
+		{Status: models.TaskStatusTodo, AssignedTo: 8, RemainingEstimate: 40},
+		{Status: models.TaskStatusTodo, AssignedTo: 9},
+	}
+
+	testCases := []struct {
+		name             string
+		dailyCapacity    float64
+		expectedCapacity float64
+		expectedAtRisk   bool
+	}{
+		// The members 8 and 10, and 9 who is assigned an open task
+		{name: "Capacity Of The People On The Project", expectedCapacity: 3 * 8 * 8},
+		{name: "Capacity Of The Milestone", dailyCapacity: 10, expectedCapacity: 80, expectedAtRisk: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			milestones := new(MockMilestoneRepository)
+			milestones.On("GetMilestoneByID", mock.Anything, uint(3)).Return(&models.Milestone{
+				ID: 3, ProjectID: 1, Name: "v2", TargetDate: target, DailyCapacity: tc.dailyCapacity, Tasks: tasks,
+			}, nil)
+			projects := new(MockProjectRepository)
+			projects.On("GetProjectByID", mock.Anything, uint(1)).Return(&models.Project{UserIDs: []uint{8, 10}}, nil)
+
+			service := NewMilestoneService(milestones, new(MockTaskRepository), projects, noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder)).(*MilestoneServiceImplementation)
+			service.now = func() time.Time { return now }
+
+			milestone, err := service.GetMilestoneByID(context.Background(), 3)
+
+			require.NoError(t, err)
+			assert.Equal(t, &models.MilestoneProgress{
+				TotalTasks:        4,
+				DoneTasks:         1,
+				Percent:           25,
+				RemainingEstimate: 90,
+				Capacity:          tc.expectedCapacity,
+				AtRisk:            tc.expectedAtRisk,
+			}, milestone.Progress)
+		})
+	}
+}
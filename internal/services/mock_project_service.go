@@ -8,9 +8,10 @@ import (
 type MockProjectService struct {
 	CreateProjectFunc       func(ctx context.Context, project *models.Project) error
 	GetProjectByIDFunc      func(ctx context.Context, id uint) (*models.Project, error)
-	GetPaginatedProjectsFunc      func(ctx context.Context, page, pageSize int) ([]models.Project, int64, error)
+	GetPaginatedProjectsFunc      func(ctx context.Context, page, pageSize int, includeArchived bool) ([]models.Project, int64, error)
 	UpdateProjectFunc       func(ctx context.Context, project *models.Project) error
-	DeleteProjectFunc       func(ctx context.Context, id uint) error
+	ChangeProjectStatusFunc func(ctx context.Context, id, version uint, status string, force bool) (*models.Project, error)
+	DeleteProjectFunc       func(ctx context.Context, id uint, version uint) error
 	GetTasksByProjectIDFunc func(ctx context.Context, projectID uint) ([]models.Task, error)
 }
 
@@ -28,9 +29,9 @@ func (m *MockProjectService) GetProjectByID(ctx context.Context, id uint) (*mode
 	return nil, nil
 }
 
-func (m *MockProjectService) GetPaginatedProjects(ctx context.Context, page, pageSize int) ([]models.Project, int64, error) {
+func (m *MockProjectService) GetPaginatedProjects(ctx context.Context, page, pageSize int, includeArchived bool) ([]models.Project, int64, error) {
 	if m.GetPaginatedProjectsFunc != nil {
-		return m.GetPaginatedProjectsFunc(ctx, page, pageSize)
+		return m.GetPaginatedProjectsFunc(ctx, page, pageSize, includeArchived)
 	}
 	return nil, 0, nil
 }
@@ -42,9 +43,16 @@ func (m *MockProjectService) UpdateProject(ctx context.Context, project *models.
 	return nil
 }
 
-func (m *MockProjectService) DeleteProject(ctx context.Context, id uint) error {
+func (m *MockProjectService) ChangeProjectStatus(ctx context.Context, id, version uint, status string, force bool) (*models.Project, error) {
+	if m.ChangeProjectStatusFunc != nil {
+		return m.ChangeProjectStatusFunc(ctx, id, version, status, force)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectService) DeleteProject(ctx context.Context, id uint, version uint) error {
 	if m.DeleteProjectFunc != nil {
-		return m.DeleteProjectFunc(ctx, id)
+		return m.DeleteProjectFunc(ctx, id, version)
 	}
 	return nil
 }
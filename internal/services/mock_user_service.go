@@ -10,7 +10,8 @@ type MockUserService struct {
 	CreateUserFunc    func(ctx context.Context, user *models.User) error
 	GetUserByIDFunc   func(ctx context.Context, id uint) (*models.User, error)
 	GetAllUsersFunc   func(ctx context.Context, page, pageSize int) ([]models.User, int64, error)
-	DeleteUserFunc    func(ctx context.Context, id uint) error
+	UpdateUserFunc    func(ctx context.Context, user *models.User) error
+	DeleteUserFunc    func(ctx context.Context, id uint, version uint) error
 }
 
 func (m *MockUserService) CreateUser(ctx context.Context, user *models.User) error {
@@ -25,6 +26,10 @@ func (m *MockUserService) GetAllUsers(ctx context.Context, page, pageSize int) (
 	return m.GetAllUsersFunc(ctx, page, pageSize)
 }
 
-func (m *MockUserService) DeleteUser(ctx context.Context, id uint) error {
-	return m.DeleteUserFunc(ctx, id)
+func (m *MockUserService) UpdateUser(ctx context.Context, user *models.User) error {
+	return m.UpdateUserFunc(ctx, user)
+}
+
+func (m *MockUserService) DeleteUser(ctx context.Context, id uint, version uint) error {
+	return m.DeleteUserFunc(ctx, id, version)
 }
\ No newline at end of file
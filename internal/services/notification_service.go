@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/logger"
+	"fmt"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationEventTypes are the event types users can subscribe to
+var NotificationEventTypes = []events.Type{
+	events.TaskAssigned,
+	events.TaskStatusChanged,
+	events.TaskUpdated,
+	events.TaskDeleted,
+	events.CommentCreated,
+	events.UserMentioned,
+	events.ProjectMemberAdded,
+	events.ProjectMemberRemoved,
+}
+
+type NotificationService interface {
+	HandleEvent(ctx context.Context, event events.Event) error
+	GetNotifications(ctx context.Context, userID uint, unreadOnly bool, page, pageSize int) ([]models.Notification, int64, int64, error)
+	MarkAsRead(ctx context.Context, userID, id uint) error
+	MarkAllAsRead(ctx context.Context, userID uint) (int64, error)
+	GetPreferences(ctx context.Context, userID uint) (map[events.Type]bool, error)
+	UpdatePreferences(ctx context.Context, userID uint, preferences map[events.Type]bool) (map[events.Type]bool, error)
+}
+
+// NotificationChannel delivers inbox notifications outside the app, e.g. by email
+type NotificationChannel interface {
+	Deliver(ctx context.Context, notification *models.Notification) error
+}
+
+type NotificationServiceImplementation struct {
+	repo     repositories.NotificationRepository
+	channels []NotificationChannel
+	log      logger.Logger
+}
+
+func NewNotificationService(repo repositories.NotificationRepository, log logger.Logger, channels ...NotificationChannel) NotificationService {
+	return &NotificationServiceImplementation{repo: repo, channels: channels, log: log}
+}
+
+// HandleEvent delivers an event to the inbox and channels of each of its recipients,
+// or of the user it is about when it has none, except the user who caused it and
+// those who unsubscribed from its type.
+func (s *NotificationServiceImplementation) HandleEvent(ctx context.Context, event events.Event) error {
+	if !isNotificationEventType(event.Type) {
+		return nil
+	}
+
+	recipients := event.Recipients
+	if len(recipients) == 0 && event.UserID != 0 {
+		recipients = []uint{event.UserID}
+	}
+	for _, userID := range recipients {
+		if userID == event.ActorID {
+			continue
+		}
+		if err := s.notify(ctx, userID, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *NotificationServiceImplementation) notify(ctx context.Context, userID uint, event events.Event) error {
+	subscribed, err := s.isSubscribed(ctx, userID, event.Type)
+	if err != nil || !subscribed {
+		return err
+	}
+
+	notification := &models.Notification{
+		UserID:    userID,
+		Type:      string(event.Type),
+		ActorID:   event.ActorID,
+		ProjectID: event.ProjectID,
+		TaskID:    event.TaskID,
+		CommentID: event.CommentID,
+		Message:   NotificationMessage(event),
+	}
+	if err := s.repo.CreateNotification(ctx, notification); err != nil {
+		return err
+	}
+
+	// Every channel gets the notification, even if an earlier one failed. Channels
+	// retry on their own, failing the event would redeliver it and duplicate the
+	// inbox notification.
+	for _, channel := range s.channels {
+		if err := channel.Deliver(ctx, notification); err != nil {
+			s.log.Error("Failed to deliver a notification", "notificationId", notification.ID, "userId", userID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *NotificationServiceImplementation) GetNotifications(ctx context.Context, userID uint, unreadOnly bool, page, pageSize int) ([]models.Notification, int64, int64, error) {
+	notifications, total, err := s.repo.GetNotificationsByUser(ctx, userID, unreadOnly, page, pageSize)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return notifications, total, unread, nil
+}
+
+func (s *NotificationServiceImplementation) MarkAsRead(ctx context.Context, userID, id uint) error {
+	matched, err := s.repo.MarkAsRead(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *NotificationServiceImplementation) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	return s.repo.MarkAllAsRead(ctx, userID)
+}
+
+// GetPreferences returns whether the user is subscribed to each notification event type.
+func (s *NotificationServiceImplementation) GetPreferences(ctx context.Context, userID uint) (map[events.Type]bool, error) {
+	stored, err := s.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make(map[events.Type]bool, len(NotificationEventTypes))
+	for _, eventType := range NotificationEventTypes {
+		preferences[eventType] = true
+	}
+	for _, preference := range stored {
+		if isNotificationEventType(events.Type(preference.EventType)) {
+			preferences[events.Type(preference.EventType)] = preference.Enabled
+		}
+	}
+	return preferences, nil
+}
+
+func (s *NotificationServiceImplementation) UpdatePreferences(ctx context.Context, userID uint, preferences map[events.Type]bool) (map[events.Type]bool, error) {
+	updates := make([]models.NotificationPreference, 0, len(preferences))
+	for eventType, enabled := range preferences {
+		if !isNotificationEventType(eventType) {
+			return nil, fmt.Errorf("unknown notification event type %q", eventType)
+		}
+		updates = append(updates, models.NotificationPreference{
+			UserID:    userID,
+			EventType: string(eventType),
+			Enabled:   enabled,
+		})
+	}
+
+	if err := s.repo.SavePreferences(ctx, updates); err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(ctx, userID)
+}
+
+func (s *NotificationServiceImplementation) isSubscribed(ctx context.Context, userID uint, eventType events.Type) (bool, error) {
+	preferences, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return preferences[eventType], nil
+}
+
+func isNotificationEventType(eventType events.Type) bool {
+	for _, t := range NotificationEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationMessage describes an event in a sentence addressed to its recipient.
+func NotificationMessage(event events.Event) string {
+	title := event.Data["task_title"]
+
+	switch event.Type {
+	case events.TaskAssigned:
+		return fmt.Sprintf("You were assigned to task %q", title)
+	case events.TaskStatusChanged:
+		return fmt.Sprintf("Task %q moved from %s to %s", title, event.Data["previous_status"], event.Data["status"])
+	case events.TaskUpdated:
+		return fmt.Sprintf("Task %q was updated", title)
+	case events.TaskDeleted:
+		return fmt.Sprintf("Task %q was deleted", title)
+	case events.CommentCreated:
+		return fmt.Sprintf("New comment on task %q", title)
+	case events.UserMentioned:
+		return fmt.Sprintf("You were mentioned in a comment on task %q", title)
+	case events.ProjectMemberAdded:
+		return fmt.Sprintf("You were added to project #%d", event.ProjectID)
+	case events.ProjectMemberRemoved:
+		return fmt.Sprintf("You were removed from project #%d", event.ProjectID)
+	default:
+		return string(event.Type)
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationRepository mocks the NotificationRepository for testing
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetNotificationsByUser(ctx context.Context, userID uint, unreadOnly bool, page, pageSize int) ([]models.Notification, int64, error) {
+	args := m.Called(ctx, userID, unreadOnly, page, pageSize)
+	notifications, _ := args.Get(0).([]models.Notification)
+	return notifications, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificationRepository) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAsRead(ctx context.Context, userID, id uint) (int64, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error) {
+	args := m.Called(ctx, userID)
+	preferences, _ := args.Get(0).([]models.NotificationPreference)
+	return preferences, args.Error(1)
+}
+
+func (m *MockNotificationRepository) SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error {
+	args := m.Called(ctx, preferences)
+	return args.Error(0)
+}
+
+// failingChannel is a notification channel that is down
+type failingChannel struct {
+	delivered int
+}
+
+func (c *failingChannel) Deliver(ctx context.Context, notification *models.Notification) error {
+	c.delivered++
+	return errors.New("smtp: connection refused")
+}
+
+func TestHandleEventWithFailingChannel(t *testing.T) {
+	repo := new(MockNotificationRepository)
+	repo.On("GetPreferences", mock.Anything, mock.Anything).Return(nil, nil)
+	repo.On("CreateNotification", mock.Anything, mock.Anything).Return(nil)
+	first, second := &failingChannel{}, &failingChannel{}
+	log := &MockLogger{}
+	service := NewNotificationService(repo, log, first, second)
+
+	err := service.HandleEvent(context.Background(), events.Event{
+		Type:       events.TaskUpdated,
+		ActorID:    1,
+		Recipients: []uint{1, 2, 3},
+		TaskID:     5,
+		Data:       map[string]string{"task_title": "Launch"},
+	})
+
+	// The event is handled, so it is not redelivered to create the notifications again
+	assert.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "CreateNotification", 2)
+	assert.Equal(t, 2, first.delivered)
+	assert.Equal(t, 2, second.delivered)
+	assert.Len(t, log.Errors, 4)
+}
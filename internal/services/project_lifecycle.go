@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/utils/helpers"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrInvalidProjectStatus = errors.New("invalid project status change")
+	ErrProjectArchived      = errors.New("the project is archived and read-only")
+	ErrProjectHasOpenTasks  = errors.New("the project still has open tasks")
+)
+
+// projectTransitions lists the statuses a project can move to from each status.
+// Archived projects are brought back as active or completed.
+var projectTransitions = map[string][]string{
+	models.ProjectStatusPlanned:   {models.ProjectStatusActive, models.ProjectStatusOnHold, models.ProjectStatusArchived},
+	models.ProjectStatusActive:    {models.ProjectStatusOnHold, models.ProjectStatusCompleted, models.ProjectStatusArchived},
+	models.ProjectStatusOnHold:    {models.ProjectStatusActive, models.ProjectStatusCompleted, models.ProjectStatusArchived},
+	models.ProjectStatusCompleted: {models.ProjectStatusActive, models.ProjectStatusArchived},
+	models.ProjectStatusArchived:  {models.ProjectStatusActive, models.ProjectStatusCompleted},
+}
+
+// checkTransition checks that project may move to status. Completing it needs all
+// of its tasks to be done, unless forced. Projects without a known status are
+// active.
+func checkTransition(project *models.Project, status string, force bool) error {
+	if _, ok := projectTransitions[status]; !ok {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidProjectStatus, status)
+	}
+	from := project.Status
+	if _, ok := projectTransitions[from]; !ok {
+		from = models.ProjectStatusActive
+	}
+	if !helpers.Contains(projectTransitions[from], status) {
+		return fmt.Errorf("%w: a project cannot go from %s to %s", ErrInvalidProjectStatus, from, status)
+	}
+	if status != models.ProjectStatusCompleted || force {
+		return nil
+	}
+
+	open := 0
+	for _, task := range project.Tasks {
+		if task.Status != models.TaskStatusDone {
+			open++
+		}
+	}
+	if open > 0 {
+		return fmt.Errorf("%w: %d tasks are not done", ErrProjectHasOpenTasks, open)
+	}
+	return nil
+}
+
+// ensureProjectWritable fails with ErrProjectArchived when the project is archived.
+// Teams without a project, with projectID 0, are always writable.
+func ensureProjectWritable(ctx context.Context, projects repositories.ProjectRepository, projectID uint) error {
+	if projectID == 0 {
+		return nil
+	}
+	status, err := projects.GetProjectStatus(ctx, projectID)
+	if err != nil {
+		return ErrProjectNotFound
+	}
+	if status == models.ProjectStatusArchived {
+		return ErrProjectArchived
+	}
+	return nil
+}
+
+// isProjectOwner tells whether the user owns the project. Projects created before
+// owners were recorded are owned by all of their members.
+func isProjectOwner(project *models.Project, userID uint) bool {
+	if project.OwnerID != 0 {
+		return project.OwnerID == userID
+	}
+	for _, memberID := range project.UserIDs {
+		if memberID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// isProjectMember tells whether the user owns the project or was added to it
+func isProjectMember(project *models.Project, userID uint) bool {
+	return project.OwnerID == userID || slices.Contains(project.UserIDs, userID)
+}
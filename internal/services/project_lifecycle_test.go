@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTransition(t *testing.T) {
+	openTasks := []models.Task{{Status: models.TaskStatusDone}, {Status: models.TaskStatusInProgress}}
+
+	testCases := []struct {
+		name        string
+		from        string
+		to          string
+		tasks       []models.Task
+		force       bool
+		expectedErr error
+	}{
+		{name: "Planned To Active", from: models.ProjectStatusPlanned, to: models.ProjectStatusActive},
+		{name: "Planned Cannot Be Completed", from: models.ProjectStatusPlanned, to: models.ProjectStatusCompleted, expectedErr: ErrInvalidProjectStatus},
+		{name: "Unknown Status", from: models.ProjectStatusActive, to: "cancelled", expectedErr: ErrInvalidProjectStatus},
+		{name: "Same Status", from: models.ProjectStatusActive, to: models.ProjectStatusActive, expectedErr: ErrInvalidProjectStatus},
+		{name: "Unarchive", from: models.ProjectStatusArchived, to: models.ProjectStatusActive},
+		{name: "Legacy Status Counts As Active", from: "in progress", to: models.ProjectStatusOnHold},
+		{name: "Complete With All Tasks Done", from: models.ProjectStatusActive, to: models.ProjectStatusCompleted, tasks: openTasks[:1]},
+		{name: "Complete With Open Tasks", from: models.ProjectStatusActive, to: models.ProjectStatusCompleted, tasks: openTasks, expectedErr: ErrProjectHasOpenTasks},
+		{name: "Force Complete With Open Tasks", from: models.ProjectStatusOnHold, to: models.ProjectStatusCompleted, tasks: openTasks, force: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			project := &models.Project{Status: tc.from, Tasks: tc.tasks}
+
+			err := checkTransition(project, tc.to, tc.force)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChangeProjectStatus(t *testing.T) {
+	existing := &models.Project{BaseModel: models.BaseModel{ID: 1, Version: 2}, Name: "Project", Status: models.ProjectStatusActive}
+
+	projects := new(MockProjectRepository)
+	projects.On("GetProjectByID", mock.Anything, uint(1)).Return(existing, nil)
+	projects.On("UpdateProject", mock.Anything, mock.MatchedBy(func(project *models.Project) bool {
+		return project.Status == models.ProjectStatusArchived
+	})).Return(nil)
+	publisher := new(MockPublisher)
+	audit := new(MockAuditRecorder)
+	service := NewProjectService(projects, new(MockTransactor), publisher, audit)
+
+	project, err := service.ChangeProjectStatus(context.Background(), 1, 2, models.ProjectStatusArchived, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ProjectStatusArchived, project.Status)
+	require.Len(t, audit.Entries, 1)
+	assert.Equal(t, map[string]models.FieldChange{
+		"status": {Before: models.ProjectStatusActive, After: models.ProjectStatusArchived},
+	}, audit.Entries[0].Changes)
+	require.Len(t, publisher.Events, 1)
+	assert.Equal(t, events.ProjectUpdated, publisher.Events[0].Type)
+
+	_, err = service.ChangeProjectStatus(context.Background(), 1, 1, models.ProjectStatusArchived, false)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+}
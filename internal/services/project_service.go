@@ -2,26 +2,35 @@ package services
 
 import (
 	"context"
+	"errors"
+	"example/project-management-system/internal/events"
 	"example/project-management-system/internal/models"
 	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
 	"fmt"
 )
 
+var ErrProjectNotFound = errors.New("project not found")
+
 type ProjectService interface {
 	CreateProject(ctx context.Context, project *models.Project) error
 	GetProjectByID(ctx context.Context, id uint) (*models.Project, error)
-	GetPaginatedProjects(ctx context.Context, page, pageSize int) ([]models.Project, int64, error)
+	GetPaginatedProjects(ctx context.Context, page, pageSize int, includeArchived bool) ([]models.Project, int64, error)
 	UpdateProject(ctx context.Context, project *models.Project) error
-	DeleteProject(ctx context.Context, id uint) error
+	ChangeProjectStatus(ctx context.Context, id, version uint, status string, force bool) (*models.Project, error)
+	DeleteProject(ctx context.Context, id uint, version uint) error
 	GetTaskByProjectID(ctx context.Context, projectID uint) ([]models.Task, error)
 }
 
 type ProjectServiceImplementation struct {
-	repo repositories.ProjectRepository
+	repo       repositories.ProjectRepository
+	transactor repositories.Transactor
+	events     events.Publisher
+	audit      AuditRecorder
 }
 
-func NewProjectService(repo repositories.ProjectRepository) ProjectService {
-	return &ProjectServiceImplementation{repo: repo}
+func NewProjectService(repo repositories.ProjectRepository, transactor repositories.Transactor, publisher events.Publisher, audit AuditRecorder) ProjectService {
+	return &ProjectServiceImplementation{repo: repo, transactor: transactor, events: publisher, audit: audit}
 }
 
 func (s *ProjectServiceImplementation) CreateProject(ctx context.Context, project *models.Project) error {
@@ -29,33 +38,144 @@ func (s *ProjectServiceImplementation) CreateProject(ctx context.Context, projec
 	if len(project.Name) < 3 {
 		return fmt.Errorf("project name must be at least 3 characters")
 	}
-	return s.repo.CreateProject(ctx, project)
+	if project.Status == "" {
+		project.Status = models.ProjectStatusPlanned
+	}
+	if project.Status != models.ProjectStatusPlanned && project.Status != models.ProjectStatusActive {
+		return fmt.Errorf("%w: projects start as planned or active", ErrInvalidProjectStatus)
+	}
+	if userID, ok := middleware.UserIDFromContext(ctx); ok {
+		project.OwnerID = userID
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateProject(ctx, project); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityProject, project.ID, nil, project); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.ProjectCreated, project)
+	})
 }
 
 func (s *ProjectServiceImplementation) GetProjectByID(ctx context.Context, id uint) (*models.Project, error) {
 	project, err := s.repo.GetProjectByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("project not found")
+		return nil, ErrProjectNotFound
 	}
 	return project, nil
 }
 
-func (s *ProjectServiceImplementation) GetPaginatedProjects(ctx context.Context, page, pageSize int) ([]models.Project, int64, error) {
-	return s.repo.GetPaginatedProjects(ctx, page, pageSize)
+// GetPaginatedProjects returns a page of the projects, the archived ones only when
+// includeArchived is set.
+func (s *ProjectServiceImplementation) GetPaginatedProjects(ctx context.Context, page, pageSize int, includeArchived bool) ([]models.Project, int64, error) {
+	return s.repo.GetPaginatedProjects(ctx, page, pageSize, includeArchived)
 }
 
+// UpdateProject saves the project, which must not be archived. A status change
+// must be a legal transition, and completing the project needs all its tasks done.
 func (s *ProjectServiceImplementation) UpdateProject(ctx context.Context, project *models.Project) error {
 	// Example: Additional validation logic
 	if len(project.Name) < 3 {
 		return fmt.Errorf("project name must be at least 3 characters")
 	}
-	return s.repo.UpdateProject(ctx, project)
+
+	existing, err := s.repo.GetProjectByID(ctx, project.ID)
+	if err != nil {
+		return ErrProjectNotFound
+	}
+	if project.Version, err = expectVersion(project.Version, existing.Version); err != nil {
+		return err
+	}
+	if existing.Status == models.ProjectStatusArchived {
+		return ErrProjectArchived
+	}
+	if project.Status == "" {
+		project.Status = existing.Status
+	}
+	if project.Status != existing.Status {
+		if err := checkTransition(existing, project.Status, false); err != nil {
+			return err
+		}
+	}
+	// The owner is kept across full updates
+	project.OwnerID = existing.OwnerID
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateProject(ctx, project); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityProject, project.ID, existing, project); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.ProjectUpdated, project)
+	})
+}
+
+// ChangeProjectStatus moves the project, when it is at version or any version when
+// it is 0, to another status of its lifecycle. Force completes a project that
+// still has open tasks.
+func (s *ProjectServiceImplementation) ChangeProjectStatus(ctx context.Context, id, version uint, status string, force bool) (*models.Project, error) {
+	existing, err := s.repo.GetProjectByID(ctx, id)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+	if _, err := expectVersion(version, existing.Version); err != nil {
+		return nil, err
+	}
+	if err := checkTransition(existing, status, force); err != nil {
+		return nil, err
+	}
+
+	project := *existing
+	project.Status = status
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateProject(ctx, &project); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityProject, project.ID, existing, &project); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.ProjectUpdated, &project)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
 }
 
-func (s *ProjectServiceImplementation) DeleteProject(ctx context.Context, id uint) error {
-	return s.repo.DeleteProject(ctx, id)
+// DeleteProject moves the project to the trash when it is at version, or any version when it is 0.
+func (s *ProjectServiceImplementation) DeleteProject(ctx context.Context, id uint, version uint) error {
+	project, err := s.repo.GetProjectByID(ctx, id)
+	if err != nil {
+		return ErrProjectNotFound
+	}
+	if _, err := expectVersion(version, project.Version); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteProject(ctx, id); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityProject, id, project, nil); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.ProjectDeleted, project)
+	})
 }
 
 func (s *ProjectServiceImplementation) GetTaskByProjectID(ctx context.Context, projectID uint) ([]models.Task, error) {
 	return s.repo.GetTaskByProjectID(ctx, projectID)
-}
\ No newline at end of file
+}
+func (s *ProjectServiceImplementation) publish(ctx context.Context, eventType events.Type, project *models.Project) error {
+	actorID, _ := middleware.UserIDFromContext(ctx)
+
+	return s.events.Publish(ctx, events.Event{
+		Type:      eventType,
+		ActorID:   actorID,
+		ProjectID: project.ID,
+		Payload:   project,
+	})
+}
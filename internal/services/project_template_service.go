@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/utils/helpers"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	ErrProjectTemplateNotFound = errors.New("project template not found")
+	ErrInvalidProjectTemplate  = errors.New("invalid project template")
+	ErrNotTemplateOwner        = errors.New("only the owner of a template can delete it")
+	ErrProjectOwnerRequired    = errors.New("a project created from a template needs an owner to assign its tasks to")
+)
+
+// NewProjectOptions name the project created from a template or by a clone and set
+// the date its dates are shifted to
+type NewProjectOptions struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	StartDate   time.Time `json:"start_date"`
+}
+
+type ProjectTemplateService interface {
+	CreateTemplate(ctx context.Context, template *models.ProjectTemplate) error
+	CreateTemplateFromProject(ctx context.Context, projectID uint, name string) (*models.ProjectTemplate, error)
+	GetTemplateByID(ctx context.Context, id uint) (*models.ProjectTemplate, error)
+	GetTemplates(ctx context.Context, page, pageSize int) ([]models.ProjectTemplate, int64, error)
+	DeleteTemplate(ctx context.Context, id uint) error
+	CloneProject(ctx context.Context, projectID uint, options NewProjectOptions) (*models.Project, error)
+	CreateProjectFromTemplate(ctx context.Context, templateID uint, options NewProjectOptions) (*models.Project, error)
+}
+
+type ProjectTemplateServiceImplementation struct {
+	repo            repositories.ProjectTemplateRepository
+	projectRepo     repositories.ProjectRepository
+	teamRepo        repositories.TeamRepository
+	taskRepo        repositories.TaskRepository
+	userProjectRepo repositories.UserProjectRepository
+	watcherRepo     repositories.WatcherRepository
+	transactor      repositories.Transactor
+	events          events.Publisher
+	audit           AuditRecorder
+}
+
+func NewProjectTemplateService(repo repositories.ProjectTemplateRepository, projectRepo repositories.ProjectRepository, teamRepo repositories.TeamRepository, taskRepo repositories.TaskRepository, userProjectRepo repositories.UserProjectRepository, watcherRepo repositories.WatcherRepository, transactor repositories.Transactor, publisher events.Publisher, audit AuditRecorder) ProjectTemplateService {
+	return &ProjectTemplateServiceImplementation{
+		repo:            repo,
+		projectRepo:     projectRepo,
+		teamRepo:        teamRepo,
+		taskRepo:        taskRepo,
+		userProjectRepo: userProjectRepo,
+		watcherRepo:     watcherRepo,
+		transactor:      transactor,
+		events:          publisher,
+		audit:           audit,
+	}
+}
+
+func (s *ProjectTemplateServiceImplementation) CreateTemplate(ctx context.Context, template *models.ProjectTemplate) error {
+	if err := validateTemplate(template); err != nil {
+		return err
+	}
+	template.ID = 0
+	template.OwnerID, _ = middleware.UserIDFromContext(ctx)
+	return s.repo.CreateTemplate(ctx, template)
+}
+
+// CreateTemplateFromProject captures the teams and tasks of a project in a new
+// template, named after the project unless a name is given
+func (s *ProjectTemplateServiceImplementation) CreateTemplateFromProject(ctx context.Context, projectID uint, name string) (*models.ProjectTemplate, error) {
+	project, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+
+	template := templateOf(project)
+	if name != "" {
+		template.Name = name
+	}
+	if err := s.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *ProjectTemplateServiceImplementation) GetTemplateByID(ctx context.Context, id uint) (*models.ProjectTemplate, error) {
+	template, err := s.repo.GetTemplateByID(ctx, id)
+	if err != nil {
+		return nil, ErrProjectTemplateNotFound
+	}
+	return template, nil
+}
+
+func (s *ProjectTemplateServiceImplementation) GetTemplates(ctx context.Context, page, pageSize int) ([]models.ProjectTemplate, int64, error) {
+	return s.repo.GetTemplates(ctx, page, pageSize)
+}
+
+func (s *ProjectTemplateServiceImplementation) DeleteTemplate(ctx context.Context, id uint) error {
+	template, err := s.GetTemplateByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if userID, _ := middleware.UserIDFromContext(ctx); template.OwnerID != userID {
+		return ErrNotTemplateOwner
+	}
+	return s.repo.DeleteTemplate(ctx, id)
+}
+
+// CloneProject creates a project with the teams and tasks of another, without
+// their members. The clone starts on the same date as the source unless another
+// start date is given, its due dates keep their distance to it.
+func (s *ProjectTemplateServiceImplementation) CloneProject(ctx context.Context, projectID uint, options NewProjectOptions) (*models.Project, error) {
+	source, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+
+	if options.Name == "" {
+		options.Name = source.Name + " (copy)"
+	}
+	if options.Description == "" {
+		options.Description = source.Description
+	}
+	if options.StartDate.IsZero() {
+		options.StartDate = source.StartDate
+	}
+	return s.instantiate(ctx, templateOf(source), options)
+}
+
+// CreateProjectFromTemplate creates a project starting on options.StartDate with
+// the teams and tasks of a template, its due dates offset from the start date
+func (s *ProjectTemplateServiceImplementation) CreateProjectFromTemplate(ctx context.Context, templateID uint, options NewProjectOptions) (*models.Project, error) {
+	template, err := s.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if options.StartDate.IsZero() {
+		return nil, fmt.Errorf("%w: start_date is required", ErrInvalidProjectTemplate)
+	}
+	if options.Name == "" {
+		options.Name = template.Name
+	}
+	if options.Description == "" {
+		options.Description = template.Description
+	}
+	return s.instantiate(ctx, template, options)
+}
+
+// instantiate creates the project of a template, with its teams and tasks, in one
+// transaction. The user creating the project owns it and is its first member, the
+// tasks are assigned to them.
+func (s *ProjectTemplateServiceImplementation) instantiate(ctx context.Context, template *models.ProjectTemplate, options NewProjectOptions) (*models.Project, error) {
+	if len(options.Name) < 3 {
+		return nil, fmt.Errorf("project name must be at least 3 characters")
+	}
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	project := &models.Project{
+		Name:        options.Name,
+		Description: options.Description,
+		StartDate:   options.StartDate,
+		Status:      models.ProjectStatusPlanned,
+	}
+	project.OwnerID, _ = middleware.UserIDFromContext(ctx)
+	if project.OwnerID == 0 && len(template.Tasks) > 0 {
+		return nil, ErrProjectOwnerRequired
+	}
+	if date := shiftDate(options.StartDate, template.EndOffsetDays); date != nil {
+		project.EndDate = *date
+	}
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.projectRepo.CreateProject(ctx, project); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityProject, project.ID, nil, project); err != nil {
+			return err
+		}
+		if err := s.publish(ctx, events.ProjectCreated, project.ID, 0, project); err != nil {
+			return err
+		}
+		if project.OwnerID != 0 {
+			if err := s.userProjectRepo.AddUserToProject(ctx, project.OwnerID, project.ID); err != nil {
+				return err
+			}
+			project.UserIDs = []uint{project.OwnerID}
+		}
+
+		for _, skeleton := range template.Teams {
+			team := &models.Team{Name: skeleton.Name, Description: skeleton.Description, ProjectID: project.ID}
+			if err := s.teamRepo.CreateTeam(ctx, team); err != nil {
+				return err
+			}
+			if err := s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityTeam, team.ID, nil, team); err != nil {
+				return err
+			}
+			if err := s.publish(ctx, events.TeamCreated, project.ID, 0, team); err != nil {
+				return err
+			}
+		}
+
+		for _, skeleton := range template.Tasks {
+			parent, err := s.createTask(ctx, project, template.Workflow, skeleton, nil)
+			if err != nil {
+				return err
+			}
+			for _, subtask := range skeleton.Subtasks {
+				if _, err := s.createTask(ctx, project, template.Workflow, subtask, &parent.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *ProjectTemplateServiceImplementation) createTask(ctx context.Context, project *models.Project, workflow []string, skeleton models.TemplateTask, parentID *uint) (*models.Task, error) {
+	task := &models.Task{
+		Title:       skeleton.Title,
+		Description: skeleton.Description,
+		Status:      skeleton.Status,
+		ProjectID:   project.ID,
+		AssignedTo:  project.OwnerID,
+		Labels:      skeleton.Labels,
+		DueDate:     shiftDate(project.StartDate, skeleton.DueOffsetDays),
+		ParentID:    parentID,
+	}
+	if task.Status == "" {
+		task.Status = workflow[0]
+	}
+
+	if err := s.taskRepo.CreateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	// The owner creates the task and is assigned it, they watch it
+	if err := s.watcherRepo.AddWatchers(ctx, task.ID, nonZero(project.OwnerID)); err != nil {
+		return nil, err
+	}
+	if err := s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityTask, task.ID, nil, task); err != nil {
+		return nil, err
+	}
+	return task, s.publish(ctx, events.TaskCreated, project.ID, task.ID, task)
+}
+
+func (s *ProjectTemplateServiceImplementation) publish(ctx context.Context, eventType events.Type, projectID, taskID uint, payload interface{}) error {
+	actorID, _ := middleware.UserIDFromContext(ctx)
+
+	return s.events.Publish(ctx, events.Event{
+		Type:      eventType,
+		ActorID:   actorID,
+		ProjectID: projectID,
+		TaskID:    taskID,
+		Payload:   payload,
+	})
+}
+
+// validateTemplate checks a template and defaults its workflow to the task
+// statuses. Tasks without a status start in the first status of the workflow.
+func validateTemplate(template *models.ProjectTemplate) error {
+	if len(template.Name) < 3 {
+		return fmt.Errorf("%w: name must be at least 3 characters", ErrInvalidProjectTemplate)
+	}
+	if template.EndOffsetDays != nil && *template.EndOffsetDays < 0 {
+		return fmt.Errorf("%w: end_offset_days must not be negative", ErrInvalidProjectTemplate)
+	}
+
+	if len(template.Workflow) == 0 {
+		template.Workflow = append([]string(nil), taskStatuses...)
+	}
+	for i, status := range template.Workflow {
+		if !helpers.Contains(taskStatuses, status) {
+			return fmt.Errorf("%w: invalid workflow status %q", ErrInvalidProjectTemplate, status)
+		}
+		if helpers.Contains(template.Workflow[:i], status) {
+			return fmt.Errorf("%w: workflow status %q is repeated", ErrInvalidProjectTemplate, status)
+		}
+	}
+
+	for _, team := range template.Teams {
+		if team.Name == "" {
+			return fmt.Errorf("%w: team name is required", ErrInvalidProjectTemplate)
+		}
+	}
+	for _, task := range template.Tasks {
+		if err := validateTemplateTask(task, template.Workflow); err != nil {
+			return err
+		}
+		for _, subtask := range task.Subtasks {
+			if len(subtask.Subtasks) > 0 {
+				return fmt.Errorf("%w: subtasks cannot have subtasks", ErrInvalidProjectTemplate)
+			}
+			if err := validateTemplateTask(subtask, template.Workflow); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateTemplateTask(task models.TemplateTask, workflow []string) error {
+	if task.Title == "" {
+		return fmt.Errorf("%w: task title is required", ErrInvalidProjectTemplate)
+	}
+	if task.Status != "" && !helpers.Contains(workflow, task.Status) {
+		return fmt.Errorf("%w: task status %q is not in the workflow", ErrInvalidProjectTemplate, task.Status)
+	}
+	return nil
+}
+
+// templateOf captures a project as a template. Due dates become offsets from the
+// start date of the project, and are dropped when it has none.
+func templateOf(project *models.Project) *models.ProjectTemplate {
+	template := &models.ProjectTemplate{
+		Name:          project.Name,
+		Description:   project.Description,
+		EndOffsetDays: daysBetween(project.StartDate, project.EndDate),
+		Workflow:      append([]string(nil), taskStatuses...),
+	}
+	for _, team := range project.Teams {
+		template.Teams = append(template.Teams, models.TemplateTeam{Name: team.Name, Description: team.Description})
+	}
+
+	skeletonOf := func(task models.Task) models.TemplateTask {
+		skeleton := models.TemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			Status:      task.Status,
+			Labels:      task.Labels,
+		}
+		if task.DueDate != nil {
+			skeleton.DueOffsetDays = daysBetween(project.StartDate, *task.DueDate)
+		}
+		return skeleton
+	}
+
+	parents := make(map[uint]int)
+	for _, task := range project.Tasks {
+		if task.ParentID == nil {
+			parents[task.ID] = len(template.Tasks)
+			template.Tasks = append(template.Tasks, skeletonOf(task))
+		}
+	}
+	for _, task := range project.Tasks {
+		if task.ParentID == nil {
+			continue
+		}
+		if i, ok := parents[*task.ParentID]; ok {
+			template.Tasks[i].Subtasks = append(template.Tasks[i].Subtasks, skeletonOf(task))
+		}
+	}
+	return template
+}
+
+// daysBetween is the number of whole days from start to date, nil when either is
+// not set
+func daysBetween(start, date time.Time) *int {
+	if start.IsZero() || date.IsZero() {
+		return nil
+	}
+	days := int(math.Round(date.Sub(start).Hours() / 24))
+	return &days
+}
+
+// shiftDate is the date days after start, nil without an offset or start date
+func shiftDate(start time.Time, days *int) *time.Time {
+	if days == nil || start.IsZero() {
+		return nil
+	}
+	date := start.AddDate(0, 0, *days)
+	return &date
+}
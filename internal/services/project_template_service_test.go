@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/pkg/middleware"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockUserProjectRepository mocks the UserProjectRepository for testing
+type MockUserProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserProjectRepository) AddUserToProject(ctx context.Context, userID uint, projectID uint) error {
+	args := m.Called(ctx, userID, projectID)
+	return args.Error(0)
+}
+
+func (m *MockUserProjectRepository) RemoveUserFromProject(ctx context.Context, userID uint, projectID uint) error {
+	args := m.Called(ctx, userID, projectID)
+	return args.Error(0)
+}
+
+func TestCloneProjectOwner(t *testing.T) {
+	start := time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)
+	source := &models.Project{
+		BaseModel: models.BaseModel{ID: 1},
+		Name:      "Quarterly Launch",
+		StartDate: start,
+		Tasks:     []models.Task{{BaseModel: models.BaseModel{ID: 3}, Title: "Plan the launch", Status: models.TaskStatusTodo, AssignedTo: 9}},
+	}
+
+	testCases := []struct {
+		name        string
+		userID      uint
+		expectedErr error
+	}{
+		{name: "The Owner Becomes A Member", userID: 7},
+		{name: "No Owner", expectedErr: ErrProjectOwnerRequired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projects := new(MockProjectRepository)
+			projects.On("GetProjectByID", mock.Anything, uint(1)).Return(source, nil)
+			projects.On("CreateProject", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				args.Get(1).(*models.Project).ID = 2
+			}).Return(nil).Maybe()
+			tasks := new(MockTaskRepository)
+			tasks.On("CreateTask", mock.Anything, mock.Anything).Return(nil).Maybe()
+			members := new(MockUserProjectRepository)
+			members.On("AddUserToProject", mock.Anything, uint(7), uint(2)).Return(nil).Maybe()
+			watchers := new(MockWatcherRepository)
+			watchers.On("AddWatchers", mock.Anything, mock.Anything, []uint{7}).Return(nil).Maybe()
+			ctx := context.Background()
+			if tc.userID != 0 {
+				ctx = middleware.WithUserID(ctx, tc.userID)
+			}
+			service := NewProjectTemplateService(nil, projects, nil, tasks, members, watchers, new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
+
+			project, err := service.CloneProject(ctx, 1, NewProjectOptions{})
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				projects.AssertNotCalled(t, "CreateProject", mock.Anything, mock.Anything)
+				tasks.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.userID, project.OwnerID)
+			members.AssertExpectations(t)
+			watchers.AssertExpectations(t)
+			tasks.AssertCalled(t, "CreateTask", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+				return task.ProjectID == 2 && task.AssignedTo == tc.userID
+			}))
+		})
+	}
+}
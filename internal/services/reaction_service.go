@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReactionAlreadyExists = errors.New("reaction already exists")
+	ErrReactionNotFound      = errors.New("reaction not found")
+)
+
+// emojiShortcodeRegex matches shortcodes such as "thumbsup", "+1" or "white_check_mark"
+var emojiShortcodeRegex = regexp.MustCompile(`^[a-z0-9_+\-]{1,32}$`)
+
+type ReactionService interface {
+	AddReaction(ctx context.Context, reaction *models.Reaction) ([]models.ReactionCount, error)
+	RemoveReaction(ctx context.Context, targetType string, targetID, userID uint, emoji string) ([]models.ReactionCount, error)
+}
+
+type ReactionServiceImplementation struct {
+	repo        repositories.ReactionRepository
+	taskRepo    repositories.TaskRepository
+	commentRepo repositories.CommentRepository
+}
+
+func NewReactionService(
+	repo repositories.ReactionRepository,
+	taskRepo repositories.TaskRepository,
+	commentRepo repositories.CommentRepository,
+) ReactionService {
+	return &ReactionServiceImplementation{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		commentRepo: commentRepo,
+	}
+}
+
+// AddReaction stores a reaction and returns the updated counts of its target.
+func (s *ReactionServiceImplementation) AddReaction(ctx context.Context, reaction *models.Reaction) ([]models.ReactionCount, error) {
+	emoji, err := NormalizeEmoji(reaction.Emoji)
+	if err != nil {
+		return nil, err
+	}
+	reaction.Emoji = emoji
+
+	if reaction.UserID == 0 {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	if err := s.checkTarget(ctx, reaction.TargetType, reaction.TargetID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateReaction(ctx, reaction); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrReactionAlreadyExists
+		}
+		return nil, err
+	}
+
+	return s.repo.GetReactionCounts(ctx, reaction.TargetType, reaction.TargetID)
+}
+
+// RemoveReaction deletes the user's reaction and returns the updated counts of its target.
+func (s *ReactionServiceImplementation) RemoveReaction(ctx context.Context, targetType string, targetID, userID uint, emoji string) ([]models.ReactionCount, error) {
+	emoji, err := NormalizeEmoji(emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.repo.DeleteReaction(ctx, targetType, targetID, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		return nil, ErrReactionNotFound
+	}
+
+	return s.repo.GetReactionCounts(ctx, targetType, targetID)
+}
+
+func (s *ReactionServiceImplementation) checkTarget(ctx context.Context, targetType string, targetID uint) error {
+	if targetID == 0 {
+		return fmt.Errorf("target ID is required")
+	}
+
+	switch targetType {
+	case models.ReactionTargetTask:
+		if _, err := s.taskRepo.GetTaskByID(ctx, targetID); err != nil {
+			return fmt.Errorf("task not found")
+		}
+	case models.ReactionTargetComment:
+		if _, err := s.commentRepo.GetCommentByID(ctx, targetID); err != nil {
+			return fmt.Errorf("comment not found")
+		}
+	default:
+		return fmt.Errorf("invalid reaction target %q", targetType)
+	}
+	return nil
+}
+
+// NormalizeEmoji turns ":ThumbsUp:" into "thumbsup" and rejects anything that is not a shortcode.
+func NormalizeEmoji(emoji string) (string, error) {
+	emoji = strings.ToLower(strings.Trim(strings.TrimSpace(emoji), ":"))
+	if !emojiShortcodeRegex.MatchString(emoji) {
+		return "", fmt.Errorf("invalid emoji shortcode")
+	}
+	return emoji, nil
+}
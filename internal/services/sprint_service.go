@@ -0,0 +1,441 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSprintNotFound     = errors.New("sprint not found")
+	ErrInvalidSprint      = errors.New("invalid sprint")
+	ErrSprintState        = errors.New("invalid sprint state change")
+	ErrActiveSprintExists = errors.New("the project already has an active sprint")
+	ErrInvalidSprintTask  = errors.New("only tasks of the project of the sprint can be in it")
+	ErrTaskNotInSprint    = errors.New("the task is not in the sprint")
+	ErrInvalidCarryOver   = errors.New("unfinished tasks are carried over to a planned or active sprint of the same project")
+)
+
+const burndownUnitTasks = "tasks"
+
+type SprintService interface {
+	CreateSprint(ctx context.Context, sprint *models.Sprint) error
+	GetSprintByID(ctx context.Context, id uint) (*models.Sprint, error)
+	GetSprintsByProject(ctx context.Context, projectID uint) ([]models.Sprint, error)
+	UpdateSprint(ctx context.Context, sprint *models.Sprint) error
+	DeleteSprint(ctx context.Context, id uint) error
+	StartSprint(ctx context.Context, id uint) (*models.Sprint, error)
+	CloseSprint(ctx context.Context, id uint, carryOverTo *uint) (*models.Sprint, error)
+	AddTasks(ctx context.Context, id uint, taskIDs []uint) (*models.Sprint, error)
+	RemoveTask(ctx context.Context, id, taskID uint) error
+	GetBurndown(ctx context.Context, id uint) (*models.Burndown, error)
+}
+
+type SprintServiceImplementation struct {
+	repo        repositories.SprintRepository
+	taskRepo    repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+	auditRepo   repositories.AuditRepository
+	watcherRepo repositories.WatcherRepository
+	transactor  repositories.Transactor
+	events      events.Publisher
+	audit       AuditRecorder
+	now         func() time.Time
+}
+
+func NewSprintService(repo repositories.SprintRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, auditRepo repositories.AuditRepository, watcherRepo repositories.WatcherRepository, transactor repositories.Transactor, publisher events.Publisher, audit AuditRecorder) SprintService {
+	return &SprintServiceImplementation{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		auditRepo:   auditRepo,
+		watcherRepo: watcherRepo,
+		transactor:  transactor,
+		events:      publisher,
+		audit:       audit,
+		now:         time.Now,
+	}
+}
+
+// CreateSprint adds a planned sprint to a project
+func (s *SprintServiceImplementation) CreateSprint(ctx context.Context, sprint *models.Sprint) error {
+	if err := validateSprint(sprint); err != nil {
+		return err
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, sprint.ProjectID); err != nil {
+		return err
+	}
+	sprint.ID = 0
+	sprint.State = models.SprintStatePlanned
+	sprint.ClosedAt = nil
+	sprint.Tasks = nil
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateSprint(ctx, sprint); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntitySprint, sprint.ID, nil, sprint)
+	})
+}
+
+func (s *SprintServiceImplementation) GetSprintByID(ctx context.Context, id uint) (*models.Sprint, error) {
+	sprint, err := s.repo.GetSprintByID(ctx, id)
+	if err != nil {
+		return nil, ErrSprintNotFound
+	}
+	return sprint, nil
+}
+
+func (s *SprintServiceImplementation) GetSprintsByProject(ctx context.Context, projectID uint) ([]models.Sprint, error) {
+	if _, err := s.projectRepo.GetProjectStatus(ctx, projectID); err != nil {
+		return nil, ErrProjectNotFound
+	}
+	return s.repo.GetSprintsByProject(ctx, projectID)
+}
+
+// UpdateSprint changes the name, goal and dates of a sprint that is not closed.
+// Its state changes with StartSprint and CloseSprint.
+func (s *SprintServiceImplementation) UpdateSprint(ctx context.Context, sprint *models.Sprint) error {
+	existing, err := s.writableSprint(ctx, sprint.ID)
+	if err != nil {
+		return err
+	}
+	sprint.ProjectID = existing.ProjectID
+	if err := validateSprint(sprint); err != nil {
+		return err
+	}
+	sprint.CreatedAt = existing.CreatedAt
+	sprint.State = existing.State
+	sprint.ClosedAt = existing.ClosedAt
+	sprint.Tasks = nil
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateSprint(ctx, sprint); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntitySprint, sprint.ID, existing, sprint)
+	})
+}
+
+// DeleteSprint deletes a sprint that is not active, its tasks go back to the backlog
+func (s *SprintServiceImplementation) DeleteSprint(ctx context.Context, id uint) error {
+	sprint, err := s.GetSprintByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if sprint.State == models.SprintStateActive {
+		return fmt.Errorf("%w: close the sprint before deleting it", ErrSprintState)
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, sprint.ProjectID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i := range sprint.Tasks {
+			if err := s.setTaskSprint(ctx, &sprint.Tasks[i], nil); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.DeleteSprint(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntitySprint, id, sprint, nil)
+	})
+}
+
+// StartSprint makes a planned sprint the active sprint of its project
+func (s *SprintServiceImplementation) StartSprint(ctx context.Context, id uint) (*models.Sprint, error) {
+	sprint, err := s.writableSprint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.State != models.SprintStatePlanned {
+		return nil, fmt.Errorf("%w: only planned sprints can be started, the sprint is %s", ErrSprintState, sprint.State)
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		active, err := s.repo.GetActiveSprint(ctx, sprint.ProjectID)
+		if err == nil {
+			return fmt.Errorf("%w: sprint %d", ErrActiveSprintExists, active.ID)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return s.changeState(ctx, sprint, models.SprintStateActive)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+// CloseSprint closes the active sprint. Its tasks that are not done are carried
+// over to the sprint carryOverTo, or back to the backlog when it is nil.
+func (s *SprintServiceImplementation) CloseSprint(ctx context.Context, id uint, carryOverTo *uint) (*models.Sprint, error) {
+	sprint, err := s.writableSprint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.State != models.SprintStateActive {
+		return nil, fmt.Errorf("%w: only active sprints can be closed, the sprint is %s", ErrSprintState, sprint.State)
+	}
+	if carryOverTo != nil {
+		target, err := s.repo.GetSprintByID(ctx, *carryOverTo)
+		if err != nil || target.ID == sprint.ID || target.ProjectID != sprint.ProjectID || target.State == models.SprintStateClosed {
+			return nil, ErrInvalidCarryOver
+		}
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Closed first, so the burndown of the last day is taken before the carry-over
+		if err := s.changeState(ctx, sprint, models.SprintStateClosed); err != nil {
+			return err
+		}
+		for i := range sprint.Tasks {
+			if sprint.Tasks[i].Status == models.TaskStatusDone {
+				continue
+			}
+			if err := s.setTaskSprint(ctx, &sprint.Tasks[i], carryOverTo); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSprintByID(ctx, id)
+}
+
+// AddTasks plans tasks of the project in a sprint that is not closed, moving them
+// from the backlog or another sprint
+func (s *SprintServiceImplementation) AddTasks(ctx context.Context, id uint, taskIDs []uint) (*models.Sprint, error) {
+	sprint, err := s.writableSprint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(taskIDs) == 0 {
+		return nil, fmt.Errorf("%w: task_ids is required", ErrInvalidSprint)
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, taskID := range taskIDs {
+			task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+			if err != nil {
+				return fmt.Errorf("%w: %d", ErrTaskNotFound, taskID)
+			}
+			if task.ProjectID != sprint.ProjectID {
+				return fmt.Errorf("%w: task %d", ErrInvalidSprintTask, taskID)
+			}
+			if task.SprintID != nil && *task.SprintID == sprint.ID {
+				continue
+			}
+			if err := s.setTaskSprint(ctx, task, &sprint.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSprintByID(ctx, id)
+}
+
+// RemoveTask moves a task of a sprint that is not closed back to the backlog
+func (s *SprintServiceImplementation) RemoveTask(ctx context.Context, id, taskID uint) error {
+	sprint, err := s.writableSprint(ctx, id)
+	if err != nil {
+		return err
+	}
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	if task.SprintID == nil || *task.SprintID != sprint.ID {
+		return ErrTaskNotInSprint
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.setTaskSprint(ctx, task, nil)
+	})
+}
+
+// GetBurndown counts the tasks in the sprint, and those not done, at the end of
+// each of its days up to today. The counts are replayed from the audit log of the
+// tasks of the project, undoing their changes from now back to the first day.
+func (s *SprintServiceImplementation) GetBurndown(ctx context.Context, id uint) (*models.Burndown, error) {
+	sprint, err := s.repo.GetSprintByID(ctx, id)
+	if err != nil {
+		return nil, ErrSprintNotFound
+	}
+	burndown := &models.Burndown{SprintID: sprint.ID, Unit: burndownUnitTasks, Points: []models.BurndownPoint{}}
+
+	first := utcDate(sprint.StartDate)
+	last := utcDate(sprint.EndDate)
+	until := s.now()
+	if sprint.ClosedAt != nil && sprint.ClosedAt.Before(until) {
+		until = *sprint.ClosedAt
+	}
+	if end := utcDate(until); end.Before(last) {
+		last = end
+	}
+	if last.Before(first) {
+		return burndown, nil
+	}
+
+	tasks, err := s.repo.GetProjectTasks(ctx, sprint.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[uint]*burndownTaskState, len(tasks))
+	ids := make([]uint, 0, len(tasks))
+	for _, task := range tasks {
+		state := &burndownTaskState{exists: !task.DeletedAt.Valid, status: task.Status}
+		if task.SprintID != nil {
+			state.sprintID = *task.SprintID
+		}
+		states[task.ID] = state
+		ids = append(ids, task.ID)
+	}
+	entries, err := s.auditRepo.GetEntriesSince(ctx, models.AuditEntityTask, ids, first)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk the days backwards, undoing the changes made after the end of each
+	next := len(entries) - 1
+	var points []models.BurndownPoint
+	for day := last; !day.Before(first); day = day.AddDate(0, 0, -1) {
+		cutoff := day.AddDate(0, 0, 1)
+		if until.Before(cutoff) {
+			cutoff = until
+		}
+		for ; next >= 0 && entries[next].CreatedAt.After(cutoff); next-- {
+			if state, ok := states[entries[next].EntityID]; ok {
+				state.undo(entries[next])
+			}
+		}
+
+		point := models.BurndownPoint{Date: day.Format(time.DateOnly)}
+		for _, state := range states {
+			if !state.exists || state.sprintID != sprint.ID {
+				continue
+			}
+			point.Total++
+			if state.status != models.TaskStatusDone {
+				point.Remaining++
+			}
+		}
+		points = append(points, point)
+	}
+
+	// The ideal line runs from the work on the first day to none on the last
+	days := int(utcDate(sprint.EndDate).Sub(first).Hours() / 24)
+	start := float64(points[len(points)-1].Remaining)
+	for i := len(points) - 1; i >= 0; i-- {
+		day := len(points) - 1 - i
+		if days > 0 {
+			points[i].Ideal = math.Round(start*float64(days-day)/float64(days)*100) / 100
+		}
+		burndown.Points = append(burndown.Points, points[i])
+	}
+	return burndown, nil
+}
+
+// burndownTaskState is what the burndown needs of a task at one point in time
+type burndownTaskState struct {
+	exists   bool // created and not in the trash
+	status   string
+	sprintID uint
+}
+
+// undo reverts the task to its state before the audited change
+func (t *burndownTaskState) undo(entry models.AuditEntry) {
+	switch entry.Action {
+	case models.AuditActionCreate, models.AuditActionCopy, models.AuditActionRestore:
+		t.exists = false
+	case models.AuditActionDelete:
+		t.exists = true
+	}
+	if change, ok := entry.Changes["status"]; ok {
+		t.status, _ = change.Before.(string)
+	}
+	if change, ok := entry.Changes["sprint_id"]; ok {
+		id, _ := change.Before.(float64)
+		t.sprintID = uint(id)
+	}
+}
+
+// writableSprint returns a sprint that is not closed, in a project that is not archived
+func (s *SprintServiceImplementation) writableSprint(ctx context.Context, id uint) (*models.Sprint, error) {
+	sprint, err := s.GetSprintByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.State == models.SprintStateClosed {
+		return nil, fmt.Errorf("%w: the sprint is closed", ErrSprintState)
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, sprint.ProjectID); err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+func (s *SprintServiceImplementation) changeState(ctx context.Context, sprint *models.Sprint, state string) error {
+	before := *sprint
+	before.Tasks = nil
+	sprint.State = state
+	if state == models.SprintStateClosed {
+		closedAt := s.now()
+		sprint.ClosedAt = &closedAt
+	}
+	if err := s.repo.UpdateSprint(ctx, sprint); err != nil {
+		return err
+	}
+	return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntitySprint, sprint.ID, &before, sprint)
+}
+
+// setTaskSprint moves a task to a sprint, or to the backlog when sprintID is nil.
+// The change is in the audit log of the task, which the burndown is replayed from.
+func (s *SprintServiceImplementation) setTaskSprint(ctx context.Context, task *models.Task, sprintID *uint) error {
+	before := *task
+	task.SprintID = sprintID
+	if err := s.taskRepo.UpdateTask(ctx, task); err != nil {
+		return err
+	}
+	if err := s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityTask, task.ID, &before, task); err != nil {
+		return err
+	}
+
+	return publishTaskChange(ctx, s.events, s.watcherRepo, events.TaskUpdated, task)
+}
+
+func validateSprint(sprint *models.Sprint) error {
+	if sprint.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSprint)
+	}
+	if sprint.ProjectID == 0 {
+		return fmt.Errorf("%w: the sprint must be associated with a project", ErrInvalidSprint)
+	}
+	if sprint.StartDate.IsZero() || sprint.EndDate.IsZero() {
+		return fmt.Errorf("%w: start_date and end_date are required", ErrInvalidSprint)
+	}
+	if utcDate(sprint.EndDate).Before(utcDate(sprint.StartDate)) {
+		return fmt.Errorf("%w: end_date is before start_date", ErrInvalidSprint)
+	}
+	return nil
+}
+
+// utcDate returns the start of the day of t in UTC
+func utcDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
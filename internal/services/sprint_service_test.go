@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockSprintRepository mocks the SprintRepository for testing
+type MockSprintRepository struct {
+	mock.Mock
+}
+
+func (m *MockSprintRepository) CreateSprint(ctx context.Context, sprint *models.Sprint) error {
+	args := m.Called(ctx, sprint)
+	return args.Error(0)
+}
+
+func (m *MockSprintRepository) GetSprintByID(ctx context.Context, id uint) (*models.Sprint, error) {
+	args := m.Called(ctx, id)
+	sprint, _ := args.Get(0).(*models.Sprint)
+	return sprint, args.Error(1)
+}
+
+func (m *MockSprintRepository) GetSprintsByProject(ctx context.Context, projectID uint) ([]models.Sprint, error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]models.Sprint), args.Error(1)
+}
+
+func (m *MockSprintRepository) GetActiveSprint(ctx context.Context, projectID uint) (*models.Sprint, error) {
+	args := m.Called(ctx, projectID)
+	sprint, _ := args.Get(0).(*models.Sprint)
+	return sprint, args.Error(1)
+}
+
+func (m *MockSprintRepository) UpdateSprint(ctx context.Context, sprint *models.Sprint) error {
+	args := m.Called(ctx, sprint)
+	return args.Error(0)
+}
+
+func (m *MockSprintRepository) DeleteSprint(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSprintRepository) GetProjectTasks(ctx context.Context, projectID uint) ([]models.Task, error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func TestGetBurndown(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2026, time.March, d, hour, 0, 0, 0, time.UTC) }
+	sprintID := uint(5)
+	sprint := &models.Sprint{ID: sprintID, ProjectID: 1, StartDate: day(2, 0), EndDate: day(6, 0), State: models.SprintStateActive}
+
+	deleted := models.BaseModel{ID: 4}
+	deleted.DeletedAt = gorm.DeletedAt{Time: day(4, 8), Valid: true}
+	tasks := []models.Task{
+		{BaseModel: models.BaseModel{ID: 1}, Status: models.TaskStatusDone, SprintID: &sprintID},
+		{BaseModel: models.BaseModel{ID: 2}, Status: models.TaskStatusInProgress, SprintID: &sprintID},
+		{BaseModel: models.BaseModel{ID: 3}, Status: models.TaskStatusTodo},
+		{BaseModel: deleted, Status: models.TaskStatusTodo, SprintID: &sprintID},
+	}
+	change := func(before, after interface{}) models.FieldChange { return models.FieldChange{Before: before, After: after} }
+	entry := func(taskID uint, at time.Time, action string, changes map[string]models.FieldChange) models.AuditEntry {
+		return models.AuditEntry{EntityType: models.AuditEntityTask, EntityID: taskID, CreatedAt: at, Action: action, Changes: changes}
+	}
+	entries := []models.AuditEntry{
+		entry(1, day(2, 10), models.AuditActionUpdate, map[string]models.FieldChange{"sprint_id": change(nil, float64(5))}),
+		entry(2, day(2, 10), models.AuditActionUpdate, map[string]models.FieldChange{"sprint_id": change(nil, float64(5))}),
+		entry(3, day(2, 10), models.AuditActionUpdate, map[string]models.FieldChange{"sprint_id": change(nil, float64(5))}),
+		entry(4, day(3, 10), models.AuditActionCreate, map[string]models.FieldChange{"status": change(nil, models.TaskStatusTodo)}),
+		entry(4, day(3, 11), models.AuditActionUpdate, map[string]models.FieldChange{"sprint_id": change(nil, float64(5))}),
+		entry(1, day(3, 15), models.AuditActionUpdate, map[string]models.FieldChange{"status": change(models.TaskStatusTodo, models.TaskStatusDone)}),
+		entry(4, day(4, 8), models.AuditActionDelete, map[string]models.FieldChange{"status": change(models.TaskStatusTodo, nil), "sprint_id": change(float64(5), nil)}),
+		entry(3, day(4, 9), models.AuditActionUpdate, map[string]models.FieldChange{"sprint_id": change(float64(5), nil)}),
+	}
+
+	sprints := new(MockSprintRepository)
+	sprints.On("GetSprintByID", mock.Anything, sprintID).Return(sprint, nil)
+	sprints.On("GetProjectTasks", mock.Anything, uint(1)).Return(tasks, nil)
+	auditRepo := new(MockAuditRepository)
+	auditRepo.On("GetEntriesSince", mock.Anything, models.AuditEntityTask, []uint{1, 2, 3, 4}, day(2, 0)).Return(entries, nil)
+
+	service := NewSprintService(sprints, new(MockTaskRepository), activeProjects(), auditRepo, noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder)).(*SprintServiceImplementation)
+	service.now = func() time.Time { return day(4, 12) }
+
+	burndown, err := service.GetBurndown(context.Background(), sprintID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "tasks", burndown.Unit)
+	assert.Equal(t, []models.BurndownPoint{
+		{Date: "2026-03-02", Total: 3, Remaining: 3, Ideal: 3},
+		{Date: "2026-03-03", Total: 4, Remaining: 3, Ideal: 2.25},
+		{Date: "2026-03-04", Total: 2, Remaining: 1, Ideal: 1.5},
+	}, burndown.Points)
+}
+
+func TestCloseSprint(t *testing.T) {
+	sprintID, nextID := uint(5), uint(6)
+	done := models.Task{BaseModel: models.BaseModel{ID: 1, Version: 1}, Title: "Done", Status: models.TaskStatusDone, ProjectID: 1, SprintID: &sprintID}
+	open := models.Task{BaseModel: models.BaseModel{ID: 2, Version: 1}, Title: "Open", Status: models.TaskStatusInProgress, ProjectID: 1, SprintID: &sprintID}
+
+	testCases := []struct {
+		name        string
+		carryOverTo *uint
+		next        *models.Sprint
+		expectedErr error
+	}{
+		{name: "Carry Over To The Next Sprint", carryOverTo: &nextID, next: &models.Sprint{ID: nextID, ProjectID: 1, State: models.SprintStatePlanned}},
+		{name: "Carry Over To The Backlog"},
+		{name: "Carry Over To Another Project", carryOverTo: &nextID, next: &models.Sprint{ID: nextID, ProjectID: 2, State: models.SprintStatePlanned}, expectedErr: ErrInvalidCarryOver},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sprint := &models.Sprint{ID: sprintID, ProjectID: 1, Name: "Sprint", State: models.SprintStateActive, Tasks: []models.Task{done, open}}
+			sprints := new(MockSprintRepository)
+			sprints.On("GetSprintByID", mock.Anything, sprintID).Return(sprint, nil)
+			if tc.next != nil {
+				sprints.On("GetSprintByID", mock.Anything, nextID).Return(tc.next, nil)
+			}
+			sprints.On("UpdateSprint", mock.Anything, mock.Anything).Return(nil).Maybe()
+			tasks := new(MockTaskRepository)
+			tasks.On("UpdateTask", mock.Anything, mock.Anything).Return(nil).Maybe()
+			audit := new(MockAuditRecorder)
+
+			service := NewSprintService(sprints, tasks, activeProjects(), new(MockAuditRepository), noWatchers(), new(MockTransactor), new(MockPublisher), audit)
+
+			closed, err := service.CloseSprint(context.Background(), sprintID, tc.carryOverTo)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				sprints.AssertNotCalled(t, "UpdateSprint", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.SprintStateClosed, closed.State)
+			assert.NotNil(t, closed.ClosedAt)
+
+			// Only the open task is carried over, and the move is in its history
+			tasks.AssertNumberOfCalls(t, "UpdateTask", 1)
+			carried := tasks.Calls[0].Arguments.Get(1).(*models.Task)
+			assert.Equal(t, open.ID, carried.ID)
+			assert.Equal(t, tc.carryOverTo, carried.SprintID)
+			require.Len(t, audit.Entries, 2)
+			assert.Equal(t, models.AuditEntitySprint, audit.Entries[0].EntityType)
+			assert.Equal(t, models.AuditEntityTask, audit.Entries[1].EntityType)
+			assert.Contains(t, audit.Entries[1].Changes, "sprint_id")
+		})
+	}
+}
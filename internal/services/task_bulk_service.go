@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/utils/mergepatch"
+	"fmt"
+)
+
+// MaxBulkTaskOperations is how many operations one bulk request may contain
+const MaxBulkTaskOperations = 100
+
+// Operations of a bulk task request
+const (
+	BulkOpCreate = "create"
+	BulkOpUpdate = "update"
+	BulkOpMove   = "move"
+	BulkOpDelete = "delete"
+)
+
+var (
+	ErrInvalidBulkOperation = errors.New("invalid bulk operation")
+	ErrBulkRolledBack       = errors.New("rolled back because another operation failed")
+)
+
+// bulkUpdateFields are the task fields a bulk update may change
+var bulkUpdateFields = []string{"assigned_to", "status", "labels", "due_date"}
+
+// BulkTaskOperation is one change of a bulk request. Task is the task to create,
+// Fields a JSON merge patch of the fields to update and ProjectID the project to
+// move the task to, along with its comments, subtasks and labels. Version makes
+// the change conditional like If-Match, 0 applies it to any version.
+type BulkTaskOperation struct {
+	Op        string          `json:"op" enums:"create,update,move,delete"`
+	ID        uint            `json:"id,omitempty"`
+	Version   uint            `json:"version,omitempty"`
+	Task      *models.Task    `json:"task,omitempty"`
+	Fields    json.RawMessage `json:"fields,omitempty" swaggertype:"object"`
+	ProjectID uint            `json:"project_id,omitempty"`
+}
+
+// BulkTaskResult is the outcome of the operation at Index, Task is the created or
+// changed task and Err why the operation failed
+type BulkTaskResult struct {
+	Index int
+	Op    string
+	Task  *models.Task
+	Err   error
+}
+
+type TaskBulkService interface {
+	BulkTasks(ctx context.Context, operations []BulkTaskOperation, atomic bool) ([]BulkTaskResult, error)
+}
+
+type TaskBulkServiceImplementation struct {
+	tasks      TaskService
+	transfers  TaskTransferService
+	transactor repositories.Transactor
+}
+
+func NewTaskBulkService(tasks TaskService, transfers TaskTransferService, transactor repositories.Transactor) TaskBulkService {
+	return &TaskBulkServiceImplementation{tasks: tasks, transfers: transfers, transactor: transactor}
+}
+
+// BulkTasks runs the operations in order. Atomic runs all of them in one
+// transaction, which is rolled back when any fails: the failed operation keeps its
+// error, the others get ErrBulkRolledBack and BulkTasks returns ErrBulkRolledBack.
+// Otherwise every operation is committed on its own and the results tell which
+// failed.
+func (s *TaskBulkServiceImplementation) BulkTasks(ctx context.Context, operations []BulkTaskOperation, atomic bool) ([]BulkTaskResult, error) {
+	if len(operations) == 0 {
+		return nil, fmt.Errorf("%w: no operations", ErrInvalidBulkOperation)
+	}
+	if len(operations) > MaxBulkTaskOperations {
+		return nil, fmt.Errorf("%w: at most %d operations are allowed", ErrInvalidBulkOperation, MaxBulkTaskOperations)
+	}
+
+	results := make([]BulkTaskResult, len(operations))
+	for i, operation := range operations {
+		results[i] = BulkTaskResult{Index: i, Op: operation.Op}
+	}
+
+	if !atomic {
+		for i, operation := range operations {
+			results[i].Task, results[i].Err = s.run(ctx, operation)
+		}
+		return results, nil
+	}
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, operation := range operations {
+			task, err := s.run(ctx, operation)
+			if err != nil {
+				results[i].Err = err
+				return err
+			}
+			results[i].Task = task
+		}
+		return nil
+	})
+	if err != nil {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Task = nil
+				results[i].Err = ErrBulkRolledBack
+			}
+		}
+		return results, ErrBulkRolledBack
+	}
+	return results, nil
+}
+
+// run applies a single operation through the TaskService or TaskTransferService, so
+// it is validated, audited and published like the same change made on its own
+func (s *TaskBulkServiceImplementation) run(ctx context.Context, operation BulkTaskOperation) (*models.Task, error) {
+	if operation.Op == BulkOpCreate {
+		if operation.Task == nil {
+			return nil, fmt.Errorf("%w: create needs a task", ErrInvalidBulkOperation)
+		}
+		task := *operation.Task
+		task.ID = 0
+		if err := s.tasks.CreateTask(ctx, &task); err != nil {
+			return nil, err
+		}
+		return &task, nil
+	}
+
+	if operation.ID == 0 {
+		return nil, fmt.Errorf("%w: %s needs the id of a task", ErrInvalidBulkOperation, operation.Op)
+	}
+
+	switch operation.Op {
+	case BulkOpUpdate:
+		task, err := s.tasks.GetTaskByID(ctx, operation.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(operation.Fields) == 0 {
+			return nil, fmt.Errorf("%w: update needs the fields to change", ErrInvalidBulkOperation)
+		}
+		if err := mergepatch.Apply(task, operation.Fields, bulkUpdateFields...); err != nil {
+			return nil, err
+		}
+		return task, s.update(ctx, task, operation.Version)
+	case BulkOpMove:
+		return s.transfers.MoveTask(ctx, operation.ID, operation.Version, DefaultTaskTransferOptions(operation.ProjectID))
+	case BulkOpDelete:
+		return nil, s.tasks.DeleteTask(ctx, operation.ID, operation.Version)
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidBulkOperation, operation.Op)
+	}
+}
+
+func (s *TaskBulkServiceImplementation) update(ctx context.Context, task *models.Task, version uint) error {
+	if version != 0 {
+		task.Version = version
+	}
+	return s.tasks.UpdateTask(ctx, task)
+}
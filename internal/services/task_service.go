@@ -2,25 +2,62 @@ package services
 
 import (
 	"context"
+	"errors"
+	"example/project-management-system/internal/events"
 	"example/project-management-system/internal/models"
 	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/utils/helpers"
+	"example/project-management-system/pkg/middleware"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
+var (
+	ErrTaskNotFound      = errors.New("task not found")
+	ErrTaskProjectChange = errors.New("tasks are moved to another project with move, not by changing project_id")
+	ErrInvalidParentTask = errors.New("the parent must be a task of the same project that is not a subtask itself")
+	ErrInvalidTaskQuery  = errors.New("invalid task query")
+)
+
+var taskStatuses = []string{models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusDone}
+
+// taskSortColumns are the columns of tasks they can be sorted by
+var taskSortColumns = []string{"id", "title", "status", "due_date", "created_at", "updated_at"}
+
+// TaskListQuery narrows and sorts a list of tasks. Filters holds the raw filter of
+// each custom field by key: values separated by commas match any of them, and
+// "min..max" matches the numbers or dates in a range where either bound can be left
+// out. Sort lists columns or "cf.<key>" custom fields separated by commas, each
+// descending when prefixed by "-".
+type TaskListQuery struct {
+	Filters map[string]string
+	Sort    string
+}
+
 type TaskService interface {
 	CreateTask(ctx context.Context, task *models.Task) error
 	GetTaskByID(ctx context.Context, id uint) (*models.Task, error)
-	GetTasksByProject(ctx context.Context, projectID uint, page, pageSize int) ([]models.Task, int64, error)
+	GetTasksByProject(ctx context.Context, projectID uint, query TaskListQuery, page, pageSize int) ([]models.Task, int64, error)
 	UpdateTask(ctx context.Context, task *models.Task) error
-	DeleteTask(ctx context.Context, id uint) error
+	DeleteTask(ctx context.Context, id uint, version uint) error
 }
 
 type TaskServiceImplementation struct {
-	repo repositories.TaskRepository
+	repo        repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+	fieldRepo   repositories.CustomFieldRepository
+	watcherRepo repositories.WatcherRepository
+	transactor  repositories.Transactor
+	events      events.Publisher
+	audit       AuditRecorder
 }
 
-func NewTaskService(repo repositories.TaskRepository) TaskService {
-	return &TaskServiceImplementation{repo: repo}
+func NewTaskService(repo repositories.TaskRepository, projectRepo repositories.ProjectRepository, fieldRepo repositories.CustomFieldRepository, watcherRepo repositories.WatcherRepository, transactor repositories.Transactor, publisher events.Publisher, audit AuditRecorder) TaskService {
+	return &TaskServiceImplementation{repo: repo, projectRepo: projectRepo, fieldRepo: fieldRepo, watcherRepo: watcherRepo, transactor: transactor, events: publisher, audit: audit}
 }
 
 func (s *TaskServiceImplementation) CreateTask(ctx context.Context, task *models.Task) error {
@@ -31,28 +68,437 @@ func (s *TaskServiceImplementation) CreateTask(ctx context.Context, task *models
 	if task.ProjectID == 0 {
 		return fmt.Errorf("task must be associated with a project")
 	}
-	return s.repo.CreateTask(ctx, task)
+	if task.Status == "" {
+		task.Status = models.TaskStatusTodo
+	}
+	if !helpers.Contains(taskStatuses, task.Status) {
+		return fmt.Errorf("invalid task status %q", task.Status)
+	}
+	if task.OriginalEstimate < 0 || task.RemainingEstimate < 0 {
+		return fmt.Errorf("original_estimate and remaining_estimate cannot be negative")
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, task.ProjectID); err != nil {
+		return err
+	}
+	if err := s.validateParent(ctx, task); err != nil {
+		return err
+	}
+	fieldValues, err := s.prepareCustomFields(ctx, task, nil)
+	if err != nil {
+		return err
+	}
+	// Until work is logged, all of the estimated work is left
+	if task.RemainingEstimate == 0 {
+		task.RemainingEstimate = task.OriginalEstimate
+	}
+	// New tasks start in the backlog, they are added to sprints and milestones by them
+	task.SprintID = nil
+	task.MilestoneID = nil
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateTask(ctx, task); err != nil {
+			return err
+		}
+		if err := s.fieldRepo.SetTaskValues(ctx, task.ID, fieldValues); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityTask, task.ID, nil, task); err != nil {
+			return err
+		}
+		// The creator and the assignee watch the task
+		creatorID, _ := middleware.UserIDFromContext(ctx)
+		if err := s.watcherRepo.AddWatchers(ctx, task.ID, nonZero(creatorID, task.AssignedTo)); err != nil {
+			return err
+		}
+
+		if err := s.publishChange(ctx, events.TaskCreated, task, nil); err != nil {
+			return err
+		}
+		if task.AssignedTo != 0 {
+			return s.publish(ctx, events.TaskAssigned, task, task.AssignedTo, nil, nil)
+		}
+		return nil
+	})
 }
 
 func (s *TaskServiceImplementation) GetTaskByID(ctx context.Context, id uint) (*models.Task, error) {
 	task, err := s.repo.GetTaskByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("task not found")
+		return nil, ErrTaskNotFound
 	}
 	return task, nil
 }
 
-func (s *TaskServiceImplementation) GetTasksByProject(ctx context.Context, projectID uint, page, pageSize int) ([]models.Task, int64, error) {
-	return s.repo.GetTaskByProject(ctx, projectID, page, pageSize)
+func (s *TaskServiceImplementation) GetTasksByProject(ctx context.Context, projectID uint, query TaskListQuery, page, pageSize int) ([]models.Task, int64, error) {
+	taskQuery, err := s.taskQuery(ctx, projectID, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.repo.GetTaskByProject(ctx, projectID, taskQuery, page, pageSize)
 }
 
 func (s *TaskServiceImplementation) UpdateTask(ctx context.Context, task *models.Task) error {
 	if task.Title == "" {
 		return fmt.Errorf("task title is required")
 	}
-	return s.repo.UpdateTask(ctx, task)
+	if task.Status != "" && !helpers.Contains(taskStatuses, task.Status) {
+		return fmt.Errorf("invalid task status %q", task.Status)
+	}
+	if task.OriginalEstimate < 0 || task.RemainingEstimate < 0 {
+		return fmt.Errorf("original_estimate and remaining_estimate cannot be negative")
+	}
+
+	existing, err := s.repo.GetTaskByID(ctx, task.ID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	if task.Version, err = expectVersion(task.Version, existing.Version); err != nil {
+		return err
+	}
+	if task.Status == "" {
+		task.Status = existing.Status
+	}
+	if task.ProjectID == 0 {
+		task.ProjectID = existing.ProjectID
+	}
+	if task.ProjectID != existing.ProjectID {
+		return ErrTaskProjectChange
+	}
+	task.SprintID = existing.SprintID
+	task.MilestoneID = existing.MilestoneID
+	task.Checklist = existing.Checklist
+	if err := ensureProjectWritable(ctx, s.projectRepo, task.ProjectID); err != nil {
+		return err
+	}
+	if task.Status == models.TaskStatusDone && existing.Status != models.TaskStatusDone && !checklistComplete(existing.Project, existing) {
+		return fmt.Errorf("%w: %d of %d are checked", ErrChecklistIncomplete, existing.Checklist.Done, existing.Checklist.Total)
+	}
+	if err := s.validateParent(ctx, task); err != nil {
+		return err
+	}
+	// Tasks keep their custom field values unless new ones are given
+	keepFields := task.CustomFields == nil
+	var fieldValues []models.TaskFieldValue
+	if keepFields {
+		task.CustomFields = existing.CustomFields
+	} else if fieldValues, err = s.prepareCustomFields(ctx, task, existing); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateTask(ctx, task); err != nil {
+			return err
+		}
+		if !keepFields {
+			if err := s.fieldRepo.SetTaskValues(ctx, task.ID, fieldValues); err != nil {
+				return err
+			}
+		}
+		if err := s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityTask, task.ID, existing, task); err != nil {
+			return err
+		}
+
+		// A new assignee watches the task, and hears about the assignment instead of
+		// the update. Watchers hear about a status change instead of the update.
+		assigned := task.AssignedTo != 0 && task.AssignedTo != existing.AssignedTo
+		if assigned {
+			if err := s.watcherRepo.AddWatchers(ctx, task.ID, []uint{task.AssignedTo}); err != nil {
+				return err
+			}
+			if err := s.publish(ctx, events.TaskAssigned, task, task.AssignedTo, nil, nil); err != nil {
+				return err
+			}
+		}
+		watchers, err := s.watcherRepo.GetWatcherIDs(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		if task.Status != existing.Status {
+			data := map[string]string{"previous_status": existing.Status, "status": task.Status}
+			if err := s.publish(ctx, events.TaskStatusChanged, task, 0, watchers, data); err != nil {
+				return err
+			}
+			return s.publishChange(ctx, events.TaskUpdated, task, nil)
+		}
+		if assigned {
+			watchers = slices.DeleteFunc(watchers, func(userID uint) bool { return userID == task.AssignedTo })
+		}
+		return s.publishChange(ctx, events.TaskUpdated, task, watchers)
+	})
+}
+
+// DeleteTask moves the task to the trash when it is at version, or any version when it is 0.
+func (s *TaskServiceImplementation) DeleteTask(ctx context.Context, id uint, version uint) error {
+	task, err := s.repo.GetTaskByID(ctx, id)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	if _, err := expectVersion(version, task.Version); err != nil {
+		return err
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, task.ProjectID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteTask(ctx, id); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityTask, id, task, nil); err != nil {
+			return err
+		}
+		watchers, err := s.watcherRepo.GetWatcherIDs(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.publishChange(ctx, events.TaskDeleted, task, watchers)
+	})
+}
+
+// validateParent checks that a subtask belongs to a task of its project, which is
+// not a subtask itself. Subtasks are only one level deep, so there are no cycles.
+func (s *TaskServiceImplementation) validateParent(ctx context.Context, task *models.Task) error {
+	if task.ParentID == nil {
+		return nil
+	}
+	if *task.ParentID == task.ID {
+		return ErrInvalidParentTask
+	}
+	parent, err := s.repo.GetTaskByID(ctx, *task.ParentID)
+	if err != nil || parent.ProjectID != task.ProjectID || parent.ParentID != nil {
+		return ErrInvalidParentTask
+	}
+	if task.ID == 0 {
+		return nil
+	}
+
+	// A task with subtasks cannot become a subtask
+	subtasks, err := s.repo.GetSubtasks(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if len(subtasks) > 0 {
+		return fmt.Errorf("%w: the task has subtasks", ErrInvalidParentTask)
+	}
+	return nil
+}
+
+// prepareCustomFields validates the custom field values of a task of its project and
+// returns the rows storing them. New tasks get the default of the fields they leave
+// out, and need a value for required fields. Updated tasks cannot clear the value of
+// a required field.
+func (s *TaskServiceImplementation) prepareCustomFields(ctx context.Context, task *models.Task, existing *models.Task) ([]models.TaskFieldValue, error) {
+	fields, err := s.fieldRepo.GetFieldsByProject(ctx, task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*models.CustomField, len(fields))
+	for i := range fields {
+		byKey[fields[i].Key] = &fields[i]
+	}
+	for key := range task.CustomFields {
+		if byKey[key] == nil {
+			return nil, fmt.Errorf("%w: the project has no field %q", ErrInvalidFieldValue, key)
+		}
+	}
+
+	values := make(map[string]interface{}, len(fields))
+	var rows []models.TaskFieldValue
+	var userIDs []uint
+	for i := range fields {
+		field := &fields[i]
+		raw, given := task.CustomFields[field.Key]
+		if !given && existing == nil {
+			raw = field.Default
+		}
+		value, err := normalizeFieldValue(field, raw)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			if field.Required && (existing == nil || existing.CustomFields[field.Key] != nil) {
+				return nil, fmt.Errorf("%w: %s is required", ErrInvalidFieldValue, field.Key)
+			}
+			continue
+		}
+		if userID, ok := value.(uint); ok {
+			userIDs = append(userIDs, userID)
+		}
+		values[field.Key] = value
+		rows = append(rows, fieldValueRows(field, value)...)
+	}
+
+	if len(userIDs) > 0 {
+		existingIDs, err := s.fieldRepo.GetExistingUserIDs(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range userIDs {
+			if !slices.Contains(existingIDs, userID) {
+				return nil, fmt.Errorf("%w: user %d not found", ErrInvalidFieldValue, userID)
+			}
+		}
+	}
+
+	task.CustomFields = values
+	if len(values) == 0 {
+		task.CustomFields = nil
+	}
+	return rows, nil
+}
+
+// taskQuery turns the custom field filters and sorting of a list of tasks of the
+// project into a repository query.
+func (s *TaskServiceImplementation) taskQuery(ctx context.Context, projectID uint, query TaskListQuery) (repositories.TaskQuery, error) {
+	var taskQuery repositories.TaskQuery
+	if len(query.Filters) == 0 && query.Sort == "" {
+		return taskQuery, nil
+	}
+
+	byKey := map[string]*models.CustomField{}
+	if len(query.Filters) > 0 || strings.Contains(query.Sort, "cf.") {
+		fields, err := s.fieldRepo.GetFieldsByProject(ctx, projectID)
+		if err != nil {
+			return taskQuery, err
+		}
+		for i := range fields {
+			byKey[fields[i].Key] = &fields[i]
+		}
+	}
+
+	// Filters are applied in a stable order
+	keys := make([]string, 0, len(query.Filters))
+	for key := range query.Filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		field := byKey[key]
+		if field == nil {
+			return taskQuery, fmt.Errorf("%w: the project has no field %q", ErrInvalidTaskQuery, key)
+		}
+		filter, err := parseFieldFilter(field, query.Filters[key])
+		if err != nil {
+			return taskQuery, err
+		}
+		taskQuery.Fields = append(taskQuery.Fields, filter)
+	}
+
+	for _, term := range strings.Split(query.Sort, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		order := repositories.TaskOrder{Desc: strings.HasPrefix(term, "-")}
+		name := strings.TrimPrefix(term, "-")
+		if key, ok := strings.CutPrefix(name, "cf."); ok {
+			field := byKey[key]
+			if field == nil {
+				return taskQuery, fmt.Errorf("%w: the project has no field %q", ErrInvalidTaskQuery, key)
+			}
+			order.FieldID = field.ID
+			order.Column = fieldValueColumn(field.Type)
+		} else if helpers.Contains(taskSortColumns, name) {
+			order.Column = name
+		} else {
+			return taskQuery, fmt.Errorf("%w: cannot sort by %q, use one of %s or cf.<key>", ErrInvalidTaskQuery, name, strings.Join(taskSortColumns, ", "))
+		}
+		taskQuery.Sort = append(taskQuery.Sort, order)
+	}
+	return taskQuery, nil
+}
+
+// parseFieldFilter parses the raw filter of a custom field
+func parseFieldFilter(field *models.CustomField, raw string) (repositories.TaskFieldFilter, error) {
+	filter := repositories.TaskFieldFilter{FieldID: field.ID, Column: fieldValueColumn(field.Type)}
+	invalid := func(value string) error {
+		return fmt.Errorf("%w: %q is not %s", ErrInvalidTaskQuery, value, fieldValueDescription(field.Type))
+	}
+	parse := func(value string) (interface{}, error) {
+		switch field.Type {
+		case models.CustomFieldTypeNumber, models.CustomFieldTypeUser:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, invalid(value)
+			}
+			return number, nil
+		case models.CustomFieldTypeDate:
+			date, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				return nil, invalid(value)
+			}
+			return date, nil
+		}
+		return value, nil
+	}
+
+	if min, max, isRange := strings.Cut(raw, ".."); isRange {
+		if filter.Column == repositories.FieldValueText {
+			return filter, fmt.Errorf("%w: only number, user and date fields can be filtered by range", ErrInvalidTaskQuery)
+		}
+		if min == "" && max == "" {
+			return filter, fmt.Errorf("%w: a range of %s needs a bound", ErrInvalidTaskQuery, field.Key)
+		}
+		var err error
+		if min != "" {
+			if filter.Min, err = parse(min); err != nil {
+				return filter, err
+			}
+		}
+		if max != "" {
+			if filter.Max, err = parse(max); err != nil {
+				return filter, err
+			}
+		}
+		return filter, nil
+	}
+
+	for _, value := range strings.Split(raw, ",") {
+		if value == "" {
+			continue
+		}
+		parsed, err := parse(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Values = append(filter.Values, parsed)
+	}
+	if len(filter.Values) == 0 {
+		return filter, fmt.Errorf("%w: the filter of %s has no value", ErrInvalidTaskQuery, field.Key)
+	}
+	return filter, nil
 }
 
-func (s *TaskServiceImplementation) DeleteTask(ctx context.Context, id uint) error {
-	return s.repo.DeleteTask(ctx, id)
+func (s *TaskServiceImplementation) publish(ctx context.Context, eventType events.Type, task *models.Task, userID uint, recipients []uint, data map[string]string) error {
+	actorID, _ := middleware.UserIDFromContext(ctx)
+	if data == nil {
+		data = map[string]string{}
+	}
+	data["task_title"] = task.Title
+
+	return s.events.Publish(ctx, events.Event{
+		Type:       eventType,
+		ActorID:    actorID,
+		UserID:     userID,
+		Recipients: recipients,
+		ProjectID:  task.ProjectID,
+		TaskID:     task.ID,
+		Data:       data,
+	})
+}
+
+// publishChange announces that the task itself was created, updated or deleted,
+// to the recipients if any.
+func (s *TaskServiceImplementation) publishChange(ctx context.Context, eventType events.Type, task *models.Task, recipients []uint) error {
+	actorID, _ := middleware.UserIDFromContext(ctx)
+
+	return s.events.Publish(ctx, events.Event{
+		Type:       eventType,
+		ActorID:    actorID,
+		Recipients: recipients,
+		ProjectID:  task.ProjectID,
+		TaskID:     task.ID,
+		Data:       map[string]string{"task_title": task.Title},
+		Payload:    task,
+	})
 }
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"example/project-management-system/internal/events"
 	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,8 +25,8 @@ func (m *MockTaskRepository) GetTaskByID(ctx context.Context, id uint) (*models.
     return args.Get(0).(*models.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) GetTaskByProject(ctx context.Context, projectID uint, page, pageSize int) ([]models.Task, int64, error) {
-    args := m.Called(ctx, projectID, page, pageSize)
+func (m *MockTaskRepository) GetTaskByProject(ctx context.Context, projectID uint, query repositories.TaskQuery, page, pageSize int) ([]models.Task, int64, error) {
+    args := m.Called(ctx, projectID, query, page, pageSize)
     return args.Get(0).([]models.Task), args.Get(1).(int64), args.Error(2)
 }
 
@@ -38,6 +40,56 @@ func (m *MockTaskRepository) DeleteTask(ctx context.Context, id uint) error {
     return args.Error(0)
 }
 
+func (m *MockTaskRepository) GetExistingTaskIDs(ctx context.Context, ids []uint) ([]uint, error) {
+    args := m.Called(ctx, ids)
+    return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetSubtasks(ctx context.Context, parentID uint) ([]models.Task, error) {
+    args := m.Called(ctx, parentID)
+    return args.Get(0).([]models.Task), args.Error(1)
+}
+
+// MockPublisher records the published events
+type MockPublisher struct {
+    Events []events.Event
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, event events.Event) error {
+    m.Events = append(m.Events, event)
+    return nil
+}
+
+// MockTransactor runs the function directly and counts the transactions
+type MockTransactor struct {
+    Transactions int
+}
+
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+    m.Transactions++
+    return fn(ctx)
+}
+
+// MockAuditRecorder records the audited changes
+type MockAuditRecorder struct {
+    Entries []models.AuditEntry
+}
+
+func (m *MockAuditRecorder) Record(ctx context.Context, action, entityType string, entityID uint, before, after interface{}) error {
+    changes, err := DiffFields(before, after)
+    if err != nil {
+        return err
+    }
+    m.Entries = append(m.Entries, models.AuditEntry{Action: action, EntityType: entityType, EntityID: entityID, Changes: changes})
+    return nil
+}
+
+// activeProjects is a project repository in which every project is active
+func activeProjects() *MockProjectRepository {
+    projects := new(MockProjectRepository)
+    projects.On("GetProjectStatus", mock.Anything, mock.Anything).Return(models.ProjectStatusActive, nil).Maybe()
+    return projects
+}
 
 func TestCreateTask(t *testing.T) {
     t.Parallel()
@@ -96,7 +148,7 @@ func TestCreateTask(t *testing.T) {
             mockRepo.On("CreateTask", mock.Anything, tc.task).Return(tc.mockRepoReturn)
 
             // Create service with mock repository
-            service := NewTaskService(mockRepo)
+            service := NewTaskService(mockRepo, activeProjects(), noCustomFields(), noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
 
             // Perform the test
             err := service.CreateTask(context.Background(), tc.task)
@@ -161,7 +213,7 @@ func TestGetTaskByID(t *testing.T) {
                 Return(tc.mockRepoReturn, tc.mockRepoError)
 
             // Create service with mock repository
-            service := NewTaskService(mockRepo)
+            service := NewTaskService(mockRepo, activeProjects(), noCustomFields(), noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
 
             // Perform the test
             task, err := service.GetTaskByID(context.Background(), tc.taskID)
@@ -228,10 +280,12 @@ func TestUpdateTask(t *testing.T) {
             mockRepo := new(MockTaskRepository)
             
             // Setup expectations
+            mockRepo.On("GetTaskByID", mock.Anything, tc.task.ID).
+                Return(&models.Task{BaseModel: tc.task.BaseModel, Title: "Task", ProjectID: 1}, nil)
             mockRepo.On("UpdateTask", mock.Anything, tc.task).Return(tc.mockRepoReturn)
 
             // Create service with mock repository
-            service := NewTaskService(mockRepo)
+            service := NewTaskService(mockRepo, activeProjects(), noCustomFields(), noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
 
             // Perform the test
             err := service.UpdateTask(context.Background(), tc.task)
@@ -313,17 +367,19 @@ func TestGetTasksByProject(t *testing.T) {
             mockRepo.On("GetTaskByProject", 
                 mock.Anything, 
                 tc.projectID, 
+                repositories.TaskQuery{},
                 tc.page, 
                 tc.pageSize,
             ).Return(tc.mockTasksReturn, tc.mockTotalReturn, tc.mockRepoError)
 
             // Create service with mock repository
-            service := NewTaskService(mockRepo)
+            service := NewTaskService(mockRepo, activeProjects(), noCustomFields(), noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
 
             // Perform the test
             tasks, total, err := service.GetTasksByProject(
                 context.Background(), 
                 tc.projectID, 
+                TaskListQuery{},
                 tc.page, 
                 tc.pageSize,
             )
@@ -351,6 +407,7 @@ func TestDeleteTask(t *testing.T) {
     testCases := []struct {
         name           string
         taskID         uint
+        version        uint
         mockRepoReturn error
         expectedError  bool
     }{
@@ -366,6 +423,13 @@ func TestDeleteTask(t *testing.T) {
             mockRepoReturn: assert.AnError,
             expectedError:  true,
         },
+        {
+            name:           "Matching Version",
+            taskID:         1,
+            version:        2,
+            mockRepoReturn: nil,
+            expectedError:  false,
+        },
     }
 
     for _, tc := range testCases {
@@ -374,13 +438,15 @@ func TestDeleteTask(t *testing.T) {
             mockRepo := new(MockTaskRepository)
             
             // Setup expectations
+            mockRepo.On("GetTaskByID", mock.Anything, tc.taskID).
+                Return(&models.Task{BaseModel: models.BaseModel{ID: tc.taskID, Version: 2}, ProjectID: 1}, nil)
             mockRepo.On("DeleteTask", mock.Anything, tc.taskID).Return(tc.mockRepoReturn)
 
             // Create service with mock repository
-            service := NewTaskService(mockRepo)
+            service := NewTaskService(mockRepo, activeProjects(), noCustomFields(), noWatchers(), new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
 
             // Perform the test
-            err := service.DeleteTask(context.Background(), tc.taskID)
+            err := service.DeleteTask(context.Background(), tc.taskID, tc.version)
 
             // Assertions
             if tc.expectedError {
@@ -394,3 +460,73 @@ func TestDeleteTask(t *testing.T) {
         })
     }
 }
+
+func TestUpdateTaskPublishesEvents(t *testing.T) {
+    t.Parallel()
+
+    existing := &models.Task{
+        BaseModel:  models.BaseModel{ID: 1},
+        Title:      "Task",
+        Status:     models.TaskStatusTodo,
+        ProjectID:  1,
+        AssignedTo: 2,
+    }
+    updated := &models.Task{
+        BaseModel:  models.BaseModel{ID: 1},
+        Title:      "Task",
+        Status:     models.TaskStatusDone,
+        ProjectID:  1,
+        AssignedTo: 3,
+    }
+
+    mockRepo := new(MockTaskRepository)
+    mockRepo.On("GetTaskByID", mock.Anything, uint(1)).Return(existing, nil)
+    mockRepo.On("UpdateTask", mock.Anything, updated).Return(nil)
+
+    transactor := new(MockTransactor)
+    publisher := new(MockPublisher)
+    audit := new(MockAuditRecorder)
+    service := NewTaskService(mockRepo, activeProjects(), noCustomFields(), noWatchers(), transactor, publisher, audit)
+
+    err := service.UpdateTask(context.Background(), updated)
+
+    assert.NoError(t, err)
+    assert.Equal(t, 1, transactor.Transactions)
+    assert.Len(t, audit.Entries, 1)
+    assert.Equal(t, models.AuditActionUpdate, audit.Entries[0].Action)
+    assert.Equal(t, map[string]models.FieldChange{
+        "status":      {Before: models.TaskStatusTodo, After: models.TaskStatusDone},
+        "assigned_to": {Before: float64(2), After: float64(3)},
+    }, audit.Entries[0].Changes)
+    assert.Len(t, publisher.Events, 3)
+    assert.Equal(t, events.TaskAssigned, publisher.Events[0].Type)
+    assert.Equal(t, uint(3), publisher.Events[0].UserID)
+    assert.Equal(t, events.TaskStatusChanged, publisher.Events[1].Type)
+    assert.Equal(t, models.TaskStatusTodo, publisher.Events[1].Data["previous_status"])
+    assert.Equal(t, models.TaskStatusDone, publisher.Events[1].Data["status"])
+    assert.Equal(t, events.TaskUpdated, publisher.Events[2].Type)
+    assert.Equal(t, updated, publisher.Events[2].Payload)
+}
+
+func TestTaskChangesInArchivedProject(t *testing.T) {
+    task := &models.Task{BaseModel: models.BaseModel{ID: 1, Version: 1}, Title: "Task", ProjectID: 1}
+
+    mockRepo := new(MockTaskRepository)
+    mockRepo.On("GetTaskByID", mock.Anything, uint(1)).Return(task, nil)
+    projects := new(MockProjectRepository)
+    projects.On("GetProjectStatus", mock.Anything, uint(1)).Return(models.ProjectStatusArchived, nil)
+    transactor := new(MockTransactor)
+    service := NewTaskService(mockRepo, projects, noCustomFields(), noWatchers(), transactor, new(MockPublisher), new(MockAuditRecorder))
+
+    err := service.CreateTask(context.Background(), &models.Task{Title: "New Task", ProjectID: 1})
+    assert.ErrorIs(t, err, ErrProjectArchived)
+
+    err = service.UpdateTask(context.Background(), &models.Task{BaseModel: models.BaseModel{ID: 1}, Title: "Renamed"})
+    assert.ErrorIs(t, err, ErrProjectArchived)
+
+    err = service.DeleteTask(context.Background(), 1, 0)
+    assert.ErrorIs(t, err, ErrProjectArchived)
+
+    assert.Equal(t, 0, transactor.Transactions)
+    mockRepo.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
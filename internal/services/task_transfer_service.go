@@ -0,0 +1,366 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrAssigneeNotMember = errors.New("the assignee is not a member of the target project")
+	ErrInvalidTransfer   = errors.New("invalid task move or copy")
+)
+
+// TaskTransferOptions selects the project a task is moved or copied to and what
+// comes along with it. Comments, subtasks and labels that are not carried are
+// dropped: a moved task's comments go to the trash and its subtasks stay behind as
+// tasks of their own, a copy does not get them. Custom field values are carried to
+// the fields of the target project with the same key and type, when they accept
+// them, and dropped otherwise.
+type TaskTransferOptions struct {
+	ProjectID uint `json:"project_id"`
+	Comments  bool `json:"comments"`
+	Subtasks  bool `json:"subtasks"`
+	Labels    bool `json:"labels"`
+}
+
+// DefaultTaskTransferOptions carry everything along
+func DefaultTaskTransferOptions(projectID uint) TaskTransferOptions {
+	return TaskTransferOptions{ProjectID: projectID, Comments: true, Subtasks: true, Labels: true}
+}
+
+type TaskTransferService interface {
+	MoveTask(ctx context.Context, id, version uint, options TaskTransferOptions) (*models.Task, error)
+	CopyTask(ctx context.Context, id uint, options TaskTransferOptions) (*models.Task, error)
+}
+
+type TaskTransferServiceImplementation struct {
+	taskRepo    repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+	commentRepo repositories.CommentRepository
+	fieldRepo   repositories.CustomFieldRepository
+	watcherRepo repositories.WatcherRepository
+	worklogRepo repositories.WorklogRepository
+	submissions repositories.TimesheetSubmissionRepository
+	transactor  repositories.Transactor
+	events      events.Publisher
+	audit       AuditRecorder
+}
+
+func NewTaskTransferService(taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, commentRepo repositories.CommentRepository, fieldRepo repositories.CustomFieldRepository, watcherRepo repositories.WatcherRepository, worklogRepo repositories.WorklogRepository, submissions repositories.TimesheetSubmissionRepository, transactor repositories.Transactor, publisher events.Publisher, audit AuditRecorder) TaskTransferService {
+	return &TaskTransferServiceImplementation{
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		commentRepo: commentRepo,
+		fieldRepo:   fieldRepo,
+		watcherRepo: watcherRepo,
+		worklogRepo: worklogRepo,
+		submissions: submissions,
+		transactor:  transactor,
+		events:      publisher,
+		audit:       audit,
+	}
+}
+
+// MoveTask moves the task, when it is at version or any version when it is 0, to
+// another project. The assignees of the moved tasks must be members of it. A moved
+// subtask leaves its parent behind and becomes a task of its own.
+func (s *TaskTransferServiceImplementation) MoveTask(ctx context.Context, id, version uint, options TaskTransferOptions) (*models.Task, error) {
+	task, err := s.taskRepo.GetTaskByID(ctx, id)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+	if task.Version, err = expectVersion(version, task.Version); err != nil {
+		return nil, err
+	}
+	if options.ProjectID == task.ProjectID {
+		return nil, fmt.Errorf("%w: the task is already in that project", ErrInvalidTransfer)
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, task.ProjectID); err != nil {
+		return nil, err
+	}
+
+	project, subtasks, err := s.prepare(ctx, task, options)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := s.prepareFields(ctx, task.ProjectID, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, moved := range append([]models.Task{*task}, subtasks...) {
+		if err := s.ensureWorklogsOpen(ctx, &moved); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		task.ParentID = nil
+		moved := append([]models.Task{*task}, subtasks...)
+		for i := range moved {
+			if err := s.move(ctx, &moved[i], project.ID, fields, options); err != nil {
+				return err
+			}
+		}
+		*task = moved[0]
+
+		if options.Subtasks {
+			return nil
+		}
+		// Subtasks left behind become tasks of their own
+		left, err := s.taskRepo.GetSubtasks(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		for i := range left {
+			before := left[i]
+			left[i].ParentID = nil
+			if err := s.taskRepo.UpdateTask(ctx, &left[i]); err != nil {
+				return err
+			}
+			if err := s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityTask, left[i].ID, &before, &left[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// CopyTask copies the task into a project, which may be its own. The copy of a
+// subtask keeps its parent within the same project and is a task of its own in
+// another.
+func (s *TaskTransferServiceImplementation) CopyTask(ctx context.Context, id uint, options TaskTransferOptions) (*models.Task, error) {
+	source, err := s.taskRepo.GetTaskByID(ctx, id)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+
+	project, subtasks, err := s.prepare(ctx, source, options)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := s.prepareFields(ctx, source.ProjectID, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *uint
+	if source.ProjectID == project.ID {
+		parentID = source.ParentID
+	}
+
+	var copied *models.Task
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if copied, err = s.copy(ctx, source, project.ID, parentID, fields, options); err != nil {
+			return err
+		}
+		for i := range subtasks {
+			if _, err := s.copy(ctx, &subtasks[i], project.ID, &copied.ID, fields, options); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+// prepare loads the target project and the subtasks carried along, and checks that
+// the project is not archived and the assignees of all of them are its members
+func (s *TaskTransferServiceImplementation) prepare(ctx context.Context, task *models.Task, options TaskTransferOptions) (*models.Project, []models.Task, error) {
+	if options.ProjectID == 0 {
+		return nil, nil, fmt.Errorf("%w: project_id is required", ErrInvalidTransfer)
+	}
+	project, err := s.projectRepo.GetProjectByID(ctx, options.ProjectID)
+	if err != nil {
+		return nil, nil, ErrProjectNotFound
+	}
+	if project.Status == models.ProjectStatusArchived {
+		return nil, nil, ErrProjectArchived
+	}
+
+	var subtasks []models.Task
+	if options.Subtasks {
+		if subtasks, err = s.taskRepo.GetSubtasks(ctx, task.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, transferred := range append([]models.Task{*task}, subtasks...) {
+		if transferred.AssignedTo != 0 && !slices.Contains(project.UserIDs, transferred.AssignedTo) {
+			return nil, nil, fmt.Errorf("%w: task %d is assigned to user %d", ErrAssigneeNotMember, transferred.ID, transferred.AssignedTo)
+		}
+	}
+	return project, subtasks, nil
+}
+
+// ensureWorklogsOpen fails with ErrWorklogLocked when time logged on the task is in
+// a submitted or approved timesheet of its project. The worklogs go along with a
+// moved task and would leave the timesheet.
+func (s *TaskTransferServiceImplementation) ensureWorklogsOpen(ctx context.Context, task *models.Task) error {
+	worklogs, err := s.worklogRepo.GetWorklogsByTask(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	for _, worklog := range worklogs {
+		if err := ensureWeekOpen(ctx, s.submissions, worklog.UserID, task.ProjectID, worklog.StartedAt); err != nil {
+			return fmt.Errorf("task %d: %w", task.ID, err)
+		}
+	}
+	return nil
+}
+
+// fieldMapping maps the custom fields of the project a task comes from onto those
+// of the project it goes to
+type fieldMapping struct {
+	sourceTypes map[string]string // type of each source field by key
+	target      []models.CustomField
+}
+
+// prepareFields loads the custom fields of the source and target projects
+func (s *TaskTransferServiceImplementation) prepareFields(ctx context.Context, sourceProjectID, targetProjectID uint) (*fieldMapping, error) {
+	source, err := s.fieldRepo.GetFieldsByProject(ctx, sourceProjectID)
+	if err != nil {
+		return nil, err
+	}
+	target := source
+	if targetProjectID != sourceProjectID {
+		if target, err = s.fieldRepo.GetFieldsByProject(ctx, targetProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	mapping := &fieldMapping{sourceTypes: make(map[string]string, len(source)), target: target}
+	for _, field := range source {
+		mapping.sourceTypes[field.Key] = field.Type
+	}
+	return mapping, nil
+}
+
+// carry sets the custom field values of the task to those that a target field of
+// the same key and type accepts, and returns the rows storing them
+func (m *fieldMapping) carry(task *models.Task) []models.TaskFieldValue {
+	values := make(map[string]interface{})
+	var rows []models.TaskFieldValue
+	for i := range m.target {
+		field := &m.target[i]
+		raw, ok := task.CustomFields[field.Key]
+		if !ok || m.sourceTypes[field.Key] != field.Type {
+			continue
+		}
+		value, err := normalizeFieldValue(field, raw)
+		if err != nil || value == nil {
+			continue
+		}
+		values[field.Key] = value
+		rows = append(rows, fieldValueRows(field, value)...)
+	}
+
+	task.CustomFields = values
+	if len(values) == 0 {
+		task.CustomFields = nil
+	}
+	return rows
+}
+
+func (s *TaskTransferServiceImplementation) move(ctx context.Context, task *models.Task, projectID uint, fields *fieldMapping, options TaskTransferOptions) error {
+	before := *task
+	task.ProjectID = projectID
+	task.Project = models.Project{}
+	// Sprints and milestones belong to the source project
+	task.SprintID = nil
+	task.MilestoneID = nil
+	if !options.Labels {
+		task.Labels = nil
+	}
+	fieldValues := fields.carry(task)
+
+	if err := s.taskRepo.UpdateTask(ctx, task); err != nil {
+		return err
+	}
+	if err := s.fieldRepo.SetTaskValues(ctx, task.ID, fieldValues); err != nil {
+		return err
+	}
+	if !options.Comments {
+		if err := s.commentRepo.DeleteCommentsByTask(ctx, task.ID); err != nil {
+			return err
+		}
+	}
+	if err := s.audit.Record(ctx, models.AuditActionMove, models.AuditEntityTask, task.ID, &before, task); err != nil {
+		return err
+	}
+	return publishTaskChange(ctx, s.events, s.watcherRepo, events.TaskUpdated, task)
+}
+
+func (s *TaskTransferServiceImplementation) copy(ctx context.Context, source *models.Task, projectID uint, parentID *uint, fields *fieldMapping, options TaskTransferOptions) (*models.Task, error) {
+	copied := &models.Task{
+		Title:             source.Title,
+		Description:       source.Description,
+		Status:            source.Status,
+		ProjectID:         projectID,
+		AssignedTo:        source.AssignedTo,
+		DueDate:           source.DueDate,
+		ParentID:          parentID,
+		OriginalEstimate:  source.OriginalEstimate,
+		RemainingEstimate: source.RemainingEstimate,
+		CustomFields:      source.CustomFields,
+	}
+	if options.Labels {
+		copied.Labels = source.Labels
+	}
+	fieldValues := fields.carry(copied)
+
+	if err := s.taskRepo.CreateTask(ctx, copied); err != nil {
+		return nil, err
+	}
+	if err := s.fieldRepo.SetTaskValues(ctx, copied.ID, fieldValues); err != nil {
+		return nil, err
+	}
+	// The user copying the task and the assignee watch the copy
+	creatorID, _ := middleware.UserIDFromContext(ctx)
+	if err := s.watcherRepo.AddWatchers(ctx, copied.ID, nonZero(creatorID, copied.AssignedTo)); err != nil {
+		return nil, err
+	}
+	if options.Comments {
+		comments, err := s.commentRepo.ListCommentsByTask(ctx, source.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			if err := s.commentRepo.CreateComment(ctx, &models.Comment{Content: comment.Content, TaskID: copied.ID, UserID: comment.UserID}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The changes from the source to the copy show where it was copied from
+	if err := s.audit.Record(ctx, models.AuditActionCopy, models.AuditEntityTask, copied.ID, source, copied); err != nil {
+		return nil, err
+	}
+	if err := publishTaskChange(ctx, s.events, s.watcherRepo, events.TaskCreated, copied); err != nil {
+		return nil, err
+	}
+	if copied.AssignedTo != 0 {
+		return copied, s.events.Publish(ctx, events.Event{
+			Type:      events.TaskAssigned,
+			ActorID:   creatorID,
+			UserID:    copied.AssignedTo,
+			ProjectID: copied.ProjectID,
+			TaskID:    copied.ID,
+			Data:      map[string]string{"task_title": copied.Title},
+		})
+	}
+	return copied, nil
+}
@@ -2,38 +2,59 @@ package services
 
 import (
 	"context"
+	"example/project-management-system/internal/events"
 	"example/project-management-system/internal/models"
 	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"errors"
 	"fmt"
 )
 
+var ErrTeamNotFound = errors.New("team not found")
+
 type TeamService interface {
 	CreateTeam(ctx context.Context, team *models.Team) error
 	GetTeamByID(ctx context.Context, id uint) (*models.Team, error)
 	GetPaginatedTeams(ctx context.Context, page, pageSize int) ([]models.Team, int64, error)
 	UpdateTeam(ctx context.Context, team *models.Team) error
-	DeleteTeam(ctx context.Context, id uint) error
+	DeleteTeam(ctx context.Context, id uint, version uint) error
 }
 
 type TeamServiceImplementation struct {
-	repo repositories.TeamRepository
+	repo        repositories.TeamRepository
+	projectRepo repositories.ProjectRepository
+	transactor  repositories.Transactor
+	events      events.Publisher
+	audit       AuditRecorder
 }
 
-func NewTeamService(repo repositories.TeamRepository) TeamService {
-	return &TeamServiceImplementation{repo: repo}
+func NewTeamService(repo repositories.TeamRepository, projectRepo repositories.ProjectRepository, transactor repositories.Transactor, publisher events.Publisher, audit AuditRecorder) TeamService {
+	return &TeamServiceImplementation{repo: repo, projectRepo: projectRepo, transactor: transactor, events: publisher, audit: audit}
 }
 
 func (s *TeamServiceImplementation) CreateTeam(ctx context.Context, team *models.Team) error {
 	if team.Name == "" {
 		return fmt.Errorf("team name is required")
 	}
-	return s.repo.CreateTeam(ctx, team)
+	if err := ensureProjectWritable(ctx, s.projectRepo, team.ProjectID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateTeam(ctx, team); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityTeam, team.ID, nil, team); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.TeamCreated, team)
+	})
 }
 
 func (s *TeamServiceImplementation) GetTeamByID(ctx context.Context, id uint) (*models.Team, error) {
 	team, err := s.repo.GetTeamByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("team not found")
+		return nil, ErrTeamNotFound
 	}
 	return team, nil
 }
@@ -46,9 +67,63 @@ func (s *TeamServiceImplementation) UpdateTeam(ctx context.Context, team *models
 	if team.Name == "" {
 		return fmt.Errorf("team name is required")
 	}
-	return s.repo.UpdateTeam(ctx, team)
+
+	existing, err := s.repo.GetTeamByID(ctx, team.ID)
+	if err != nil {
+		return ErrTeamNotFound
+	}
+	if team.Version, err = expectVersion(team.Version, existing.Version); err != nil {
+		return err
+	}
+	// Teams can neither leave nor join an archived project
+	for _, projectID := range []uint{existing.ProjectID, team.ProjectID} {
+		if err := ensureProjectWritable(ctx, s.projectRepo, projectID); err != nil {
+			return err
+		}
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateTeam(ctx, team); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityTeam, team.ID, existing, team); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.TeamUpdated, team)
+	})
+}
+
+// DeleteTeam moves the team to the trash when it is at version, or any version when it is 0.
+func (s *TeamServiceImplementation) DeleteTeam(ctx context.Context, id uint, version uint) error {
+	team, err := s.repo.GetTeamByID(ctx, id)
+	if err != nil {
+		return ErrTeamNotFound
+	}
+	if _, err := expectVersion(version, team.Version); err != nil {
+		return err
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, team.ProjectID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteTeam(ctx, id); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityTeam, id, team, nil); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.TeamDeleted, team)
+	})
 }
 
-func (s *TeamServiceImplementation) DeleteTeam(ctx context.Context, id uint) error {
-	return s.repo.DeleteTeam(ctx, id)
+func (s *TeamServiceImplementation) publish(ctx context.Context, eventType events.Type, team *models.Team) error {
+	actorID, _ := middleware.UserIDFromContext(ctx)
+
+	return s.events.Publish(ctx, events.Event{
+		Type:      eventType,
+		ActorID:   actorID,
+		ProjectID: team.ProjectID,
+		Payload:   team,
+	})
 }
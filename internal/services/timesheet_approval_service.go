@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTimesheetNotFound    = errors.New("timesheet not found")
+	ErrInvalidTimesheetWeek = errors.New("invalid timesheet submission")
+	ErrTimesheetSubmitted   = errors.New("the timesheet is already submitted")
+	ErrTimesheetNotPending  = errors.New("only submitted timesheets can be reviewed")
+	ErrNotTimesheetApprover = errors.New("only the project owner can review its timesheets")
+)
+
+type TimesheetApprovalService interface {
+	SubmitTimesheet(ctx context.Context, projectID uint, week time.Time) (*models.TimesheetSubmission, error)
+	GetSubmissionByID(ctx context.Context, id uint) (*models.TimesheetSubmission, error)
+	GetMySubmissions(ctx context.Context) ([]models.TimesheetSubmission, error)
+	ApproveTimesheet(ctx context.Context, id uint, comment string) (*models.TimesheetSubmission, error)
+	RejectTimesheet(ctx context.Context, id uint, comment string) (*models.TimesheetSubmission, error)
+	GetPendingApprovals(ctx context.Context, projectID uint) (*models.PendingApprovals, error)
+}
+
+type TimesheetApprovalServiceImplementation struct {
+	repo        repositories.TimesheetSubmissionRepository
+	worklogRepo repositories.WorklogRepository
+	projectRepo repositories.ProjectRepository
+	transactor  repositories.Transactor
+	audit       AuditRecorder
+	now         func() time.Time
+}
+
+func NewTimesheetApprovalService(repo repositories.TimesheetSubmissionRepository, worklogRepo repositories.WorklogRepository, projectRepo repositories.ProjectRepository, transactor repositories.Transactor, audit AuditRecorder) TimesheetApprovalService {
+	return &TimesheetApprovalServiceImplementation{
+		repo:        repo,
+		worklogRepo: worklogRepo,
+		projectRepo: projectRepo,
+		transactor:  transactor,
+		audit:       audit,
+		now:         time.Now,
+	}
+}
+
+// SubmitTimesheet sends the time the current user logged on a project in the week
+// of the given day for approval. A rejected week can be submitted again.
+func (s *TimesheetApprovalServiceImplementation) SubmitTimesheet(ctx context.Context, projectID uint, week time.Time) (*models.TimesheetSubmission, error) {
+	if err := ensureProjectWritable(ctx, s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+	if week.IsZero() {
+		return nil, fmt.Errorf("%w: week_start is required", ErrInvalidTimesheetWeek)
+	}
+	start := weekStart(week)
+	if start.After(s.now()) {
+		return nil, fmt.Errorf("%w: the week has not started", ErrInvalidTimesheetWeek)
+	}
+
+	userID, _ := middleware.UserIDFromContext(ctx)
+	var submission *models.TimesheetSubmission
+	var worklogs []models.Worklog
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		worklogs, err = s.worklogRepo.GetWorklogs(ctx, repositories.WorklogFilter{UserID: userID, ProjectID: projectID, From: start, To: start.AddDate(0, 0, 7)})
+		if err != nil {
+			return err
+		}
+		if len(worklogs) == 0 {
+			return fmt.Errorf("%w: no time was logged on the project in the week of %s", ErrInvalidTimesheetWeek, start.Format(time.DateOnly))
+		}
+		totalMinutes := 0
+		for _, worklog := range worklogs {
+			totalMinutes += worklog.DurationMinutes
+		}
+
+		existing, err := s.repo.GetSubmission(ctx, userID, projectID, start)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if existing != nil && existing.Status != models.TimesheetStatusRejected {
+			return fmt.Errorf("%w for the week of %s", ErrTimesheetSubmitted, start.Format(time.DateOnly))
+		}
+
+		submission = &models.TimesheetSubmission{
+			UserID:       userID,
+			ProjectID:    projectID,
+			WeekStart:    start,
+			Status:       models.TimesheetStatusSubmitted,
+			TotalMinutes: totalMinutes,
+			SubmittedAt:  s.now().UTC(),
+		}
+		if existing == nil {
+			if err := s.repo.CreateSubmission(ctx, submission); err != nil {
+				return err
+			}
+			return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityTimesheet, submission.ID, nil, submission)
+		}
+
+		submission.ID = existing.ID
+		submission.CreatedAt = existing.CreatedAt
+		if err := s.repo.UpdateSubmission(ctx, submission); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityTimesheet, submission.ID, existing, submission)
+	})
+	if err != nil {
+		return nil, err
+	}
+	submission.Worklogs = worklogs
+	return submission, nil
+}
+
+// GetSubmissionByID returns a submitted timesheet with the worklogs of its week
+func (s *TimesheetApprovalServiceImplementation) GetSubmissionByID(ctx context.Context, id uint) (*models.TimesheetSubmission, error) {
+	submission, err := s.repo.GetSubmissionByID(ctx, id)
+	if err != nil {
+		return nil, ErrTimesheetNotFound
+	}
+	if submission.Worklogs, err = s.weekWorklogs(ctx, submission); err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+// GetMySubmissions returns the timesheets the current user submitted
+func (s *TimesheetApprovalServiceImplementation) GetMySubmissions(ctx context.Context) ([]models.TimesheetSubmission, error) {
+	userID, _ := middleware.UserIDFromContext(ctx)
+	return s.repo.GetSubmissions(ctx, repositories.TimesheetSubmissionFilter{UserID: userID})
+}
+
+// ApproveTimesheet approves a submitted timesheet, its worklogs stay locked
+func (s *TimesheetApprovalServiceImplementation) ApproveTimesheet(ctx context.Context, id uint, comment string) (*models.TimesheetSubmission, error) {
+	return s.review(ctx, id, models.TimesheetStatusApproved, comment)
+}
+
+// RejectTimesheet sends a submitted timesheet back to its user with a comment,
+// unlocking its worklogs
+func (s *TimesheetApprovalServiceImplementation) RejectTimesheet(ctx context.Context, id uint, comment string) (*models.TimesheetSubmission, error) {
+	if comment == "" {
+		return nil, fmt.Errorf("%w: a comment is required to reject a timesheet", ErrInvalidTimesheetWeek)
+	}
+	return s.review(ctx, id, models.TimesheetStatusRejected, comment)
+}
+
+// GetPendingApprovals returns the submitted timesheets of a project waiting for review,
+// oldest week first
+func (s *TimesheetApprovalServiceImplementation) GetPendingApprovals(ctx context.Context, projectID uint) (*models.PendingApprovals, error) {
+	if _, err := s.projectRepo.GetProjectStatus(ctx, projectID); err != nil {
+		return nil, ErrProjectNotFound
+	}
+	submissions, err := s.repo.GetSubmissions(ctx, repositories.TimesheetSubmissionFilter{ProjectID: projectID, Status: models.TimesheetStatusSubmitted})
+	if err != nil {
+		return nil, err
+	}
+
+	pending := &models.PendingApprovals{ProjectID: projectID, Count: len(submissions), Timesheets: submissions}
+	for _, submission := range submissions {
+		pending.TotalMinutes += submission.TotalMinutes
+	}
+	pending.TotalHours = minutesToHours(pending.TotalMinutes)
+	return pending, nil
+}
+
+func (s *TimesheetApprovalServiceImplementation) review(ctx context.Context, id uint, status, comment string) (*models.TimesheetSubmission, error) {
+	submission, err := s.repo.GetSubmissionByID(ctx, id)
+	if err != nil {
+		return nil, ErrTimesheetNotFound
+	}
+	project, err := s.projectRepo.GetProjectByID(ctx, submission.ProjectID)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+	reviewerID, _ := middleware.UserIDFromContext(ctx)
+	if !isProjectOwner(project, reviewerID) {
+		return nil, ErrNotTimesheetApprover
+	}
+	if submission.Status != models.TimesheetStatusSubmitted {
+		return nil, fmt.Errorf("%w, it is %s", ErrTimesheetNotPending, submission.Status)
+	}
+
+	before := *submission
+	reviewedAt := s.now().UTC()
+	submission.Status = status
+	submission.Comment = comment
+	submission.ReviewerID = &reviewerID
+	submission.ReviewedAt = &reviewedAt
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateSubmission(ctx, submission); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityTimesheet, submission.ID, &before, submission)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if submission.Worklogs, err = s.weekWorklogs(ctx, submission); err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+func (s *TimesheetApprovalServiceImplementation) weekWorklogs(ctx context.Context, submission *models.TimesheetSubmission) ([]models.Worklog, error) {
+	start := submission.WeekStart.UTC()
+	return s.worklogRepo.GetWorklogs(ctx, repositories.WorklogFilter{
+		UserID:    submission.UserID,
+		ProjectID: submission.ProjectID,
+		From:      start,
+		To:        start.AddDate(0, 0, 7),
+	})
+}
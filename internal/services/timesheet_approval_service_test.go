@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockTimesheetSubmissionRepository mocks the TimesheetSubmissionRepository for testing
+type MockTimesheetSubmissionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimesheetSubmissionRepository) CreateSubmission(ctx context.Context, submission *models.TimesheetSubmission) error {
+	args := m.Called(ctx, submission)
+	return args.Error(0)
+}
+
+func (m *MockTimesheetSubmissionRepository) GetSubmissionByID(ctx context.Context, id uint) (*models.TimesheetSubmission, error) {
+	args := m.Called(ctx, id)
+	submission, _ := args.Get(0).(*models.TimesheetSubmission)
+	return submission, args.Error(1)
+}
+
+func (m *MockTimesheetSubmissionRepository) GetSubmission(ctx context.Context, userID, projectID uint, weekStart time.Time) (*models.TimesheetSubmission, error) {
+	args := m.Called(ctx, userID, projectID, weekStart)
+	submission, _ := args.Get(0).(*models.TimesheetSubmission)
+	return submission, args.Error(1)
+}
+
+func (m *MockTimesheetSubmissionRepository) GetSubmissions(ctx context.Context, filter repositories.TimesheetSubmissionFilter) ([]models.TimesheetSubmission, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.TimesheetSubmission), args.Error(1)
+}
+
+func (m *MockTimesheetSubmissionRepository) UpdateSubmission(ctx context.Context, submission *models.TimesheetSubmission) error {
+	args := m.Called(ctx, submission)
+	return args.Error(0)
+}
+
+// openWeeks is a submission repository without submitted timesheets
+func openWeeks() *MockTimesheetSubmissionRepository {
+	submissions := new(MockTimesheetSubmissionRepository)
+	submissions.On("GetSubmission", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
+	return submissions
+}
+
+func TestSubmitTimesheet(t *testing.T) {
+	monday := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	logged := []models.Worklog{{ID: 1, DurationMinutes: 90}, {ID: 2, DurationMinutes: 45}}
+
+	testCases := []struct {
+		name        string
+		existing    *models.TimesheetSubmission
+		worklogs    []models.Worklog
+		expectedErr error
+	}{
+		{name: "First Submission", worklogs: logged},
+		{name: "Resubmitted After Rejection", existing: &models.TimesheetSubmission{ID: 3, Status: models.TimesheetStatusRejected, Comment: "Missing Friday"}, worklogs: logged},
+		{name: "Already Approved", existing: &models.TimesheetSubmission{ID: 3, Status: models.TimesheetStatusApproved}, worklogs: logged, expectedErr: ErrTimesheetSubmitted},
+		{name: "Nothing Logged", worklogs: []models.Worklog{}, expectedErr: ErrInvalidTimesheetWeek},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			worklogs := new(MockWorklogRepository)
+			worklogs.On("GetWorklogs", mock.Anything, repositories.WorklogFilter{UserID: 7, ProjectID: 1, From: monday, To: monday.AddDate(0, 0, 7)}).Return(tc.worklogs, nil)
+			submissions := new(MockTimesheetSubmissionRepository)
+			if tc.existing != nil {
+				submissions.On("GetSubmission", mock.Anything, uint(7), uint(1), monday).Return(tc.existing, nil)
+			} else {
+				submissions.On("GetSubmission", mock.Anything, uint(7), uint(1), monday).Return(nil, gorm.ErrRecordNotFound).Maybe()
+			}
+			submissions.On("CreateSubmission", mock.Anything, mock.Anything).Return(nil).Maybe()
+			submissions.On("UpdateSubmission", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			service := NewTimesheetApprovalService(submissions, worklogs, activeProjects(), new(MockTransactor), new(MockAuditRecorder)).(*TimesheetApprovalServiceImplementation)
+			service.now = func() time.Time { return monday.AddDate(0, 0, 5) }
+
+			// Any day of the week submits the week
+			submission, err := service.SubmitTimesheet(middleware.WithUserID(context.Background(), 7), 1, monday.AddDate(0, 0, 3))
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				submissions.AssertNotCalled(t, "CreateSubmission", mock.Anything, mock.Anything)
+				submissions.AssertNotCalled(t, "UpdateSubmission", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.TimesheetStatusSubmitted, submission.Status)
+			assert.Equal(t, monday, submission.WeekStart)
+			assert.Equal(t, 135, submission.TotalMinutes)
+			assert.Empty(t, submission.Comment)
+			if tc.existing != nil {
+				assert.Equal(t, tc.existing.ID, submission.ID)
+				submissions.AssertCalled(t, "UpdateSubmission", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestReviewTimesheet(t *testing.T) {
+	testCases := []struct {
+		name        string
+		reviewerID  uint
+		status      string
+		reject      bool
+		comment     string
+		expectedErr error
+	}{
+		{name: "Owner Approves", reviewerID: 1, status: models.TimesheetStatusSubmitted},
+		{name: "Owner Rejects With A Comment", reviewerID: 1, status: models.TimesheetStatusSubmitted, reject: true, comment: "Split the meeting"},
+		{name: "Rejecting Needs A Comment", reviewerID: 1, status: models.TimesheetStatusSubmitted, reject: true, expectedErr: ErrInvalidTimesheetWeek},
+		{name: "Only The Owner Reviews", reviewerID: 2, status: models.TimesheetStatusSubmitted, expectedErr: ErrNotTimesheetApprover},
+		{name: "Reviewed Once", reviewerID: 1, status: models.TimesheetStatusApproved, expectedErr: ErrTimesheetNotPending},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			submissions := new(MockTimesheetSubmissionRepository)
+			submissions.On("GetSubmissionByID", mock.Anything, uint(3)).Return(&models.TimesheetSubmission{ID: 3, UserID: 7, ProjectID: 5, Status: tc.status}, nil).Maybe()
+			submissions.On("UpdateSubmission", mock.Anything, mock.Anything).Return(nil).Maybe()
+			projects := new(MockProjectRepository)
+			projects.On("GetProjectByID", mock.Anything, uint(5)).Return(&models.Project{BaseModel: models.BaseModel{ID: 5}, OwnerID: 1}, nil).Maybe()
+			worklogs := new(MockWorklogRepository)
+			worklogs.On("GetWorklogs", mock.Anything, mock.Anything).Return([]models.Worklog{}, nil).Maybe()
+			audit := new(MockAuditRecorder)
+
+			service := NewTimesheetApprovalService(submissions, worklogs, projects, new(MockTransactor), audit)
+			ctx := middleware.WithUserID(context.Background(), tc.reviewerID)
+
+			var submission *models.TimesheetSubmission
+			var err error
+			if tc.reject {
+				submission, err = service.RejectTimesheet(ctx, 3, tc.comment)
+			} else {
+				submission, err = service.ApproveTimesheet(ctx, 3, tc.comment)
+			}
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				submissions.AssertNotCalled(t, "UpdateSubmission", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			expectedStatus := models.TimesheetStatusApproved
+			if tc.reject {
+				expectedStatus = models.TimesheetStatusRejected
+			}
+			assert.Equal(t, expectedStatus, submission.Status)
+			assert.Equal(t, tc.comment, submission.Comment)
+			require.NotNil(t, submission.ReviewerID)
+			assert.Equal(t, tc.reviewerID, *submission.ReviewerID)
+			require.Len(t, audit.Entries, 1)
+			assert.Contains(t, audit.Entries[0].Changes, "status")
+		})
+	}
+}
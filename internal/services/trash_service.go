@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/storage"
+	"example/project-management-system/internal/utils/helpers"
+	"example/project-management-system/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotInTrash    = errors.New("entity is not in the trash")
+	ErrParentInTrash = errors.New("the entity it belongs to is in the trash, restore that first")
+)
+
+// trashNameLength is how much of a comment is shown as its name in the trash
+const trashNameLength = 80
+
+// TrashSettings configures how long deleted entities can be restored
+type TrashSettings struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+type TrashService interface {
+	GetTrash(ctx context.Context, entityType string, page, pageSize int) ([]models.TrashItem, int64, error)
+	Restore(ctx context.Context, entityType string, id uint) error
+	PurgeExpired(ctx context.Context, now time.Time) error
+	RunPurge(ctx context.Context)
+}
+
+type TrashServiceImplementation struct {
+	repo       repositories.TrashRepository
+	store      storage.BlobStore
+	transactor repositories.Transactor
+	audit      AuditRecorder
+	settings   TrashSettings
+	log        logger.Logger
+}
+
+func NewTrashService(
+	repo repositories.TrashRepository,
+	store storage.BlobStore,
+	transactor repositories.Transactor,
+	audit AuditRecorder,
+	settings TrashSettings,
+	log logger.Logger,
+) TrashService {
+	return &TrashServiceImplementation{
+		repo:       repo,
+		store:      store,
+		transactor: transactor,
+		audit:      audit,
+		settings:   settings,
+		log:        log,
+	}
+}
+
+// GetTrash returns the deleted entities of one type, or of every type when entityType is empty.
+func (s *TrashServiceImplementation) GetTrash(ctx context.Context, entityType string, page, pageSize int) ([]models.TrashItem, int64, error) {
+	if entityType != "" && !helpers.Contains(repositories.TrashEntityTypes, entityType) {
+		return nil, 0, fmt.Errorf("invalid entity type %q", entityType)
+	}
+
+	items, total, err := s.repo.GetTrash(ctx, entityType, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range items {
+		items[i].Name = truncate(items[i].Name, trashNameLength)
+		items[i].PurgeAt = items[i].DeletedAt.Add(s.settings.Retention)
+	}
+	return items, total, nil
+}
+
+// Restore takes an entity out of the trash along with the children deleted with it.
+// A task, team or comment can only be restored while its parent is not in the trash.
+func (s *TrashServiceImplementation) Restore(ctx context.Context, entityType string, id uint) error {
+	var deletedAt gorm.DeletedAt
+	var parent interface{}
+	var parentID uint
+
+	switch entityType {
+	case models.AuditEntityUser:
+		var user models.User
+		deletedAt = s.find(ctx, &user, id, &user.DeletedAt)
+	case models.AuditEntityProject:
+		var project models.Project
+		deletedAt = s.find(ctx, &project, id, &project.DeletedAt)
+	case models.AuditEntityTask:
+		var task models.Task
+		deletedAt = s.find(ctx, &task, id, &task.DeletedAt)
+		parent, parentID = &models.Project{}, task.ProjectID
+	case models.AuditEntityTeam:
+		var team models.Team
+		deletedAt = s.find(ctx, &team, id, &team.DeletedAt)
+		parent, parentID = &models.Project{}, team.ProjectID
+	case models.AuditEntityComment:
+		var comment models.Comment
+		deletedAt = s.find(ctx, &comment, id, &comment.DeletedAt)
+		parent, parentID = &models.Task{}, comment.TaskID
+	default:
+		return fmt.Errorf("invalid entity type %q", entityType)
+	}
+	if !deletedAt.Valid {
+		return ErrNotInTrash
+	}
+
+	if parentID != 0 {
+		if err := s.repo.FindWithDeleted(ctx, parent, parentID); err == nil && isDeleted(parent) {
+			return ErrParentInTrash
+		}
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Restore(ctx, entityType, id, deletedAt.Time); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionRestore, entityType, id, nil, nil)
+	})
+}
+
+// PurgeExpired permanently deletes what has been in the trash for longer than the retention.
+// The blobs of the purged attachments are deleted once the purge is committed.
+func (s *TrashServiceImplementation) PurgeExpired(ctx context.Context, now time.Time) error {
+	var blobKeys []string
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		purged, keys, err := s.repo.Purge(ctx, now.Add(-s.settings.Retention))
+		if err != nil {
+			return err
+		}
+		blobKeys = keys
+
+		for _, item := range purged {
+			before := map[string]string{"name": truncate(item.Name, trashNameLength)}
+			if err := s.audit.Record(ctx, models.AuditActionPurge, item.EntityType, item.EntityID, before, nil); err != nil {
+				return err
+			}
+		}
+		if len(purged) > 0 {
+			s.log.Info("Purged the trash", "entities", len(purged))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The attachments are gone either way, a blob left behind is never served
+	for _, key := range blobKeys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			s.log.Error("Failed to delete a purged attachment", "key", key, "error", err)
+		}
+	}
+	return nil
+}
+
+// RunPurge empties expired entities from the trash every purge interval until ctx is cancelled.
+func (s *TrashServiceImplementation) RunPurge(ctx context.Context) {
+	ticker := time.NewTicker(s.settings.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.PurgeExpired(ctx, now); err != nil {
+				s.log.Error("Failed to purge the trash", "error", err)
+			}
+		}
+	}
+}
+
+// find loads an entity including deleted ones and returns its deletion time,
+// which is not valid when it could not be found.
+func (s *TrashServiceImplementation) find(ctx context.Context, dest interface{}, id uint, deletedAt *gorm.DeletedAt) gorm.DeletedAt {
+	if err := s.repo.FindWithDeleted(ctx, dest, id); err != nil {
+		return gorm.DeletedAt{}
+	}
+	return *deletedAt
+}
+
+func isDeleted(entity interface{}) bool {
+	switch entity := entity.(type) {
+	case *models.Project:
+		return entity.DeletedAt.Valid
+	case *models.Task:
+		return entity.DeletedAt.Valid
+	}
+	return false
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length]) + "…"
+}
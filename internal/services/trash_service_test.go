@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/storage"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockTrashRepository mocks the TrashRepository for testing, FindWithDeleted copies
+// the entity registered for its type and ID into dest
+type MockTrashRepository struct {
+	mock.Mock
+	entities map[uint]interface{}
+}
+
+func (m *MockTrashRepository) GetTrash(ctx context.Context, entityType string, page, pageSize int) ([]models.TrashItem, int64, error) {
+	args := m.Called(ctx, entityType, page, pageSize)
+	items, _ := args.Get(0).([]models.TrashItem)
+	return items, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTrashRepository) FindWithDeleted(ctx context.Context, dest interface{}, id uint) error {
+	switch dest := dest.(type) {
+	case *models.Project:
+		if project, ok := m.entities[id].(models.Project); ok {
+			*dest = project
+			return nil
+		}
+	case *models.Task:
+		if task, ok := m.entities[id].(models.Task); ok {
+			*dest = task
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (m *MockTrashRepository) Restore(ctx context.Context, entityType string, id uint, deletedAt time.Time) error {
+	args := m.Called(ctx, entityType, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) Purge(ctx context.Context, deletedBefore time.Time) ([]models.TrashItem, []string, error) {
+	args := m.Called(ctx, deletedBefore)
+	items, _ := args.Get(0).([]models.TrashItem)
+	keys, _ := args.Get(1).([]string)
+	return items, keys, args.Error(2)
+}
+
+func TestRestoreTask(t *testing.T) {
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	deleted := gorm.DeletedAt{Time: deletedAt, Valid: true}
+
+	testCases := []struct {
+		name        string
+		entities    map[uint]interface{}
+		expectedErr error
+	}{
+		{
+			name: "Successful Restore",
+			entities: map[uint]interface{}{
+				1: models.Task{BaseModel: models.BaseModel{ID: 1, DeletedAt: deleted}, ProjectID: 2},
+				2: models.Project{BaseModel: models.BaseModel{ID: 2}},
+			},
+		},
+		{
+			name: "Project In Trash",
+			entities: map[uint]interface{}{
+				1: models.Task{BaseModel: models.BaseModel{ID: 1, DeletedAt: deleted}, ProjectID: 2},
+				2: models.Project{BaseModel: models.BaseModel{ID: 2, DeletedAt: deleted}},
+			},
+			expectedErr: ErrParentInTrash,
+		},
+		{
+			name: "Not Deleted",
+			entities: map[uint]interface{}{
+				1: models.Task{BaseModel: models.BaseModel{ID: 1}, ProjectID: 2},
+			},
+			expectedErr: ErrNotInTrash,
+		},
+		{
+			name:        "Not Found",
+			entities:    map[uint]interface{}{},
+			expectedErr: ErrNotInTrash,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &MockTrashRepository{entities: tc.entities}
+			if tc.expectedErr == nil {
+				repo.On("Restore", mock.Anything, models.AuditEntityTask, uint(1), deletedAt).Return(nil)
+			}
+			audit := new(MockAuditRecorder)
+			service := NewTrashService(repo, nil, new(MockTransactor), audit, TrashSettings{Retention: time.Hour}, &MockLogger{})
+
+			err := service.Restore(context.Background(), models.AuditEntityTask, 1)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, audit.Entries)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, audit.Entries, 1)
+				assert.Equal(t, models.AuditActionRestore, audit.Entries[0].Action)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo := &MockTrashRepository{}
+	repo.On("Purge", mock.Anything, now.Add(-30*24*time.Hour)).Return([]models.TrashItem{
+		{EntityType: models.AuditEntityProject, EntityID: 3, Name: "Apollo"},
+		{EntityType: models.AuditEntityTask, EntityID: 8, Name: "Launch"},
+	}, []string{"tasks/8/blob"}, nil)
+	audit := new(MockAuditRecorder)
+	dir := t.TempDir()
+	store := storage.NewLocalStore(dir)
+	require.NoError(t, store.Put(context.Background(), "tasks/8/blob", strings.NewReader("x"), 1, "text/plain"))
+	service := NewTrashService(repo, store, new(MockTransactor), audit, TrashSettings{Retention: 30 * 24 * time.Hour}, &MockLogger{})
+
+	err := service.PurgeExpired(context.Background(), now)
+
+	assert.NoError(t, err)
+	_, err = store.Get(context.Background(), "tasks/8/blob")
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+	blobs, err := os.ReadDir(filepath.Join(dir, "tasks", "8"))
+	require.NoError(t, err)
+	assert.Empty(t, blobs)
+	assert.Len(t, audit.Entries, 2)
+	assert.Equal(t, models.AuditActionPurge, audit.Entries[0].Action)
+	assert.Equal(t, models.FieldChange{Before: "Apollo", After: nil}, audit.Entries[0].Changes["name"])
+	repo.AssertExpectations(t)
+}
+
+func TestGetTrashSetsPurgeTime(t *testing.T) {
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := &MockTrashRepository{}
+	repo.On("GetTrash", mock.Anything, models.AuditEntityComment, 1, 10).Return([]models.TrashItem{
+		{EntityType: models.AuditEntityComment, EntityID: 4, Name: "Short comment", DeletedAt: deletedAt},
+	}, int64(1), nil)
+	service := NewTrashService(repo, nil, new(MockTransactor), new(MockAuditRecorder), TrashSettings{Retention: 48 * time.Hour}, &MockLogger{})
+
+	items, total, err := service.GetTrash(context.Background(), models.AuditEntityComment, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, deletedAt.Add(48*time.Hour), items[0].PurgeAt)
+
+	_, _, err = service.GetTrash(context.Background(), "webhook", 1, 10)
+	assert.Error(t, err)
+}
@@ -1,20 +1,63 @@
 package services
 
-import "example/project-management-system/internal/repositories"
+import (
+	"context"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+)
 
 type UserProjectService interface {
-	AddUserToProject(userID uint, projectID uint) error
+	AddUserToProject(ctx context.Context, userID uint, projectID uint) error
+	RemoveUserFromProject(ctx context.Context, userID uint, projectID uint) error
 }
 
 type UserProjectServiceImplementation struct {
 	userProjectRepository repositories.UserProjectRepository
+	transactor            repositories.Transactor
+	events                events.Publisher
+	audit                 AuditRecorder
 }
 
-func NewUserProjectService(userProjectRepository repositories.UserProjectRepository) UserProjectService {
-	return &UserProjectServiceImplementation{userProjectRepository: userProjectRepository}
+func NewUserProjectService(userProjectRepository repositories.UserProjectRepository, transactor repositories.Transactor, publisher events.Publisher, audit AuditRecorder) UserProjectService {
+	return &UserProjectServiceImplementation{userProjectRepository: userProjectRepository, transactor: transactor, events: publisher, audit: audit}
 }
 
 // AddUserToProject adds a user to a project.
-func (service *UserProjectServiceImplementation) AddUserToProject(userID uint, projectID uint) error {
-	return service.userProjectRepository.AddUserToProject(userID, projectID)
+func (service *UserProjectServiceImplementation) AddUserToProject(ctx context.Context, userID uint, projectID uint) error {
+	return service.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := service.userProjectRepository.AddUserToProject(ctx, userID, projectID); err != nil {
+			return err
+		}
+		member := map[string]uint{"user_id": userID}
+		if err := service.audit.Record(ctx, models.AuditActionMemberAdded, models.AuditEntityProject, projectID, nil, member); err != nil {
+			return err
+		}
+		return service.publish(ctx, events.ProjectMemberAdded, userID, projectID)
+	})
+}
+
+// RemoveUserFromProject removes a user from a project.
+func (service *UserProjectServiceImplementation) RemoveUserFromProject(ctx context.Context, userID uint, projectID uint) error {
+	return service.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := service.userProjectRepository.RemoveUserFromProject(ctx, userID, projectID); err != nil {
+			return err
+		}
+		member := map[string]uint{"user_id": userID}
+		if err := service.audit.Record(ctx, models.AuditActionMemberRemoved, models.AuditEntityProject, projectID, member, nil); err != nil {
+			return err
+		}
+		return service.publish(ctx, events.ProjectMemberRemoved, userID, projectID)
+	})
+}
+
+func (service *UserProjectServiceImplementation) publish(ctx context.Context, eventType events.Type, userID uint, projectID uint) error {
+	actorID, _ := middleware.UserIDFromContext(ctx)
+	return service.events.Publish(ctx, events.Event{
+		Type:      eventType,
+		ActorID:   actorID,
+		UserID:    userID,
+		ProjectID: projectID,
+	})
 }
@@ -2,37 +2,98 @@ package services
 
 import (
 	"context"
+	"errors"
 	"example/project-management-system/internal/models"
 	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/utils/helpers"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
 )
 
+var ErrUserChangeForbidden = errors.New("users can only be changed by themselves or an administrator")
+
 // UserService defines the methods for performing business operations on Users.
 type UserService interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByID(ctx context.Context, id uint) (*models.User, error)
 	GetAllUsers(ctx context.Context, page, pageSize int) ([]models.User, int64, error)
-	DeleteUser(ctx context.Context, id uint) error
+	UpdateUser(ctx context.Context, user *models.User) error
+	DeleteUser(ctx context.Context, id uint, version uint) error
 }
 
 // UserServiceImplementation is an implementation of the UserService.
 type UserServiceImplementation struct {
-	userRepo repositories.UserRepository
+	userRepo   repositories.UserRepository
+	transactor repositories.Transactor
+	audit      AuditRecorder
 }
 
-func NewUserService(userRepo repositories.UserRepository) UserService {
-	return &UserServiceImplementation{userRepo: userRepo}
+func NewUserService(userRepo repositories.UserRepository, transactor repositories.Transactor, audit AuditRecorder) UserService {
+	return &UserServiceImplementation{userRepo: userRepo, transactor: transactor, audit: audit}
 }
 
 func (s *UserServiceImplementation) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
 	return s.userRepo.GetUserByID(ctx, id)
 }
 
-func (s *UserServiceImplementation) DeleteUser(ctx context.Context, id uint) error {
-	return s.userRepo.DeleteUser(ctx, id)
+// UpdateUser saves the profile of the user. Users can change themselves, and
+// administrators anyone. The username, password and role are kept.
+func (s *UserServiceImplementation) UpdateUser(ctx context.Context, user *models.User) error {
+	if !helpers.IsValidEmail(user.Email) {
+		return fmt.Errorf("invalid email address")
+	}
+
+	existing, err := s.userRepo.GetUserByID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	actorID, _ := middleware.UserIDFromContext(ctx)
+	if actorID != user.ID {
+		actor, err := s.userRepo.GetUserByID(ctx, actorID)
+		if err != nil || actor.Role != models.UserRoleAdmin {
+			return ErrUserChangeForbidden
+		}
+	}
+	if user.Version, err = expectVersion(user.Version, existing.Version); err != nil {
+		return err
+	}
+	user.Username = existing.Username
+	user.Password = existing.Password
+	user.Role = existing.Role
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityUser, user.ID, existing, user)
+	})
+}
+
+// DeleteUser moves the user to the trash when it is at version, or any version when it is 0.
+func (s *UserServiceImplementation) DeleteUser(ctx context.Context, id uint, version uint) error {
+	user, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	if _, err := expectVersion(version, user.Version); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.DeleteUser(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityUser, id, user, nil)
+	})
 }
 
 func (s *UserServiceImplementation) CreateUser(ctx context.Context, user *models.User) error {
-	return s.userRepo.CreateUser(ctx, user)
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityUser, user.ID, nil, user)
+	})
 }
 
 func (s *UserServiceImplementation) GetAllUsers(ctx context.Context, page, pageSize int) ([]models.User, int64, error) {
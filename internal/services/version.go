@@ -0,0 +1,19 @@
+package services
+
+import "example/project-management-system/internal/repositories"
+
+// ErrVersionConflict is returned when an entity was changed since the version a
+// change is based on
+var ErrVersionConflict = repositories.ErrVersionConflict
+
+// expectVersion returns the version of an entity a change based on version applies
+// to. Version 0 applies to the current version, others only to themselves.
+func expectVersion(version, current uint) (uint, error) {
+	if version == 0 {
+		return current, nil
+	}
+	if version != current {
+		return 0, ErrVersionConflict
+	}
+	return version, nil
+}
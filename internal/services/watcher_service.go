@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+)
+
+type WatcherService interface {
+	Watch(ctx context.Context, taskID uint) ([]models.User, error)
+	Unwatch(ctx context.Context, taskID uint) ([]models.User, error)
+	GetWatchers(ctx context.Context, taskID uint) ([]models.User, error)
+	GetWatchedTasks(ctx context.Context, userID uint, page, pageSize int) ([]models.Task, int64, error)
+}
+
+type WatcherServiceImplementation struct {
+	repo     repositories.WatcherRepository
+	taskRepo repositories.TaskRepository
+}
+
+func NewWatcherService(repo repositories.WatcherRepository, taskRepo repositories.TaskRepository) WatcherService {
+	return &WatcherServiceImplementation{repo: repo, taskRepo: taskRepo}
+}
+
+// Watch makes the current user a watcher of the task and returns its watchers
+func (s *WatcherServiceImplementation) Watch(ctx context.Context, taskID uint) ([]models.User, error) {
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+	userID, _ := middleware.UserIDFromContext(ctx)
+	if err := s.repo.AddWatchers(ctx, taskID, []uint{userID}); err != nil {
+		return nil, err
+	}
+	return s.repo.GetWatchers(ctx, taskID)
+}
+
+// Unwatch stops the current user from watching the task and returns its watchers
+func (s *WatcherServiceImplementation) Unwatch(ctx context.Context, taskID uint) ([]models.User, error) {
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+	userID, _ := middleware.UserIDFromContext(ctx)
+	if err := s.repo.RemoveWatcher(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetWatchers(ctx, taskID)
+}
+
+func (s *WatcherServiceImplementation) GetWatchers(ctx context.Context, taskID uint) ([]models.User, error) {
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+	return s.repo.GetWatchers(ctx, taskID)
+}
+
+// GetWatchedTasks returns the tasks the user watches, most recently updated first
+func (s *WatcherServiceImplementation) GetWatchedTasks(ctx context.Context, userID uint, page, pageSize int) ([]models.Task, int64, error) {
+	return s.repo.GetWatchedTasks(ctx, userID, page, pageSize)
+}
+
+// publishTaskChange announces a change to the task, to its watchers
+func publishTaskChange(ctx context.Context, publisher events.Publisher, watcherRepo repositories.WatcherRepository, eventType events.Type, task *models.Task) error {
+	watchers, err := watcherRepo.GetWatcherIDs(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	actorID, _ := middleware.UserIDFromContext(ctx)
+
+	return publisher.Publish(ctx, events.Event{
+		Type:       eventType,
+		ActorID:    actorID,
+		Recipients: watchers,
+		ProjectID:  task.ProjectID,
+		TaskID:     task.ID,
+		Data:       map[string]string{"task_title": task.Title},
+		Payload:    task,
+	})
+}
+
+// nonZero returns the user IDs that are set
+func nonZero(userIDs ...uint) []uint {
+	set := make([]uint, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID != 0 {
+			set = append(set, userID)
+		}
+	}
+	return set
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/pkg/middleware"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockWatcherRepository mocks the WatcherRepository for testing
+type MockWatcherRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatcherRepository) AddWatchers(ctx context.Context, taskID uint, userIDs []uint) error {
+	args := m.Called(ctx, taskID, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockWatcherRepository) RemoveWatcher(ctx context.Context, taskID, userID uint) error {
+	args := m.Called(ctx, taskID, userID)
+	return args.Error(0)
+}
+
+func (m *MockWatcherRepository) GetWatcherIDs(ctx context.Context, taskID uint) ([]uint, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockWatcherRepository) GetWatchers(ctx context.Context, taskID uint) ([]models.User, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockWatcherRepository) GetWatchedTasks(ctx context.Context, userID uint, page, pageSize int) ([]models.Task, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	return args.Get(0).([]models.Task), args.Get(1).(int64), args.Error(2)
+}
+
+// noWatchers is a watcher repository in which no task has watchers
+func noWatchers() *MockWatcherRepository {
+	watchers := new(MockWatcherRepository)
+	watchers.On("AddWatchers", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	watchers.On("GetWatcherIDs", mock.Anything, mock.Anything).Return([]uint{}, nil).Maybe()
+	return watchers
+}
+
+func TestCreateTaskAddsWatchers(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("CreateTask", mock.Anything, mock.Anything).Return(nil)
+	watchers := new(MockWatcherRepository)
+	watchers.On("AddWatchers", mock.Anything, mock.Anything, []uint{4, 5}).Return(nil)
+	service := NewTaskService(mockRepo, activeProjects(), noCustomFields(), watchers, new(MockTransactor), new(MockPublisher), new(MockAuditRecorder))
+
+	err := service.CreateTask(middleware.WithUserID(context.Background(), 4), &models.Task{Title: "Task", ProjectID: 1, AssignedTo: 5})
+
+	require.NoError(t, err)
+	watchers.AssertExpectations(t)
+}
+
+func TestTaskChangesNotifyWatchers(t *testing.T) {
+	existing := &models.Task{BaseModel: models.BaseModel{ID: 1}, Title: "Task", Status: models.TaskStatusTodo, ProjectID: 1, AssignedTo: 2}
+
+	testCases := []struct {
+		name               string
+		update             models.Task
+		expectedTypes      []events.Type
+		expectedRecipients [][]uint
+	}{
+		{
+			name:               "Edit",
+			update:             models.Task{Title: "Renamed", Status: models.TaskStatusTodo, AssignedTo: 2},
+			expectedTypes:      []events.Type{events.TaskUpdated},
+			expectedRecipients: [][]uint{{2, 4, 6}},
+		},
+		{
+			name:               "Status Change",
+			update:             models.Task{Title: "Task", Status: models.TaskStatusDone, AssignedTo: 2},
+			expectedTypes:      []events.Type{events.TaskStatusChanged, events.TaskUpdated},
+			expectedRecipients: [][]uint{{2, 4, 6}, nil},
+		},
+		{
+			name:               "Reassignment",
+			update:             models.Task{Title: "Task", Status: models.TaskStatusTodo, AssignedTo: 6},
+			expectedTypes:      []events.Type{events.TaskAssigned, events.TaskUpdated},
+			expectedRecipients: [][]uint{nil, {2, 4}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			update := tc.update
+			update.ID = existing.ID
+			update.ProjectID = existing.ProjectID
+
+			mockRepo := new(MockTaskRepository)
+			mockRepo.On("GetTaskByID", mock.Anything, uint(1)).Return(existing, nil)
+			mockRepo.On("UpdateTask", mock.Anything, mock.Anything).Return(nil)
+			watchers := new(MockWatcherRepository)
+			watchers.On("AddWatchers", mock.Anything, uint(1), []uint{6}).Return(nil).Maybe()
+			watchers.On("GetWatcherIDs", mock.Anything, uint(1)).Return([]uint{2, 4, 6}, nil)
+			publisher := new(MockPublisher)
+			service := NewTaskService(mockRepo, activeProjects(), noCustomFields(), watchers, new(MockTransactor), publisher, new(MockAuditRecorder))
+
+			err := service.UpdateTask(middleware.WithUserID(context.Background(), 4), &update)
+
+			require.NoError(t, err)
+			require.Len(t, publisher.Events, len(tc.expectedTypes))
+			for i, event := range publisher.Events {
+				assert.Equal(t, tc.expectedTypes[i], event.Type)
+				assert.Equal(t, tc.expectedRecipients[i], event.Recipients)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tasks := new(MockTaskRepository)
+	tasks.On("GetTaskByID", mock.Anything, uint(1)).Return(&models.Task{BaseModel: models.BaseModel{ID: 1}}, nil)
+	tasks.On("GetTaskByID", mock.Anything, uint(2)).Return((*models.Task)(nil), assert.AnError)
+	watchers := new(MockWatcherRepository)
+	watchers.On("AddWatchers", mock.Anything, uint(1), []uint{7}).Return(nil)
+	watchers.On("RemoveWatcher", mock.Anything, uint(1), uint(7)).Return(nil)
+	watchers.On("GetWatchers", mock.Anything, uint(1)).Return([]models.User{{BaseModel: models.BaseModel{ID: 7}}}, nil).Once()
+	watchers.On("GetWatchers", mock.Anything, uint(1)).Return([]models.User{}, nil).Once()
+	service := NewWatcherService(watchers, tasks)
+	ctx := middleware.WithUserID(context.Background(), 7)
+
+	users, err := service.Watch(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []models.User{{BaseModel: models.BaseModel{ID: 7}}}, users)
+
+	users, err = service.Unwatch(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	_, err = service.Watch(ctx, 2)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	watchers.AssertExpectations(t)
+}
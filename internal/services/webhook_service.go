@@ -0,0 +1,478 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/utils/helpers"
+	"example/project-management-system/pkg/logger"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrNotProjectOwner         = errors.New("only the project owner can manage its webhooks")
+)
+
+// WebhookEventTypes are the event types webhooks can subscribe to
+var WebhookEventTypes = []events.Type{
+	events.TaskCreated,
+	events.TaskUpdated,
+	events.TaskDeleted,
+	events.CommentCreated,
+	events.CommentUpdated,
+	events.CommentDeleted,
+	events.TeamCreated,
+	events.TeamUpdated,
+	events.TeamDeleted,
+	events.ProjectCreated,
+	events.ProjectUpdated,
+	events.ProjectDeleted,
+}
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+
+	webhookBatchSize       = 50
+	webhookPollInterval    = 5 * time.Second
+	webhookMaxResponseBody = 1024
+)
+
+// WebhookSettings configures retries and when a failing webhook is disabled
+type WebhookSettings struct {
+	MaxAttempts  int           // attempts per delivery before it fails
+	BackoffBase  time.Duration // wait before the first retry, doubled for every further one
+	DisableAfter int           // consecutive failed deliveries before the webhook is disabled
+	Timeout      time.Duration
+}
+
+// WebhookPayload is the JSON body posted to webhook endpoints
+type WebhookPayload struct {
+	Event      events.Type `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	ActorID    uint        `json:"actor_id"`
+	ProjectID  uint        `json:"project_id"`
+	Data       interface{} `json:"data"`
+}
+
+type WebhookService interface {
+	HandleEvent(ctx context.Context, event events.Event) error
+	CreateWebhook(ctx context.Context, userID uint, webhook *models.Webhook) error
+	GetWebhooksByProject(ctx context.Context, userID, projectID uint) ([]models.Webhook, error)
+	GetWebhookByID(ctx context.Context, userID, id uint) (*models.Webhook, error)
+	UpdateWebhook(ctx context.Context, userID uint, webhook *models.Webhook) error
+	DeleteWebhook(ctx context.Context, userID, id uint) error
+	GetDeliveries(ctx context.Context, userID, webhookID uint, page, pageSize int) ([]models.WebhookDelivery, int64, error)
+	Redeliver(ctx context.Context, userID, webhookID, deliveryID uint) (*models.WebhookDelivery, error)
+	ProcessDueDeliveries(ctx context.Context, now time.Time) error
+	RunDeliveries(ctx context.Context)
+}
+
+type WebhookServiceImplementation struct {
+	repo        repositories.WebhookRepository
+	projectRepo repositories.ProjectRepository
+	transactor  repositories.Transactor
+	audit       AuditRecorder
+	client      *http.Client
+	settings    WebhookSettings
+	log         logger.Logger
+	wake        chan struct{}
+}
+
+func NewWebhookService(
+	repo repositories.WebhookRepository,
+	projectRepo repositories.ProjectRepository,
+	transactor repositories.Transactor,
+	audit AuditRecorder,
+	settings WebhookSettings,
+	log logger.Logger,
+) WebhookService {
+	return &WebhookServiceImplementation{
+		repo:        repo,
+		projectRepo: projectRepo,
+		transactor:  transactor,
+		audit:       audit,
+		client:      &http.Client{Timeout: settings.Timeout},
+		settings:    settings,
+		log:         log,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// HandleEvent queues a delivery of the event for every active webhook of its project subscribed to it.
+func (s *WebhookServiceImplementation) HandleEvent(ctx context.Context, event events.Event) error {
+	if !isWebhookEventType(event.Type) || event.ProjectID == 0 {
+		return nil
+	}
+
+	webhooks, err := s.repo.GetWebhooksByProject(ctx, event.ProjectID, true)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	now := time.Now()
+	for _, webhook := range webhooks {
+		if len(webhook.Events) > 0 && !helpers.Contains(webhook.Events, string(event.Type)) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(WebhookPayload{
+				Event:      event.Type,
+				OccurredAt: event.OccurredAt,
+				ActorID:    event.ActorID,
+				ProjectID:  event.ProjectID,
+				Data:       event.Payload,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to encode webhook payload: %w", err)
+			}
+		}
+
+		err := s.repo.CreateDelivery(ctx, &models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventType:     string(event.Type),
+			Payload:       string(payload),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if payload != nil {
+		s.notify()
+	}
+	return nil
+}
+
+func (s *WebhookServiceImplementation) CreateWebhook(ctx context.Context, userID uint, webhook *models.Webhook) error {
+	if err := s.authorize(ctx, userID, webhook.ProjectID); err != nil {
+		return err
+	}
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+	webhook.ID = 0
+	webhook.Active = true
+	webhook.ConsecutiveFailures = 0
+	webhook.DisabledAt = nil
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateWebhook(ctx, webhook); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityWebhook, webhook.ID, nil, webhook)
+	})
+}
+
+func (s *WebhookServiceImplementation) GetWebhooksByProject(ctx context.Context, userID, projectID uint) ([]models.Webhook, error) {
+	if err := s.authorize(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.repo.GetWebhooksByProject(ctx, projectID, false)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookServiceImplementation) GetWebhookByID(ctx context.Context, userID, id uint) (*models.Webhook, error) {
+	webhook, err := s.getOwnedWebhook(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// UpdateWebhook changes the URL, event filter, secret or active flag of a webhook.
+// Reactivating a disabled webhook resets its failure count.
+func (s *WebhookServiceImplementation) UpdateWebhook(ctx context.Context, userID uint, webhook *models.Webhook) error {
+	existing, err := s.getOwnedWebhook(ctx, userID, webhook.ID)
+	if err != nil {
+		return err
+	}
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+
+	before := *existing
+	existing.URL = webhook.URL
+	existing.Events = webhook.Events
+	if webhook.Secret != "" {
+		existing.Secret = webhook.Secret
+	}
+	if webhook.Active && !existing.Active {
+		existing.ConsecutiveFailures = 0
+		existing.DisabledAt = nil
+	}
+	existing.Active = webhook.Active
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateWebhook(ctx, existing); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityWebhook, existing.ID, &before, existing)
+	})
+	if err != nil {
+		return err
+	}
+
+	*webhook = *existing
+	webhook.Secret = ""
+	return nil
+}
+
+func (s *WebhookServiceImplementation) DeleteWebhook(ctx context.Context, userID, id uint) error {
+	webhook, err := s.getOwnedWebhook(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteWebhook(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityWebhook, id, webhook, nil)
+	})
+}
+
+func (s *WebhookServiceImplementation) GetDeliveries(ctx context.Context, userID, webhookID uint, page, pageSize int) ([]models.WebhookDelivery, int64, error) {
+	if _, err := s.getOwnedWebhook(ctx, userID, webhookID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.GetDeliveriesByWebhook(ctx, webhookID, page, pageSize)
+}
+
+// Redeliver sends the payload of a previous delivery again right away, as a new delivery.
+// It is attempted even if the webhook was disabled.
+func (s *WebhookServiceImplementation) Redeliver(ctx context.Context, userID, webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	webhook, err := s.getOwnedWebhook(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.repo.GetDeliveryByID(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	now := time.Now()
+	delivery := &models.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventType:     previous.EventType,
+		Payload:       previous.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	}
+	if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	delivery.Webhook = *webhook
+	if err := s.attempt(ctx, delivery, now); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// ProcessDueDeliveries attempts every pending delivery whose next attempt is due.
+func (s *WebhookServiceImplementation) ProcessDueDeliveries(ctx context.Context, now time.Time) error {
+	for {
+		deliveries, err := s.repo.GetDueDeliveries(ctx, now, webhookBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range deliveries {
+			if err := s.attempt(ctx, &deliveries[i], now); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < webhookBatchSize {
+			return nil
+		}
+	}
+}
+
+// RunDeliveries sends queued deliveries as they come in and retries failed ones
+// when their backoff expires, until ctx is cancelled.
+func (s *WebhookServiceImplementation) RunDeliveries(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+
+		if err := s.ProcessDueDeliveries(ctx, time.Now()); err != nil {
+			s.log.Error("Failed to process webhook deliveries", "error", err)
+		}
+	}
+}
+
+// attempt posts the delivery once and records the outcome, scheduling a retry
+// or failing the delivery and possibly disabling its webhook.
+func (s *WebhookServiceImplementation) attempt(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) error {
+	webhook := &delivery.Webhook
+
+	status, body, sendErr := s.send(ctx, webhook, delivery)
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+	delivery.Error = ""
+	if sendErr != nil {
+		delivery.Error = sendErr.Error()
+	}
+
+	// The webhook loaded with the delivery may be stale, its failures are counted
+	// in place by the repository
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.CompletedAt = &now
+		if err := s.repo.ResetWebhookFailures(ctx, webhook.ID); err != nil {
+			return err
+		}
+	case delivery.Attempts < s.settings.MaxAttempts:
+		next := now.Add(s.settings.BackoffBase << (delivery.Attempts - 1))
+		delivery.NextAttemptAt = &next
+		s.log.Warn("Webhook delivery failed, retrying", "webhookId", webhook.ID, "deliveryId", delivery.ID, "attempt", delivery.Attempts, "error", sendErr)
+	default:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.CompletedAt = &now
+		s.log.Error("Webhook delivery failed", "webhookId", webhook.ID, "deliveryId", delivery.ID, "attempts", delivery.Attempts, "error", sendErr)
+
+		disabled, err := s.repo.RecordWebhookFailure(ctx, webhook.ID, s.settings.DisableAfter, now)
+		if err != nil {
+			return err
+		}
+		if disabled {
+			s.log.Warn("Webhook disabled after repeated failures", "webhookId", webhook.ID, "failures", s.settings.DisableAfter)
+		}
+	}
+	return s.repo.UpdateDelivery(ctx, delivery)
+}
+
+func (s *WebhookServiceImplementation) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "project-management-system-webhooks")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, []byte(delivery.Payload)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, string(body), fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// SignWebhookPayload returns the X-Webhook-Signature value of a payload,
+// the hex encoded HMAC-SHA256 of the body keyed with the webhook secret.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookServiceImplementation) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *WebhookServiceImplementation) getOwnedWebhook(ctx context.Context, userID, id uint) (*models.Webhook, error) {
+	webhook, err := s.repo.GetWebhookByID(ctx, id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+	if err := s.authorize(ctx, userID, webhook.ProjectID); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// authorize checks that the user owns the project
+func (s *WebhookServiceImplementation) authorize(ctx context.Context, userID, projectID uint) error {
+	project, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return ErrProjectNotFound
+	}
+	if !isProjectOwner(project, userID) {
+		return ErrNotProjectOwner
+	}
+	return nil
+}
+
+func validateWebhook(webhook *models.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook URL must be an absolute http or https URL")
+	}
+
+	for _, eventType := range webhook.Events {
+		if !isWebhookEventType(events.Type(eventType)) {
+			return fmt.Errorf("unknown webhook event type %q", eventType)
+		}
+	}
+	return nil
+}
+
+func isWebhookEventType(eventType events.Type) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
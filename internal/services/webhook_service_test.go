@@ -0,0 +1,437 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectRepository) CreateProject(ctx context.Context, project *models.Project) error {
+	args := m.Called(ctx, project)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetProjectByID(ctx context.Context, id uint) (*models.Project, error) {
+	args := m.Called(ctx, id)
+	project, _ := args.Get(0).(*models.Project)
+	return project, args.Error(1)
+}
+
+func (m *MockProjectRepository) GetPaginatedProjects(ctx context.Context, page, pageSize int, includeArchived bool) ([]models.Project, int64, error) {
+	args := m.Called(ctx, page, pageSize, includeArchived)
+	return args.Get(0).([]models.Project), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProjectRepository) GetProjectStatus(ctx context.Context, id uint) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockProjectRepository) UpdateProject(ctx context.Context, project *models.Project) error {
+	args := m.Called(ctx, project)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) DeleteProject(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetTaskByProjectID(ctx context.Context, projectID uint) ([]models.Task, error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+// fakeWebhookRepository keeps webhooks and deliveries in memory
+type fakeWebhookRepository struct {
+	mu         sync.Mutex
+	webhooks   map[uint]*models.Webhook
+	deliveries []*models.WebhookDelivery
+}
+
+func newFakeWebhookRepository(webhooks ...models.Webhook) *fakeWebhookRepository {
+	repo := &fakeWebhookRepository{webhooks: map[uint]*models.Webhook{}}
+	for i := range webhooks {
+		repo.webhooks[webhooks[i].ID] = &webhooks[i]
+	}
+	return repo
+}
+
+func (r *fakeWebhookRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhook.ID = uint(len(r.webhooks) + 1)
+	stored := *webhook
+	r.webhooks[webhook.ID] = &stored
+	return nil
+}
+
+func (r *fakeWebhookRepository) GetWebhookByID(ctx context.Context, id uint) (*models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	copied := *webhook
+	return &copied, nil
+}
+
+func (r *fakeWebhookRepository) GetWebhooksByProject(ctx context.Context, projectID uint, activeOnly bool) ([]models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var webhooks []models.Webhook
+	for _, webhook := range r.webhooks {
+		if webhook.ProjectID == projectID && (!activeOnly || webhook.Active) {
+			webhooks = append(webhooks, *webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (r *fakeWebhookRepository) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *webhook
+	r.webhooks[webhook.ID] = &stored
+	return nil
+}
+
+func (r *fakeWebhookRepository) RecordWebhookFailure(ctx context.Context, id uint, disableAfter int, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhook := r.webhooks[id]
+	webhook.ConsecutiveFailures++
+	if !webhook.Active || webhook.ConsecutiveFailures < disableAfter {
+		return false, nil
+	}
+	webhook.Active = false
+	webhook.DisabledAt = &now
+	return true, nil
+}
+
+func (r *fakeWebhookRepository) ResetWebhookFailures(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.webhooks[id].ConsecutiveFailures = 0
+	return nil
+}
+
+func (r *fakeWebhookRepository) DeleteWebhook(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.webhooks, id)
+	return nil
+}
+
+func (r *fakeWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivery.ID = uint(len(r.deliveries) + 1)
+	stored := *delivery
+	r.deliveries = append(r.deliveries, &stored)
+	return nil
+}
+
+func (r *fakeWebhookRepository) GetDeliveryByID(ctx context.Context, webhookID, id uint) (*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, delivery := range r.deliveries {
+		if delivery.ID == id && delivery.WebhookID == webhookID {
+			copied := *delivery
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *fakeWebhookRepository) GetDeliveriesByWebhook(ctx context.Context, webhookID uint, page, pageSize int) ([]models.WebhookDelivery, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []models.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+	return deliveries, int64(len(deliveries)), nil
+}
+
+func (r *fakeWebhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []models.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		webhook := r.webhooks[delivery.WebhookID]
+		if delivery.Status == models.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) && webhook != nil && webhook.Active {
+			due := *delivery
+			due.Webhook = *webhook
+			deliveries = append(deliveries, due)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *fakeWebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *delivery
+	stored.Webhook = models.Webhook{}
+	r.deliveries[delivery.ID-1] = &stored
+	return nil
+}
+
+// webhookEndpoint is a test server answering with the configured status codes in turn
+type webhookEndpoint struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhookEndpoint(t *testing.T, statuses ...int) *webhookEndpoint {
+	endpoint := &webhookEndpoint{statuses: statuses}
+	endpoint.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		endpoint.mu.Lock()
+		defer endpoint.mu.Unlock()
+		status := endpoint.statuses[len(endpoint.requests)%len(endpoint.statuses)]
+		endpoint.requests = append(endpoint.requests, r)
+		endpoint.bodies = append(endpoint.bodies, body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(endpoint.Close)
+	return endpoint
+}
+
+func newTestWebhookService(repo *fakeWebhookRepository, projectRepo *MockProjectRepository) *WebhookServiceImplementation {
+	return NewWebhookService(repo, projectRepo, new(MockTransactor), new(MockAuditRecorder), WebhookSettings{
+		MaxAttempts:  3,
+		BackoffBase:  time.Minute,
+		DisableAfter: 2,
+		Timeout:      5 * time.Second,
+	}, &MockLogger{}).(*WebhookServiceImplementation)
+}
+
+func TestWebhookDeliversSignedPayload(t *testing.T) {
+	endpoint := newWebhookEndpoint(t, http.StatusOK)
+	repo := newFakeWebhookRepository(
+		models.Webhook{ID: 1, ProjectID: 1, URL: endpoint.URL, Secret: "s3cret", Active: true},
+		models.Webhook{ID: 2, ProjectID: 1, URL: endpoint.URL, Secret: "other", Active: true, Events: []string{string(events.TeamCreated)}},
+	)
+	service := newTestWebhookService(repo, new(MockProjectRepository))
+
+	task := &models.Task{BaseModel: models.BaseModel{ID: 5}, Title: "Setup Server", ProjectID: 1}
+	err := service.HandleEvent(context.Background(), events.Event{
+		Type:      events.TaskCreated,
+		ActorID:   2,
+		ProjectID: 1,
+		TaskID:    5,
+		Payload:   task,
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.deliveries, 1, "the webhook filtering on team.created must be skipped")
+
+	require.NoError(t, service.ProcessDueDeliveries(context.Background(), time.Now()))
+
+	require.Len(t, endpoint.requests, 1)
+	req := endpoint.requests[0]
+	assert.Equal(t, "task.created", req.Header.Get(WebhookEventHeader))
+	assert.Equal(t, "1", req.Header.Get(WebhookDeliveryHeader))
+	assert.Equal(t, SignWebhookPayload("s3cret", endpoint.bodies[0]), req.Header.Get(WebhookSignatureHeader))
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(endpoint.bodies[0], &payload))
+	assert.Equal(t, "task.created", payload["event"])
+	assert.Equal(t, "Setup Server", payload["data"].(map[string]interface{})["title"])
+
+	assert.Equal(t, models.WebhookDeliverySucceeded, repo.deliveries[0].Status)
+	assert.Equal(t, 1, repo.deliveries[0].Attempts)
+	assert.Equal(t, http.StatusOK, repo.deliveries[0].ResponseStatus)
+}
+
+func TestWebhookRetriesWithBackoffAndDisables(t *testing.T) {
+	endpoint := newWebhookEndpoint(t, http.StatusInternalServerError)
+	repo := newFakeWebhookRepository(models.Webhook{ID: 1, ProjectID: 1, URL: endpoint.URL, Secret: "s3cret", Active: true})
+	service := newTestWebhookService(repo, new(MockProjectRepository))
+	ctx := context.Background()
+
+	publish := func() {
+		err := service.HandleEvent(ctx, events.Event{Type: events.ProjectUpdated, ProjectID: 1, Payload: &models.Project{Name: "Apollo"}})
+		require.NoError(t, err)
+	}
+
+	publish()
+	now := time.Now()
+
+	// First attempt fails, the retry waits one backoff period, the next one two
+	require.NoError(t, service.ProcessDueDeliveries(ctx, now))
+	assert.Equal(t, models.WebhookDeliveryPending, repo.deliveries[0].Status)
+	assert.Equal(t, now.Add(time.Minute), *repo.deliveries[0].NextAttemptAt)
+
+	require.NoError(t, service.ProcessDueDeliveries(ctx, now.Add(30*time.Second)))
+	assert.Len(t, endpoint.requests, 1, "retry must wait for its backoff")
+
+	require.NoError(t, service.ProcessDueDeliveries(ctx, now.Add(time.Minute)))
+	assert.Equal(t, now.Add(3*time.Minute), *repo.deliveries[0].NextAttemptAt)
+
+	require.NoError(t, service.ProcessDueDeliveries(ctx, now.Add(3*time.Minute)))
+	assert.Equal(t, models.WebhookDeliveryFailed, repo.deliveries[0].Status)
+	assert.Equal(t, 3, repo.deliveries[0].Attempts)
+	assert.Equal(t, 1, repo.webhooks[1].ConsecutiveFailures)
+	assert.True(t, repo.webhooks[1].Active)
+
+	// A second failed delivery disables the webhook
+	publish()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, service.ProcessDueDeliveries(ctx, now.Add(time.Hour*time.Duration(i+1))))
+	}
+	assert.False(t, repo.webhooks[1].Active)
+	assert.NotNil(t, repo.webhooks[1].DisabledAt)
+
+	// Disabled webhooks receive no new deliveries
+	publish()
+	assert.Len(t, repo.deliveries, 2)
+}
+
+func TestWebhookCountsFailuresWithinABatch(t *testing.T) {
+	endpoint := newWebhookEndpoint(t, http.StatusInternalServerError)
+	repo := newFakeWebhookRepository(models.Webhook{ID: 1, ProjectID: 1, URL: endpoint.URL, Secret: "s3cret", Active: true})
+	service := newTestWebhookService(repo, new(MockProjectRepository))
+	ctx := context.Background()
+	now := time.Now()
+
+	// Both deliveries are on their last attempt and are loaded in one batch, each
+	// with a copy of the webhook showing no failures yet
+	for i := 0; i < 2; i++ {
+		require.NoError(t, repo.CreateDelivery(ctx, &models.WebhookDelivery{
+			WebhookID:     1,
+			EventType:     string(events.ProjectUpdated),
+			Payload:       `{}`,
+			Status:        models.WebhookDeliveryPending,
+			Attempts:      2,
+			NextAttemptAt: &now,
+		}))
+	}
+
+	require.NoError(t, service.ProcessDueDeliveries(ctx, now))
+
+	assert.Len(t, endpoint.requests, 2)
+	assert.Equal(t, 2, repo.webhooks[1].ConsecutiveFailures)
+	assert.False(t, repo.webhooks[1].Active)
+	assert.NotNil(t, repo.webhooks[1].DisabledAt)
+}
+
+func TestWebhookRedeliver(t *testing.T) {
+	endpoint := newWebhookEndpoint(t, http.StatusOK)
+	repo := newFakeWebhookRepository(models.Webhook{ID: 1, ProjectID: 1, URL: endpoint.URL, Secret: "s3cret", Active: false})
+	require.NoError(t, repo.CreateDelivery(context.Background(), &models.WebhookDelivery{
+		WebhookID: 1,
+		EventType: string(events.TaskDeleted),
+		Payload:   `{"event":"task.deleted"}`,
+		Status:    models.WebhookDeliveryFailed,
+		Attempts:  3,
+	}))
+
+	projectRepo := new(MockProjectRepository)
+	projectRepo.On("GetProjectByID", mock.Anything, uint(1)).Return(&models.Project{BaseModel: models.BaseModel{ID: 1}, OwnerID: 2}, nil)
+	service := newTestWebhookService(repo, projectRepo)
+
+	t.Run("Owner", func(t *testing.T) {
+		delivery, err := service.Redeliver(context.Background(), 2, 1, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), delivery.ID)
+		assert.Equal(t, models.WebhookDeliverySucceeded, delivery.Status)
+		assert.Equal(t, `{"event":"task.deleted"}`, string(endpoint.bodies[0]))
+	})
+
+	t.Run("Not The Owner", func(t *testing.T) {
+		_, err := service.Redeliver(context.Background(), 3, 1, 1)
+
+		assert.ErrorIs(t, err, ErrNotProjectOwner)
+	})
+
+	t.Run("Unknown Delivery", func(t *testing.T) {
+		_, err := service.Redeliver(context.Background(), 2, 1, 42)
+
+		assert.ErrorIs(t, err, ErrWebhookDeliveryNotFound)
+	})
+}
+
+func TestCreateWebhook(t *testing.T) {
+	projectRepo := new(MockProjectRepository)
+	projectRepo.On("GetProjectByID", mock.Anything, uint(1)).Return(&models.Project{BaseModel: models.BaseModel{ID: 1}, OwnerID: 2}, nil)
+	projectRepo.On("GetProjectByID", mock.Anything, uint(2)).
+		Return(&models.Project{BaseModel: models.BaseModel{ID: 2}, UserIDs: []uint{3}}, nil)
+
+	testCases := []struct {
+		name          string
+		userID        uint
+		webhook       models.Webhook
+		expectedError string
+	}{
+		{
+			name:    "Owner",
+			userID:  2,
+			webhook: models.Webhook{ProjectID: 1, URL: "https://ci.example.com/hook", Events: []string{"task.created"}},
+		},
+		{
+			name:    "Member Of Project Without Owner",
+			userID:  3,
+			webhook: models.Webhook{ProjectID: 2, URL: "https://ci.example.com/hook"},
+		},
+		{
+			name:          "Not The Owner",
+			userID:        3,
+			webhook:       models.Webhook{ProjectID: 1, URL: "https://ci.example.com/hook"},
+			expectedError: ErrNotProjectOwner.Error(),
+		},
+		{
+			name:          "Invalid URL",
+			userID:        2,
+			webhook:       models.Webhook{ProjectID: 1, URL: "ftp://ci.example.com/hook"},
+			expectedError: "webhook URL must be an absolute http or https URL",
+		},
+		{
+			name:          "Unknown Event",
+			userID:        2,
+			webhook:       models.Webhook{ProjectID: 1, URL: "https://ci.example.com/hook", Events: []string{"user.mentioned"}},
+			expectedError: `unknown webhook event type "user.mentioned"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newTestWebhookService(newFakeWebhookRepository(), projectRepo)
+
+			err := service.CreateWebhook(context.Background(), tc.userID, &tc.webhook)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tc.webhook.Active)
+			assert.Len(t, tc.webhook.Secret, 64)
+		})
+	}
+}
@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWorklogNotFound  = errors.New("worklog not found")
+	ErrInvalidWorklog   = errors.New("invalid worklog")
+	ErrWorklogOverlap   = errors.New("the worklog overlaps another worklog of the user")
+	ErrNotWorklogAuthor = errors.New("only the user who logged the time can change it")
+	ErrTimerRunning     = errors.New("a timer is already running")
+	ErrNoTimerRunning   = errors.New("no timer is running")
+	ErrWorklogLocked    = errors.New("the worklog is in a submitted or approved timesheet")
+	ErrInvalidTimesheet = errors.New("invalid timesheet range")
+)
+
+const (
+	// maxWorklogMinutes is the longest a single worklog can be
+	maxWorklogMinutes = 24 * 60
+	// maxTimesheetDays is the longest date range of a timesheet
+	maxTimesheetDays = 366
+)
+
+// TimesheetQuery selects the days of a timesheet, From through To, and how they
+// are grouped. It defaults to the current week, by day.
+type TimesheetQuery struct {
+	From  time.Time
+	To    time.Time
+	Group string
+}
+
+type WorklogService interface {
+	CreateWorklog(ctx context.Context, worklog *models.Worklog) error
+	GetWorklogsByTask(ctx context.Context, taskID uint) ([]models.Worklog, error)
+	UpdateWorklog(ctx context.Context, worklog *models.Worklog) error
+	DeleteWorklog(ctx context.Context, id uint) error
+	GetTimer(ctx context.Context) (*models.Timer, error)
+	StartTimer(ctx context.Context, taskID uint, note string) (*models.Timer, error)
+	StopTimer(ctx context.Context) (*models.Worklog, error)
+	DiscardTimer(ctx context.Context) error
+	GetProjectTimesheet(ctx context.Context, projectID uint, query TimesheetQuery) (*models.Timesheet, error)
+	GetUserTimesheet(ctx context.Context, userID uint, query TimesheetQuery) (*models.Timesheet, error)
+}
+
+type WorklogServiceImplementation struct {
+	repo        repositories.WorklogRepository
+	submissions repositories.TimesheetSubmissionRepository
+	taskRepo    repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+	transactor  repositories.Transactor
+	audit       AuditRecorder
+	now         func() time.Time
+}
+
+func NewWorklogService(repo repositories.WorklogRepository, submissions repositories.TimesheetSubmissionRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, transactor repositories.Transactor, audit AuditRecorder) WorklogService {
+	return &WorklogServiceImplementation{
+		repo:        repo,
+		submissions: submissions,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		transactor:  transactor,
+		audit:       audit,
+		now:         time.Now,
+	}
+}
+
+// CreateWorklog logs time of the current user on a task
+func (s *WorklogServiceImplementation) CreateWorklog(ctx context.Context, worklog *models.Worklog) error {
+	worklog.ID = 0
+	worklog.UserID, _ = middleware.UserIDFromContext(ctx)
+	if err := s.prepare(ctx, worklog); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.ensureNoOverlap(ctx, worklog); err != nil {
+			return err
+		}
+		if err := s.repo.CreateWorklog(ctx, worklog); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityWorklog, worklog.ID, nil, worklog)
+	})
+}
+
+func (s *WorklogServiceImplementation) GetWorklogsByTask(ctx context.Context, taskID uint) ([]models.Worklog, error) {
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+	return s.repo.GetWorklogsByTask(ctx, taskID)
+}
+
+// UpdateWorklog changes the start, duration and note of a worklog of the current user
+func (s *WorklogServiceImplementation) UpdateWorklog(ctx context.Context, worklog *models.Worklog) error {
+	existing, err := s.ownWorklog(ctx, worklog.ID)
+	if err != nil {
+		return err
+	}
+	worklog.UserID = existing.UserID
+	worklog.TaskID = existing.TaskID
+	worklog.CreatedAt = existing.CreatedAt
+	if err := s.prepare(ctx, worklog); err != nil {
+		return err
+	}
+	// Moving it out of a locked week changes that week too
+	task, err := s.taskRepo.GetTaskByID(ctx, existing.TaskID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	if err := ensureWeekOpen(ctx, s.submissions, existing.UserID, task.ProjectID, existing.StartedAt); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.ensureNoOverlap(ctx, worklog); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateWorklog(ctx, worklog); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityWorklog, worklog.ID, existing, worklog)
+	})
+}
+
+// DeleteWorklog deletes a worklog of the current user
+func (s *WorklogServiceImplementation) DeleteWorklog(ctx context.Context, id uint) error {
+	worklog, err := s.ownWorklog(ctx, id)
+	if err != nil {
+		return err
+	}
+	task, err := s.taskRepo.GetTaskByID(ctx, worklog.TaskID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, task.ProjectID); err != nil {
+		return err
+	}
+	if err := ensureWeekOpen(ctx, s.submissions, worklog.UserID, task.ProjectID, worklog.StartedAt); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteWorklog(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityWorklog, id, worklog, nil)
+	})
+}
+
+// GetTimer returns the running timer of the current user
+func (s *WorklogServiceImplementation) GetTimer(ctx context.Context) (*models.Timer, error) {
+	userID, _ := middleware.UserIDFromContext(ctx)
+	timer, err := s.repo.GetTimer(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoTimerRunning
+	}
+	return timer, err
+}
+
+// StartTimer starts the timer of the current user on a task, a user runs one timer
+// at a time
+func (s *WorklogServiceImplementation) StartTimer(ctx context.Context, taskID uint, note string) (*models.Timer, error) {
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, task.ProjectID); err != nil {
+		return nil, err
+	}
+
+	userID, _ := middleware.UserIDFromContext(ctx)
+	timer := &models.Timer{UserID: userID, TaskID: taskID, StartedAt: s.now().UTC().Truncate(time.Second), Note: note}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		running, err := s.repo.GetTimer(ctx, userID)
+		if err == nil {
+			return fmt.Errorf("%w on task %d", ErrTimerRunning, running.TaskID)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return s.repo.CreateTimer(ctx, timer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// StopTimer stops the timer of the current user and logs the time since it was
+// started, at least a minute. The timer keeps running when the time cannot be
+// logged, for example because it overlaps a worklog.
+func (s *WorklogServiceImplementation) StopTimer(ctx context.Context) (*models.Worklog, error) {
+	timer, err := s.GetTimer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	minutes := int(math.Ceil(s.now().Sub(timer.StartedAt).Minutes()))
+	worklog := &models.Worklog{
+		UserID:          timer.UserID,
+		TaskID:          timer.TaskID,
+		StartedAt:       timer.StartedAt,
+		DurationMinutes: max(minutes, 1),
+		Note:            timer.Note,
+	}
+	if worklog.DurationMinutes > maxWorklogMinutes {
+		return nil, fmt.Errorf("%w: the timer ran for more than %d hours, discard it and log the time instead", ErrInvalidWorklog, maxWorklogMinutes/60)
+	}
+	if err := s.prepare(ctx, worklog); err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.ensureNoOverlap(ctx, worklog); err != nil {
+			return err
+		}
+		if err := s.repo.CreateWorklog(ctx, worklog); err != nil {
+			return err
+		}
+		if err := s.repo.DeleteTimer(ctx, timer.UserID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityWorklog, worklog.ID, nil, worklog)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return worklog, nil
+}
+
+// DiscardTimer stops the timer of the current user without logging its time
+func (s *WorklogServiceImplementation) DiscardTimer(ctx context.Context) error {
+	timer, err := s.GetTimer(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteTimer(ctx, timer.UserID)
+}
+
+func (s *WorklogServiceImplementation) GetProjectTimesheet(ctx context.Context, projectID uint, query TimesheetQuery) (*models.Timesheet, error) {
+	if _, err := s.projectRepo.GetProjectStatus(ctx, projectID); err != nil {
+		return nil, ErrProjectNotFound
+	}
+	return s.timesheet(ctx, repositories.WorklogFilter{ProjectID: projectID}, query)
+}
+
+func (s *WorklogServiceImplementation) GetUserTimesheet(ctx context.Context, userID uint, query TimesheetQuery) (*models.Timesheet, error) {
+	return s.timesheet(ctx, repositories.WorklogFilter{UserID: userID}, query)
+}
+
+// timesheet totals the matching worklogs started in the range per day or week, user
+// and task. Worklogs count on the day they started, in UTC.
+func (s *WorklogServiceImplementation) timesheet(ctx context.Context, filter repositories.WorklogFilter, query TimesheetQuery) (*models.Timesheet, error) {
+	if query.Group == "" {
+		query.Group = models.TimesheetGroupDay
+	}
+	if query.Group != models.TimesheetGroupDay && query.Group != models.TimesheetGroupWeek {
+		return nil, fmt.Errorf("%w: group must be day or week", ErrInvalidTimesheet)
+	}
+	if query.From.IsZero() {
+		query.From = weekStart(s.now())
+	}
+	if query.To.IsZero() {
+		query.To = utcDate(query.From).AddDate(0, 0, 6)
+	}
+	from, to := utcDate(query.From), utcDate(query.To)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to is before from", ErrInvalidTimesheet)
+	}
+	if to.Sub(from) >= maxTimesheetDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: a timesheet spans at most %d days", ErrInvalidTimesheet, maxTimesheetDays)
+	}
+
+	filter.From, filter.To = from, to.AddDate(0, 0, 1)
+	worklogs, err := s.repo.GetWorklogs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	type rowKey struct {
+		period string
+		userID uint
+		taskID uint
+	}
+	rows := make(map[rowKey]*models.TimesheetRow)
+	timesheet := &models.Timesheet{
+		From:  from.Format(time.DateOnly),
+		To:    to.Format(time.DateOnly),
+		Group: query.Group,
+		Rows:  []models.TimesheetRow{},
+	}
+	for _, worklog := range worklogs {
+		period := utcDate(worklog.StartedAt)
+		if query.Group == models.TimesheetGroupWeek {
+			period = weekStart(period)
+		}
+		key := rowKey{period.Format(time.DateOnly), worklog.UserID, worklog.TaskID}
+		row, ok := rows[key]
+		if !ok {
+			row = &models.TimesheetRow{
+				Period:    key.period,
+				UserID:    worklog.UserID,
+				ProjectID: worklog.Task.ProjectID,
+				TaskID:    worklog.TaskID,
+				TaskTitle: worklog.Task.Title,
+			}
+			rows[key] = row
+		}
+		row.Minutes += worklog.DurationMinutes
+		timesheet.TotalMinutes += worklog.DurationMinutes
+	}
+
+	for _, row := range rows {
+		row.Hours = minutesToHours(row.Minutes)
+		timesheet.Rows = append(timesheet.Rows, *row)
+	}
+	sort.Slice(timesheet.Rows, func(i, j int) bool {
+		a, b := timesheet.Rows[i], timesheet.Rows[j]
+		if a.Period != b.Period {
+			return a.Period < b.Period
+		}
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		return a.TaskID < b.TaskID
+	})
+	timesheet.TotalHours = minutesToHours(timesheet.TotalMinutes)
+	return timesheet, nil
+}
+
+// prepare validates a worklog on a task of a project that is not archived and sets
+// its end
+func (s *WorklogServiceImplementation) prepare(ctx context.Context, worklog *models.Worklog) error {
+	if worklog.StartedAt.IsZero() {
+		return fmt.Errorf("%w: started_at is required", ErrInvalidWorklog)
+	}
+	if worklog.DurationMinutes <= 0 || worklog.DurationMinutes > maxWorklogMinutes {
+		return fmt.Errorf("%w: duration_minutes must be between 1 and %d", ErrInvalidWorklog, maxWorklogMinutes)
+	}
+	if worklog.StartedAt.After(s.now()) {
+		return fmt.Errorf("%w: started_at is in the future", ErrInvalidWorklog)
+	}
+
+	task, err := s.taskRepo.GetTaskByID(ctx, worklog.TaskID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	if err := ensureProjectWritable(ctx, s.projectRepo, task.ProjectID); err != nil {
+		return err
+	}
+	if err := ensureWeekOpen(ctx, s.submissions, worklog.UserID, task.ProjectID, worklog.StartedAt); err != nil {
+		return err
+	}
+
+	worklog.StartedAt = worklog.StartedAt.UTC()
+	worklog.EndedAt = worklog.StartedAt.Add(time.Duration(worklog.DurationMinutes) * time.Minute)
+	worklog.Task = models.Task{}
+	return nil
+}
+
+func (s *WorklogServiceImplementation) ensureNoOverlap(ctx context.Context, worklog *models.Worklog) error {
+	overlapping, err := s.repo.GetOverlappingWorklogs(ctx, worklog.UserID, worklog.StartedAt, worklog.EndedAt, worklog.ID)
+	if err != nil {
+		return err
+	}
+	if len(overlapping) > 0 {
+		return fmt.Errorf("%w: worklog %d", ErrWorklogOverlap, overlapping[0].ID)
+	}
+	return nil
+}
+
+// ensureWeekOpen fails with ErrWorklogLocked when the user submitted the time of the
+// week of startedAt on the project, unless the timesheet was rejected
+func ensureWeekOpen(ctx context.Context, submissions repositories.TimesheetSubmissionRepository, userID, projectID uint, startedAt time.Time) error {
+	submission, err := submissions.GetSubmission(ctx, userID, projectID, weekStart(startedAt))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if submission.Status != models.TimesheetStatusRejected {
+		return fmt.Errorf("%w of the week of %s", ErrWorklogLocked, submission.WeekStart.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// ownWorklog returns a worklog of the current user
+func (s *WorklogServiceImplementation) ownWorklog(ctx context.Context, id uint) (*models.Worklog, error) {
+	worklog, err := s.repo.GetWorklogByID(ctx, id)
+	if err != nil {
+		return nil, ErrWorklogNotFound
+	}
+	if userID, _ := middleware.UserIDFromContext(ctx); worklog.UserID != userID {
+		return nil, ErrNotWorklogAuthor
+	}
+	return worklog, nil
+}
+
+// weekStart returns the Monday of the week of t, in UTC
+func weekStart(t time.Time) time.Time {
+	day := utcDate(t)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+func minutesToHours(minutes int) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
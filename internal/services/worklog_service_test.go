@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/pkg/middleware"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockWorklogRepository mocks the WorklogRepository for testing
+type MockWorklogRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorklogRepository) CreateWorklog(ctx context.Context, worklog *models.Worklog) error {
+	args := m.Called(ctx, worklog)
+	return args.Error(0)
+}
+
+func (m *MockWorklogRepository) GetWorklogByID(ctx context.Context, id uint) (*models.Worklog, error) {
+	args := m.Called(ctx, id)
+	worklog, _ := args.Get(0).(*models.Worklog)
+	return worklog, args.Error(1)
+}
+
+func (m *MockWorklogRepository) GetWorklogsByTask(ctx context.Context, taskID uint) ([]models.Worklog, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]models.Worklog), args.Error(1)
+}
+
+func (m *MockWorklogRepository) GetWorklogs(ctx context.Context, filter repositories.WorklogFilter) ([]models.Worklog, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.Worklog), args.Error(1)
+}
+
+func (m *MockWorklogRepository) GetOverlappingWorklogs(ctx context.Context, userID uint, start, end time.Time, excludeID uint) ([]models.Worklog, error) {
+	args := m.Called(ctx, userID, start, end, excludeID)
+	return args.Get(0).([]models.Worklog), args.Error(1)
+}
+
+func (m *MockWorklogRepository) UpdateWorklog(ctx context.Context, worklog *models.Worklog) error {
+	args := m.Called(ctx, worklog)
+	return args.Error(0)
+}
+
+func (m *MockWorklogRepository) DeleteWorklog(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWorklogRepository) GetTimer(ctx context.Context, userID uint) (*models.Timer, error) {
+	args := m.Called(ctx, userID)
+	timer, _ := args.Get(0).(*models.Timer)
+	return timer, args.Error(1)
+}
+
+func (m *MockWorklogRepository) CreateTimer(ctx context.Context, timer *models.Timer) error {
+	args := m.Called(ctx, timer)
+	return args.Error(0)
+}
+
+func (m *MockWorklogRepository) DeleteTimer(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestStopTimer(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 11, 0, 30, 0, time.UTC)
+	timer := &models.Timer{UserID: 7, TaskID: 3, StartedAt: time.Date(2026, time.March, 4, 9, 30, 0, 0, time.UTC), Note: "Review"}
+
+	testCases := []struct {
+		name        string
+		overlapping []models.Worklog
+		expectedErr error
+	}{
+		{name: "Logs The Time Since The Start"},
+		{name: "Keeps The Timer On Overlap", overlapping: []models.Worklog{{ID: 1}}, expectedErr: ErrWorklogOverlap},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			worklogs := new(MockWorklogRepository)
+			worklogs.On("GetTimer", mock.Anything, uint(7)).Return(timer, nil)
+			worklogs.On("GetOverlappingWorklogs", mock.Anything, uint(7), timer.StartedAt, timer.StartedAt.Add(91*time.Minute), uint(0)).Return(tc.overlapping, nil)
+			worklogs.On("CreateWorklog", mock.Anything, mock.Anything).Return(nil).Maybe()
+			worklogs.On("DeleteTimer", mock.Anything, uint(7)).Return(nil).Maybe()
+			tasks := new(MockTaskRepository)
+			tasks.On("GetTaskByID", mock.Anything, uint(3)).Return(&models.Task{BaseModel: models.BaseModel{ID: 3}, ProjectID: 1}, nil)
+
+			service := NewWorklogService(worklogs, openWeeks(), tasks, activeProjects(), new(MockTransactor), new(MockAuditRecorder)).(*WorklogServiceImplementation)
+			service.now = func() time.Time { return now }
+
+			worklog, err := service.StopTimer(middleware.WithUserID(context.Background(), 7))
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				worklogs.AssertNotCalled(t, "DeleteTimer", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			// A started minute counts as a whole one
+			assert.Equal(t, 91, worklog.DurationMinutes)
+			assert.Equal(t, "Review", worklog.Note)
+			worklogs.AssertCalled(t, "DeleteTimer", mock.Anything, uint(7))
+		})
+	}
+}
+
+func TestGetTimesheet(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2026, time.March, day, hour, 0, 0, 0, time.UTC) }
+	design := models.Task{BaseModel: models.BaseModel{ID: 1}, Title: "Design", ProjectID: 4}
+	build := models.Task{BaseModel: models.BaseModel{ID: 2}, Title: "Build", ProjectID: 4}
+	logged := []models.Worklog{
+		{UserID: 1, TaskID: 1, Task: design, StartedAt: at(2, 9), DurationMinutes: 60},
+		{UserID: 1, TaskID: 1, Task: design, StartedAt: at(2, 14), DurationMinutes: 30},
+		{UserID: 2, TaskID: 2, Task: build, StartedAt: at(3, 9), DurationMinutes: 20},
+		{UserID: 1, TaskID: 2, Task: build, StartedAt: at(9, 9), DurationMinutes: 45},
+	}
+
+	testCases := []struct {
+		name     string
+		group    string
+		expected []models.TimesheetRow
+	}{
+		{
+			name:  "By Day",
+			group: models.TimesheetGroupDay,
+			expected: []models.TimesheetRow{
+				{Period: "2026-03-02", UserID: 1, ProjectID: 4, TaskID: 1, TaskTitle: "Design", Minutes: 90, Hours: 1.5},
+				{Period: "2026-03-03", UserID: 2, ProjectID: 4, TaskID: 2, TaskTitle: "Build", Minutes: 20, Hours: 0.33},
+				{Period: "2026-03-09", UserID: 1, ProjectID: 4, TaskID: 2, TaskTitle: "Build", Minutes: 45, Hours: 0.75},
+			},
+		},
+		{
+			name:  "By Week",
+			group: models.TimesheetGroupWeek,
+			expected: []models.TimesheetRow{
+				{Period: "2026-03-02", UserID: 1, ProjectID: 4, TaskID: 1, TaskTitle: "Design", Minutes: 90, Hours: 1.5},
+				{Period: "2026-03-02", UserID: 2, ProjectID: 4, TaskID: 2, TaskTitle: "Build", Minutes: 20, Hours: 0.33},
+				{Period: "2026-03-09", UserID: 1, ProjectID: 4, TaskID: 2, TaskTitle: "Build", Minutes: 45, Hours: 0.75},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			worklogs := new(MockWorklogRepository)
+			worklogs.On("GetWorklogs", mock.Anything, repositories.WorklogFilter{ProjectID: 4, From: at(2, 0), To: at(16, 0)}).Return(logged, nil)
+
+			service := NewWorklogService(worklogs, openWeeks(), new(MockTaskRepository), activeProjects(), new(MockTransactor), new(MockAuditRecorder))
+
+			timesheet, err := service.GetProjectTimesheet(context.Background(), 4, TimesheetQuery{From: at(2, 0), To: at(15, 0), Group: tc.group})
+
+			require.NoError(t, err)
+			assert.Equal(t, "2026-03-15", timesheet.To)
+			assert.Equal(t, tc.expected, timesheet.Rows)
+			assert.Equal(t, 155, timesheet.TotalMinutes)
+			assert.Equal(t, 2.58, timesheet.TotalHours)
+		})
+	}
+
+	t.Run("Defaults To The Current Week", func(t *testing.T) {
+		worklogs := new(MockWorklogRepository)
+		worklogs.On("GetWorklogs", mock.Anything, repositories.WorklogFilter{UserID: 1, From: at(2, 0), To: at(9, 0)}).Return([]models.Worklog{}, nil)
+
+		service := NewWorklogService(worklogs, openWeeks(), new(MockTaskRepository), activeProjects(), new(MockTransactor), new(MockAuditRecorder)).(*WorklogServiceImplementation)
+		service.now = func() time.Time { return at(5, 16) }
+
+		timesheet, err := service.GetUserTimesheet(context.Background(), 1, TimesheetQuery{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "2026-03-02", timesheet.From)
+		assert.Equal(t, "2026-03-08", timesheet.To)
+		assert.Equal(t, models.TimesheetGroupDay, timesheet.Group)
+		assert.Empty(t, timesheet.Rows)
+	})
+}
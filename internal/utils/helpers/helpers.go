@@ -4,7 +4,6 @@ import (
 	"errors"
 	"example/project-management-system/internal/models"
 	"regexp"
-	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -32,14 +31,6 @@ func ValidateUser(user *models.User) error {
     return nil
 }
 
-// Helper function to check for duplicate key errors
-func IsDuplicateKeyError(err error) bool {
-    // This is a MySQL/PostgreSQL specific check
-    // You might need to adjust for your specific database
-    return strings.Contains(err.Error(), "duplicate key") || 
-        strings.Contains(err.Error(), "unique constraint")
-}
-
 // Custom error for existing user
 var ErrUserAlreadyExists = errors.New("user already exists")
 
@@ -58,4 +49,17 @@ func HashPassword(password string) (string, error) {
         return "", err
     }
     return string(hashedBytes), nil
-}
\ No newline at end of file
+}
+// mentionRegex matches @username mentions that are not part of an email address
+var mentionRegex = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9_][A-Za-z0-9_.\-]*[A-Za-z0-9_]|[A-Za-z0-9_])`)
+
+// ExtractMentions returns the unique usernames mentioned in content, in order of appearance
+func ExtractMentions(content string) []string {
+    var usernames []string
+    for _, match := range mentionRegex.FindAllStringSubmatch(content, -1) {
+        if !Contains(usernames, match[1]) {
+            usernames = append(usernames, match[1])
+        }
+    }
+    return usernames
+}
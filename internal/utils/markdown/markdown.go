@@ -0,0 +1,197 @@
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// RenderQueryValue is the value of the `render` query parameter that asks for HTML output
+const RenderQueryValue = "html"
+
+// TaskLinkPrefix is the path task references such as #123 are linked to
+const TaskLinkPrefix = "/api/v1/tasks/"
+
+// TaskResolver reports which of the referenced task IDs exist.
+type TaskResolver interface {
+	GetExistingTaskIDs(ctx context.Context, ids []uint) ([]uint, error)
+}
+
+// Renderer turns CommonMark sources into sanitized HTML.
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+	tasks    TaskResolver
+}
+
+// NewRenderer creates a Renderer. Task references are only linked when tasks is not nil.
+func NewRenderer(tasks TaskResolver) *Renderer {
+	md := goldmark.New(
+		goldmark.WithParserOptions(
+			parser.WithInlineParsers(util.Prioritized(&taskReferenceParser{}, 999)),
+		),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(&taskReferenceRenderer{}, 999)),
+		),
+	)
+
+	// Allowlist of user generated content, plus the class used on task links
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^task-ref$`)).OnElements("a")
+
+	return &Renderer{
+		markdown: md,
+		policy:   policy,
+		tasks:    tasks,
+	}
+}
+
+// Render converts each source to sanitized HTML, resolving all task references with a single lookup.
+func (r *Renderer) Render(ctx context.Context, sources ...string) ([]string, error) {
+	docs := make([]ast.Node, len(sources))
+	var refs []*TaskReference
+	for i, source := range sources {
+		docs[i] = r.markdown.Parser().Parse(text.NewReader([]byte(source)))
+		refs = append(refs, collectTaskReferences(docs[i])...)
+	}
+
+	if err := r.resolveTaskReferences(ctx, refs); err != nil {
+		return nil, err
+	}
+
+	rendered := make([]string, len(sources))
+	for i, source := range sources {
+		var buf bytes.Buffer
+		if err := r.markdown.Renderer().Render(&buf, []byte(source), docs[i]); err != nil {
+			return nil, fmt.Errorf("failed to render markdown: %w", err)
+		}
+		rendered[i] = r.policy.Sanitize(buf.String())
+	}
+	return rendered, nil
+}
+
+func (r *Renderer) resolveTaskReferences(ctx context.Context, refs []*TaskReference) error {
+	if r.tasks == nil || len(refs) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ref.TaskID)
+	}
+
+	existing, err := r.tasks.GetExistingTaskIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to resolve task references: %w", err)
+	}
+
+	found := make(map[uint]bool, len(existing))
+	for _, id := range existing {
+		found[id] = true
+	}
+	for _, ref := range refs {
+		ref.Exists = found[ref.TaskID]
+	}
+	return nil
+}
+
+// collectTaskReferences returns the task references of a document, skipping those already inside a link.
+func collectTaskReferences(doc ast.Node) []*TaskReference {
+	var refs []*TaskReference
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.Link, *ast.AutoLink:
+			return ast.WalkSkipChildren, nil
+		case *TaskReference:
+			refs = append(refs, node)
+		}
+		return ast.WalkContinue, nil
+	})
+	return refs
+}
+
+// KindTaskReference is the node kind of a #123 task reference
+var KindTaskReference = ast.NewNodeKind("TaskReference")
+
+// TaskReference is an inline reference to a task, written as #<id>
+type TaskReference struct {
+	ast.BaseInline
+	TaskID uint
+	Exists bool
+}
+
+func (n *TaskReference) Kind() ast.NodeKind {
+	return KindTaskReference
+}
+
+func (n *TaskReference) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"TaskID": strconv.FormatUint(uint64(n.TaskID), 10)}, nil)
+}
+
+type taskReferenceParser struct{}
+
+func (p *taskReferenceParser) Trigger() []byte {
+	return []byte{'#'}
+}
+
+func (p *taskReferenceParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	// Only match at a word boundary, so "abc#1" and "page#1" stay plain text
+	if prev := block.PrecendingCharacter(); isWordCharacter(prev) || prev == '/' || prev == '&' {
+		return nil
+	}
+
+	line, _ := block.PeekLine()
+	end := 1
+	for end < len(line) && line[end] >= '0' && line[end] <= '9' {
+		end++
+	}
+	if end == 1 || (end < len(line) && isWordCharacter(rune(line[end]))) {
+		return nil
+	}
+
+	id, err := strconv.ParseUint(string(line[1:end]), 10, 32)
+	if err != nil || id == 0 {
+		return nil
+	}
+
+	block.Advance(end)
+	return &TaskReference{TaskID: uint(id)}
+}
+
+func isWordCharacter(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+type taskReferenceRenderer struct{}
+
+func (r *taskReferenceRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindTaskReference, r.renderTaskReference)
+}
+
+func (r *taskReferenceRenderer) renderTaskReference(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	ref := node.(*TaskReference)
+	if ref.Exists {
+		fmt.Fprintf(w, `<a href="%s%d" class="task-ref">#%d</a>`, TaskLinkPrefix, ref.TaskID, ref.TaskID)
+	} else {
+		fmt.Fprintf(w, "#%d", ref.TaskID)
+	}
+	return ast.WalkSkipChildren, nil
+}
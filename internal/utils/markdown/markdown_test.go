@@ -0,0 +1,84 @@
+package markdown
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTaskResolver struct {
+	existing []uint
+	calls    int
+}
+
+func (s *stubTaskResolver) GetExistingTaskIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	s.calls++
+	return s.existing, nil
+}
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "CommonMark Emphasis",
+			source:   "**bold** and _em_",
+			expected: "<p><strong>bold</strong> and <em>em</em></p>\n",
+		},
+		{
+			name:     "Raw HTML Is Dropped",
+			source:   "<script>alert(1)</script>\n\nhello",
+			expected: "\n<p>hello</p>\n",
+		},
+		{
+			name:     "Javascript Links Are Removed",
+			source:   "[click](javascript:alert(1))",
+			expected: "<p>click</p>\n",
+		},
+		{
+			name:     "Existing Task Reference Is Linked",
+			source:   "fixed by #12",
+			expected: `<p>fixed by <a href="/api/v1/tasks/12" class="task-ref" rel="nofollow">#12</a></p>` + "\n",
+		},
+		{
+			name:     "Unknown Task Reference Stays Text",
+			source:   "see #99",
+			expected: "<p>see #99</p>\n",
+		},
+		{
+			name:     "Reference Inside Word Is Ignored",
+			source:   "abc#12",
+			expected: "<p>abc#12</p>\n",
+		},
+		{
+			name:     "Reference Inside Code Is Ignored",
+			source:   "`#12`",
+			expected: "<p><code>#12</code></p>\n",
+		},
+	}
+
+	renderer := NewRenderer(&stubTaskResolver{existing: []uint{12}})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := renderer.Render(context.Background(), tc.source)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rendered[0])
+		})
+	}
+}
+
+func TestRenderResolvesReferencesOnce(t *testing.T) {
+	resolver := &stubTaskResolver{existing: []uint{1, 2}}
+	renderer := NewRenderer(resolver)
+
+	rendered, err := renderer.Render(context.Background(), "#1", "#2", "no refs")
+	require.NoError(t, err)
+
+	assert.Len(t, rendered, 3)
+	assert.Equal(t, 1, resolver.calls)
+}
@@ -0,0 +1,133 @@
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"example/project-management-system/internal/utils/helpers"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ContentType is the media type of JSON merge patches, see RFC 7396
+const ContentType = "application/merge-patch+json"
+
+// ErrInvalidPatch is returned for patches that are not a JSON object, change a
+// field that may not be changed or have a value of the wrong type
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// Apply applies the merge patch to the struct target points to. The members of the
+// patch are the JSON names of the fields, and only the listed fields may be changed.
+// A null member resets its field to the zero value, an object member is merged into
+// its field and any other member replaces it. Nothing is changed when the patch is
+// invalid.
+func Apply(target interface{}, patch []byte, fields ...string) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("merge patch target must point to a struct, not %T", target)
+	}
+	value = value.Elem()
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil || members == nil {
+		return fmt.Errorf("%w: it must be a JSON object", ErrInvalidPatch)
+	}
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Decode every member before changing the target, so an invalid patch changes nothing
+	indexes := jsonFields(value.Type())
+	patched := make([]reflect.Value, len(names))
+	for i, name := range names {
+		index, ok := indexes[name]
+		if !ok || !helpers.Contains(fields, name) {
+			return fmt.Errorf("%w: %q cannot be changed", ErrInvalidPatch, name)
+		}
+
+		field := value.FieldByIndex(index)
+		patched[i] = reflect.New(field.Type()).Elem()
+		if err := mergeField(patched[i], field, members[name]); err != nil {
+			return fmt.Errorf("%w: %q: %v", ErrInvalidPatch, name, err)
+		}
+	}
+
+	for i, name := range names {
+		value.FieldByIndex(indexes[name]).Set(patched[i])
+	}
+	return nil
+}
+
+// mergeField sets result to the value of current with member merged into it
+func mergeField(result, current reflect.Value, member json.RawMessage) error {
+	member = bytes.TrimSpace(member)
+	switch {
+	case bytes.Equal(member, []byte("null")):
+		return nil
+	case len(member) > 0 && member[0] == '{':
+		source, err := json.Marshal(current.Interface())
+		if err != nil {
+			return err
+		}
+		var before, patch interface{}
+		if err := json.Unmarshal(source, &before); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(member, &patch); err != nil {
+			return err
+		}
+		merged, err := json.Marshal(merge(before, patch))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(merged, result.Addr().Interface())
+	default:
+		return json.Unmarshal(member, result.Addr().Interface())
+	}
+}
+
+// merge is the MergePatch function of RFC 7396 on decoded JSON values
+func merge(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+		} else {
+			targetObject[name] = merge(targetObject[name], value)
+		}
+	}
+	return targetObject
+}
+
+// jsonFields maps the JSON names of the fields of t, including those of embedded
+// structs, to their index
+func jsonFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		fields[name] = field.Index
+	}
+	return fields
+}
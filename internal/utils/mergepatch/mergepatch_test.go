@@ -0,0 +1,134 @@
+package mergepatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type base struct {
+	ID uint `json:"id"`
+}
+
+type settings struct {
+	Theme    string `json:"theme"`
+	Language string `json:"language"`
+}
+
+type resource struct {
+	base
+	Title    string     `json:"title"`
+	Status   string     `json:"status,omitempty"`
+	Due      time.Time  `json:"due"`
+	Settings settings   `json:"settings"`
+	Tags     []string   `json:"tags"`
+	Secret   string     `json:"-"`
+	Parent   *time.Time `json:"parent"`
+}
+
+func TestApply(t *testing.T) {
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	current := resource{
+		base:     base{ID: 7},
+		Title:    "Title",
+		Status:   "todo",
+		Settings: settings{Theme: "dark", Language: "en"},
+		Tags:     []string{"a", "b"},
+		Secret:   "secret",
+	}
+	fields := []string{"title", "status", "due", "settings", "tags"}
+
+	testCases := []struct {
+		name     string
+		patch    string
+		expected func(r *resource)
+		err      bool
+	}{
+		{
+			name:     "Omitted Fields Are Kept",
+			patch:    `{"title":"New"}`,
+			expected: func(r *resource) { r.Title = "New" },
+		},
+		{
+			name:     "Null Resets The Field",
+			patch:    `{"status":null}`,
+			expected: func(r *resource) { r.Status = "" },
+		},
+		{
+			name:     "Objects Are Merged",
+			patch:    `{"settings":{"language":"vi","theme":null}}`,
+			expected: func(r *resource) { r.Settings = settings{Language: "vi"} },
+		},
+		{
+			name:     "Arrays Are Replaced",
+			patch:    `{"tags":["c"]}`,
+			expected: func(r *resource) { r.Tags = []string{"c"} },
+		},
+		{
+			name:     "Times Are Decoded",
+			patch:    `{"due":"2026-03-01T00:00:00Z"}`,
+			expected: func(r *resource) { r.Due = due },
+		},
+		{
+			name:     "Empty Patch Changes Nothing",
+			patch:    `{}`,
+			expected: func(r *resource) {},
+		},
+		{
+			name:  "Field Not In The Allowlist",
+			patch: `{"title":"New","id":8}`,
+			err:   true,
+		},
+		{
+			name:  "Unknown Field",
+			patch: `{"owner":1}`,
+			err:   true,
+		},
+		{
+			name:  "Hidden Field",
+			patch: `{"Secret":"x"}`,
+			err:   true,
+		},
+		{
+			name:  "Wrong Type",
+			patch: `{"title":"New","tags":"c"}`,
+			err:   true,
+		},
+		{
+			name:  "Not An Object",
+			patch: `["title"]`,
+			err:   true,
+		},
+		{
+			name:  "Null Patch",
+			patch: `null`,
+			err:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target := current
+			target.Tags = append([]string(nil), current.Tags...)
+
+			err := Apply(&target, []byte(tc.patch), fields...)
+			expected := current
+			expected.Tags = append([]string(nil), current.Tags...)
+			if tc.err {
+				assert.ErrorIs(t, err, ErrInvalidPatch)
+			} else {
+				require.NoError(t, err)
+				tc.expected(&expected)
+			}
+			assert.Equal(t, expected, target)
+		})
+	}
+}
+
+func TestApplyRequiresStructPointer(t *testing.T) {
+	var title string
+	assert.Error(t, Apply(&title, []byte(`{}`)))
+	assert.Error(t, Apply(resource{}, []byte(`{}`)))
+}
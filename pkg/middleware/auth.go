@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"example/project-management-system/internal/utils/response"
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,10 +22,52 @@ const (
 	internalServerErrorMessage = "Internal Server Error"
 )
 
+// testUserIDHeader identifies the calling user when JWT validation is skipped in the test environment.
+const testUserIDHeader = "X-User-ID"
+
+type contextKey string
+
+const userIDContextKey contextKey = "user_id"
+
+// CustomClaims holds the application claims carried by the access token.
+type CustomClaims struct {
+	UserID uint `json:"user_id"`
+}
+
+func (c CustomClaims) Validate(ctx context.Context) error {
+	return nil
+}
+
+// WithUserID returns a copy of ctx carrying the authenticated user ID.
+func WithUserID(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, userIDContextKey, userID)
+}
+
+// UserIDFromContext returns the authenticated user ID set by ValidateJWT.
+func UserIDFromContext(ctx context.Context) (uint, bool) {
+	userID, ok := ctx.Value(userIDContextKey).(uint)
+	return userID, ok && userID != 0
+}
+
+// withClaimsUserID copies the user ID from the validated token claims into the request context.
+func withClaimsUserID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, ok := r.Context().Value(jwtmiddleware.ContextKey{}).(*validator.ValidatedClaims); ok {
+			if customClaims, ok := claims.CustomClaims.(*CustomClaims); ok && customClaims.UserID != 0 {
+				r = r.WithContext(WithUserID(r.Context(), customClaims.UserID))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func ValidateJWT(audience, domain, env string, next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// For testing
 		if env == "test" {
+			if userID, err := strconv.ParseUint(r.Header.Get(testUserIDHeader), 10, 64); err == nil {
+				r = r.WithContext(WithUserID(r.Context(), uint(userID)))
+			}
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -40,6 +84,9 @@ func ValidateJWT(audience, domain, env string, next http.HandlerFunc) http.Handl
 			validator.HS256,
 			issuerURL.String(),
 			[]string{audience},
+			validator.WithCustomClaims(func() validator.CustomClaims {
+				return new(CustomClaims)
+			}),
 		)
 		if err != nil {
 			// log.Fatalf("Failed to set up the jwt validator")
@@ -81,6 +128,6 @@ func ValidateJWT(audience, domain, env string, next http.HandlerFunc) http.Handl
 			jwtmiddleware.WithErrorHandler(errorHandler),
 		)
 
-		middleware.CheckJWT(next).ServeHTTP(w, r)
+		middleware.CheckJWT(withClaimsUserID(next)).ServeHTTP(w, r)
 	})
 }
\ No newline at end of file
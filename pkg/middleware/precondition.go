@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"example/project-management-system/internal/utils/response"
+	"fmt"
+	"net/http"
+)
+
+// RequireIfMatch rejects changes without an If-Match header with 428 Precondition
+// Required when required is set, so clients cannot overwrite changes they have not seen.
+func RequireIfMatch(required bool, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if required && r.Header.Get("If-Match") == "" {
+			response.WriteJson(w, http.StatusPreconditionRequired, response.GeneralError(fmt.Errorf("If-Match header with the ETag of the entity is required")))
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,160 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"example/project-management-system/internal/models"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// uploadFile posts content as the file field of a multipart form
+func uploadFile(t *testing.T, path string, userID uint, filename, contentType string, content []byte) (int, map[string]interface{}) {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	require.NoError(t, err)
+	part.Write(content)
+	require.NoError(t, form.Close())
+
+	req, err := http.NewRequest(http.MethodPost, testServer.URL+path, &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("X-User-ID", fmt.Sprint(userID))
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var response map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&response)
+	return resp.StatusCode, response
+}
+
+func TestAttachments(t *testing.T) {
+	owner := models.User{Username: "attachment-owner", Email: "attachment-owner@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&owner).Error)
+	member := models.User{Username: "attachment-member", Email: "attachment-member@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&member).Error)
+	outsider := models.User{Username: "attachment-outsider", Email: "attachment-outsider@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&outsider).Error)
+	project := models.Project{Name: "Attachment Project", Status: models.ProjectStatusActive, OwnerID: owner.ID, Users: []models.User{member}}
+	require.NoError(t, testDB.Create(&project).Error)
+
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+		"title":       "Login Page",
+		"project_id":  project.ID,
+		"assigned_to": member.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	taskID := uint(task["id"].(float64))
+	status, comment := doRequest(t, http.MethodPost, "/api/v1/comments", member.ID, map[string]interface{}{
+		"content": "See the spec",
+		"task_id": taskID,
+		"user_id": member.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	commentID := uint(comment["id"].(float64))
+
+	screenshot := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR screenshot")
+	var screenshotID uint
+
+	t.Run("Members attach files to tasks and comments", func(t *testing.T) {
+		status, attachment := uploadFile(t, fmt.Sprintf("/api/v1/tasks/%d/attachments", taskID), member.ID, "login.png", "application/octet-stream", screenshot)
+		require.Equal(t, http.StatusCreated, status)
+		screenshotID = uint(attachment["id"].(float64))
+		assert.Equal(t, "login.png", attachment["filename"])
+		assert.Equal(t, "image/png", attachment["content_type"])
+		assert.Equal(t, float64(len(screenshot)), attachment["size"])
+		assert.Equal(t, float64(member.ID), attachment["uploaded_by"])
+		assert.Len(t, attachment["checksum"], 64)
+		assert.NotContains(t, attachment, "storage_key")
+
+		status, attachment = uploadFile(t, fmt.Sprintf("/api/v1/comments/%d/attachments", commentID), owner.ID, "spec.txt", "text/plain", []byte("The spec"))
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, float64(commentID), attachment["comment_id"])
+		assert.Equal(t, float64(taskID), attachment["task_id"])
+
+		status, _ = uploadFile(t, fmt.Sprintf("/api/v1/tasks/%d/attachments", taskID), outsider.ID, "login.png", "image/png", screenshot)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("Size and type are limited", func(t *testing.T) {
+		status, _ := uploadFile(t, fmt.Sprintf("/api/v1/tasks/%d/attachments", taskID), member.ID, "huge.txt", "text/plain", bytes.Repeat([]byte("x"), 65<<10))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+
+		status, _ = uploadFile(t, fmt.Sprintf("/api/v1/tasks/%d/attachments", taskID), member.ID, "page.html", "text/html", []byte("<html><body>hi</body></html>"))
+		assert.Equal(t, http.StatusUnsupportedMediaType, status)
+	})
+
+	t.Run("Attachments are listed and downloaded by members", func(t *testing.T) {
+		listed := func(path string, userID uint) (int, []map[string]interface{}) {
+			req, err := http.NewRequest(http.MethodGet, testServer.URL+path, nil)
+			require.NoError(t, err)
+			req.Header.Set("X-User-ID", fmt.Sprint(userID))
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			var attachments []map[string]interface{}
+			json.NewDecoder(resp.Body).Decode(&attachments)
+			return resp.StatusCode, attachments
+		}
+
+		status, attachments := listed(fmt.Sprintf("/api/v1/tasks/%d/attachments", taskID), owner.ID)
+		require.Equal(t, http.StatusOK, status)
+		assert.Len(t, attachments, 2)
+		status, attachments = listed(fmt.Sprintf("/api/v1/comments/%d/attachments", commentID), member.ID)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, attachments, 1)
+		assert.Equal(t, "spec.txt", attachments[0]["filename"])
+		status, _ = listed(fmt.Sprintf("/api/v1/tasks/%d/attachments", taskID), outsider.ID)
+		assert.Equal(t, http.StatusForbidden, status)
+
+		download := func(userID uint) *http.Response {
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/attachments/%d/content", testServer.URL, screenshotID), nil)
+			require.NoError(t, err)
+			req.Header.Set("X-User-ID", fmt.Sprint(userID))
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			t.Cleanup(func() { resp.Body.Close() })
+			return resp
+		}
+
+		resp := download(owner.ID)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		content, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, screenshot, content)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=login.png`, resp.Header.Get("Content-Disposition"))
+		assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+
+		assert.Equal(t, http.StatusForbidden, download(outsider.ID).StatusCode)
+	})
+
+	t.Run("Only the uploader or the owner deletes an attachment", func(t *testing.T) {
+		var spec models.Attachment
+		require.NoError(t, testDB.Where("comment_id = ?", commentID).First(&spec).Error)
+		status, _ := doRequest(t, http.MethodDelete, fmt.Sprintf("/api/v1/attachments/%d", spec.ID), member.ID, nil)
+		assert.Equal(t, http.StatusForbidden, status)
+
+		status, _ = doRequest(t, http.MethodDelete, fmt.Sprintf("/api/v1/attachments/%d", screenshotID), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		status, _ = doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/attachments/%d/content", screenshotID), owner.ID, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
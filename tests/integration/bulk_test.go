@@ -0,0 +1,100 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTasks(t *testing.T) {
+	user := models.User{Username: "bulk-user", Email: "bulk-user@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&user).Error)
+	projectID, err := insertTestProduct("Bulk Project", "Planned in bulk")
+	require.NoError(t, err)
+	targetID, err := insertTestProduct("Bulk Target", "Tasks moved here")
+	require.NoError(t, err)
+	status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/projects/%d/users/%d", targetID, user.ID), user.ID, nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	createTask := func(title string) uint {
+		status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", user.ID, map[string]interface{}{
+			"title":       title,
+			"project_id":  projectID,
+			"assigned_to": user.ID,
+		})
+		require.Equal(t, http.StatusCreated, status)
+		return uint(task["id"].(float64))
+	}
+	getTask := func(id uint) (int, map[string]interface{}) {
+		return doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks/%d", id), user.ID, nil)
+	}
+	first, second := createTask("Bulk First"), createTask("Bulk Second")
+
+	t.Run("Best effort applies what it can", func(t *testing.T) {
+		status, body := doRequest(t, http.MethodPost, "/api/v1/tasks/bulk", user.ID, map[string]interface{}{
+			"operations": []map[string]interface{}{
+				{"op": "create", "task": map[string]interface{}{"title": "Bulk Created", "project_id": projectID, "assigned_to": user.ID}},
+				{"op": "update", "id": first, "fields": map[string]interface{}{"status": models.TaskStatusInProgress, "labels": []string{"backend"}, "due_date": "2030-01-31T00:00:00Z"}},
+				{"op": "move", "id": second, "project_id": targetID},
+				{"op": "delete", "id": 999999},
+			},
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(3), body["succeeded"])
+		assert.Equal(t, float64(1), body["failed"])
+
+		var statuses []float64
+		for _, result := range body["results"].([]interface{}) {
+			statuses = append(statuses, result.(map[string]interface{})["status"].(float64))
+		}
+		assert.Equal(t, []float64{http.StatusCreated, http.StatusOK, http.StatusOK, http.StatusNotFound}, statuses)
+
+		_, task := getTask(first)
+		assert.Equal(t, models.TaskStatusInProgress, task["status"])
+		assert.Equal(t, []interface{}{"backend"}, task["labels"])
+		assert.Equal(t, "2030-01-31T00:00:00Z", task["due_date"])
+
+		_, task = getTask(second)
+		assert.Equal(t, float64(targetID), task["project_id"])
+	})
+
+	t.Run("Atomic changes nothing when one operation fails", func(t *testing.T) {
+		status, body := doRequest(t, http.MethodPost, "/api/v1/tasks/bulk", user.ID, map[string]interface{}{
+			"atomic": true,
+			"operations": []map[string]interface{}{
+				{"op": "update", "id": first, "fields": map[string]interface{}{"status": models.TaskStatusDone}},
+				{"op": "delete", "id": second},
+				{"op": "update", "id": first, "fields": map[string]interface{}{"title": "Not allowed"}},
+			},
+		})
+		require.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Equal(t, float64(3), body["failed"])
+
+		_, task := getTask(first)
+		assert.Equal(t, models.TaskStatusInProgress, task["status"])
+		status, _ = getTask(second)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("Atomic applies all operations", func(t *testing.T) {
+		status, body := doRequest(t, http.MethodPost, "/api/v1/tasks/bulk", user.ID, map[string]interface{}{
+			"atomic": true,
+			"operations": []map[string]interface{}{
+				{"op": "update", "id": first, "fields": map[string]interface{}{"status": models.TaskStatusDone, "labels": nil}},
+				{"op": "delete", "id": second},
+			},
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(2), body["succeeded"])
+
+		_, task := getTask(first)
+		assert.Equal(t, models.TaskStatusDone, task["status"])
+		assert.Nil(t, task["labels"])
+		status, _ = getTask(second)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskChecklists(t *testing.T) {
+	member := models.User{Username: "checklist-member", Email: "checklist-member@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&member).Error)
+	project := models.Project{Name: "Checklist Project", Status: models.ProjectStatusActive, OwnerID: member.ID}
+	require.NoError(t, testDB.Create(&project).Error)
+
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+		"title":       "Release Notes",
+		"project_id":  project.ID,
+		"assigned_to": member.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	taskID := uint(task["id"].(float64))
+	taskPath := fmt.Sprintf("/api/v1/tasks/%d", taskID)
+	checklistPath := taskPath + "/checklist"
+
+	var itemIDs []uint
+	for _, text := range []string{"Draft", "Review", "Publish"} {
+		status, item := doRequest(t, http.MethodPost, checklistPath, member.ID, map[string]interface{}{"text": text})
+		require.Equal(t, http.StatusCreated, status)
+		itemIDs = append(itemIDs, uint(item["id"].(float64)))
+	}
+
+	t.Run("Items are reordered and toggled", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPut, checklistPath+"/order", member.ID, map[string]interface{}{
+			"item_ids": []uint{itemIDs[2], itemIDs[0]},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = doRequest(t, http.MethodPut, checklistPath+"/order", member.ID, map[string]interface{}{
+			"item_ids": []uint{itemIDs[1], itemIDs[0], itemIDs[2]},
+		})
+		require.Equal(t, http.StatusOK, status)
+
+		status, item := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/checklist-items/%d/toggle", itemIDs[0]), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, true, item["done"])
+		assert.Equal(t, float64(member.ID), item["done_by"])
+		assert.NotEmpty(t, item["done_at"])
+
+		var items []models.ChecklistItem
+		require.NoError(t, testDB.Where("task_id = ?", taskID).Order("position").Find(&items).Error)
+		require.Len(t, items, 3)
+		assert.Equal(t, []string{"Review", "Draft", "Publish"}, []string{items[0].Text, items[1].Text, items[2].Text})
+	})
+
+	t.Run("Task lists show the progress", func(t *testing.T) {
+		status, body := doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks?project_id=%d", project.ID), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		listed := body["tasks"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"done": float64(1), "total": float64(3)}, listed["checklist"])
+	})
+
+	t.Run("The guard keeps tasks with unchecked items open", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPatch, fmt.Sprintf("/api/v1/projects/%d", project.ID), member.ID, mergePatch, map[string]interface{}{
+			"require_checklist": true,
+		})
+		require.Equal(t, http.StatusOK, status)
+
+		status, _, _ = sendRequest(t, http.MethodPatch, taskPath, member.ID, mergePatch, map[string]interface{}{"status": models.TaskStatusDone})
+		assert.Equal(t, http.StatusConflict, status)
+
+		status, _ = doRequest(t, http.MethodDelete, fmt.Sprintf("/api/v1/checklist-items/%d", itemIDs[2]), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		status, _ = doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/checklist-items/%d/toggle", itemIDs[1]), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+
+		status, _, body := sendRequest(t, http.MethodPatch, taskPath, member.ID, mergePatch, map[string]interface{}{"status": models.TaskStatusDone})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, models.TaskStatusDone, body["status"])
+		assert.Equal(t, map[string]interface{}{"done": float64(2), "total": float64(2)}, body["checklist"])
+	})
+}
@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFields(t *testing.T) {
+	owner := models.User{Username: "fields-owner", Email: "fields-owner@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&owner).Error)
+	member := models.User{Username: "fields-member", Email: "fields-member@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&member).Error)
+	project := models.Project{Name: "Fields Project", Status: models.ProjectStatusActive, OwnerID: owner.ID}
+	require.NoError(t, testDB.Create(&project).Error)
+	fieldsPath := fmt.Sprintf("/api/v1/projects/%d/custom-fields", project.ID)
+
+	t.Run("Only the owner defines fields", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, fieldsPath, member.ID, map[string]interface{}{
+			"key": "customer", "name": "Customer", "type": "text",
+		})
+		assert.Equal(t, http.StatusForbidden, status)
+
+		for _, field := range []map[string]interface{}{
+			{"key": "customer", "name": "Customer", "type": "text"},
+			{"key": "severity", "name": "Severity", "type": "select", "options": []string{"low", "high", "critical"}, "required": true, "default": "low"},
+			{"key": "points", "name": "Points", "type": "number"},
+			{"key": "components", "name": "Components", "type": "multi_select", "options": []string{"api", "ui"}},
+			{"key": "reviewer", "name": "Reviewer", "type": "user"},
+		} {
+			status, _ := doRequest(t, http.MethodPost, fieldsPath, owner.ID, field)
+			require.Equal(t, http.StatusCreated, status, field["key"])
+		}
+
+		status, _ = doRequest(t, http.MethodPost, fieldsPath, owner.ID, map[string]interface{}{
+			"key": "customer", "name": "Client", "type": "text",
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	createTask := func(t *testing.T, title string, fields map[string]interface{}) (int, map[string]interface{}) {
+		return doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+			"title":         title,
+			"project_id":    project.ID,
+			"assigned_to":   member.ID,
+			"custom_fields": fields,
+		})
+	}
+	var taskID uint
+
+	t.Run("Tasks hold validated values", func(t *testing.T) {
+		status, task := createTask(t, "Outage", map[string]interface{}{
+			"customer": "Acme", "severity": "critical", "points": 8, "components": []string{"ui", "api"}, "reviewer": owner.ID,
+		})
+		require.Equal(t, http.StatusCreated, status)
+		taskID = uint(task["id"].(float64))
+
+		status, task = doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks/%d", taskID), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, map[string]interface{}{
+			"customer": "Acme", "severity": "critical", "points": float64(8), "components": []interface{}{"api", "ui"}, "reviewer": float64(owner.ID),
+		}, task["custom_fields"])
+
+		status, task = createTask(t, "Typo", map[string]interface{}{"points": 1})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, map[string]interface{}{"severity": "low", "points": float64(1)}, task["custom_fields"])
+
+		status, _ = createTask(t, "Invalid", map[string]interface{}{"severity": "urgent"})
+		assert.Equal(t, http.StatusBadRequest, status)
+		status, _ = createTask(t, "Unknown", map[string]interface{}{"color": "red"})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Values are patched by key", func(t *testing.T) {
+		status, _, task := sendRequest(t, http.MethodPatch, fmt.Sprintf("/api/v1/tasks/%d", taskID), member.ID, mergePatch, map[string]interface{}{
+			"custom_fields": map[string]interface{}{"points": 5, "customer": nil},
+		})
+		require.Equal(t, http.StatusOK, status)
+		fields := task["custom_fields"].(map[string]interface{})
+		assert.Equal(t, float64(5), fields["points"])
+		assert.NotContains(t, fields, "customer")
+		assert.Equal(t, "critical", fields["severity"])
+
+		status, _, _ = sendRequest(t, http.MethodPatch, fmt.Sprintf("/api/v1/tasks/%d", taskID), member.ID, mergePatch, map[string]interface{}{
+			"custom_fields": map[string]interface{}{"severity": nil},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	listPath := func(t *testing.T, path string, query url.Values) []string {
+		status, body := doRequest(t, http.MethodGet, path+"?"+query.Encode(), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		var titles []string
+		for _, task := range body["tasks"].([]interface{}) {
+			titles = append(titles, task.(map[string]interface{})["title"].(string))
+		}
+		return titles
+	}
+	list := func(t *testing.T, query url.Values) []string {
+		query.Set("project_id", fmt.Sprint(project.ID))
+		return listPath(t, "/api/v1/tasks", query)
+	}
+
+	t.Run("Lists are filtered and sorted by fields", func(t *testing.T) {
+		assert.Equal(t, []string{"Outage"}, list(t, url.Values{"cf.severity": {"high,critical"}}))
+		assert.Equal(t, []string{"Outage"}, list(t, url.Values{"cf.components": {"api"}, "cf.points": {"2..10"}}))
+		assert.Equal(t, []string{"Typo", "Outage"}, list(t, url.Values{"sort": {"cf.points"}}))
+		assert.Equal(t, []string{"Outage", "Typo"}, list(t, url.Values{"sort": {"-cf.points,title"}}))
+
+		status, _ := doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks?project_id=%d&cf.color=red", project.ID), member.ID, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Project task lists are filtered and sorted by fields", func(t *testing.T) {
+		tasksPath := fmt.Sprintf("/api/v1/projects/%d/tasks", project.ID)
+		assert.Equal(t, []string{"Outage"}, listPath(t, tasksPath, url.Values{"cf.severity": {"high,critical"}}))
+		assert.Equal(t, []string{"Outage", "Typo"}, listPath(t, tasksPath, url.Values{"sort": {"-cf.points,title"}}))
+
+		status, _ := doRequest(t, http.MethodGet, tasksPath+"?cf.color=red", member.ID, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Deleting a field deletes its values", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodGet, fieldsPath, member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+
+		var points models.CustomField
+		require.NoError(t, testDB.Where("project_id = ? AND field_key = ?", project.ID, "points").First(&points).Error)
+		status, _ = doRequest(t, http.MethodDelete, fmt.Sprintf("/api/v1/custom-fields/%d", points.ID), owner.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+
+		status, task := doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks/%d", taskID), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.NotContains(t, task["custom_fields"], "points")
+	})
+}
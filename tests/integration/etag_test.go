@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskConcurrentUpdates(t *testing.T) {
+	admin := models.User{Username: "etag-admin", Email: "etag-admin@example.com", Password: "secret", Role: models.UserRoleAdmin}
+	require.NoError(t, testDB.Create(&admin).Error)
+	projectID, err := insertTestProduct("ETag Project", "Edited concurrently")
+	require.NoError(t, err)
+
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", admin.ID, map[string]interface{}{
+		"title":       "ETag Task",
+		"project_id":  projectID,
+		"assigned_to": admin.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	path := fmt.Sprintf("/api/v1/tasks/%d", uint(task["id"].(float64)))
+
+	status, header, _ := sendRequest(t, http.MethodGet, path, admin.ID, nil, nil)
+	require.Equal(t, http.StatusOK, status)
+	etag := header.Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	update := func(title string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":          task["id"],
+			"title":       title,
+			"project_id":  projectID,
+			"assigned_to": admin.ID,
+		}
+	}
+
+	t.Run("Missing If-Match", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPut, path, admin.ID, nil, update("Without ETag"))
+		assert.Equal(t, http.StatusPreconditionRequired, status)
+	})
+
+	t.Run("First writer wins", func(t *testing.T) {
+		status, header, body := sendRequest(t, http.MethodPut, path, admin.ID, http.Header{"If-Match": {etag}}, update("First"))
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `"2"`, header.Get("ETag"))
+		assert.Equal(t, float64(2), body["version"])
+
+		status, _, _ = sendRequest(t, http.MethodPut, path, admin.ID, http.Header{"If-Match": {etag}}, update("Second"))
+		assert.Equal(t, http.StatusPreconditionFailed, status)
+
+		status, _, _ = sendRequest(t, http.MethodDelete, path, admin.ID, http.Header{"If-Match": {etag}}, nil)
+		assert.Equal(t, http.StatusPreconditionFailed, status)
+
+		status, current := doRequest(t, http.MethodGet, path, admin.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "First", current["title"])
+	})
+
+	t.Run("Delete with the current ETag", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodDelete, path, admin.ID, http.Header{"If-Match": {`"2"`}}, nil)
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
@@ -0,0 +1,57 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotentCreate(t *testing.T) {
+	user := models.User{Username: "idempotency-user", Email: "idempotency-user@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&user).Error)
+	other := models.User{Username: "idempotency-other", Email: "idempotency-other@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&other).Error)
+	projectID, err := insertTestProduct("Idempotency Project", "Retried requests")
+	require.NoError(t, err)
+
+	key := http.Header{"Idempotency-Key": {"create-task-1"}}
+	task := map[string]interface{}{
+		"title":       "Retried Task",
+		"project_id":  projectID,
+		"assigned_to": user.ID,
+	}
+
+	status, header, created := sendRequest(t, http.MethodPost, "/api/v1/tasks", user.ID, key, task)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Empty(t, header.Get("Idempotent-Replayed"))
+
+	t.Run("A retry replays the response", func(t *testing.T) {
+		status, header, replayed := sendRequest(t, http.MethodPost, "/api/v1/tasks", user.ID, key, task)
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, "true", header.Get("Idempotent-Replayed"))
+		assert.Equal(t, created["id"], replayed["id"])
+
+		var count int64
+		require.NoError(t, testDB.Model(&models.Task{}).Where("title = ?", "Retried Task").Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Reusing the key for another body is rejected", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPost, "/api/v1/tasks", user.ID, key, map[string]interface{}{
+			"title":       "Another Task",
+			"project_id":  projectID,
+			"assigned_to": user.ID,
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+	})
+
+	t.Run("Keys belong to a user", func(t *testing.T) {
+		status, header, body := sendRequest(t, http.MethodPost, "/api/v1/tasks", other.ID, key, task)
+		require.Equal(t, http.StatusCreated, status)
+		assert.Empty(t, header.Get("Idempotent-Replayed"))
+		assert.NotEqual(t, created["id"], body["id"])
+	})
+}
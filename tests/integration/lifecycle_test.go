@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectLifecycle(t *testing.T) {
+	member := models.User{Username: "lifecycle-member", Email: "lifecycle-member@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&member).Error)
+
+	status, project := doRequest(t, http.MethodPost, "/api/v1/projects", member.ID, map[string]interface{}{
+		"name": "Lifecycle Project",
+	})
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, models.ProjectStatusPlanned, project["status"])
+	projectID := uint(project["id"].(float64))
+	projectPath := fmt.Sprintf("/api/v1/projects/%d", projectID)
+
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+		"title":       "Lifecycle Task",
+		"project_id":  projectID,
+		"assigned_to": member.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	taskID := uint(task["id"].(float64))
+
+	ifMatch := http.Header{"If-Match": {"*"}}
+	changeStatus := func(body map[string]interface{}) int {
+		status, _, _ := sendRequest(t, http.MethodPost, projectPath+"/status", member.ID, ifMatch, body)
+		return status
+	}
+	listed := func(query string) bool {
+		status, page := doRequest(t, http.MethodGet, "/api/v1/projects?pageSize=1000"+query, member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		for _, listed := range page["projects"].([]interface{}) {
+			if uint(listed.(map[string]interface{})["id"].(float64)) == projectID {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("Illegal transitions are rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnprocessableEntity, changeStatus(map[string]interface{}{"status": models.ProjectStatusCompleted}))
+		assert.Equal(t, http.StatusUnprocessableEntity, changeStatus(map[string]interface{}{"status": "cancelled"}))
+	})
+
+	t.Run("Completing needs the tasks done unless forced", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, changeStatus(map[string]interface{}{"status": models.ProjectStatusActive}))
+		assert.Equal(t, http.StatusConflict, changeStatus(map[string]interface{}{"status": models.ProjectStatusCompleted}))
+		assert.Equal(t, http.StatusOK, changeStatus(map[string]interface{}{"status": models.ProjectStatusCompleted, "force": true}))
+	})
+
+	t.Run("Archived projects are hidden and read-only", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, changeStatus(map[string]interface{}{"status": models.ProjectStatusArchived}))
+		assert.False(t, listed(""))
+		assert.True(t, listed("&include_archived=true"))
+
+		status, _ := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+			"title":       "Too Late",
+			"project_id":  projectID,
+			"assigned_to": member.ID,
+		})
+		assert.Equal(t, http.StatusConflict, status)
+		status, _, _ = sendRequest(t, http.MethodDelete, fmt.Sprintf("/api/v1/tasks/%d", taskID), member.ID, ifMatch, nil)
+		assert.Equal(t, http.StatusConflict, status)
+		status, _ = doRequest(t, http.MethodPost, "/api/v1/comments", member.ID, map[string]interface{}{
+			"content": "Too late",
+			"task_id": taskID,
+			"user_id": member.ID,
+		})
+		assert.Equal(t, http.StatusConflict, status)
+		status, _ = doRequest(t, http.MethodPost, "/api/v1/teams", member.ID, map[string]interface{}{
+			"name":       "Too Late",
+			"project_id": projectID,
+		})
+		assert.Equal(t, http.StatusConflict, status)
+		status, _, _ = sendRequest(t, http.MethodPut, projectPath, member.ID, ifMatch, map[string]interface{}{
+			"name": "Renamed Project",
+		})
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("Unarchived projects are writable again", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, changeStatus(map[string]interface{}{"status": models.ProjectStatusActive}))
+		assert.True(t, listed(""))
+
+		status, _ := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+			"title":       "Back At It",
+			"project_id":  projectID,
+			"assigned_to": member.ID,
+		})
+		assert.Equal(t, http.StatusCreated, status)
+	})
+}
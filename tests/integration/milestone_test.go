@@ -0,0 +1,79 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMilestones(t *testing.T) {
+	member := models.User{Username: "milestone-member", Email: "milestone-member@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&member).Error)
+	project := models.Project{Name: "Release Project", Status: models.ProjectStatusActive}
+	require.NoError(t, testDB.Create(&project).Error)
+
+	createTask := func(title string, estimate float64) uint {
+		status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+			"title":              title,
+			"project_id":         project.ID,
+			"assigned_to":        member.ID,
+			"remaining_estimate": estimate,
+		})
+		require.Equal(t, http.StatusCreated, status)
+		return uint(task["id"].(float64))
+	}
+	shipped := createTask("Shipped", 0)
+	pending := createTask("Pending", 20)
+
+	status, milestone := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/projects/%d/milestones", project.ID), member.ID, map[string]interface{}{
+		"name":           "v2.0",
+		"target_date":    time.Now().UTC().AddDate(0, 0, 30),
+		"daily_capacity": 4,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	milestonePath := fmt.Sprintf("/api/v1/milestones/%d", uint(milestone["id"].(float64)))
+
+	progressOf := func(milestone map[string]interface{}) map[string]interface{} {
+		return milestone["progress"].(map[string]interface{})
+	}
+
+	t.Run("Progress of the linked tasks", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPatch, fmt.Sprintf("/api/v1/tasks/%d", shipped), member.ID, http.Header{"If-Match": {"*"}}, map[string]interface{}{
+			"status": models.TaskStatusDone,
+		})
+		require.Equal(t, http.StatusOK, status)
+
+		status, milestone := doRequest(t, http.MethodPost, milestonePath+"/tasks", member.ID, map[string]interface{}{
+			"task_ids": []uint{shipped, pending},
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Len(t, milestone["tasks"], 2)
+
+		progress := progressOf(milestone)
+		assert.Equal(t, float64(2), progress["total_tasks"])
+		assert.Equal(t, float64(1), progress["done_tasks"])
+		assert.Equal(t, float64(50), progress["percent"])
+		assert.Equal(t, float64(20), progress["remaining_estimate"])
+		assert.Equal(t, false, progress["at_risk"])
+	})
+
+	t.Run("At risk when the estimate exceeds the capacity", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPut, milestonePath, member.ID, map[string]interface{}{
+			"name":           "v2.0",
+			"target_date":    time.Now().UTC().AddDate(0, 0, 1),
+			"daily_capacity": 4,
+		})
+		require.Equal(t, http.StatusOK, status)
+
+		status, milestone := doRequest(t, http.MethodGet, milestonePath, member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		progress := progressOf(milestone)
+		assert.LessOrEqual(t, progress["capacity"].(float64), float64(8))
+		assert.Equal(t, true, progress["at_risk"])
+	})
+}
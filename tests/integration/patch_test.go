@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mergePatch = http.Header{"Content-Type": {"application/merge-patch+json"}, "If-Match": {"*"}}
+
+func TestPatchResources(t *testing.T) {
+	author := models.User{Username: "patch-author", Email: "patch-author@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&author).Error)
+	other := models.User{Username: "patch-other", Email: "patch-other@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&other).Error)
+	projectID, err := insertTestProduct("Patch Project", "Patched partially")
+	require.NoError(t, err)
+
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", author.ID, map[string]interface{}{
+		"title":       "Patch Task",
+		"description": "Kept",
+		"project_id":  projectID,
+		"assigned_to": author.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	taskPath := fmt.Sprintf("/api/v1/tasks/%d", uint(task["id"].(float64)))
+
+	t.Run("Task fields are patched and omitted ones kept", func(t *testing.T) {
+		status, _, body := sendRequest(t, http.MethodPatch, taskPath, author.ID, mergePatch, map[string]interface{}{
+			"status":      models.TaskStatusInProgress,
+			"assigned_to": other.ID,
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Patch Task", body["title"])
+		assert.Equal(t, "Kept", body["description"])
+		assert.Equal(t, models.TaskStatusInProgress, body["status"])
+		assert.Equal(t, float64(other.ID), body["assigned_to"])
+		assert.Equal(t, "patch-other", body["assignee"].(map[string]interface{})["username"])
+	})
+
+	t.Run("Null resets a task field", func(t *testing.T) {
+		status, _, body := sendRequest(t, http.MethodPatch, taskPath, author.ID, mergePatch, map[string]interface{}{
+			"description": nil,
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "", body["description"])
+	})
+
+	t.Run("Fields outside the allowlist are rejected", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPatch, taskPath, author.ID, mergePatch, map[string]interface{}{
+			"id":         999,
+			"project_id": 999,
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Project name is patched", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v1/projects/%d", projectID)
+		status, _, body := sendRequest(t, http.MethodPatch, path, author.ID, mergePatch, map[string]interface{}{
+			"name": "Renamed Project",
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Renamed Project", body["name"])
+		assert.Equal(t, "Patched partially", body["description"])
+	})
+
+	t.Run("Only the author changes a comment", func(t *testing.T) {
+		status, comment := doRequest(t, http.MethodPost, "/api/v1/comments", author.ID, map[string]interface{}{
+			"content": "Frist",
+			"task_id": task["id"],
+			"user_id": author.ID,
+		})
+		require.Equal(t, http.StatusCreated, status)
+		path := fmt.Sprintf("/api/v1/comments/%d", uint(comment["id"].(float64)))
+
+		status, _, _ = sendRequest(t, http.MethodPatch, path, other.ID, mergePatch, map[string]interface{}{"content": "Hijacked"})
+		assert.Equal(t, http.StatusForbidden, status)
+
+		status, _, body := sendRequest(t, http.MethodPatch, path, author.ID, mergePatch, map[string]interface{}{"content": "First"})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "First", body["content"])
+	})
+
+	t.Run("Users change their own profile", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v1/users/%d", author.ID)
+		status, _, _ := sendRequest(t, http.MethodPatch, path, other.ID, mergePatch, map[string]interface{}{"first_name": "Mallory"})
+		assert.Equal(t, http.StatusForbidden, status)
+
+		status, _, _ = sendRequest(t, http.MethodPatch, path, author.ID, mergePatch, map[string]interface{}{"role": models.UserRoleAdmin})
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		status, _, body := sendRequest(t, http.MethodPatch, path, author.ID, mergePatch, map[string]interface{}{"first_name": "Ada"})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Ada", body["first_name"])
+		assert.Equal(t, "patch-author", body["username"])
+	})
+}
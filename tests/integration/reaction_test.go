@@ -0,0 +1,33 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateReaction(t *testing.T) {
+	projectID, err := insertTestProduct("Reaction Project", "Tasks to react to")
+	require.NoError(t, err)
+	user := models.User{Username: "reaction-user", Email: "reaction-user@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&user).Error)
+	userID := user.ID
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", userID, map[string]interface{}{
+		"title":       "Ship it",
+		"project_id":  projectID,
+		"assigned_to": userID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	path := fmt.Sprintf("/api/v1/tasks/%d/reactions", uint(task["id"].(float64)))
+
+	status, _ = doRequest(t, http.MethodPost, path, userID, map[string]interface{}{"emoji": ":rocket:"})
+	require.Equal(t, http.StatusCreated, status)
+
+	status, body := doRequest(t, http.MethodPost, path, userID, map[string]interface{}{"emoji": "rocket"})
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Contains(t, fmt.Sprint(body), "reaction already exists")
+}
@@ -0,0 +1,124 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSprints(t *testing.T) {
+	member := models.User{Username: "sprint-member", Email: "sprint-member@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&member).Error)
+	project := models.Project{Name: "Sprint Project", Status: models.ProjectStatusActive}
+	require.NoError(t, testDB.Create(&project).Error)
+	other := models.Project{Name: "Other Sprint Project", Status: models.ProjectStatusActive}
+	require.NoError(t, testDB.Create(&other).Error)
+
+	createTask := func(title string, projectID uint) uint {
+		status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+			"title":       title,
+			"project_id":  projectID,
+			"assigned_to": member.ID,
+		})
+		require.Equal(t, http.StatusCreated, status)
+		return uint(task["id"].(float64))
+	}
+	finished := createTask("Finished In Sprint", project.ID)
+	unfinished := createTask("Unfinished In Sprint", project.ID)
+	elsewhere := createTask("Elsewhere", other.ID)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	createSprint := func(name string, start time.Time) uint {
+		status, sprint := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/projects/%d/sprints", project.ID), member.ID, map[string]interface{}{
+			"name":       name,
+			"goal":       "Ship it",
+			"start_date": start,
+			"end_date":   start.AddDate(0, 0, 13),
+		})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, models.SprintStatePlanned, sprint["state"])
+		return uint(sprint["id"].(float64))
+	}
+	first := createSprint("Sprint 1", today.AddDate(0, 0, -2))
+	second := createSprint("Sprint 2", today.AddDate(0, 0, 12))
+	sprintPath := fmt.Sprintf("/api/v1/sprints/%d", first)
+
+	t.Run("Sprints need valid dates", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/projects/%d/sprints", project.ID), member.ID, map[string]interface{}{
+			"name":       "Backwards",
+			"start_date": today,
+			"end_date":   today.AddDate(0, 0, -1),
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Only tasks of the project join a sprint", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, sprintPath+"/tasks", member.ID, map[string]interface{}{
+			"task_ids": []uint{elsewhere},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		status, sprint := doRequest(t, http.MethodPost, sprintPath+"/tasks", member.ID, map[string]interface{}{
+			"task_ids": []uint{finished, unfinished},
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Len(t, sprint["tasks"], 2)
+	})
+
+	t.Run("A project has one active sprint", func(t *testing.T) {
+		status, sprint := doRequest(t, http.MethodPost, sprintPath+"/start", member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, models.SprintStateActive, sprint["state"])
+
+		status, _ = doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/sprints/%d/start", second), member.ID, nil)
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("Closing carries over the unfinished tasks", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPatch, fmt.Sprintf("/api/v1/tasks/%d", finished), member.ID, http.Header{"If-Match": {"*"}}, map[string]interface{}{
+			"status": models.TaskStatusDone,
+		})
+		require.Equal(t, http.StatusOK, status)
+
+		status, closed := doRequest(t, http.MethodPost, sprintPath+"/close", member.ID, map[string]interface{}{
+			"carry_over_to": second,
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, models.SprintStateClosed, closed["state"])
+		require.Len(t, closed["tasks"], 1)
+		assert.Equal(t, float64(finished), closed["tasks"].([]interface{})[0].(map[string]interface{})["id"])
+
+		status, next := doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/sprints/%d", second), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, next["tasks"], 1)
+		assert.Equal(t, float64(unfinished), next["tasks"].([]interface{})[0].(map[string]interface{})["id"])
+
+		status, _ = doRequest(t, http.MethodPost, sprintPath+"/tasks", member.ID, map[string]interface{}{
+			"task_ids": []uint{unfinished},
+		})
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("The burndown is replayed from the task history", func(t *testing.T) {
+		status, burndown := doRequest(t, http.MethodGet, sprintPath+"/burndown", member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "tasks", burndown["unit"])
+
+		points := burndown["points"].([]interface{})
+		require.Len(t, points, 3)
+		start := points[0].(map[string]interface{})
+		assert.Equal(t, today.AddDate(0, 0, -2).Format(time.DateOnly), start["date"])
+		assert.Equal(t, float64(0), start["total"])
+
+		// Closed today with one of its two tasks done, before the other was carried over
+		last := points[2].(map[string]interface{})
+		assert.Equal(t, today.Format(time.DateOnly), last["date"])
+		assert.Equal(t, float64(2), last["total"])
+		assert.Equal(t, float64(1), last["remaining"])
+	})
+}
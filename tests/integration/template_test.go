@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectTemplates(t *testing.T) {
+	owner := models.User{Username: "template-owner", Email: "template-owner@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&owner).Error)
+	other := models.User{Username: "template-other", Email: "template-other@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&other).Error)
+
+	start := time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)
+	source := models.Project{Name: "Quarterly Launch", Description: "Every quarter", StartDate: start, EndDate: start.AddDate(0, 0, 84)}
+	require.NoError(t, testDB.Create(&source).Error)
+	require.NoError(t, testDB.Create(&models.Team{Name: "Launch Crew", ProjectID: source.ID}).Error)
+	due := start.AddDate(0, 0, 14)
+	parent := models.Task{Title: "Plan the launch", Status: models.TaskStatusInProgress, ProjectID: source.ID, AssignedTo: owner.ID, Labels: []string{"planning"}, DueDate: &due}
+	require.NoError(t, testDB.Create(&parent).Error)
+	subtaskDue := start.AddDate(0, 0, 7)
+	require.NoError(t, testDB.Create(&models.Task{Title: "Book the venue", Status: models.TaskStatusTodo, ProjectID: source.ID, AssignedTo: owner.ID, DueDate: &subtaskDue, ParentID: &parent.ID}).Error)
+
+	// tasksOf returns the tasks of a project by title
+	tasksOf := func(projectID uint) map[string]models.Task {
+		var tasks []models.Task
+		require.NoError(t, testDB.Where("project_id = ?", projectID).Find(&tasks).Error)
+		byTitle := make(map[string]models.Task)
+		for _, task := range tasks {
+			byTitle[task.Title] = task
+		}
+		return byTitle
+	}
+
+	var templateID uint
+	t.Run("Capture a project as a template", func(t *testing.T) {
+		status, template := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/projects/%d/templates", source.ID), owner.ID, map[string]interface{}{
+			"name": "Quarter Template",
+		})
+		require.Equal(t, http.StatusCreated, status)
+		templateID = uint(template["id"].(float64))
+		assert.Equal(t, float64(84), template["end_offset_days"])
+		assert.Len(t, template["teams"], 1)
+
+		tasks := template["tasks"].([]interface{})
+		require.Len(t, tasks, 1)
+		task := tasks[0].(map[string]interface{})
+		assert.Equal(t, float64(14), task["due_offset_days"])
+		assert.Equal(t, []interface{}{"planning"}, task["labels"])
+		subtask := task["subtasks"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, float64(7), subtask["due_offset_days"])
+	})
+
+	t.Run("Create a project from the template", func(t *testing.T) {
+		status, project := doRequest(t, http.MethodPost, "/api/v1/projects/from-template", other.ID, map[string]interface{}{
+			"template_id": templateID,
+			"name":        "Q2 Launch",
+			"start_date":  "2026-04-06T00:00:00Z",
+		})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, "Q2 Launch", project["name"])
+		assert.Equal(t, "2026-06-29T00:00:00Z", project["end_date"])
+		assert.Equal(t, float64(other.ID), project["owner_id"])
+		assert.Len(t, project["teams"], 1)
+		assert.Equal(t, []interface{}{float64(other.ID)}, project["user_ids"])
+
+		projectID := uint(project["id"].(float64))
+		tasks := tasksOf(projectID)
+		require.Len(t, tasks, 2)
+		parent, subtask := tasks["Plan the launch"], tasks["Book the venue"]
+		assert.Equal(t, models.TaskStatusInProgress, parent.Status)
+		assert.Equal(t, other.ID, parent.AssignedTo)
+		assert.Equal(t, []string{"planning"}, parent.Labels)
+		assert.True(t, time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC).Equal(*parent.DueDate))
+		assert.True(t, time.Date(2026, time.April, 13, 0, 0, 0, 0, time.UTC).Equal(*subtask.DueDate))
+		require.NotNil(t, subtask.ParentID)
+		assert.Equal(t, parent.ID, *subtask.ParentID)
+
+		var watchers []models.TaskWatcher
+		require.NoError(t, testDB.Where("task_id IN ?", []uint{parent.ID, subtask.ID}).Find(&watchers).Error)
+		require.Len(t, watchers, 2)
+		for _, watcher := range watchers {
+			assert.Equal(t, other.ID, watcher.UserID)
+		}
+	})
+
+	t.Run("Creating from a template needs a start date", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, "/api/v1/projects/from-template", owner.ID, map[string]interface{}{
+			"template_id": templateID,
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Clone a project to a new start date", func(t *testing.T) {
+		status, clone := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/projects/%d/clone", source.ID), owner.ID, map[string]interface{}{
+			"start_date": "2026-07-06T00:00:00Z",
+		})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, "Quarterly Launch (copy)", clone["name"])
+		assert.Equal(t, "Every quarter", clone["description"])
+
+		tasks := tasksOf(uint(clone["id"].(float64)))
+		require.Len(t, tasks, 2)
+		assert.True(t, time.Date(2026, time.July, 20, 0, 0, 0, 0, time.UTC).Equal(*tasks["Plan the launch"].DueDate))
+	})
+
+	t.Run("Task statuses must be in the workflow", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, "/api/v1/project-templates", owner.ID, map[string]interface{}{
+			"name":     "Two Step",
+			"workflow": []string{models.TaskStatusTodo, models.TaskStatusDone},
+			"tasks":    []map[string]interface{}{{"title": "Review", "status": models.TaskStatusInProgress}},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Only the owner deletes a template", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v1/project-templates/%d", templateID)
+		status, _ := doRequest(t, http.MethodDelete, path, other.ID, nil)
+		assert.Equal(t, http.StatusForbidden, status)
+		status, _ = doRequest(t, http.MethodDelete, path, owner.ID, nil)
+		assert.Equal(t, http.StatusOK, status)
+		status, _ = doRequest(t, http.MethodGet, path, owner.ID, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
@@ -0,0 +1,194 @@
+package integration
+
+import (
+	"context"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/services"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveAndCopyTasks(t *testing.T) {
+	member := models.User{Username: "transfer-member", Email: "transfer-member@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&member).Error)
+	outsider := models.User{Username: "transfer-outsider", Email: "transfer-outsider@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&outsider).Error)
+	sourceID, err := insertTestProduct("Transfer Source", "Tasks start here")
+	require.NoError(t, err)
+	targetID, err := insertTestProduct("Transfer Target", "Tasks end up here")
+	require.NoError(t, err)
+	status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/projects/%d/users/%d", targetID, member.ID), member.ID, nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	createTask := func(body map[string]interface{}) uint {
+		body["project_id"] = sourceID
+		status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, body)
+		require.Equal(t, http.StatusCreated, status)
+		return uint(task["id"].(float64))
+	}
+	parent := createTask(map[string]interface{}{"title": "Transfer Parent", "assigned_to": member.ID, "labels": []string{"ops"}})
+	subtask := createTask(map[string]interface{}{"title": "Transfer Subtask", "assigned_to": member.ID, "parent_id": parent})
+	status, _ = doRequest(t, http.MethodPost, "/api/v1/comments", member.ID, map[string]interface{}{
+		"content": "Carried along",
+		"task_id": parent,
+		"user_id": member.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	ifMatch := http.Header{"If-Match": {"*"}}
+
+	t.Run("Changing the project with PUT is rejected", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPut, fmt.Sprintf("/api/v1/tasks/%d", parent), member.ID, ifMatch, map[string]interface{}{
+			"title":      "Transfer Parent",
+			"project_id": targetID,
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Assignees must be members of the target project", func(t *testing.T) {
+		assigned := createTask(map[string]interface{}{"title": "Transfer Outsider", "assigned_to": outsider.ID})
+		status, _, _ := sendRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/tasks/%d/move", assigned), member.ID, ifMatch, map[string]interface{}{
+			"project_id": targetID,
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+	})
+
+	t.Run("Copy leaves out what is not carried", func(t *testing.T) {
+		status, _, copied := sendRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/tasks/%d/copy", parent), member.ID, nil, map[string]interface{}{
+			"project_id": targetID,
+			"comments":   false,
+			"labels":     false,
+		})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, "Transfer Parent", copied["title"])
+		assert.Equal(t, float64(targetID), copied["project_id"])
+		assert.Nil(t, copied["labels"])
+
+		var subtasks, comments int64
+		copyID := uint(copied["id"].(float64))
+		require.NoError(t, testDB.Model(&models.Task{}).Where("parent_id = ?", copyID).Count(&subtasks).Error)
+		require.NoError(t, testDB.Model(&models.Comment{}).Where("task_id = ?", copyID).Count(&comments).Error)
+		assert.Equal(t, int64(1), subtasks)
+		assert.Equal(t, int64(0), comments)
+
+		var watchers []uint
+		require.NoError(t, testDB.Model(&models.TaskWatcher{}).Where("task_id = ?", copyID).Pluck("user_id", &watchers).Error)
+		assert.Equal(t, []uint{member.ID}, watchers)
+	})
+
+	t.Run("Move carries comments, subtasks and labels", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/tasks/%d/watch", parent), outsider.ID, nil, nil)
+		require.Equal(t, http.StatusOK, status)
+
+		status, _, moved := sendRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/tasks/%d/move", parent), member.ID, ifMatch, map[string]interface{}{
+			"project_id": targetID,
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(targetID), moved["project_id"])
+		assert.Equal(t, []interface{}{"ops"}, moved["labels"])
+
+		_, task := doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks/%d", subtask), member.ID, nil)
+		assert.Equal(t, float64(targetID), task["project_id"])
+		assert.Equal(t, float64(parent), task["parent_id"])
+
+		var comments int64
+		require.NoError(t, testDB.Model(&models.Comment{}).Where("task_id = ?", parent).Count(&comments).Error)
+		assert.Equal(t, int64(1), comments)
+	})
+
+	t.Run("Watchers are notified of the move", func(t *testing.T) {
+		event := lastTaskEvent(t, events.TaskUpdated, parent)
+		assert.Equal(t, []uint{member.ID, outsider.ID}, event.Recipients)
+
+		notifications := services.NewNotificationService(repositories.NewNotificationRepository(testDB), testLogger)
+		require.NoError(t, notifications.HandleEvent(context.Background(), event))
+
+		var notified []uint
+		require.NoError(t, testDB.Model(&models.Notification{}).
+			Where("task_id = ? AND type = ?", parent, events.TaskUpdated).
+			Pluck("user_id", &notified).Error)
+		assert.Equal(t, []uint{outsider.ID}, notified)
+	})
+
+	t.Run("The move is in the task history", func(t *testing.T) {
+		status, history := doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks/%d/history", parent), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		entry := history["entries"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, models.AuditActionMove, entry["action"])
+		change := entry["changes"].(map[string]interface{})["project_id"].(map[string]interface{})
+		assert.Equal(t, float64(sourceID), change["before"])
+		assert.Equal(t, float64(targetID), change["after"])
+	})
+}
+
+func TestMoveAndCopyTaskCustomFields(t *testing.T) {
+	owner := models.User{Username: "transfer-fields-owner", Email: "transfer-fields-owner@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&owner).Error)
+	source := models.Project{Name: "Fields Source", Status: models.ProjectStatusActive, OwnerID: owner.ID}
+	require.NoError(t, testDB.Create(&source).Error)
+	target := models.Project{Name: "Fields Target", Status: models.ProjectStatusActive, OwnerID: owner.ID}
+	require.NoError(t, testDB.Create(&target).Error)
+	status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/projects/%d/users/%d", target.ID, owner.ID), owner.ID, nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	// The target has a severity field like the source's, a points field of another
+	// type and no customer field
+	for _, field := range []models.CustomField{
+		{ProjectID: source.ID, Key: "customer", Name: "Customer", Type: models.CustomFieldTypeText},
+		{ProjectID: source.ID, Key: "severity", Name: "Severity", Type: models.CustomFieldTypeSelect, Options: []string{"low", "high"}},
+		{ProjectID: source.ID, Key: "points", Name: "Points", Type: models.CustomFieldTypeNumber},
+		{ProjectID: target.ID, Key: "severity", Name: "Severity", Type: models.CustomFieldTypeSelect, Options: []string{"low", "high", "critical"}},
+		{ProjectID: target.ID, Key: "points", Name: "Points", Type: models.CustomFieldTypeText},
+	} {
+		require.NoError(t, testDB.Create(&field).Error)
+	}
+
+	createTask := func(t *testing.T) uint {
+		status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", owner.ID, map[string]interface{}{
+			"title":         "Escalation",
+			"project_id":    source.ID,
+			"assigned_to":   owner.ID,
+			"custom_fields": map[string]interface{}{"customer": "Acme", "severity": "high", "points": 3},
+		})
+		require.Equal(t, http.StatusCreated, status)
+		return uint(task["id"].(float64))
+	}
+	carried := map[string]interface{}{"severity": "high"}
+
+	t.Run("A moved task keeps the values the target accepts and can be patched", func(t *testing.T) {
+		taskID := createTask(t)
+		taskPath := fmt.Sprintf("/api/v1/tasks/%d", taskID)
+
+		status, _, moved := sendRequest(t, http.MethodPost, taskPath+"/move", owner.ID, http.Header{"If-Match": {"*"}}, map[string]interface{}{
+			"project_id": target.ID,
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, carried, moved["custom_fields"])
+
+		status, _, patched := sendRequest(t, http.MethodPatch, taskPath, owner.ID, mergePatch, map[string]interface{}{"title": "Escalated"})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Escalated", patched["title"])
+		assert.Equal(t, carried, patched["custom_fields"])
+
+		var values int64
+		require.NoError(t, testDB.Model(&models.TaskFieldValue{}).Where("task_id = ?", taskID).Count(&values).Error)
+		assert.Equal(t, int64(1), values)
+	})
+
+	t.Run("A copy gets the values the target accepts", func(t *testing.T) {
+		status, _, copied := sendRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/tasks/%d/copy", createTask(t)), owner.ID, nil, map[string]interface{}{
+			"project_id": target.ID,
+		})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, carried, copied["custom_fields"])
+
+		status, task := doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks/%d", uint(copied["id"].(float64))), owner.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, carried, task["custom_fields"])
+	})
+}
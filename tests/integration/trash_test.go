@@ -0,0 +1,135 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doRequest sends a request as the given user and decodes the JSON response
+func doRequest(t *testing.T, method, path string, userID uint, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	status, _, response := sendRequest(t, method, path, userID, nil, body)
+	return status, response
+}
+
+// sendRequest is doRequest with extra request headers that also returns the
+// response headers
+func sendRequest(t *testing.T, method, path string, userID uint, header http.Header, body interface{}) (int, http.Header, map[string]interface{}) {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, testServer.URL+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", fmt.Sprint(userID))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var response map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&response)
+	return resp.StatusCode, resp.Header, response
+}
+
+func TestTrashAndRestoreProject(t *testing.T) {
+	admin := models.User{Username: "trash-admin", Email: "trash-admin@example.com", Password: "secret", Role: models.UserRoleAdmin}
+	require.NoError(t, testDB.Create(&admin).Error)
+	projectID, err := insertTestProduct("Trash Project", "Deleted and restored")
+	require.NoError(t, err)
+	since := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", admin.ID, map[string]interface{}{
+		"title":       "Trash Task",
+		"project_id":  projectID,
+		"assigned_to": admin.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	taskID := uint(task["id"].(float64))
+
+	status, _ = doRequest(t, http.MethodPost, "/api/v1/comments", admin.ID, map[string]interface{}{
+		"content": "Trash Comment",
+		"task_id": taskID,
+		"user_id": admin.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+
+	status, _, _ = sendRequest(t, http.MethodDelete, fmt.Sprintf("/api/v1/projects/%d", projectID), admin.ID, http.Header{"If-Match": {"*"}}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	status, _ = doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks/%d", taskID), admin.ID, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	t.Run("Trash lists the deleted task", func(t *testing.T) {
+		status, trash := doRequest(t, http.MethodGet, "/api/v1/trash?type=task", admin.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		items := trash["items"].([]interface{})
+		require.NotEmpty(t, items)
+		item := items[0].(map[string]interface{})
+		assert.Equal(t, "Trash Task", item["name"])
+		assert.NotEmpty(t, item["deleted_at"])
+	})
+
+	t.Run("Restoring the project restores its task", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/tasks/%d/restore", taskID), admin.ID, nil)
+		assert.Equal(t, http.StatusConflict, status)
+
+		status, _ = doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/projects/%d/restore", projectID), admin.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+
+		status, restored := doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/tasks/%d", taskID), admin.ID, nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Trash Task", restored["title"])
+	})
+
+	t.Run("Audit log records the changes", func(t *testing.T) {
+		query := url.Values{"entity_type": {models.AuditEntityProject}, "from": {since}}
+		status, audit := doRequest(t, http.MethodGet, "/api/v1/audit?"+query.Encode(), admin.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+
+		var actions []string
+		for _, entry := range audit["entries"].([]interface{}) {
+			entry := entry.(map[string]interface{})
+			if uint(entry["entity_id"].(float64)) == uint(projectID) {
+				actions = append(actions, entry["action"].(string))
+			}
+		}
+		assert.Equal(t, []string{models.AuditActionRestore, models.AuditActionDelete}, actions)
+	})
+}
+
+func TestNotificationPreferencesUpsert(t *testing.T) {
+	user := models.User{Username: "preferences", Email: "preferences@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&user).Error)
+
+	for _, frequency := range []string{"daily", "hourly"} {
+		status, _ := doRequest(t, http.MethodPut, "/api/v1/me/email-preferences", user.ID, map[string]string{"frequency": frequency})
+		require.Equal(t, http.StatusOK, status)
+	}
+
+	status, preference := doRequest(t, http.MethodGet, "/api/v1/me/email-preferences", user.ID, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "hourly", preference["frequency"])
+}
@@ -0,0 +1,36 @@
+package integration
+
+import (
+	"example/project-management-system/internal/models"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUser(t *testing.T) {
+	projectID, err := insertTestProduct("User Project", "The new user joins it")
+	require.NoError(t, err)
+
+	status, user := doRequest(t, http.MethodPost, "/api/v1/users", 0, map[string]interface{}{
+		"username":    "created-user",
+		"email":       "created-user@example.com",
+		"first_name":  "Created",
+		"project_ids": []int{projectID},
+	})
+	require.Equal(t, http.StatusCreated, status)
+	userID := uint(user["id"].(float64))
+
+	var created models.User
+	require.NoError(t, testDB.Preload("Projects").First(&created, userID).Error)
+	assert.Equal(t, "created-user", created.Username)
+	require.Len(t, created.Projects, 1)
+	assert.Equal(t, uint(projectID), created.Projects[0].ID)
+
+	var entries int64
+	require.NoError(t, testDB.Model(&models.AuditEntry{}).
+		Where("entity_type = ? AND entity_id = ? AND action = ?", models.AuditEntityUser, userID, models.AuditActionCreate).
+		Count(&entries).Error)
+	assert.Equal(t, int64(1), entries)
+}
@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"example/project-management-system/internal/events"
+	"example/project-management-system/internal/models"
+	"example/project-management-system/internal/repositories"
+	"example/project-management-system/internal/services"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskWatchers(t *testing.T) {
+	var owner, assignee, commenter, reviewer, stakeholder models.User
+	for i, user := range []*models.User{&owner, &assignee, &commenter, &reviewer, &stakeholder} {
+		name := fmt.Sprintf("watcher-%d", i)
+		*user = models.User{Username: name, Email: name + "@example.com", Password: "secret"}
+		require.NoError(t, testDB.Create(user).Error)
+	}
+	project := models.Project{Name: "Watcher Project", Status: models.ProjectStatusActive, OwnerID: owner.ID}
+	require.NoError(t, testDB.Create(&project).Error)
+
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", owner.ID, map[string]interface{}{
+		"title":       "Launch",
+		"project_id":  project.ID,
+		"assigned_to": assignee.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	taskID := uint(task["id"].(float64))
+	taskPath := fmt.Sprintf("/api/v1/tasks/%d", taskID)
+
+	watcherIDs := func(t *testing.T) []uint {
+		req, err := http.NewRequest(http.MethodGet, testServer.URL+taskPath+"/watchers", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-User-ID", fmt.Sprint(owner.ID))
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var watchers []models.User
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&watchers))
+		ids := make([]uint, len(watchers))
+		for i, watcher := range watchers {
+			ids[i] = watcher.ID
+		}
+		return ids
+	}
+
+	t.Run("The creator, assignee, commenters and mentioned users watch", func(t *testing.T) {
+		assert.Equal(t, []uint{owner.ID, assignee.ID}, watcherIDs(t))
+
+		status, _ := doRequest(t, http.MethodPost, "/api/v1/comments", commenter.ID, map[string]interface{}{
+			"task_id": taskID,
+			"user_id": commenter.ID,
+			"content": "Can @" + reviewer.Username + " take a look?",
+		})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, []uint{owner.ID, assignee.ID, commenter.ID, reviewer.ID}, watcherIDs(t))
+
+		event := lastTaskEvent(t, events.CommentCreated, taskID)
+		assert.Equal(t, []uint{owner.ID, assignee.ID, commenter.ID}, event.Recipients)
+	})
+
+	t.Run("Users watch and unwatch", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPost, taskPath+"/watch", stakeholder.ID, nil, nil)
+		require.Equal(t, http.StatusOK, status)
+		status, _, _ = sendRequest(t, http.MethodPost, taskPath+"/watch", stakeholder.ID, nil, nil)
+		require.Equal(t, http.StatusOK, status)
+		status, _, _ = sendRequest(t, http.MethodDelete, taskPath+"/watch", commenter.ID, nil, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []uint{owner.ID, assignee.ID, reviewer.ID, stakeholder.ID}, watcherIDs(t))
+
+		status, _, _ = sendRequest(t, http.MethodPost, "/api/v1/tasks/999999/watch", stakeholder.ID, nil, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("Watched tasks are listed", func(t *testing.T) {
+		status, body := doRequest(t, http.MethodGet, "/api/v1/me/watching", stakeholder.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(1), body["total"])
+		tasks := body["tasks"].([]interface{})
+		require.Len(t, tasks, 1)
+		assert.Equal(t, float64(taskID), tasks[0].(map[string]interface{})["id"])
+
+		status, body = doRequest(t, http.MethodGet, "/api/v1/me/watching", commenter.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(0), body["total"])
+	})
+
+	t.Run("Watchers are notified of task changes", func(t *testing.T) {
+		status, _, _ := sendRequest(t, http.MethodPatch, taskPath, assignee.ID, mergePatch, map[string]interface{}{"status": models.TaskStatusInProgress})
+		require.Equal(t, http.StatusOK, status)
+
+		event := lastTaskEvent(t, events.TaskStatusChanged, taskID)
+		assert.Equal(t, []uint{owner.ID, assignee.ID, reviewer.ID, stakeholder.ID}, event.Recipients)
+
+		notifications := services.NewNotificationService(repositories.NewNotificationRepository(testDB), testLogger)
+		require.NoError(t, notifications.HandleEvent(context.Background(), event))
+
+		var notified []uint
+		require.NoError(t, testDB.Model(&models.Notification{}).
+			Where("task_id = ? AND type = ?", taskID, events.TaskStatusChanged).
+			Order("user_id").Pluck("user_id", &notified).Error)
+		assert.Equal(t, []uint{owner.ID, reviewer.ID, stakeholder.ID}, notified)
+	})
+}
+
+// lastTaskEvent returns the last event of the type about the task from the outbox
+func lastTaskEvent(t *testing.T, eventType events.Type, taskID uint) events.Event {
+	t.Helper()
+	var outboxEvents []models.OutboxEvent
+	require.NoError(t, testDB.Where("type = ?", eventType).Order("id DESC").Find(&outboxEvents).Error)
+	for _, outboxEvent := range outboxEvents {
+		var event events.Event
+		require.NoError(t, json.Unmarshal([]byte(outboxEvent.Payload), &event))
+		if event.TaskID == taskID {
+			return event
+		}
+	}
+	t.Fatalf("no %s event about task %d", eventType, taskID)
+	return events.Event{}
+}
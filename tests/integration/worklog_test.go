@@ -0,0 +1,242 @@
+package integration
+
+import (
+	"encoding/csv"
+	"example/project-management-system/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTracking(t *testing.T) {
+	member := models.User{Username: "worklog-member", Email: "worklog-member@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&member).Error)
+	colleague := models.User{Username: "worklog-colleague", Email: "worklog-colleague@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&colleague).Error)
+	project := models.Project{Name: "Worklog Project", Status: models.ProjectStatusActive}
+	require.NoError(t, testDB.Create(&project).Error)
+
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+		"title":             "Billable Work",
+		"project_id":        project.ID,
+		"assigned_to":       member.ID,
+		"original_estimate": 6,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, float64(6), task["original_estimate"])
+	assert.Equal(t, float64(6), task["remaining_estimate"])
+	taskID := uint(task["id"].(float64))
+	worklogsPath := fmt.Sprintf("/api/v1/tasks/%d/worklogs", taskID)
+
+	monday := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	var worklogID uint
+
+	t.Run("Worklogs of a user do not overlap", func(t *testing.T) {
+		status, worklog := doRequest(t, http.MethodPost, worklogsPath, member.ID, map[string]interface{}{
+			"started_at":       monday,
+			"duration_minutes": 90,
+			"note":             "Kick-off",
+		})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, float64(member.ID), worklog["user_id"])
+		assert.Equal(t, monday.Add(90*time.Minute).Format(time.RFC3339), worklog["ended_at"])
+		worklogID = uint(worklog["id"].(float64))
+
+		status, _ = doRequest(t, http.MethodPost, worklogsPath, member.ID, map[string]interface{}{
+			"started_at":       monday.Add(time.Hour),
+			"duration_minutes": 60,
+		})
+		assert.Equal(t, http.StatusConflict, status)
+
+		// Another user logs the same hours
+		status, _ = doRequest(t, http.MethodPost, worklogsPath, colleague.ID, map[string]interface{}{
+			"started_at":       monday.Add(time.Hour),
+			"duration_minutes": 60,
+		})
+		assert.Equal(t, http.StatusCreated, status)
+
+		// Back to back with the first one
+		status, _ = doRequest(t, http.MethodPost, worklogsPath, member.ID, map[string]interface{}{
+			"started_at":       monday.AddDate(0, 0, 2).Add(90 * time.Minute),
+			"duration_minutes": 30,
+		})
+		assert.Equal(t, http.StatusCreated, status)
+	})
+
+	t.Run("Only the author changes a worklog", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v1/worklogs/%d", worklogID)
+		status, _ := doRequest(t, http.MethodPut, path, colleague.ID, map[string]interface{}{
+			"started_at":       monday,
+			"duration_minutes": 120,
+		})
+		assert.Equal(t, http.StatusForbidden, status)
+
+		status, worklog := doRequest(t, http.MethodPut, path, member.ID, map[string]interface{}{
+			"started_at":       monday,
+			"duration_minutes": 120,
+			"note":             "Kick-off and planning",
+		})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(120), worklog["duration_minutes"])
+	})
+
+	t.Run("A user runs one timer at a time", func(t *testing.T) {
+		status, timer := doRequest(t, http.MethodPost, "/api/v1/me/timer", member.ID, map[string]interface{}{
+			"task_id": taskID,
+			"note":    "Timed",
+		})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, float64(taskID), timer["task_id"])
+
+		status, _ = doRequest(t, http.MethodPost, "/api/v1/me/timer", member.ID, map[string]interface{}{"task_id": taskID})
+		assert.Equal(t, http.StatusConflict, status)
+
+		status, worklog := doRequest(t, http.MethodPost, "/api/v1/me/timer/stop", member.ID, nil)
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, float64(1), worklog["duration_minutes"])
+		assert.Equal(t, "Timed", worklog["note"])
+
+		status, _ = doRequest(t, http.MethodGet, "/api/v1/me/timer", member.ID, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("The project timesheet totals the time per week", func(t *testing.T) {
+		status, timesheet := doRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/projects/%d/timesheet?from=2026-03-01&to=2026-03-08&group=week", project.ID), member.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(210), timesheet["total_minutes"])
+		assert.Equal(t, 3.5, timesheet["total_hours"])
+
+		rows := timesheet["rows"].([]interface{})
+		require.Len(t, rows, 2)
+		own := rows[0].(map[string]interface{})
+		assert.Equal(t, "2026-03-02", own["period"])
+		assert.Equal(t, float64(member.ID), own["user_id"])
+		assert.Equal(t, 2.5, own["hours"])
+		assert.Equal(t, float64(colleague.ID), rows[1].(map[string]interface{})["user_id"])
+	})
+
+	t.Run("The user timesheet is available as CSV", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, testServer.URL+fmt.Sprintf("/api/v1/users/%d/timesheet?from=2026-03-02&to=2026-03-08&format=csv", member.ID), nil)
+		require.NoError(t, err)
+		req.Header.Set("X-User-ID", fmt.Sprint(member.ID))
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"day", "user_id", "project_id", "task_id", "task", "hours"},
+			{"2026-03-02", fmt.Sprint(member.ID), fmt.Sprint(project.ID), fmt.Sprint(taskID), "Billable Work", "2.00"},
+			{"2026-03-04", fmt.Sprint(member.ID), fmt.Sprint(project.ID), fmt.Sprint(taskID), "Billable Work", "0.50"},
+		}, records)
+	})
+}
+
+func TestTimesheetApproval(t *testing.T) {
+	owner := models.User{Username: "approval-owner", Email: "approval-owner@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&owner).Error)
+	member := models.User{Username: "approval-member", Email: "approval-member@example.com", Password: "secret"}
+	require.NoError(t, testDB.Create(&member).Error)
+	project := models.Project{Name: "Approval Project", Status: models.ProjectStatusActive, OwnerID: owner.ID}
+	require.NoError(t, testDB.Create(&project).Error)
+
+	status, task := doRequest(t, http.MethodPost, "/api/v1/tasks", member.ID, map[string]interface{}{
+		"title":       "Invoiced Work",
+		"project_id":  project.ID,
+		"assigned_to": member.ID,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	monday := time.Date(2026, time.March, 9, 9, 0, 0, 0, time.UTC)
+	status, worklog := doRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/tasks/%d/worklogs", uint(task["id"].(float64))), member.ID, map[string]interface{}{
+		"started_at":       monday,
+		"duration_minutes": 240,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	worklogPath := fmt.Sprintf("/api/v1/worklogs/%d", uint(worklog["id"].(float64)))
+	editWorklog := func() int {
+		status, _ := doRequest(t, http.MethodPut, worklogPath, member.ID, map[string]interface{}{
+			"started_at":       monday,
+			"duration_minutes": 180,
+		})
+		return status
+	}
+
+	submitPath := fmt.Sprintf("/api/v1/projects/%d/timesheets", project.ID)
+	pendingPath := submitPath + "/pending"
+	status, submission := doRequest(t, http.MethodPost, submitPath, member.ID, map[string]interface{}{"week_start": "2026-03-11"})
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, models.TimesheetStatusSubmitted, submission["status"])
+	assert.Equal(t, float64(240), submission["total_minutes"])
+	assert.Len(t, submission["worklogs"], 1)
+	timesheetPath := fmt.Sprintf("/api/v1/timesheets/%d", uint(submission["id"].(float64)))
+
+	t.Run("Submitted weeks are locked and pending", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, editWorklog())
+
+		status, _ := doRequest(t, http.MethodPost, submitPath, member.ID, map[string]interface{}{"week_start": "2026-03-09"})
+		assert.Equal(t, http.StatusConflict, status)
+
+		status, pending := doRequest(t, http.MethodGet, pendingPath, owner.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(1), pending["count"])
+		assert.Equal(t, float64(4), pending["total_hours"])
+	})
+
+	t.Run("Only the project owner reviews", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, timesheetPath+"/approve", member.ID, nil)
+		assert.Equal(t, http.StatusForbidden, status)
+
+		status, _ = doRequest(t, http.MethodPost, timesheetPath+"/reject", owner.ID, map[string]interface{}{})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Rejected weeks can be changed and submitted again", func(t *testing.T) {
+		status, rejected := doRequest(t, http.MethodPost, timesheetPath+"/reject", owner.ID, map[string]interface{}{"comment": "Three hours were agreed"})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, models.TimesheetStatusRejected, rejected["status"])
+		assert.Equal(t, float64(owner.ID), rejected["reviewer_id"])
+
+		assert.Equal(t, http.StatusOK, editWorklog())
+
+		status, resubmitted := doRequest(t, http.MethodPost, submitPath, member.ID, map[string]interface{}{"week_start": "2026-03-09"})
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, rejected["id"], resubmitted["id"])
+		assert.Equal(t, float64(180), resubmitted["total_minutes"])
+	})
+
+	t.Run("Approved weeks stay locked", func(t *testing.T) {
+		status, approved := doRequest(t, http.MethodPost, timesheetPath+"/approve", owner.ID, map[string]interface{}{"comment": "Thanks"})
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, models.TimesheetStatusApproved, approved["status"])
+
+		assert.Equal(t, http.StatusConflict, editWorklog())
+		status, _ = doRequest(t, http.MethodDelete, worklogPath, member.ID, nil)
+		assert.Equal(t, http.StatusConflict, status)
+
+		status, pending := doRequest(t, http.MethodGet, pendingPath, owner.ID, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(0), pending["count"])
+	})
+
+	t.Run("Tasks with approved time are not moved", func(t *testing.T) {
+		other := models.Project{Name: "Approval Elsewhere", Status: models.ProjectStatusActive, OwnerID: owner.ID, Users: []models.User{member}}
+		require.NoError(t, testDB.Create(&other).Error)
+		taskID := uint(task["id"].(float64))
+
+		status, _, _ := sendRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/tasks/%d/move", taskID), member.ID, http.Header{"If-Match": {"*"}}, map[string]interface{}{
+			"project_id": other.ID,
+		})
+		assert.Equal(t, http.StatusConflict, status)
+
+		var stored models.Task
+		require.NoError(t, testDB.First(&stored, taskID).Error)
+		assert.Equal(t, project.ID, stored.ProjectID)
+	})
+}